@@ -1,17 +1,53 @@
 package main
 
 import (
+	"api/db/migrations"
 	"api/internal/config"
+	"api/internal/migration"
+	"encoding/json"
+	"flag"
 	"fmt"
+	"os"
 )
 
 func main() {
+	migrate := flag.Bool("migrate", false, "apply pending migrations before starting")
+	printConfig := flag.Bool("print-config", false, "print the effective configuration with secrets redacted and exit")
+	flag.Parse()
+
 	viperConfig := config.NewViper()
+	if *printConfig {
+		output, err := json.MarshalIndent(config.Redacted(viperConfig), "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to print config: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(output))
+		return
+	}
+
 	log := config.NewLogger(viperConfig)
+	if err := config.ValidateSecrets(viperConfig, false); err != nil {
+		log.Fatalf("Refusing to start, invalid configuration: %v", err)
+	}
 	app := config.NewFiber(viperConfig)
 	db := config.NewDatabase(viperConfig, log, false)
+
+	// schema must be current before serving
+	migrator := migration.NewMigrator(db, log, migrations.FS)
+	if *migrate {
+		total, err := migrator.Up()
+		if err != nil {
+			log.Fatalf("Failed to migrate database: %v", err)
+		}
+		log.Infof("Applied %d migrations", total)
+	}
+
+	if err := migrator.EnsureUpToDate(); err != nil {
+		log.Fatalf("Refusing to start, %v. Run with --migrate or cmd/migrate up", err)
+	}
 	validator := config.NewValidator(viperConfig)
-	redis := config.NewRedis(viperConfig)
+	sessions := config.NewSessionStore(viperConfig, log)
 
 	//app config
 	config.Bootstrap(&config.BootstrapConfig{
@@ -20,7 +56,7 @@ func main() {
 		DB:       db,
 		Config:   viperConfig,
 		Validate: validator,
-		Redis:    redis,
+		Sessions: sessions,
 	})
 
 	webPort := viperConfig.GetInt("web.port")
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"api/db/migrations"
+	"api/internal/config"
+	"api/internal/migration"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+const usage = `usage: migrate [--test] <command>
+
+commands:
+  up          apply every pending migration
+  down [N]    roll back the last N migrations, default 1
+  status      list migrations and when they were applied
+  baseline V  mark migrations up to version V as applied without running them
+  drift       compare the entities and enums with the database schema
+`
+
+func main() {
+	isTest := flag.Bool("test", false, "use the testing database")
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	viperConfig := config.NewViper()
+	log := config.NewLogger(viperConfig)
+	db := config.NewDatabase(viperConfig, log, *isTest)
+
+	migrator := migration.NewMigrator(db, log, migrations.FS)
+
+	switch flag.Arg(0) {
+	case "up":
+		total, err := migrator.Up()
+		if err != nil {
+			log.Fatalf("Failed to migrate database: %v", err)
+		}
+		fmt.Printf("applied %d migrations\n", total)
+
+	case "down":
+		n := 1
+		if flag.NArg() > 1 {
+			value, err := strconv.Atoi(flag.Arg(1))
+			if err != nil || value <= 0 {
+				log.Fatalf("Invalid number of migrations: %s", flag.Arg(1))
+			}
+			n = value
+		}
+
+		total, err := migrator.Down(n)
+		if err != nil {
+			log.Fatalf("Failed to roll back database: %v", err)
+		}
+		fmt.Printf("rolled back %d migrations\n", total)
+
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			log.Fatalf("Failed to read migration status: %v", err)
+		}
+
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%d  %-60s %s\n", status.Version, status.Name, appliedAt)
+		}
+
+	case "baseline":
+		if flag.NArg() < 2 {
+			flag.Usage()
+			os.Exit(2)
+		}
+
+		version, err := strconv.ParseInt(flag.Arg(1), 10, 64)
+		if err != nil {
+			log.Fatalf("Invalid version: %s", flag.Arg(1))
+		}
+
+		total, err := migrator.Baseline(version)
+		if err != nil {
+			log.Fatalf("Failed to baseline database: %v", err)
+		}
+		fmt.Printf("marked %d migrations as applied\n", total)
+
+	case "drift":
+		drifts, err := migration.NewDriftChecker(db, log).Check()
+		if err != nil {
+			log.Fatalf("Failed to check schema drift: %v", err)
+		}
+
+		for _, drift := range drifts {
+			fmt.Println(drift)
+		}
+		if len(drifts) > 0 {
+			os.Exit(1)
+		}
+		fmt.Println("no schema drift")
+
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
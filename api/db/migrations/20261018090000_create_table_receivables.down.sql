@@ -0,0 +1,14 @@
+-- DropIndex
+DROP INDEX IF EXISTS "cash_deposits_sales_id_date_idx";
+DROP INDEX IF EXISTS "collections_date_idx";
+DROP INDEX IF EXISTS "collections_period_id_idx";
+DROP INDEX IF EXISTS "collections_sales_id_idx";
+DROP INDEX IF EXISTS "collections_customer_id_idx";
+DROP INDEX IF EXISTS "receivables_customer_id_idx";
+DROP INDEX IF EXISTS "customers_route_id_idx";
+
+-- DropTable
+DROP TABLE IF EXISTS "cash_deposits";
+DROP TABLE IF EXISTS "collections";
+DROP TABLE IF EXISTS "receivables";
+DROP TABLE IF EXISTS "customers";
@@ -0,0 +1,59 @@
+-- CreateTable: customers
+CREATE TABLE "customers" (
+    "id" SERIAL PRIMARY KEY,
+    "name" VARCHAR(100) NOT NULL,
+    "phone" VARCHAR(20),
+    "address" TEXT,
+    "route_id" INTEGER NOT NULL REFERENCES "routes"("id") ON DELETE RESTRICT,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" TIMESTAMP(3)
+);
+
+-- CreateTable: receivables
+CREATE TABLE "receivables" (
+    "id" SERIAL PRIMARY KEY,
+    "date" DATE NOT NULL,
+    "amount" DECIMAL(12,2) NOT NULL,
+    "description" TEXT,
+    "customer_id" INTEGER NOT NULL REFERENCES "customers"("id") ON DELETE RESTRICT,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" TIMESTAMP(3)
+);
+
+-- CreateTable: collections
+CREATE TABLE "collections" (
+    "id" SERIAL PRIMARY KEY,
+    "date" DATE NOT NULL,
+    "amount" DECIMAL(12,2) NOT NULL,
+    "notes" TEXT,
+    "customer_id" INTEGER NOT NULL REFERENCES "customers"("id") ON DELETE RESTRICT,
+    "sales_id" INTEGER NOT NULL REFERENCES "sales"("id") ON DELETE RESTRICT,
+    "period_id" INTEGER NOT NULL REFERENCES "periods"("id") ON DELETE RESTRICT,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" TIMESTAMP(3)
+);
+
+-- CreateTable: cash_deposits
+CREATE TABLE "cash_deposits" (
+    "id" SERIAL PRIMARY KEY,
+    "date" DATE NOT NULL,
+    "amount" DECIMAL(12,2) NOT NULL,
+    "notes" TEXT,
+    "sales_id" INTEGER NOT NULL REFERENCES "sales"("id") ON DELETE RESTRICT,
+    "received_by" VARCHAR REFERENCES "users"("id") ON DELETE SET NULL,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" TIMESTAMP(3)
+);
+
+-- CreateIndex
+CREATE INDEX "customers_route_id_idx" ON "customers"("route_id");
+CREATE INDEX "receivables_customer_id_idx" ON "receivables"("customer_id");
+CREATE INDEX "collections_customer_id_idx" ON "collections"("customer_id");
+CREATE INDEX "collections_sales_id_idx" ON "collections"("sales_id");
+CREATE INDEX "collections_period_id_idx" ON "collections"("period_id");
+CREATE INDEX "collections_date_idx" ON "collections"("date");
+CREATE INDEX "cash_deposits_sales_id_date_idx" ON "cash_deposits"("sales_id", "date");
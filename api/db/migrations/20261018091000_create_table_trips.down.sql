@@ -0,0 +1,9 @@
+-- DropIndex
+DROP INDEX IF EXISTS "trips_driver_id_idx";
+DROP INDEX IF EXISTS "trips_vehicle_id_idx";
+DROP INDEX IF EXISTS "trips_date_idx";
+
+-- DropTable
+DROP TABLE IF EXISTS "trip_routes";
+DROP TABLE IF EXISTS "trip_helpers";
+DROP TABLE IF EXISTS "trips";
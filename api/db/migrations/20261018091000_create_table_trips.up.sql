@@ -0,0 +1,34 @@
+-- CreateTable: trips
+CREATE TABLE "trips" (
+    "id" SERIAL PRIMARY KEY,
+    "date" DATE NOT NULL,
+    "sacks_loaded" INTEGER NOT NULL DEFAULT 0,
+    "sacks_returned" INTEGER NOT NULL DEFAULT 0,
+    "income" DECIMAL(12,2) NOT NULL DEFAULT 0,
+    "notes" TEXT,
+    "vehicle_id" INTEGER NOT NULL REFERENCES "vehicles"("id") ON DELETE RESTRICT,
+    "driver_id" INTEGER NOT NULL REFERENCES "employees"("id") ON DELETE RESTRICT,
+    "vehicle_history_id" INTEGER REFERENCES "vehicle_history"("id") ON DELETE SET NULL,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" TIMESTAMP(3)
+);
+
+-- CreateTable: trip_helpers
+CREATE TABLE "trip_helpers" (
+    "trip_id" INTEGER NOT NULL REFERENCES "trips"("id") ON DELETE CASCADE,
+    "employee_id" INTEGER NOT NULL REFERENCES "employees"("id") ON DELETE RESTRICT,
+    PRIMARY KEY ("trip_id", "employee_id")
+);
+
+-- CreateTable: trip_routes
+CREATE TABLE "trip_routes" (
+    "trip_id" INTEGER NOT NULL REFERENCES "trips"("id") ON DELETE CASCADE,
+    "route_id" INTEGER NOT NULL REFERENCES "routes"("id") ON DELETE RESTRICT,
+    PRIMARY KEY ("trip_id", "route_id")
+);
+
+-- CreateIndex
+CREATE INDEX "trips_date_idx" ON "trips"("date");
+CREATE INDEX "trips_vehicle_id_idx" ON "trips"("vehicle_id");
+CREATE INDEX "trips_driver_id_idx" ON "trips"("driver_id");
@@ -0,0 +1,9 @@
+-- DropIndex
+DROP INDEX IF EXISTS "payroll_items_payroll_id_idx";
+
+-- DropTable
+DROP TABLE IF EXISTS "payroll_items";
+DROP TABLE IF EXISTS "pay_rules";
+
+-- DropEnum
+DROP TYPE IF EXISTS "PayrollItemType";
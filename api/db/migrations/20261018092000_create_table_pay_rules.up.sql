@@ -0,0 +1,29 @@
+-- CreateEnum
+CREATE TYPE "PayrollItemType" AS ENUM ('DAILY', 'TRIP', 'SACK', 'BONUS', 'DEDUCTION');
+
+-- CreateTable: pay_rules
+CREATE TABLE "pay_rules" (
+    "id" SERIAL PRIMARY KEY,
+    "role" "EmployeeRole" NOT NULL UNIQUE,
+    "daily_rate" DECIMAL(12,2),
+    "trip_rate" DECIMAL(12,2) NOT NULL DEFAULT 0,
+    "sack_rate" DECIMAL(12,2) NOT NULL DEFAULT 0,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" TIMESTAMP(3)
+);
+
+-- CreateTable: payroll_items
+CREATE TABLE "payroll_items" (
+    "id" SERIAL PRIMARY KEY,
+    "type" "PayrollItemType" NOT NULL,
+    "description" TEXT NOT NULL,
+    "quantity" DECIMAL(12,2) NOT NULL DEFAULT 0,
+    "rate" DECIMAL(12,2) NOT NULL DEFAULT 0,
+    "amount" DECIMAL(12,2) NOT NULL DEFAULT 0,
+    "payroll_id" INTEGER NOT NULL REFERENCES "payrolls"("id") ON DELETE CASCADE,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- CreateIndex
+CREATE INDEX "payroll_items_payroll_id_idx" ON "payroll_items"("payroll_id");
@@ -0,0 +1,8 @@
+-- DropIndex
+DROP INDEX IF EXISTS "cash_advance_repayments_payroll_id_idx";
+DROP INDEX IF EXISTS "cash_advance_repayments_cash_advance_id_idx";
+DROP INDEX IF EXISTS "cash_advances_employee_id_idx";
+
+-- DropTable
+DROP TABLE IF EXISTS "cash_advance_repayments";
+DROP TABLE IF EXISTS "cash_advances";
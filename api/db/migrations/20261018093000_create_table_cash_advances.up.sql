@@ -0,0 +1,29 @@
+-- CreateTable: cash_advances
+CREATE TABLE "cash_advances" (
+    "id" SERIAL PRIMARY KEY,
+    "date" DATE NOT NULL,
+    "amount" DECIMAL(12,2) NOT NULL,
+    "installment" DECIMAL(12,2) NOT NULL,
+    "notes" TEXT,
+    "employee_id" INTEGER NOT NULL REFERENCES "employees"("id"),
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" TIMESTAMP(3)
+);
+
+-- CreateTable: cash_advance_repayments
+CREATE TABLE "cash_advance_repayments" (
+    "id" SERIAL PRIMARY KEY,
+    "date" DATE NOT NULL,
+    "amount" DECIMAL(12,2) NOT NULL,
+    "notes" TEXT,
+    "cash_advance_id" INTEGER NOT NULL REFERENCES "cash_advances"("id"),
+    "payroll_id" INTEGER REFERENCES "payrolls"("id") ON DELETE CASCADE,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- CreateIndex
+CREATE INDEX "cash_advances_employee_id_idx" ON "cash_advances"("employee_id");
+CREATE INDEX "cash_advance_repayments_cash_advance_id_idx" ON "cash_advance_repayments"("cash_advance_id");
+CREATE INDEX "cash_advance_repayments_payroll_id_idx" ON "cash_advance_repayments"("payroll_id");
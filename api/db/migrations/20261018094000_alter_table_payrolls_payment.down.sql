@@ -0,0 +1,7 @@
+-- AlterTable: payrolls
+ALTER TABLE "payrolls"
+    DROP COLUMN IF EXISTS "paid_by",
+    DROP COLUMN IF EXISTS "payment_method";
+
+-- DropEnum
+DROP TYPE IF EXISTS "PaymentMethod";
@@ -0,0 +1,7 @@
+-- CreateEnum
+CREATE TYPE "PaymentMethod" AS ENUM ('CASH', 'TRANSFER');
+
+-- AlterTable: payrolls
+ALTER TABLE "payrolls"
+    ADD COLUMN "payment_method" "PaymentMethod",
+    ADD COLUMN "paid_by" VARCHAR REFERENCES "users"("id") ON DELETE SET NULL;
@@ -0,0 +1,10 @@
+-- AlterTable: employee_attendances
+ALTER TABLE "employee_attendances"
+    DROP COLUMN IF EXISTS "geofence_id",
+    DROP COLUMN IF EXISTS "location_accuracy",
+    DROP COLUMN IF EXISTS "longitude",
+    DROP COLUMN IF EXISTS "latitude",
+    DROP COLUMN IF EXISTS "check_in_at";
+
+-- DropTable
+DROP TABLE IF EXISTS "geofences";
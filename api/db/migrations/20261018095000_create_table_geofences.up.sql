@@ -0,0 +1,21 @@
+-- CreateTable: geofences
+CREATE TABLE "geofences" (
+    "id" SERIAL PRIMARY KEY,
+    "name" VARCHAR(100) NOT NULL,
+    "latitude" DECIMAL(10,7) NOT NULL,
+    "longitude" DECIMAL(10,7) NOT NULL,
+    "radius" INTEGER NOT NULL,
+    "is_active" BOOLEAN NOT NULL DEFAULT true,
+    "factory_id" INTEGER REFERENCES "factories"("id") ON DELETE SET NULL,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" TIMESTAMP(3)
+);
+
+-- AlterTable: employee_attendances
+ALTER TABLE "employee_attendances"
+    ADD COLUMN "check_in_at" TIMESTAMP(3),
+    ADD COLUMN "latitude" DECIMAL(10,7),
+    ADD COLUMN "longitude" DECIMAL(10,7),
+    ADD COLUMN "location_accuracy" DECIMAL(8,2),
+    ADD COLUMN "geofence_id" INTEGER REFERENCES "geofences"("id") ON DELETE SET NULL;
@@ -0,0 +1,5 @@
+-- AlterTable: users
+ALTER TABLE "users"
+    DROP COLUMN IF EXISTS "employee_id";
+
+-- Postgres can not drop an enum value, EMPLOYEE stays in "UserRole"
@@ -0,0 +1,6 @@
+-- AlterEnum
+ALTER TYPE "UserRole" ADD VALUE IF NOT EXISTS 'EMPLOYEE';
+
+-- AlterTable: users
+ALTER TABLE "users"
+    ADD COLUMN "employee_id" INTEGER UNIQUE REFERENCES "employees"("id") ON DELETE SET NULL;
@@ -0,0 +1,9 @@
+-- DropTable
+DROP TABLE IF EXISTS "employee_status_histories";
+
+-- AlterTable: employees
+ALTER TABLE "employees"
+    DROP COLUMN IF EXISTS "status";
+
+-- DropEnum
+DROP TYPE IF EXISTS "EmployeeStatus";
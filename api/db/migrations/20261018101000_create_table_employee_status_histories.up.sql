@@ -0,0 +1,20 @@
+-- CreateEnum
+CREATE TYPE "EmployeeStatus" AS ENUM ('ACTIVE', 'ON_LEAVE', 'RESIGNED', 'TERMINATED');
+
+-- AlterTable: employees
+ALTER TABLE "employees"
+    ADD COLUMN "status" "EmployeeStatus" NOT NULL DEFAULT 'ACTIVE';
+
+-- CreateTable: employee_status_histories
+CREATE TABLE "employee_status_histories" (
+    "id" SERIAL PRIMARY KEY,
+    "status" "EmployeeStatus" NOT NULL,
+    "effective_date" DATE NOT NULL,
+    "reason" TEXT,
+    "employee_id" INTEGER NOT NULL REFERENCES "employees"("id") ON DELETE CASCADE,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- CreateIndex
+CREATE INDEX "employee_status_histories_employee_id_idx" ON "employee_status_histories"("employee_id");
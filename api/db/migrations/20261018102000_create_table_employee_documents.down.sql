@@ -0,0 +1,17 @@
+-- DropTable
+DROP TABLE IF EXISTS "employee_documents";
+
+-- DropEnum
+DROP TYPE IF EXISTS "EmployeeDocumentType";
+
+-- AlterTable: employees
+ALTER TABLE "employees"
+    DROP COLUMN IF EXISTS "nik",
+    DROP COLUMN IF EXISTS "birth_date",
+    DROP COLUMN IF EXISTS "address",
+    DROP COLUMN IF EXISTS "phone",
+    DROP COLUMN IF EXISTS "emergency_contact_name",
+    DROP COLUMN IF EXISTS "emergency_contact_phone",
+    DROP COLUMN IF EXISTS "bank_name",
+    DROP COLUMN IF EXISTS "bank_account_number",
+    DROP COLUMN IF EXISTS "bank_account_name";
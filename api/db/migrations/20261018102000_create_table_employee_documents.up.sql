@@ -0,0 +1,33 @@
+-- AlterTable: employees
+ALTER TABLE "employees"
+    ADD COLUMN "nik" VARCHAR(16) UNIQUE,
+    ADD COLUMN "birth_date" DATE,
+    ADD COLUMN "address" TEXT,
+    ADD COLUMN "phone" VARCHAR(20),
+    ADD COLUMN "emergency_contact_name" VARCHAR(100),
+    ADD COLUMN "emergency_contact_phone" VARCHAR(20),
+    ADD COLUMN "bank_name" VARCHAR(50),
+    ADD COLUMN "bank_account_number" VARCHAR(30),
+    ADD COLUMN "bank_account_name" VARCHAR(100);
+
+-- CreateEnum
+CREATE TYPE "EmployeeDocumentType" AS ENUM ('KTP', 'SIM', 'KK', 'NPWP', 'OTHER');
+
+-- CreateTable: employee_documents
+CREATE TABLE "employee_documents" (
+    "id" SERIAL PRIMARY KEY,
+    "type" "EmployeeDocumentType" NOT NULL,
+    "file_name" VARCHAR(255) NOT NULL,
+    "storage_key" VARCHAR(255) NOT NULL UNIQUE,
+    "content_type" VARCHAR(100) NOT NULL,
+    "size" BIGINT NOT NULL,
+    "notes" TEXT,
+    "employee_id" INTEGER NOT NULL REFERENCES "employees"("id") ON DELETE CASCADE,
+    "uploaded_by" VARCHAR REFERENCES "users"("id") ON DELETE SET NULL,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" TIMESTAMP(3)
+);
+
+-- CreateIndex
+CREATE INDEX "employee_documents_employee_id_idx" ON "employee_documents"("employee_id");
@@ -0,0 +1,7 @@
+-- DropTable
+DROP TABLE IF EXISTS "vehicle_documents";
+DROP TABLE IF EXISTS "driver_licenses";
+
+-- DropEnum
+DROP TYPE IF EXISTS "VehicleDocumentType";
+DROP TYPE IF EXISTS "LicenseClass";
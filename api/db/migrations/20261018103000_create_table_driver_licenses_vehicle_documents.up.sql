@@ -0,0 +1,35 @@
+-- CreateEnum
+CREATE TYPE "LicenseClass" AS ENUM ('A', 'A_UMUM', 'B1', 'B1_UMUM', 'B2', 'B2_UMUM');
+
+-- CreateEnum
+CREATE TYPE "VehicleDocumentType" AS ENUM ('STNK', 'KIR', 'INSURANCE');
+
+-- CreateTable: driver_licenses
+CREATE TABLE "driver_licenses" (
+    "id" SERIAL PRIMARY KEY,
+    "number" VARCHAR(30) NOT NULL,
+    "class" "LicenseClass" NOT NULL,
+    "expiry_date" DATE NOT NULL,
+    "employee_id" INTEGER NOT NULL REFERENCES "employees"("id") ON DELETE CASCADE,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" TIMESTAMP(3)
+);
+
+-- CreateTable: vehicle_documents
+CREATE TABLE "vehicle_documents" (
+    "id" SERIAL PRIMARY KEY,
+    "type" "VehicleDocumentType" NOT NULL,
+    "number" VARCHAR(50) NOT NULL,
+    "expiry_date" DATE NOT NULL,
+    "vehicle_id" INTEGER NOT NULL REFERENCES "vehicles"("id") ON DELETE CASCADE,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" TIMESTAMP(3)
+);
+
+-- CreateIndex
+CREATE INDEX "driver_licenses_employee_id_idx" ON "driver_licenses"("employee_id");
+CREATE INDEX "driver_licenses_expiry_date_idx" ON "driver_licenses"("expiry_date");
+CREATE INDEX "vehicle_documents_vehicle_id_idx" ON "vehicle_documents"("vehicle_id");
+CREATE INDEX "vehicle_documents_expiry_date_idx" ON "vehicle_documents"("expiry_date");
@@ -0,0 +1,3 @@
+-- DropTable
+DROP TABLE IF EXISTS "service_records";
+DROP TABLE IF EXISTS "maintenance_plans";
@@ -0,0 +1,38 @@
+-- CreateTable: maintenance_plans
+CREATE TABLE "maintenance_plans" (
+    "id" SERIAL PRIMARY KEY,
+    "service_type" VARCHAR(100) NOT NULL,
+    "interval_km" INTEGER,
+    "interval_days" INTEGER,
+    "start_date" DATE NOT NULL,
+    "start_odometer" INTEGER NOT NULL DEFAULT 0,
+    "is_active" BOOLEAN NOT NULL DEFAULT true,
+    "vehicle_id" INTEGER NOT NULL REFERENCES "vehicles"("id") ON DELETE CASCADE,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" TIMESTAMP(3),
+    CONSTRAINT "maintenance_plans_interval_check" CHECK ("interval_km" IS NOT NULL OR "interval_days" IS NOT NULL)
+);
+
+-- CreateTable: service_records
+CREATE TABLE "service_records" (
+    "id" SERIAL PRIMARY KEY,
+    "date" DATE NOT NULL,
+    "odometer" INTEGER NOT NULL,
+    "service_type" VARCHAR(100) NOT NULL,
+    "parts" TEXT,
+    "workshop" VARCHAR(100),
+    "cost" DECIMAL(12,2) NOT NULL,
+    "notes" TEXT,
+    "vehicle_id" INTEGER NOT NULL REFERENCES "vehicles"("id"),
+    "maintenance_plan_id" INTEGER REFERENCES "maintenance_plans"("id") ON DELETE SET NULL,
+    "vehicle_history_id" INTEGER REFERENCES "vehicle_history"("id") ON DELETE SET NULL,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" TIMESTAMP(3)
+);
+
+-- CreateIndex
+CREATE INDEX "maintenance_plans_vehicle_id_idx" ON "maintenance_plans"("vehicle_id");
+CREATE INDEX "service_records_vehicle_id_idx" ON "service_records"("vehicle_id");
+CREATE INDEX "service_records_maintenance_plan_id_idx" ON "service_records"("maintenance_plan_id");
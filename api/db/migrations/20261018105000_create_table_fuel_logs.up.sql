@@ -0,0 +1,20 @@
+-- CreateTable: fuel_logs
+CREATE TABLE "fuel_logs" (
+    "id" SERIAL PRIMARY KEY,
+    "date" DATE NOT NULL,
+    "liters" DECIMAL(8,2) NOT NULL,
+    "price_per_liter" DECIMAL(12,2) NOT NULL,
+    "total_cost" DECIMAL(12,2) NOT NULL,
+    "odometer" INTEGER NOT NULL,
+    "notes" TEXT,
+    "vehicle_id" INTEGER NOT NULL REFERENCES "vehicles"("id"),
+    "driver_id" INTEGER REFERENCES "employees"("id") ON DELETE SET NULL,
+    "vehicle_history_id" INTEGER REFERENCES "vehicle_history"("id") ON DELETE SET NULL,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" TIMESTAMP(3)
+);
+
+-- CreateIndex
+CREATE INDEX "fuel_logs_vehicle_id_odometer_idx" ON "fuel_logs"("vehicle_id", "odometer");
+CREATE INDEX "fuel_logs_driver_id_idx" ON "fuel_logs"("driver_id");
@@ -0,0 +1,6 @@
+-- AlterTable
+ALTER TABLE "trips" DROP COLUMN IF EXISTS "crew_id";
+
+-- DropTable
+DROP TABLE IF EXISTS "crew_members";
+DROP TABLE IF EXISTS "crews";
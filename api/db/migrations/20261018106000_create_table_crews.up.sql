@@ -0,0 +1,30 @@
+-- CreateTable: crews
+CREATE TABLE "crews" (
+    "id" SERIAL PRIMARY KEY,
+    "name" VARCHAR(100) NOT NULL,
+    "is_active" BOOLEAN NOT NULL DEFAULT true,
+    "vehicle_id" INTEGER REFERENCES "vehicles"("id") ON DELETE SET NULL,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "deleted_at" TIMESTAMP(3)
+);
+
+-- CreateTable: crew_members
+CREATE TABLE "crew_members" (
+    "id" SERIAL PRIMARY KEY,
+    "start_date" DATE NOT NULL,
+    "end_date" DATE,
+    "crew_id" INTEGER NOT NULL REFERENCES "crews"("id") ON DELETE CASCADE,
+    "employee_id" INTEGER NOT NULL REFERENCES "employees"("id") ON DELETE CASCADE,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT "crew_members_date_check" CHECK ("end_date" IS NULL OR "end_date" >= "start_date")
+);
+
+-- AlterTable
+ALTER TABLE "trips" ADD COLUMN "crew_id" INTEGER REFERENCES "crews"("id") ON DELETE SET NULL;
+
+-- CreateIndex
+CREATE UNIQUE INDEX "crews_name_key" ON "crews"("name") WHERE "deleted_at" IS NULL;
+CREATE INDEX "crew_members_crew_id_idx" ON "crew_members"("crew_id");
+CREATE INDEX "crew_members_employee_id_idx" ON "crew_members"("employee_id");
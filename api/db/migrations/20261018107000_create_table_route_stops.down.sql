@@ -0,0 +1,5 @@
+-- DropTable
+DROP TABLE IF EXISTS "route_stops";
+
+-- AlterTable
+ALTER TABLE "routes" DROP COLUMN IF EXISTS "visit_days";
@@ -0,0 +1,18 @@
+-- AlterTable
+ALTER TABLE "routes" ADD COLUMN "visit_days" JSONB NOT NULL DEFAULT '[]';
+
+-- CreateTable: route_stops
+CREATE TABLE "route_stops" (
+    "id" SERIAL PRIMARY KEY,
+    "sequence" INTEGER NOT NULL,
+    "name" VARCHAR(100) NOT NULL,
+    "address" TEXT,
+    "latitude" DECIMAL(10,7) NOT NULL,
+    "longitude" DECIMAL(10,7) NOT NULL,
+    "route_id" INTEGER NOT NULL REFERENCES "routes"("id") ON DELETE CASCADE,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- CreateIndex
+CREATE UNIQUE INDEX "route_stops_route_id_sequence_key" ON "route_stops"("route_id", "sequence");
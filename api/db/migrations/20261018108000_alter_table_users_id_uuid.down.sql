@@ -0,0 +1,21 @@
+-- DropForeignKey
+ALTER TABLE "periods" DROP CONSTRAINT IF EXISTS "periods_closed_by_fkey";
+ALTER TABLE "period_closures" DROP CONSTRAINT IF EXISTS "period_closures_closed_by_fkey";
+ALTER TABLE "cash_deposits" DROP CONSTRAINT IF EXISTS "cash_deposits_received_by_fkey";
+ALTER TABLE "payrolls" DROP CONSTRAINT IF EXISTS "payrolls_paid_by_fkey";
+ALTER TABLE "employee_documents" DROP CONSTRAINT IF EXISTS "employee_documents_uploaded_by_fkey";
+
+-- AlterTable
+ALTER TABLE "users" ALTER COLUMN "id" TYPE VARCHAR(200);
+ALTER TABLE "periods" ALTER COLUMN "closed_by" TYPE VARCHAR;
+ALTER TABLE "period_closures" ALTER COLUMN "closed_by" TYPE VARCHAR;
+ALTER TABLE "cash_deposits" ALTER COLUMN "received_by" TYPE VARCHAR;
+ALTER TABLE "payrolls" ALTER COLUMN "paid_by" TYPE VARCHAR;
+ALTER TABLE "employee_documents" ALTER COLUMN "uploaded_by" TYPE VARCHAR;
+
+-- AddForeignKey
+ALTER TABLE "periods" ADD CONSTRAINT "periods_closed_by_fkey" FOREIGN KEY ("closed_by") REFERENCES "users"("id") ON DELETE SET NULL;
+ALTER TABLE "period_closures" ADD CONSTRAINT "period_closures_closed_by_fkey" FOREIGN KEY ("closed_by") REFERENCES "users"("id") ON DELETE SET NULL;
+ALTER TABLE "cash_deposits" ADD CONSTRAINT "cash_deposits_received_by_fkey" FOREIGN KEY ("received_by") REFERENCES "users"("id") ON DELETE SET NULL;
+ALTER TABLE "payrolls" ADD CONSTRAINT "payrolls_paid_by_fkey" FOREIGN KEY ("paid_by") REFERENCES "users"("id") ON DELETE SET NULL;
+ALTER TABLE "employee_documents" ADD CONSTRAINT "employee_documents_uploaded_by_fkey" FOREIGN KEY ("uploaded_by") REFERENCES "users"("id") ON DELETE SET NULL;
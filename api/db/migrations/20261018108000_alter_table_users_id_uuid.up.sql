@@ -0,0 +1,21 @@
+-- DropForeignKey: columns referencing users.id
+ALTER TABLE "periods" DROP CONSTRAINT "periods_closed_by_fkey";
+ALTER TABLE "period_closures" DROP CONSTRAINT "period_closures_closed_by_fkey";
+ALTER TABLE "cash_deposits" DROP CONSTRAINT "cash_deposits_received_by_fkey";
+ALTER TABLE "payrolls" DROP CONSTRAINT "payrolls_paid_by_fkey";
+ALTER TABLE "employee_documents" DROP CONSTRAINT "employee_documents_uploaded_by_fkey";
+
+-- AlterTable: users ids are generated as uuid by the application
+ALTER TABLE "users" ALTER COLUMN "id" TYPE UUID USING "id"::uuid;
+ALTER TABLE "periods" ALTER COLUMN "closed_by" TYPE UUID USING "closed_by"::uuid;
+ALTER TABLE "period_closures" ALTER COLUMN "closed_by" TYPE UUID USING "closed_by"::uuid;
+ALTER TABLE "cash_deposits" ALTER COLUMN "received_by" TYPE UUID USING "received_by"::uuid;
+ALTER TABLE "payrolls" ALTER COLUMN "paid_by" TYPE UUID USING "paid_by"::uuid;
+ALTER TABLE "employee_documents" ALTER COLUMN "uploaded_by" TYPE UUID USING "uploaded_by"::uuid;
+
+-- AddForeignKey
+ALTER TABLE "periods" ADD CONSTRAINT "periods_closed_by_fkey" FOREIGN KEY ("closed_by") REFERENCES "users"("id") ON DELETE SET NULL;
+ALTER TABLE "period_closures" ADD CONSTRAINT "period_closures_closed_by_fkey" FOREIGN KEY ("closed_by") REFERENCES "users"("id") ON DELETE SET NULL;
+ALTER TABLE "cash_deposits" ADD CONSTRAINT "cash_deposits_received_by_fkey" FOREIGN KEY ("received_by") REFERENCES "users"("id") ON DELETE SET NULL;
+ALTER TABLE "payrolls" ADD CONSTRAINT "payrolls_paid_by_fkey" FOREIGN KEY ("paid_by") REFERENCES "users"("id") ON DELETE SET NULL;
+ALTER TABLE "employee_documents" ADD CONSTRAINT "employee_documents_uploaded_by_fkey" FOREIGN KEY ("uploaded_by") REFERENCES "users"("id") ON DELETE SET NULL;
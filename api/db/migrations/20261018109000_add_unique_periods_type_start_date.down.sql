@@ -0,0 +1,2 @@
+-- DropUniqueConstraint: merged duplicates are not restored
+ALTER TABLE "periods" DROP CONSTRAINT "periods_type_start_date_key";
@@ -0,0 +1,46 @@
+-- MergeDuplicates: concurrent requests could insert the same week twice, the
+-- oldest live row is kept and every reference is moved onto it
+CREATE TEMP TABLE "period_duplicates" ON COMMIT DROP AS
+SELECT "id", "keeper_id"
+FROM (
+    SELECT "id", FIRST_VALUE("id") OVER (
+        PARTITION BY "type", "start_date"
+        ORDER BY "deleted_at" IS NOT NULL, "id"
+    ) AS "keeper_id"
+    FROM "periods"
+) AS "ranked"
+WHERE "id" <> "keeper_id";
+
+UPDATE "employee_attendances" SET "period_id" = d."keeper_id"
+FROM "period_duplicates" d WHERE "employee_attendances"."period_id" = d."id";
+
+UPDATE "collections" SET "period_id" = d."keeper_id"
+FROM "period_duplicates" d WHERE "collections"."period_id" = d."id";
+
+-- payrolls and closures are unique per period, the keeper's row wins
+DELETE FROM "payrolls" p
+USING "period_duplicates" d
+WHERE p."period_id" = d."id"
+  AND EXISTS (
+    SELECT 1 FROM "payrolls" k
+    WHERE k."period_id" = d."keeper_id" AND k."employee_id" = p."employee_id"
+  );
+
+UPDATE "payrolls" SET "period_id" = d."keeper_id"
+FROM "period_duplicates" d WHERE "payrolls"."period_id" = d."id";
+
+DELETE FROM "period_closures" c
+USING "period_duplicates" d
+WHERE c."period_id" = d."id"
+  AND EXISTS (
+    SELECT 1 FROM "period_closures" k
+    WHERE k."period_id" = d."keeper_id" AND k."module_name" = c."module_name"
+  );
+
+UPDATE "period_closures" SET "period_id" = d."keeper_id"
+FROM "period_duplicates" d WHERE "period_closures"."period_id" = d."id";
+
+DELETE FROM "periods" WHERE "id" IN (SELECT "id" FROM "period_duplicates");
+
+-- AddUniqueConstraint
+ALTER TABLE "periods" ADD CONSTRAINT "periods_type_start_date_key" UNIQUE ("type", "start_date");
@@ -0,0 +1,14 @@
+-- AlterTable
+ALTER TABLE "periods" DROP CONSTRAINT IF EXISTS "periods_week_start_check";
+ALTER TABLE "periods" DROP COLUMN IF EXISTS "month_rule";
+ALTER TABLE "periods" DROP COLUMN IF EXISTS "week_start";
+
+-- DropEnum
+DROP TYPE IF EXISTS "PeriodMonthRule";
+
+-- AlterEnum: postgres cannot drop an enum value, the type is rebuilt and
+-- fails while BIWEEKLY periods remain
+ALTER TABLE "periods" ALTER COLUMN "type" TYPE TEXT;
+DROP TYPE "PeriodType";
+CREATE TYPE "PeriodType" AS ENUM ('WEEKLY', 'MONTHLY');
+ALTER TABLE "periods" ALTER COLUMN "type" TYPE "PeriodType" USING "type"::"PeriodType";
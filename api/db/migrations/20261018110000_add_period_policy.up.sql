@@ -0,0 +1,11 @@
+-- AlterEnum
+ALTER TYPE "PeriodType" ADD VALUE IF NOT EXISTS 'BIWEEKLY' AFTER 'WEEKLY';
+
+-- CreateEnum
+CREATE TYPE "PeriodMonthRule" AS ENUM ('START', 'END', 'MAJORITY');
+
+-- AlterTable: periods remember the policy they were calculated with,
+-- week_start follows go's time.Weekday with sunday as 0
+ALTER TABLE "periods" ADD COLUMN "week_start" SMALLINT NOT NULL DEFAULT 0;
+ALTER TABLE "periods" ADD COLUMN "month_rule" "PeriodMonthRule" NOT NULL DEFAULT 'START';
+ALTER TABLE "periods" ADD CONSTRAINT "periods_week_start_check" CHECK ("week_start" BETWEEN 0 AND 6);
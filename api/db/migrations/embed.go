@@ -0,0 +1,8 @@
+// Package migrations ships the SQL migrations inside the binary so the api
+// and the migrate command do not depend on the working directory.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -4,13 +4,13 @@ import (
 	"api/internal/delivery/http"
 	"api/internal/delivery/http/middleware"
 	"api/internal/delivery/http/route"
+	"api/internal/entity/enum"
 	"api/internal/repository"
 	"api/internal/usecase"
 	"api/internal/utils"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
-	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 	"gorm.io/gorm"
@@ -22,46 +22,95 @@ type BootstrapConfig struct {
 	DB       *gorm.DB
 	Config   *viper.Viper
 	Validate *validator.Validate
-	Redis    *redis.Client
+	Sessions utils.SessionStore
 }
 
 func Bootstrap(config *BootstrapConfig) {
 	utils.InitValidator()
-	tokenUtil := utils.NewTokenUtil(config.Config.GetString("secret_key"), config.Redis)
+	tokenUtil := utils.NewTokenUtil(config.Config.GetString("secret_key"), config.Sessions)
+	fileStorage := utils.NewLocalFileStorage(config.Config.GetString("storage.path"))
+	weekCalendar := NewWeekCalendar(config.Config, config.Log)
+	transactor := repository.NewGormTransactor(config.DB)
 
 	// Repository
 	userRepository := repository.NewUserRepository(config.Log)
 	routeRepository := repository.NewRouteRepository(config.Log)
 	salesRepository := repository.NewSalesRepository(config.Log)
 	employeeRepository := repository.NewEmployeeRepository(config.Log)
+	employeeStatusHistoryRepository := repository.NewEmployeeStatusHistoryRepository(config.Log)
+	employeeDocumentRepository := repository.NewEmployeeDocumentRepository(config.Log)
+	driverLicenseRepository := repository.NewDriverLicenseRepository(config.Log)
+	vehicleDocumentRepository := repository.NewVehicleDocumentRepository(config.Log)
+	maintenancePlanRepository := repository.NewMaintenancePlanRepository(config.Log)
+	serviceRecordRepository := repository.NewServiceRecordRepository(config.Log)
+	fuelLogRepository := repository.NewFuelLogRepository(config.Log)
+	crewRepository := repository.NewCrewRepository(config.Log)
+	crewMemberRepository := repository.NewCrewMemberRepository(config.Log)
 	employeeAttendanceRepository := repository.NewEmployeeAttendanceRepository(config.Log)
 	periodRepository := repository.NewPeriodRepository(config.Log)
 	factoryRepository := repository.NewFactoryRepository(config.Log)
 	vehicleRepository := repository.NewVehicleRepository(config.Log)
+	customerRepository := repository.NewCustomerRepository(config.Log)
+	receivableRepository := repository.NewReceivableRepository(config.Log)
+	collectionRepository := repository.NewCollectionRepository(config.Log)
+	cashDepositRepository := repository.NewCashDepositRepository(config.Log)
+	vehicleHistoryRepository := repository.NewVehicleHistoryRepository(config.Log)
+	tripRepository := repository.NewTripRepository(config.Log)
+	payRuleRepository := repository.NewPayRuleRepository(config.Log)
+	payrollRepository := repository.NewPayrollRepository(config.Log)
+	cashAdvanceRepository := repository.NewCashAdvanceRepository(config.Log)
+	cashAdvanceRepaymentRepository := repository.NewCashAdvanceRepaymentRepository(config.Log)
+	periodClosureRepository := repository.NewPeriodClosureRepository(config.Log)
+	geofenceRepository := repository.NewGeofenceRepository(config.Log)
 
 	// UseCase
-	userUseCase := usecase.NewUserUseCase(config.DB, config.Log, config.Validate, userRepository, tokenUtil)
-	routeUseCase := usecase.NewRouteUseCase(config.DB, config.Log, config.Validate, routeRepository, routeRepository)
-	salesUseCase := usecase.NewSalesUseCase(config.DB, config.Log, config.Validate, salesRepository, routeRepository, employeeRepository)
-	periodUseCase := usecase.NewPeriodUseCase(config.DB, config.Log, config.Validate, periodRepository)
-	employeeUseCase := usecase.NewEmployeeUseCase(config.DB, config.Log, config.Validate, employeeRepository, routeRepository, salesRepository)
-	employeeAttendanceUseCase := usecase.NewEmployeeAttendanceUseCase(config.DB, config.Log, config.Validate, employeeAttendanceRepository, periodUseCase)
-	factoryUseCase := usecase.NewFactoryUseCase(config.DB, config.Log, config.Validate, factoryRepository)
-	vehicleUseCase := usecase.NewVehicleUseCase(config.DB, config.Log, config.Validate, vehicleRepository)
+	userUseCase := usecase.NewUserUseCase(transactor, config.Log, config.Validate, userRepository, employeeRepository, tokenUtil)
+	routeUseCase := usecase.NewRouteUseCase(transactor, config.Log, config.Validate, routeRepository, routeRepository)
+	salesUseCase := usecase.NewSalesUseCase(transactor, config.Log, config.Validate, salesRepository, routeRepository, employeeRepository)
+	periodUseCase := usecase.NewPeriodUseCase(transactor, config.Log, config.Validate, periodRepository, periodClosureRepository, weekCalendar)
+	employeeUseCase := usecase.NewEmployeeUseCase(transactor, config.Log, config.Validate, employeeRepository, routeRepository, salesRepository, employeeStatusHistoryRepository)
+	employeeAttendanceUseCase := usecase.NewEmployeeAttendanceUseCase(transactor, config.Log, config.Validate, employeeAttendanceRepository, employeeRepository, geofenceRepository, periodUseCase)
+	factoryUseCase := usecase.NewFactoryUseCase(transactor, config.Log, config.Validate, factoryRepository)
+	vehicleUseCase := usecase.NewVehicleUseCase(transactor, config.Log, config.Validate, vehicleRepository)
+	customerUseCase := usecase.NewCustomerUseCase(transactor, config.Log, config.Validate, customerRepository, routeRepository)
+	collectionUseCase := usecase.NewCollectionUseCase(transactor, config.Log, config.Validate, customerRepository, salesRepository, receivableRepository, collectionRepository, cashDepositRepository, periodUseCase)
+	tripUseCase := usecase.NewTripUseCase(transactor, config.Log, config.Validate, tripRepository, vehicleRepository, vehicleHistoryRepository, employeeRepository, routeRepository, employeeAttendanceRepository, driverLicenseRepository, vehicleDocumentRepository, crewRepository, periodUseCase)
+	payrollUseCase := usecase.NewPayrollUseCase(transactor, config.Log, config.Validate, payrollRepository, payRuleRepository, periodRepository, employeeRepository, employeeAttendanceRepository, tripRepository, cashAdvanceRepository, cashAdvanceRepaymentRepository, periodClosureRepository)
+	geofenceUseCase := usecase.NewGeofenceUseCase(transactor, config.Log, config.Validate, geofenceRepository, factoryRepository)
+	cashAdvanceUseCase := usecase.NewCashAdvanceUseCase(transactor, config.Log, config.Validate, cashAdvanceRepository, cashAdvanceRepaymentRepository, employeeRepository)
+	employeeDocumentUseCase := usecase.NewEmployeeDocumentUseCase(transactor, config.Log, config.Validate, fileStorage, employeeRepository, employeeDocumentRepository)
+	documentExpiryUseCase := usecase.NewDocumentExpiryUseCase(transactor, config.Log, config.Validate, employeeRepository, vehicleRepository, driverLicenseRepository, vehicleDocumentRepository)
+	maintenanceUseCase := usecase.NewMaintenanceUseCase(transactor, config.Log, config.Validate, vehicleRepository, vehicleHistoryRepository, maintenancePlanRepository, serviceRecordRepository, fuelLogRepository)
+	crewUseCase := usecase.NewCrewUseCase(transactor, config.Log, config.Validate, crewRepository, crewMemberRepository, employeeRepository, vehicleRepository, employeeAttendanceRepository, periodUseCase)
+	fuelLogUseCase := usecase.NewFuelLogUseCase(transactor, config.Log, config.Validate, fuelLogRepository, vehicleRepository, vehicleHistoryRepository, employeeRepository)
 
 	// Controller
 	userController := http.NewUserController(userUseCase, config.Log)
 	routeController := http.NewRouteController(routeUseCase, config.Log)
 	salesController := http.NewSalesController(salesUseCase, config.Log)
 	employeeController := http.NewEmployeeController(employeeUseCase, config.Log)
-	employeeAttendanceController := http.NewEmployeeAttendanceController(employeeAttendanceUseCase, config.Log)
+	employeeAttendanceController := http.NewEmployeeAttendanceController(employeeAttendanceUseCase, userUseCase, config.Log)
 	factoryController := http.NewFactoryController(factoryUseCase, config.Log)
 	vehicleController := http.NewVehicleController(vehicleUseCase, config.Log)
+	customerController := http.NewCustomerController(customerUseCase, config.Log)
+	collectionController := http.NewCollectionController(collectionUseCase, config.Log)
+	tripController := http.NewTripController(tripUseCase, config.Log)
+	payrollController := http.NewPayrollController(payrollUseCase, config.Log)
+	cashAdvanceController := http.NewCashAdvanceController(cashAdvanceUseCase, config.Log)
+	geofenceController := http.NewGeofenceController(geofenceUseCase, config.Log)
+	meController := http.NewMeController(userUseCase, employeeAttendanceUseCase, payrollUseCase, config.Log)
+	employeeDocumentController := http.NewEmployeeDocumentController(employeeDocumentUseCase, config.Log)
+	documentExpiryController := http.NewDocumentExpiryController(documentExpiryUseCase, config.Log)
+	maintenanceController := http.NewMaintenanceController(maintenanceUseCase, config.Log)
+	fuelLogController := http.NewFuelLogController(fuelLogUseCase, config.Log)
+	crewController := http.NewCrewController(crewUseCase, config.Log)
+	periodController := http.NewPeriodController(periodUseCase, config.Log)
 
 	// hello
 	helloController := http.NewHelloController()
 
-	authMiddleware := middleware.NewAuth(tokenUtil, config.Log, config.Redis)
+	authMiddleware := middleware.NewAuth(userUseCase, tokenUtil, config.Log)
+	staffMiddleware := middleware.NewDenyRoles(config.Log, enum.USER_EMPLOYEE)
 
 	routeConfig := route.RouteConfig{
 		App:                          config.App,
@@ -73,8 +122,22 @@ func Bootstrap(config *BootstrapConfig) {
 		EmployeeAttendanceController: employeeAttendanceController,
 		FactoryController:            factoryController,
 		VehicleController:            vehicleController,
+		CustomerController:           customerController,
+		CollectionController:         collectionController,
+		TripController:               tripController,
+		PayrollController:            payrollController,
+		CashAdvanceController:        cashAdvanceController,
+		GeofenceController:           geofenceController,
+		MeController:                 meController,
+		EmployeeDocumentController:   employeeDocumentController,
+		DocumentExpiryController:     documentExpiryController,
+		MaintenanceController:        maintenanceController,
+		FuelLogController:            fuelLogController,
+		CrewController:               crewController,
+		PeriodController:             periodController,
 		HelloController:              helloController,
 		AuthMiddleware:               authMiddleware,
+		StaffMiddleware:              staffMiddleware,
 	}
 
 	routeConfig.Setup()
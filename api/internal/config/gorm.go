@@ -12,29 +12,24 @@ import (
 )
 
 func NewDatabase(v *viper.Viper, log *logrus.Logger, isTest bool) *gorm.DB {
-	// select database
-	var dbConfig *viper.Viper
+	// select database, read through the full key so env overrides apply
+	prefix := "database"
 	if isTest {
-		dbConfig = v.Sub("testing.database")
-		if dbConfig == nil {
-			log.Fatalf("testing.database configuration not found")
-		}
-	} else {
-		dbConfig = v.Sub("database")
-		if dbConfig == nil {
-			log.Fatalf("database configuration not found")
-		}
+		prefix = "testing.database"
+	}
+	if !v.IsSet(prefix + ".name") {
+		log.Fatalf("%s configuration not found", prefix)
 	}
 
 	// Get configuration database
-	username := dbConfig.GetString("username")
-	password := dbConfig.GetString("password")
-	host := dbConfig.GetString("host")
-	port := dbConfig.GetInt("port")
-	database := dbConfig.GetString("name")
-	idleConnection := dbConfig.GetInt("pool.idle")
-	maxConnection := dbConfig.GetInt("pool.max")
-	maxLifeTimeConnection := dbConfig.GetInt("pool.lifetime")
+	username := v.GetString(prefix + ".username")
+	password := v.GetString(prefix + ".password")
+	host := v.GetString(prefix + ".host")
+	port := v.GetInt(prefix + ".port")
+	database := v.GetString(prefix + ".name")
+	idleConnection := v.GetInt(prefix + ".pool.idle")
+	maxConnection := v.GetInt(prefix + ".pool.max")
+	maxLifeTimeConnection := v.GetInt(prefix + ".pool.lifetime")
 
 	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=disable TimeZone=Asia/Jakarta", host, username, password, database, port)
 
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"api/internal/entity/enum"
+	"api/internal/utils"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+var weekdays = map[string]time.Weekday{
+	"SUNDAY":    time.Sunday,
+	"MONDAY":    time.Monday,
+	"TUESDAY":   time.Tuesday,
+	"WEDNESDAY": time.Wednesday,
+	"THURSDAY":  time.Thursday,
+	"FRIDAY":    time.Friday,
+	"SATURDAY":  time.Saturday,
+}
+
+// NewWeekCalendar reads the period policy, an invalid value stops the application
+// instead of silently numbering periods another way
+func NewWeekCalendar(viper *viper.Viper, log *logrus.Logger) *utils.WeekCalendar {
+	periodType := enum.PeriodType(strings.ToUpper(viper.GetString("period.type")))
+	if !slices.Contains([]enum.PeriodType{enum.WEEKLY, enum.BIWEEKLY, enum.MONTHLY}, periodType) {
+		log.Fatalf("invalid period.type %q", periodType)
+	}
+
+	weekStart, ok := weekdays[strings.ToUpper(viper.GetString("period.week_start"))]
+	if !ok {
+		log.Fatalf("invalid period.week_start %q", viper.GetString("period.week_start"))
+	}
+
+	monthRule := enum.PeriodMonthRule(strings.ToUpper(viper.GetString("period.month_rule")))
+	if !slices.Contains([]enum.PeriodMonthRule{enum.MONTH_RULE_START, enum.MONTH_RULE_END, enum.MONTH_RULE_MAJORITY}, monthRule) {
+		log.Fatalf("invalid period.month_rule %q", monthRule)
+	}
+
+	epoch, err := time.Parse("2006-01-02", viper.GetString("period.epoch"))
+	if err != nil {
+		log.Fatalf("invalid period.epoch: %v", err)
+	}
+
+	return &utils.WeekCalendar{
+		Type:      periodType,
+		WeekStart: weekStart,
+		MonthRule: monthRule,
+		Epoch:     epoch,
+	}
+}
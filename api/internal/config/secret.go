@@ -0,0 +1,105 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// MinSecretKeyLength is the shortest accepted token signing key, 32 bytes
+// matches the HS256 hash size
+const MinSecretKeyLength = 32
+
+// secretKeys are never printed
+var secretKeys = []string{
+	"secret_key",
+	"database.password",
+	"testing.database.password",
+}
+
+// knownSecrets are values shipped in samples or used in development, a
+// production secret must not be one of them
+var knownSecrets = []string{
+	"sumber_rezeki",
+	"sumber-rezeki-development-secret-key",
+	"p@ssw0rd",
+	"password",
+	"postgres",
+	"secret",
+	"changeme",
+	"change_me",
+}
+
+// ValidateSecrets fails on a missing or weak secret, the application must not
+// start signing tokens with an empty or public key. A secret may come from the
+// environment or the config file, the environment wins.
+func ValidateSecrets(config *viper.Viper, isTest bool) error {
+	databaseKey := "database.password"
+	if isTest {
+		databaseKey = "testing.database.password"
+	}
+
+	errs := []error{validateSecret(config, "secret_key", isTest)}
+	if len(config.GetString("secret_key")) > 0 && len(config.GetString("secret_key")) < MinSecretKeyLength {
+		errs = append(errs, fmt.Errorf("secret_key must be at least %d characters", MinSecretKeyLength))
+	}
+	errs = append(errs, validateSecret(config, databaseKey, isTest))
+
+	return errors.Join(errs...)
+}
+
+// Helper fuction
+func validateSecret(config *viper.Viper, key string, isTest bool) error {
+	value := config.GetString(key)
+	switch {
+	case value == "":
+		return fmt.Errorf("%s is missing, set %s or %s_FILE", key, envName(key), envName(key))
+	case isTest:
+		return nil
+	case slices.Contains(knownSecrets, strings.ToLower(value)):
+		return fmt.Errorf("%s is a known default value, set a generated one", key)
+	}
+	return nil
+}
+
+// Redacted returns every setting with the secrets masked, an empty secret
+// stays empty so a missing one shows up. Variables are only seen for the keys
+// bound in envKeys or present in the config file, any other API_ variable is
+// not read by the application either.
+func Redacted(config *viper.Viper) map[string]any {
+	settings := make(map[string]any)
+
+	for _, key := range config.AllKeys() {
+		value := config.Get(key)
+		if isSecret(key) && config.GetString(key) != "" {
+			value = "******"
+		}
+
+		// rebuild the nesting of the key
+		parts := strings.Split(key, ".")
+		node := settings
+		for _, part := range parts[:len(parts)-1] {
+			child, ok := node[part].(map[string]any)
+			if !ok {
+				child = make(map[string]any)
+				node[part] = child
+			}
+			node = child
+		}
+		node[parts[len(parts)-1]] = value
+	}
+
+	return settings
+}
+
+func isSecret(key string) bool {
+	if slices.Contains(secretKeys, key) {
+		return true
+	}
+
+	name := key[strings.LastIndex(key, ".")+1:]
+	return strings.Contains(name, "password") || strings.Contains(name, "secret") || strings.Contains(name, "token")
+}
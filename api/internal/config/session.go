@@ -0,0 +1,26 @@
+package config
+
+import (
+	"api/internal/utils"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// NewSessionStore picks where issued tokens are kept. redis is the default,
+// memory runs a single instance without redis and forgets every login on restart.
+func NewSessionStore(viper *viper.Viper, log *logrus.Logger) utils.SessionStore {
+	store := strings.ToLower(viper.GetString("session.store"))
+
+	switch store {
+	case "", "redis":
+		return utils.NewRedisSessionStore(NewRedis(viper))
+	case "memory":
+		log.Warn("Sessions are kept in memory, logins are lost on restart")
+		return utils.NewMemorySessionStore()
+	default:
+		log.Fatalf("invalid session.store %q", store)
+		return nil
+	}
+}
@@ -1,11 +1,18 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/spf13/viper"
 )
 
+// EnvPrefix prefixes the environment overrides, nested keys join with an
+// underscore so database.password is API_DATABASE_PASSWORD
+const EnvPrefix = "API"
+
 func NewViper() *viper.Viper {
 	config := viper.New()
 
@@ -13,11 +20,97 @@ func NewViper() *viper.Viper {
 	config.SetConfigType("json")
 	config.AddConfigPath("./../")
 	config.AddConfigPath("./")
+	config.SetDefault("storage.path", "storage")
+	config.SetDefault("period.type", "WEEKLY")
+	config.SetDefault("period.week_start", "SUNDAY")
+	config.SetDefault("period.month_rule", "START")
+	config.SetDefault("period.epoch", "2024-01-01")
+	// the config file is optional, every key can come from the environment
 	err := config.ReadInConfig()
 
-	if err != nil {
+	var notFound viper.ConfigFileNotFoundError
+	if err != nil && !errors.As(err, &notFound) {
 		panic(fmt.Errorf("fatal error config file: %w", err))
 	}
 
+	if err := ApplyEnv(config); err != nil {
+		panic(fmt.Errorf("fatal error config env: %w", err))
+	}
+
 	return config
 }
+
+// envKeys are the keys the application reads. They are bound to their
+// variables so an override shows up in AllKeys even when the config file
+// does not have the key.
+var envKeys = []string{
+	"app.name",
+	"web.prefork",
+	"web.port",
+	"log.level",
+	"secret_key",
+	"cors_allow_origins",
+	"session.store",
+	"storage.path",
+	"redis.host",
+	"redis.database",
+	"period.type",
+	"period.week_start",
+	"period.month_rule",
+	"period.epoch",
+	"database.username",
+	"database.password",
+	"database.host",
+	"database.port",
+	"database.name",
+	"database.pool.idle",
+	"database.pool.max",
+	"database.pool.lifetime",
+	"testing.database.username",
+	"testing.database.password",
+	"testing.database.host",
+	"testing.database.port",
+	"testing.database.name",
+	"testing.database.pool.idle",
+	"testing.database.pool.max",
+	"testing.database.pool.lifetime",
+}
+
+// ApplyEnv lets the environment override any key. A key can also be read
+// from a file named by its variable with a _FILE suffix, like docker secrets,
+// the variable itself wins when both are set.
+func ApplyEnv(config *viper.Viper) error {
+	config.SetEnvPrefix(EnvPrefix)
+	config.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	config.AutomaticEnv()
+
+	for _, key := range envKeys {
+		if err := config.BindEnv(key); err != nil {
+			return err
+		}
+	}
+
+	for _, key := range config.AllKeys() {
+		if _, ok := os.LookupEnv(envName(key)); ok {
+			continue
+		}
+
+		name := envName(key) + "_FILE"
+		path, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", name, err)
+		}
+		config.Set(key, strings.TrimRight(string(content), "\r\n"))
+	}
+
+	return nil
+}
+
+func envName(key string) string {
+	return EnvPrefix + "_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
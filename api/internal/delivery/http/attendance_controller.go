@@ -1,6 +1,7 @@
 package http
 
 import (
+	"api/internal/delivery/http/middleware"
 	"api/internal/model"
 	"api/internal/usecase"
 
@@ -11,11 +12,13 @@ import (
 type EmployeeAttendanceController struct {
 	Log                       *logrus.Logger
 	EmployeeAttendanceUseCase usecase.EmployeeAttendanceUseCase
+	UserUseCase               usecase.UserUseCase
 }
 
-func NewEmployeeAttendanceController(useCase usecase.EmployeeAttendanceUseCase, logger *logrus.Logger) *EmployeeAttendanceController {
+func NewEmployeeAttendanceController(useCase usecase.EmployeeAttendanceUseCase, userUseCase usecase.UserUseCase, logger *logrus.Logger) *EmployeeAttendanceController {
 	return &EmployeeAttendanceController{
 		EmployeeAttendanceUseCase: useCase,
+		UserUseCase:               userUseCase,
 		Log:                       logger,
 	}
 }
@@ -39,3 +42,27 @@ func (c *EmployeeAttendanceController) Upsert(ctx *fiber.Ctx) error {
 	return ctx.Status(fiber.StatusOK).
 		JSON(model.WebResponse[interface{}]{Data: true})
 }
+
+func (c *EmployeeAttendanceController) CheckIn(ctx *fiber.Ctx) error {
+	// staff only check in for the employee linked to their own account
+	auth := middleware.GetUser(ctx)
+	employeeId, err := c.UserUseCase.FindEmployeeId(ctx.UserContext(), auth.ID)
+	if err != nil {
+		return err
+	}
+
+	request := new(model.CheckInRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+	request.EmployeeId = employeeId
+
+	response, err := c.EmployeeAttendanceUseCase.CheckIn(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to check in employee : %+v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.CheckInResponse]{Data: response})
+}
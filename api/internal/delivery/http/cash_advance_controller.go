@@ -0,0 +1,114 @@
+package http
+
+import (
+	"api/internal/model"
+	"api/internal/usecase"
+	"math"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type CashAdvanceController struct {
+	Log                *logrus.Logger
+	CashAdvanceUseCase usecase.CashAdvanceUseCase
+}
+
+func NewCashAdvanceController(useCase usecase.CashAdvanceUseCase, logger *logrus.Logger) *CashAdvanceController {
+	return &CashAdvanceController{
+		CashAdvanceUseCase: useCase,
+		Log:                logger,
+	}
+}
+
+func (c *CashAdvanceController) FindAll(ctx *fiber.Ctx) error {
+
+	request := &model.FindAllCashAdvanceRequest{
+		Page:            ctx.QueryInt("page"),
+		PerPage:         ctx.QueryInt("perPage"),
+		EmployeeId:      ctx.QueryInt("employeeId"),
+		OnlyOutstanding: ctx.QueryBool("onlyOutstanding"),
+	}
+
+	response, total, err := c.CashAdvanceUseCase.FindAll(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting cash advances")
+		return err
+	}
+
+	var paging *model.PageMetadata
+	if request.Page > 0 && request.PerPage > 0 {
+		paging = &model.PageMetadata{
+			Page:      request.Page,
+			PerPage:   request.PerPage,
+			TotalItem: total,
+			TotalPage: int64(math.Ceil(float64(total) / float64(request.PerPage))),
+		}
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.CashAdvanceResponse]{
+		Data:   response,
+		Paging: paging,
+	})
+}
+
+func (c *CashAdvanceController) Create(ctx *fiber.Ctx) error {
+	request := new(model.CreateCashAdvanceRequest)
+	err := ctx.BodyParser(request)
+	if err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	response, err := c.CashAdvanceUseCase.Create(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to create cash advance : %+v", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).
+		JSON(model.WebResponse[*model.CashAdvanceResponse]{Data: response})
+}
+
+func (c *CashAdvanceController) CreateRepayment(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request := new(model.CreateCashAdvanceRepaymentRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+	request.CashAdvanceId = id
+
+	response, err := c.CashAdvanceUseCase.CreateRepayment(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to create cash advance repayment : %+v", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).
+		JSON(model.WebResponse[*model.CashAdvanceRepaymentResponse]{Data: response})
+}
+
+func (c *CashAdvanceController) Statement(ctx *fiber.Ctx) error {
+	employeeId, err := strconv.Atoi(ctx.Params("employeeId"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request := &model.CashAdvanceStatementRequest{
+		EmployeeId: employeeId,
+	}
+
+	response, err := c.CashAdvanceUseCase.Statement(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting cash advance statement")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.CashAdvanceStatementResponse]{Data: response})
+}
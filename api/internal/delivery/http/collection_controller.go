@@ -0,0 +1,104 @@
+package http
+
+import (
+	"api/internal/delivery/http/middleware"
+	"api/internal/model"
+	"api/internal/usecase"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type CollectionController struct {
+	Log               *logrus.Logger
+	CollectionUseCase usecase.CollectionUseCase
+}
+
+func NewCollectionController(useCase usecase.CollectionUseCase, logger *logrus.Logger) *CollectionController {
+	return &CollectionController{
+		CollectionUseCase: useCase,
+		Log:               logger,
+	}
+}
+
+func (c *CollectionController) CreateReceivable(ctx *fiber.Ctx) error {
+	request := new(model.CreateReceivableRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	response, err := c.CollectionUseCase.CreateReceivable(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to create receivable : %+v", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).
+		JSON(model.WebResponse[*model.ReceivableResponse]{Data: response})
+}
+
+func (c *CollectionController) CreateCollection(ctx *fiber.Ctx) error {
+	request := new(model.CreateCollectionRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	response, err := c.CollectionUseCase.CreateCollection(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to create collection : %+v", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).
+		JSON(model.WebResponse[*model.CollectionResponse]{Data: response})
+}
+
+func (c *CollectionController) CollectionReport(ctx *fiber.Ctx) error {
+	request := &model.CollectionReportRequest{
+		PeriodId: ctx.QueryInt("periodId"),
+	}
+
+	response, err := c.CollectionUseCase.CollectionReport(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting collection report")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.CollectionReportResponse]{Data: response})
+}
+
+func (c *CollectionController) CreateCashDeposit(ctx *fiber.Ctx) error {
+	request := new(model.CreateCashDepositRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	auth := middleware.GetUser(ctx)
+	request.ReceivedBy = auth.ID
+
+	response, err := c.CollectionUseCase.CreateCashDeposit(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to create cash deposit : %+v", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).
+		JSON(model.WebResponse[*model.CashDepositResponse]{Data: response})
+}
+
+func (c *CollectionController) DepositReconciliation(ctx *fiber.Ctx) error {
+	request := &model.DepositReconciliationRequest{
+		Date: ctx.Query("date"),
+	}
+
+	response, err := c.CollectionUseCase.DepositReconciliation(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting deposit reconciliation")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.DepositReconciliationResponse]{Data: response})
+}
@@ -0,0 +1,178 @@
+package http
+
+import (
+	"api/internal/entity/enum"
+	"api/internal/model"
+	"api/internal/usecase"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type CrewController struct {
+	Log         *logrus.Logger
+	CrewUseCase usecase.CrewUseCase
+}
+
+func NewCrewController(useCase usecase.CrewUseCase, logger *logrus.Logger) *CrewController {
+	return &CrewController{
+		CrewUseCase: useCase,
+		Log:         logger,
+	}
+}
+
+func (c *CrewController) FindAll(ctx *fiber.Ctx) error {
+	request := &model.FindAllCrewRequest{
+		Date:       ctx.Query("date"),
+		OnlyActive: ctx.QueryBool("onlyActive"),
+	}
+
+	response, err := c.CrewUseCase.FindAll(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting crews")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.CrewResponse]{Data: response})
+}
+
+func (c *CrewController) FindById(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request := &model.FindByIdCrewRequest{
+		ID:   id,
+		Date: ctx.Query("date"),
+	}
+
+	response, err := c.CrewUseCase.FindById(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting crew")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.CrewResponse]{Data: response})
+}
+
+func (c *CrewController) Create(ctx *fiber.Ctx) error {
+	request := new(model.CreateCrewRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("error parsing request body")
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	response, err := c.CrewUseCase.Create(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error creating crew")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).
+		JSON(model.WebResponse[*model.CrewResponse]{Data: response})
+}
+
+func (c *CrewController) Update(ctx *fiber.Ctx) error {
+	request := new(model.UpdateCrewRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("error parsing request body")
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request.ID = id
+
+	response, err := c.CrewUseCase.Update(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error updating crew")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.CrewResponse]{Data: response})
+}
+
+func (c *CrewController) Delete(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request := &model.DeleteCrewRequest{
+		ID: id,
+	}
+
+	if err := c.CrewUseCase.Delete(ctx.UserContext(), request); err != nil {
+		c.Log.WithError(err).Error("error deleting crew")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[bool]{Data: true})
+}
+
+func (c *CrewController) AssignMembers(ctx *fiber.Ctx) error {
+	request := new(model.AssignCrewMembersRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("error parsing request body")
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request.ID = id
+
+	response, err := c.CrewUseCase.AssignMembers(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error assigning crew members")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.CrewResponse]{Data: response})
+}
+
+func (c *CrewController) FindMemberHistory(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request := &model.FindCrewMemberHistoryRequest{
+		ID: id,
+	}
+
+	response, err := c.CrewUseCase.FindMemberHistory(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting crew member history")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.CrewMemberResponse]{Data: response})
+}
+
+func (c *CrewController) MarkAttendance(ctx *fiber.Ctx) error {
+	request := new(model.MarkCrewAttendanceRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("error parsing request body")
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	if request.Status == "" {
+		request.Status = string(enum.PRESENT)
+	}
+
+	response, err := c.CrewUseCase.MarkAttendance(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error marking crew attendance")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.EmployeeAttendanceResponse]{Data: response})
+}
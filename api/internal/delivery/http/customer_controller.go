@@ -0,0 +1,113 @@
+package http
+
+import (
+	"api/internal/model"
+	"api/internal/usecase"
+	"math"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type CustomerController struct {
+	Log             *logrus.Logger
+	CustomerUseCase usecase.CustomerUseCase
+}
+
+func NewCustomerController(useCase usecase.CustomerUseCase, logger *logrus.Logger) *CustomerController {
+	return &CustomerController{
+		CustomerUseCase: useCase,
+		Log:             logger,
+	}
+}
+
+func (c *CustomerController) FindAll(ctx *fiber.Ctx) error {
+
+	request := &model.FindAllCustomerRequest{
+		Page:    ctx.QueryInt("page"),
+		PerPage: ctx.QueryInt("perPage"),
+		Search:  ctx.Query("search"),
+		RouteId: ctx.QueryInt("routeId"),
+	}
+
+	response, total, err := c.CustomerUseCase.FindAll(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting customers")
+		return err
+	}
+
+	var paging *model.PageMetadata
+	if request.Page > 0 && request.PerPage > 0 {
+		paging = &model.PageMetadata{
+			Page:      request.Page,
+			PerPage:   request.PerPage,
+			TotalItem: total,
+			TotalPage: int64(math.Ceil(float64(total) / float64(request.PerPage))),
+		}
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.CustomerResponse]{
+		Data:   response,
+		Paging: paging,
+	})
+}
+
+func (c *CustomerController) Create(ctx *fiber.Ctx) error {
+	request := new(model.CreateCustomerRequest)
+	err := ctx.BodyParser(request)
+	if err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	response, err := c.CustomerUseCase.Create(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to create customer : %+v", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).
+		JSON(model.WebResponse[*model.CustomerResponse]{Data: response})
+}
+
+func (c *CustomerController) Update(ctx *fiber.Ctx) error {
+	request := new(model.UpdateCustomerRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("error parsing request body")
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request.ID = id
+
+	response, err := c.CustomerUseCase.Update(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error updating customer")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.CustomerResponse]{Data: response})
+}
+
+func (c *CustomerController) Delete(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request := &model.DeleteCustomerRequest{
+		ID: id,
+	}
+
+	if err := c.CustomerUseCase.Delete(ctx.UserContext(), request); err != nil {
+		c.Log.WithError(err).Error("error deleting customer")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[bool]{Data: true})
+}
@@ -0,0 +1,170 @@
+package http
+
+import (
+	"api/internal/model"
+	"api/internal/usecase"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type DocumentExpiryController struct {
+	Log                   *logrus.Logger
+	DocumentExpiryUseCase usecase.DocumentExpiryUseCase
+}
+
+func NewDocumentExpiryController(useCase usecase.DocumentExpiryUseCase, logger *logrus.Logger) *DocumentExpiryController {
+	return &DocumentExpiryController{
+		DocumentExpiryUseCase: useCase,
+		Log:                   logger,
+	}
+}
+
+func (c *DocumentExpiryController) FindDriverLicenses(ctx *fiber.Ctx) error {
+	employeeId, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request := &model.FindAllDriverLicenseRequest{
+		EmployeeId: employeeId,
+	}
+
+	response, err := c.DocumentExpiryUseCase.FindDriverLicenses(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting driver licenses")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.DriverLicenseResponse]{Data: response})
+}
+
+func (c *DocumentExpiryController) CreateDriverLicense(ctx *fiber.Ctx) error {
+	request := new(model.CreateDriverLicenseRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("error parsing request body")
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	employeeId, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request.EmployeeId = employeeId
+
+	response, err := c.DocumentExpiryUseCase.CreateDriverLicense(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error creating driver license")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).
+		JSON(model.WebResponse[*model.DriverLicenseResponse]{Data: response})
+}
+
+func (c *DocumentExpiryController) DeleteDriverLicense(ctx *fiber.Ctx) error {
+	employeeId, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	id, err := strconv.Atoi(ctx.Params("licenseId"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request := &model.DeleteDriverLicenseRequest{
+		EmployeeId: employeeId,
+		ID:         id,
+	}
+
+	if err := c.DocumentExpiryUseCase.DeleteDriverLicense(ctx.UserContext(), request); err != nil {
+		c.Log.WithError(err).Error("error deleting driver license")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[bool]{Data: true})
+}
+
+func (c *DocumentExpiryController) FindVehicleDocuments(ctx *fiber.Ctx) error {
+	vehicleId, err := strconv.ParseInt(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request := &model.FindAllVehicleDocumentRequest{
+		VehicleId: vehicleId,
+	}
+
+	response, err := c.DocumentExpiryUseCase.FindVehicleDocuments(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting vehicle documents")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.VehicleDocumentResponse]{Data: response})
+}
+
+func (c *DocumentExpiryController) CreateVehicleDocument(ctx *fiber.Ctx) error {
+	request := new(model.CreateVehicleDocumentRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("error parsing request body")
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	vehicleId, err := strconv.ParseInt(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request.VehicleId = vehicleId
+
+	response, err := c.DocumentExpiryUseCase.CreateVehicleDocument(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error creating vehicle document")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).
+		JSON(model.WebResponse[*model.VehicleDocumentResponse]{Data: response})
+}
+
+func (c *DocumentExpiryController) DeleteVehicleDocument(ctx *fiber.Ctx) error {
+	vehicleId, err := strconv.ParseInt(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	id, err := strconv.Atoi(ctx.Params("documentId"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request := &model.DeleteVehicleDocumentRequest{
+		VehicleId: vehicleId,
+		ID:        id,
+	}
+
+	if err := c.DocumentExpiryUseCase.DeleteVehicleDocument(ctx.UserContext(), request); err != nil {
+		c.Log.WithError(err).Error("error deleting vehicle document")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[bool]{Data: true})
+}
+
+func (c *DocumentExpiryController) FindExpiring(ctx *fiber.Ctx) error {
+	request := &model.FindExpiringDocumentRequest{
+		Days: ctx.QueryInt("days", usecase.DEFAULT_EXPIRY_DAYS),
+	}
+
+	response, err := c.DocumentExpiryUseCase.FindExpiring(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting expiring documents")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.ExpiringDocumentResponse]{Data: response})
+}
@@ -31,6 +31,8 @@ func (c *EmployeeController) FindAll(ctx *fiber.Ctx) error {
 		PerPage: ctx.QueryInt("perPage"),
 		Name:    ctx.Query("search"),
 		// TODO: Salary
+
+		SubordinateOf: ctx.QueryInt("subordinateOf"),
 	}
 
 	rolesRaw := ctx.Context().QueryArgs().PeekMulti("roles[]")
@@ -41,6 +43,14 @@ func (c *EmployeeController) FindAll(ctx *fiber.Ctx) error {
 		}
 	}
 
+	statusesRaw := ctx.Context().QueryArgs().PeekMulti("statuses[]")
+	for _, s := range statusesRaw {
+		status := strings.TrimSpace(string(s))
+		if status != "" {
+			request.Statuses = append(request.Statuses, enum.EmployeeStatus(status))
+		}
+	}
+
 	response, total, err := c.EmployeeUseCase.FindAll(ctx.UserContext(), request)
 	if err != nil {
 		c.Log.WithError(err).Error("error getting user")
@@ -143,8 +153,9 @@ func (c *EmployeeController) FindById(ctx *fiber.Ctx) error {
 
 func (c *EmployeeController) FindAllWithAttendances(ctx *fiber.Ctx) error {
 	request := &model.FindAllEmployeeWithAttendanceRequest{
-		StartDate: ctx.Query("startDate"),
-		EndDate:   ctx.Query("endDate"),
+		StartDate:     ctx.Query("startDate"),
+		EndDate:       ctx.Query("endDate"),
+		SubordinateOf: ctx.QueryInt("subordinateOf"),
 	}
 
 	response, err := c.EmployeeUseCase.FindAllWithAttendances(ctx.UserContext(), request)
@@ -157,3 +168,60 @@ func (c *EmployeeController) FindAllWithAttendances(ctx *fiber.Ctx) error {
 		Data: response,
 	})
 }
+
+func (c *EmployeeController) ChangeStatus(ctx *fiber.Ctx) error {
+	request := new(model.ChangeEmployeeStatusRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("error parsing request body")
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request.ID = id
+
+	response, err := c.EmployeeUseCase.ChangeStatus(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error changing employee status")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).
+		JSON(model.WebResponse[*model.EmployeeStatusHistoryResponse]{Data: response})
+}
+
+func (c *EmployeeController) FindStatusHistory(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request := &model.FindEmployeeStatusHistoryRequest{
+		ID: id,
+	}
+
+	response, err := c.EmployeeUseCase.FindStatusHistory(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting employee status history")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.EmployeeStatusHistoryResponse]{Data: response})
+}
+
+func (c *EmployeeController) FindTree(ctx *fiber.Ctx) error {
+	request := &model.FindEmployeeTreeRequest{
+		RootId: ctx.QueryInt("rootId"),
+	}
+
+	response, err := c.EmployeeUseCase.FindTree(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting employee tree")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.EmployeeTreeResponse]{Data: response})
+}
@@ -0,0 +1,138 @@
+package http
+
+import (
+	"api/internal/delivery/http/middleware"
+	"api/internal/entity/enum"
+	"api/internal/model"
+	"api/internal/usecase"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type EmployeeDocumentController struct {
+	Log                     *logrus.Logger
+	EmployeeDocumentUseCase usecase.EmployeeDocumentUseCase
+}
+
+func NewEmployeeDocumentController(useCase usecase.EmployeeDocumentUseCase, logger *logrus.Logger) *EmployeeDocumentController {
+	return &EmployeeDocumentController{
+		EmployeeDocumentUseCase: useCase,
+		Log:                     logger,
+	}
+}
+
+func (c *EmployeeDocumentController) FindAll(ctx *fiber.Ctx) error {
+	employeeId, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request := &model.FindAllEmployeeDocumentRequest{
+		EmployeeId: employeeId,
+	}
+
+	response, err := c.EmployeeDocumentUseCase.FindAll(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting employee documents")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.EmployeeDocumentResponse]{Data: response})
+}
+
+func (c *EmployeeDocumentController) Upload(ctx *fiber.Ctx) error {
+	employeeId, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		c.Log.Warnf("Failed to read uploaded file : %+v", err)
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		c.Log.Warnf("Failed to open uploaded file : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+	defer file.Close()
+
+	request := &model.UploadEmployeeDocumentRequest{
+		EmployeeId:  employeeId,
+		Type:        enum.EmployeeDocumentType(ctx.FormValue("type")),
+		FileName:    filepath.Base(header.Filename),
+		ContentType: header.Header.Get(fiber.HeaderContentType),
+		Size:        header.Size,
+		Content:     file,
+		UploadedBy:  middleware.GetUser(ctx).ID,
+	}
+
+	if notes := ctx.FormValue("notes"); notes != "" {
+		request.Notes = &notes
+	}
+
+	response, err := c.EmployeeDocumentUseCase.Upload(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error uploading employee document")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).
+		JSON(model.WebResponse[*model.EmployeeDocumentResponse]{Data: response})
+}
+
+func (c *EmployeeDocumentController) Download(ctx *fiber.Ctx) error {
+	request, err := c.documentRequest(ctx)
+	if err != nil {
+		return err
+	}
+
+	document, err := c.EmployeeDocumentUseCase.Download(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error downloading employee document")
+		return err
+	}
+
+	ctx.Set(fiber.HeaderContentType, document.ContentType)
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("inline; filename=%q", document.FileName))
+
+	// fasthttp closes the stream once it is sent
+	return ctx.SendStream(document.Content, int(document.Size))
+}
+
+func (c *EmployeeDocumentController) Delete(ctx *fiber.Ctx) error {
+	request, err := c.documentRequest(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := c.EmployeeDocumentUseCase.Delete(ctx.UserContext(), request); err != nil {
+		c.Log.WithError(err).Error("error deleting employee document")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[bool]{Data: true})
+}
+
+func (c *EmployeeDocumentController) documentRequest(ctx *fiber.Ctx) (*model.EmployeeDocumentRequest, error) {
+	employeeId, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	id, err := strconv.Atoi(ctx.Params("documentId"))
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	return &model.EmployeeDocumentRequest{
+		EmployeeId: employeeId,
+		ID:         id,
+	}, nil
+}
@@ -0,0 +1,108 @@
+package http
+
+import (
+	"api/internal/model"
+	"api/internal/usecase"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type FuelLogController struct {
+	Log            *logrus.Logger
+	FuelLogUseCase usecase.FuelLogUseCase
+}
+
+func NewFuelLogController(useCase usecase.FuelLogUseCase, logger *logrus.Logger) *FuelLogController {
+	return &FuelLogController{
+		FuelLogUseCase: useCase,
+		Log:            logger,
+	}
+}
+
+func (c *FuelLogController) FindAll(ctx *fiber.Ctx) error {
+	vehicleId, err := strconv.ParseInt(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request := &model.FindAllFuelLogRequest{
+		VehicleId:   vehicleId,
+		StartDate:   ctx.Query("startDate"),
+		EndDate:     ctx.Query("endDate"),
+		OnlyFlagged: ctx.QueryBool("onlyFlagged"),
+	}
+
+	response, err := c.FuelLogUseCase.FindAll(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting fuel logs")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.FuelLogResponse]{Data: response})
+}
+
+func (c *FuelLogController) Create(ctx *fiber.Ctx) error {
+	request := new(model.CreateFuelLogRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("error parsing request body")
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	vehicleId, err := strconv.ParseInt(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request.VehicleId = vehicleId
+
+	response, err := c.FuelLogUseCase.Create(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error creating fuel log")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).
+		JSON(model.WebResponse[*model.FuelLogResponse]{Data: response})
+}
+
+func (c *FuelLogController) Delete(ctx *fiber.Ctx) error {
+	vehicleId, err := strconv.ParseInt(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	id, err := strconv.Atoi(ctx.Params("fuelLogId"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request := &model.DeleteFuelLogRequest{
+		VehicleId: vehicleId,
+		ID:        id,
+	}
+
+	if err := c.FuelLogUseCase.Delete(ctx.UserContext(), request); err != nil {
+		c.Log.WithError(err).Error("error deleting fuel log")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[bool]{Data: true})
+}
+
+func (c *FuelLogController) Consumption(ctx *fiber.Ctx) error {
+	request := &model.FuelConsumptionRequest{
+		StartDate: ctx.Query("startDate"),
+		EndDate:   ctx.Query("endDate"),
+		GroupBy:   ctx.Query("groupBy", "vehicle"),
+	}
+
+	response, err := c.FuelLogUseCase.Consumption(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting fuel consumption")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.FuelConsumptionResponse]{Data: response})
+}
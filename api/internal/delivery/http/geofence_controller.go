@@ -0,0 +1,112 @@
+package http
+
+import (
+	"api/internal/model"
+	"api/internal/usecase"
+	"math"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type GeofenceController struct {
+	Log             *logrus.Logger
+	GeofenceUseCase usecase.GeofenceUseCase
+}
+
+func NewGeofenceController(useCase usecase.GeofenceUseCase, logger *logrus.Logger) *GeofenceController {
+	return &GeofenceController{
+		GeofenceUseCase: useCase,
+		Log:             logger,
+	}
+}
+
+func (c *GeofenceController) FindAll(ctx *fiber.Ctx) error {
+
+	request := &model.FindAllGeofenceRequest{
+		Page:    ctx.QueryInt("page"),
+		PerPage: ctx.QueryInt("perPage"),
+		Search:  ctx.Query("search"),
+	}
+
+	response, total, err := c.GeofenceUseCase.FindAll(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting geofences")
+		return err
+	}
+
+	var paging *model.PageMetadata
+	if request.Page > 0 && request.PerPage > 0 {
+		paging = &model.PageMetadata{
+			Page:      request.Page,
+			PerPage:   request.PerPage,
+			TotalItem: total,
+			TotalPage: int64(math.Ceil(float64(total) / float64(request.PerPage))),
+		}
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.GeofenceResponse]{
+		Data:   response,
+		Paging: paging,
+	})
+}
+
+func (c *GeofenceController) Create(ctx *fiber.Ctx) error {
+	request := new(model.CreateGeofenceRequest)
+	err := ctx.BodyParser(request)
+	if err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	response, err := c.GeofenceUseCase.Create(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to create geofence : %+v", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).
+		JSON(model.WebResponse[*model.GeofenceResponse]{Data: response})
+}
+
+func (c *GeofenceController) Update(ctx *fiber.Ctx) error {
+	request := new(model.UpdateGeofenceRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("error parsing request body")
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request.ID = id
+
+	response, err := c.GeofenceUseCase.Update(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error updating geofence")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.GeofenceResponse]{Data: response})
+}
+
+func (c *GeofenceController) Delete(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request := &model.DeleteGeofenceRequest{
+		ID: id,
+	}
+
+	if err := c.GeofenceUseCase.Delete(ctx.UserContext(), request); err != nil {
+		c.Log.WithError(err).Error("error deleting geofence")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[bool]{Data: true})
+}
@@ -0,0 +1,217 @@
+package http
+
+import (
+	"api/internal/entity/enum"
+	"api/internal/model"
+	"api/internal/usecase"
+	"math"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type MaintenanceController struct {
+	Log                *logrus.Logger
+	MaintenanceUseCase usecase.MaintenanceUseCase
+}
+
+func NewMaintenanceController(useCase usecase.MaintenanceUseCase, logger *logrus.Logger) *MaintenanceController {
+	return &MaintenanceController{
+		MaintenanceUseCase: useCase,
+		Log:                logger,
+	}
+}
+
+func (c *MaintenanceController) FindPlans(ctx *fiber.Ctx) error {
+	vehicleId, err := strconv.ParseInt(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request := &model.FindAllMaintenancePlanRequest{
+		VehicleId: vehicleId,
+	}
+
+	response, err := c.MaintenanceUseCase.FindPlans(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting maintenance plans")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.MaintenancePlanResponse]{Data: response})
+}
+
+func (c *MaintenanceController) CreatePlan(ctx *fiber.Ctx) error {
+	request := new(model.CreateMaintenancePlanRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("error parsing request body")
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	vehicleId, err := strconv.ParseInt(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request.VehicleId = vehicleId
+
+	response, err := c.MaintenanceUseCase.CreatePlan(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error creating maintenance plan")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).
+		JSON(model.WebResponse[*model.MaintenancePlanResponse]{Data: response})
+}
+
+func (c *MaintenanceController) UpdatePlan(ctx *fiber.Ctx) error {
+	request := new(model.UpdateMaintenancePlanRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("error parsing request body")
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	vehicleId, err := strconv.ParseInt(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	id, err := strconv.Atoi(ctx.Params("planId"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request.VehicleId = vehicleId
+	request.ID = id
+
+	response, err := c.MaintenanceUseCase.UpdatePlan(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error updating maintenance plan")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.MaintenancePlanResponse]{Data: response})
+}
+
+func (c *MaintenanceController) DeletePlan(ctx *fiber.Ctx) error {
+	vehicleId, err := strconv.ParseInt(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	id, err := strconv.Atoi(ctx.Params("planId"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request := &model.DeleteMaintenancePlanRequest{
+		VehicleId: vehicleId,
+		ID:        id,
+	}
+
+	if err := c.MaintenanceUseCase.DeletePlan(ctx.UserContext(), request); err != nil {
+		c.Log.WithError(err).Error("error deleting maintenance plan")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[bool]{Data: true})
+}
+
+func (c *MaintenanceController) FindServices(ctx *fiber.Ctx) error {
+	vehicleId, err := strconv.ParseInt(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request := &model.FindAllServiceRecordRequest{
+		VehicleId: vehicleId,
+		Page:      ctx.QueryInt("page"),
+		PerPage:   ctx.QueryInt("perPage"),
+	}
+
+	response, total, err := c.MaintenanceUseCase.FindServices(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting service records")
+		return err
+	}
+
+	var paging *model.PageMetadata
+	if request.Page > 0 && request.PerPage > 0 {
+		paging = &model.PageMetadata{
+			Page:      request.Page,
+			PerPage:   request.PerPage,
+			TotalItem: total,
+			TotalPage: int64(math.Ceil(float64(total) / float64(request.PerPage))),
+		}
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.ServiceRecordResponse]{
+		Data:   response,
+		Paging: paging,
+	})
+}
+
+func (c *MaintenanceController) CreateService(ctx *fiber.Ctx) error {
+	request := new(model.CreateServiceRecordRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("error parsing request body")
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	vehicleId, err := strconv.ParseInt(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request.VehicleId = vehicleId
+
+	response, err := c.MaintenanceUseCase.CreateService(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error creating service record")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).
+		JSON(model.WebResponse[*model.ServiceRecordResponse]{Data: response})
+}
+
+func (c *MaintenanceController) DeleteService(ctx *fiber.Ctx) error {
+	vehicleId, err := strconv.ParseInt(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	id, err := strconv.Atoi(ctx.Params("serviceId"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request := &model.DeleteServiceRecordRequest{
+		VehicleId: vehicleId,
+		ID:        id,
+	}
+
+	if err := c.MaintenanceUseCase.DeleteService(ctx.UserContext(), request); err != nil {
+		c.Log.WithError(err).Error("error deleting service record")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[bool]{Data: true})
+}
+
+func (c *MaintenanceController) FindDue(ctx *fiber.Ctx) error {
+	request := &model.FindMaintenanceDueRequest{
+		VehicleId: int64(ctx.QueryInt("vehicleId")),
+		Status:    enum.MaintenanceStatus(ctx.Query("status")),
+	}
+
+	response, err := c.MaintenanceUseCase.FindDue(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting maintenance due")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.MaintenanceDueResponse]{Data: response})
+}
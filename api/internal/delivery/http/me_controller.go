@@ -0,0 +1,131 @@
+package http
+
+import (
+	"api/internal/delivery/http/middleware"
+	"api/internal/model"
+	"api/internal/usecase"
+	"fmt"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// MeController serves self service endpoints scoped to the employee linked to the login user
+type MeController struct {
+	Log                       *logrus.Logger
+	UserUseCase               usecase.UserUseCase
+	EmployeeAttendanceUseCase usecase.EmployeeAttendanceUseCase
+	PayrollUseCase            usecase.PayrollUseCase
+}
+
+func NewMeController(
+	userUseCase usecase.UserUseCase,
+	employeeAttendanceUseCase usecase.EmployeeAttendanceUseCase,
+	payrollUseCase usecase.PayrollUseCase,
+	logger *logrus.Logger,
+) *MeController {
+	return &MeController{
+		UserUseCase:               userUseCase,
+		EmployeeAttendanceUseCase: employeeAttendanceUseCase,
+		PayrollUseCase:            payrollUseCase,
+		Log:                       logger,
+	}
+}
+
+func (c *MeController) employeeId(ctx *fiber.Ctx) (int, error) {
+	auth := middleware.GetUser(ctx)
+	return c.UserUseCase.FindEmployeeId(ctx.UserContext(), auth.ID)
+}
+
+func (c *MeController) Attendance(ctx *fiber.Ctx) error {
+	employeeId, err := c.employeeId(ctx)
+	if err != nil {
+		return err
+	}
+
+	request := &model.FindAttendanceByEmployeeRequest{
+		EmployeeId: employeeId,
+		StartDate:  ctx.Query("startDate"),
+		EndDate:    ctx.Query("endDate"),
+	}
+
+	response, err := c.EmployeeAttendanceUseCase.FindByEmployee(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting own attendances")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.EmployeeAttendanceResponse]{Data: response})
+}
+
+func (c *MeController) CheckIn(ctx *fiber.Ctx) error {
+	employeeId, err := c.employeeId(ctx)
+	if err != nil {
+		return err
+	}
+
+	request := new(model.CheckInRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+	request.EmployeeId = employeeId
+
+	response, err := c.EmployeeAttendanceUseCase.CheckIn(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to check in : %+v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.CheckInResponse]{Data: response})
+}
+
+func (c *MeController) Payrolls(ctx *fiber.Ctx) error {
+	employeeId, err := c.employeeId(ctx)
+	if err != nil {
+		return err
+	}
+
+	request := &model.FindPayrollByEmployeeRequest{
+		EmployeeId: employeeId,
+		PeriodId:   ctx.QueryInt("periodId"),
+	}
+
+	response, err := c.PayrollUseCase.FindByEmployee(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting own payrolls")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.PayrollResponse]{Data: response})
+}
+
+func (c *MeController) Payslip(ctx *fiber.Ctx) error {
+	employeeId, err := c.employeeId(ctx)
+	if err != nil {
+		return err
+	}
+
+	periodId, err := strconv.Atoi(ctx.Params("periodId"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request := &model.PayslipRequest{
+		PeriodId:   periodId,
+		EmployeeId: employeeId,
+	}
+
+	document, err := c.PayrollUseCase.Payslip(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error generating own payslip")
+		return err
+	}
+
+	filename := fmt.Sprintf("slip-gaji-%d-%d.pdf", request.EmployeeId, request.PeriodId)
+	ctx.Set(fiber.HeaderContentType, "application/pdf")
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("inline; filename=%q", filename))
+
+	return ctx.Send(document)
+}
@@ -2,14 +2,14 @@ package middleware
 
 import (
 	"api/internal/model"
+	"api/internal/usecase"
 	"api/internal/utils"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 )
 
-func NewAuth(tokenUtil *utils.TokenUtil, log *logrus.Logger, redis *redis.Client) fiber.Handler {
+func NewAuth(userUseCase usecase.UserUseCase, tokenUtil *utils.TokenUtil, log *logrus.Logger) fiber.Handler {
 	return func(ctx *fiber.Ctx) error {
 		request := &model.VerifyUserRequest{Token: ctx.Get("Authorization", "NOT_FOUND")}
 		log.Debugf("Authorization : %s", request.Token)
@@ -20,6 +20,13 @@ func NewAuth(tokenUtil *utils.TokenUtil, log *logrus.Logger, redis *redis.Client
 			return fiber.ErrUnauthorized
 		}
 
+		// the role in the token may be stale, take the current one from the user row
+		userAuth, err = userUseCase.Verify(ctx.UserContext(), userAuth)
+		if err != nil {
+			log.Warnf("Failed verify user : %+v", err)
+			return fiber.ErrUnauthorized
+		}
+
 		log.Debugf("User : %+v", userAuth.ID)
 		ctx.Locals("auth", userAuth)
 		return ctx.Next()
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"api/internal/entity/enum"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// NewDenyRoles rejects authenticated users having one of the given roles
+func NewDenyRoles(log *logrus.Logger, roles ...enum.UserRole) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		auth := GetUser(ctx)
+
+		for _, role := range roles {
+			if auth.Role == role {
+				log.Warnf("Role %s not allowed : %s %s", auth.Role, ctx.Method(), ctx.Path())
+				return fiber.ErrForbidden
+			}
+		}
+
+		return ctx.Next()
+	}
+}
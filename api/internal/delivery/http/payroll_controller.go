@@ -0,0 +1,141 @@
+package http
+
+import (
+	"api/internal/delivery/http/middleware"
+	"api/internal/model"
+	"api/internal/usecase"
+	"fmt"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type PayrollController struct {
+	Log            *logrus.Logger
+	PayrollUseCase usecase.PayrollUseCase
+}
+
+func NewPayrollController(useCase usecase.PayrollUseCase, logger *logrus.Logger) *PayrollController {
+	return &PayrollController{
+		PayrollUseCase: useCase,
+		Log:            logger,
+	}
+}
+
+func (c *PayrollController) FindAllPayRules(ctx *fiber.Ctx) error {
+	response, err := c.PayrollUseCase.FindAllPayRules(ctx.UserContext())
+	if err != nil {
+		c.Log.WithError(err).Error("error getting pay rules")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.PayRuleResponse]{Data: response})
+}
+
+func (c *PayrollController) UpsertPayRule(ctx *fiber.Ctx) error {
+	request := new(model.UpsertPayRuleRequest)
+	err := ctx.BodyParser(request)
+	if err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	response, err := c.PayrollUseCase.UpsertPayRule(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to upsert pay rule : %+v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.PayRuleResponse]{Data: response})
+}
+
+func (c *PayrollController) FindAll(ctx *fiber.Ctx) error {
+	request := &model.FindAllPayrollRequest{
+		PeriodId: ctx.QueryInt("periodId"),
+	}
+
+	response, err := c.PayrollUseCase.FindAll(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting payrolls")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.PayrollResponse]{Data: response})
+}
+
+func (c *PayrollController) Generate(ctx *fiber.Ctx) error {
+	request := new(model.GeneratePayrollRequest)
+	err := ctx.BodyParser(request)
+	if err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	response, err := c.PayrollUseCase.Generate(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to generate payroll : %+v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.PayrollResponse]{Data: response})
+}
+
+func (c *PayrollController) Pay(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request := new(model.PayPayrollRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+	request.ID = id
+	request.PaidBy = middleware.GetUser(ctx).ID
+
+	response, err := c.PayrollUseCase.Pay(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to pay payroll : %+v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.PayrollResponse]{Data: response})
+}
+
+func (c *PayrollController) PayByPeriod(ctx *fiber.Ctx) error {
+	request := new(model.PayPayrollsByPeriodRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+	request.PaidBy = middleware.GetUser(ctx).ID
+
+	total, err := c.PayrollUseCase.PayByPeriod(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to pay payrolls : %+v", err)
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[int]{Data: total})
+}
+
+func (c *PayrollController) Payslip(ctx *fiber.Ctx) error {
+	request := &model.PayslipRequest{
+		PeriodId:   ctx.QueryInt("periodId"),
+		EmployeeId: ctx.QueryInt("employeeId"),
+	}
+
+	document, err := c.PayrollUseCase.Payslip(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error generating payslip")
+		return err
+	}
+
+	filename := fmt.Sprintf("slip-gaji-%d-%d.pdf", request.EmployeeId, request.PeriodId)
+	ctx.Set(fiber.HeaderContentType, "application/pdf")
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("inline; filename=%q", filename))
+
+	return ctx.Send(document)
+}
@@ -0,0 +1,111 @@
+package http
+
+import (
+	"api/internal/model"
+	"api/internal/usecase"
+	"math"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type PeriodController struct {
+	Log           *logrus.Logger
+	PeriodUseCase usecase.PeriodUseCase
+}
+
+func NewPeriodController(useCase usecase.PeriodUseCase, logger *logrus.Logger) *PeriodController {
+	return &PeriodController{
+		PeriodUseCase: useCase,
+		Log:           logger,
+	}
+}
+
+func (c *PeriodController) FindAll(ctx *fiber.Ctx) error {
+	request := &model.FindAllPeriodRequest{
+		Year:            ctx.QueryInt("year"),
+		Month:           ctx.QueryInt("month"),
+		Type:            ctx.Query("type"),
+		Status:          ctx.Query("status"),
+		IncludeInactive: ctx.QueryBool("includeInactive"),
+		Page:            ctx.QueryInt("page"),
+		PerPage:         ctx.QueryInt("perPage"),
+	}
+
+	response, total, err := c.PeriodUseCase.FindAll(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting periods")
+		return err
+	}
+
+	var paging *model.PageMetadata
+	if request.Page > 0 && request.PerPage > 0 {
+		paging = &model.PageMetadata{
+			Page:      request.Page,
+			PerPage:   request.PerPage,
+			TotalItem: total,
+			TotalPage: int64(math.Ceil(float64(total) / float64(request.PerPage))),
+		}
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.PeriodResponse]{
+		Data:   response,
+		Paging: paging,
+	})
+}
+
+func (c *PeriodController) FindById(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request := &model.FindByIdPeriodRequest{
+		ID: id,
+	}
+
+	response, err := c.PeriodUseCase.FindById(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting period")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.PeriodDetailResponse]{Data: response})
+}
+
+func (c *PeriodController) Generate(ctx *fiber.Ctx) error {
+	request := new(model.GeneratePeriodRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("error parsing request body")
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	response, err := c.PeriodUseCase.Generate(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error generating periods")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).
+		JSON(model.WebResponse[[]model.PeriodResponse]{Data: response})
+}
+
+func (c *PeriodController) Deactivate(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request := &model.DeactivatePeriodRequest{
+		ID: id,
+	}
+
+	response, err := c.PeriodUseCase.Deactivate(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error deactivating period")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.PeriodResponse]{Data: response})
+}
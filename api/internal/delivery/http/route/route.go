@@ -18,7 +18,21 @@ type RouteConfig struct {
 	EmployeeAttendanceController *http.EmployeeAttendanceController
 	FactoryController            *http.FactoryController
 	VehicleController            *http.VehicleController
+	CustomerController           *http.CustomerController
+	CollectionController         *http.CollectionController
+	TripController               *http.TripController
+	PayrollController            *http.PayrollController
+	CashAdvanceController        *http.CashAdvanceController
+	GeofenceController           *http.GeofenceController
+	MeController                 *http.MeController
+	EmployeeDocumentController   *http.EmployeeDocumentController
+	DocumentExpiryController     *http.DocumentExpiryController
+	MaintenanceController        *http.MaintenanceController
+	FuelLogController            *http.FuelLogController
+	CrewController               *http.CrewController
+	PeriodController             *http.PeriodController
 	AuthMiddleware               fiber.Handler
+	StaffMiddleware              fiber.Handler
 	Config                       *viper.Viper
 }
 
@@ -44,6 +58,16 @@ func (c *RouteConfig) SetupAuthRoute() {
 	// hello
 	c.App.Get("/api/hello", c.HelloController.SayHello)
 
+	// self service for the linked employee
+	me := c.App.Group("/api/me")
+	me.Get("/attendance", c.MeController.Attendance)
+	me.Post("/check-in", c.MeController.CheckIn)
+	me.Get("/payrolls", c.MeController.Payrolls)
+	me.Get("/payrolls/:periodId/payslip", c.MeController.Payslip)
+
+	// routes below are not available for EMPLOYEE accounts
+	c.App.Use(c.StaffMiddleware)
+
 	// user
 	users := c.App.Group("/api/users")
 	users.Get("/", c.UserController.FindAll)
@@ -61,21 +85,40 @@ func (c *RouteConfig) SetupAuthRoute() {
 	routes := c.App.Group("/api/routes")
 	routes.Get("/", c.RouteController.FindAll)
 	routes.Post("/", c.RouteController.Create)
+	routes.Get("/:id", c.RouteController.FindById)
 	routes.Put("/:id", c.RouteController.Update)
 	routes.Delete("/:id", c.RouteController.Delete)
 
 	// employee
 	employees := c.App.Group("/api/employees")
 	employees.Get("/", c.EmployeeController.FindAll)
+	employees.Get("/org-tree", c.EmployeeController.FindTree)
 	employees.Get("/:id", c.EmployeeController.FindById)
 	employees.Post("/", c.EmployeeController.Create)
 	employees.Put("/:id", c.EmployeeController.Update)
 	employees.Delete("/:id", c.EmployeeController.Delete)
+	employees.Get("/:id/status-history", c.EmployeeController.FindStatusHistory)
+	employees.Post("/:id/status", c.EmployeeController.ChangeStatus)
+	employees.Get("/:id/documents", c.EmployeeDocumentController.FindAll)
+	employees.Post("/:id/documents", c.EmployeeDocumentController.Upload)
+	employees.Get("/:id/documents/:documentId", c.EmployeeDocumentController.Download)
+	employees.Delete("/:id/documents/:documentId", c.EmployeeDocumentController.Delete)
+	employees.Get("/:id/licenses", c.DocumentExpiryController.FindDriverLicenses)
+	employees.Post("/:id/licenses", c.DocumentExpiryController.CreateDriverLicense)
+	employees.Delete("/:id/licenses/:licenseId", c.DocumentExpiryController.DeleteDriverLicense)
 
 	// attendance
 	attendance := c.App.Group("/api/attendance")
 	attendance.Get("/", c.EmployeeController.FindAllWithAttendances)
 	attendance.Post("/batch", c.EmployeeAttendanceController.Upsert)
+	attendance.Post("/check-in", c.EmployeeAttendanceController.CheckIn)
+
+	// geofence (self check-in area)
+	geofences := c.App.Group("/api/geofences")
+	geofences.Get("/", c.GeofenceController.FindAll)
+	geofences.Post("/", c.GeofenceController.Create)
+	geofences.Put("/:id", c.GeofenceController.Update)
+	geofences.Delete("/:id", c.GeofenceController.Delete)
 
 	// factory
 	factories := c.App.Group("/api/factories")
@@ -90,4 +133,88 @@ func (c *RouteConfig) SetupAuthRoute() {
 	vehicles.Post("/", c.VehicleController.Create)
 	vehicles.Put("/:id", c.VehicleController.Update)
 	vehicles.Delete("/:id", c.VehicleController.Delete)
+	vehicles.Get("/:id/documents", c.DocumentExpiryController.FindVehicleDocuments)
+	vehicles.Post("/:id/documents", c.DocumentExpiryController.CreateVehicleDocument)
+	vehicles.Delete("/:id/documents/:documentId", c.DocumentExpiryController.DeleteVehicleDocument)
+	vehicles.Get("/:id/maintenance-plans", c.MaintenanceController.FindPlans)
+	vehicles.Post("/:id/maintenance-plans", c.MaintenanceController.CreatePlan)
+	vehicles.Put("/:id/maintenance-plans/:planId", c.MaintenanceController.UpdatePlan)
+	vehicles.Delete("/:id/maintenance-plans/:planId", c.MaintenanceController.DeletePlan)
+	vehicles.Get("/:id/services", c.MaintenanceController.FindServices)
+	vehicles.Post("/:id/services", c.MaintenanceController.CreateService)
+	vehicles.Delete("/:id/services/:serviceId", c.MaintenanceController.DeleteService)
+	vehicles.Get("/:id/fuel-logs", c.FuelLogController.FindAll)
+	vehicles.Post("/:id/fuel-logs", c.FuelLogController.Create)
+	vehicles.Delete("/:id/fuel-logs/:fuelLogId", c.FuelLogController.Delete)
+
+	// maintenance
+	c.App.Get("/api/maintenance/due", c.MaintenanceController.FindDue)
+
+	// crew
+	crews := c.App.Group("/api/crews")
+	crews.Get("/", c.CrewController.FindAll)
+	crews.Post("/", c.CrewController.Create)
+	crews.Post("/attendance", c.CrewController.MarkAttendance)
+	crews.Get("/:id", c.CrewController.FindById)
+	crews.Put("/:id", c.CrewController.Update)
+	crews.Delete("/:id", c.CrewController.Delete)
+	crews.Put("/:id/members", c.CrewController.AssignMembers)
+	crews.Get("/:id/members/history", c.CrewController.FindMemberHistory)
+
+	// fuel
+	c.App.Get("/api/fuel/consumption", c.FuelLogController.Consumption)
+
+	// document expiry
+	c.App.Get("/api/document-expiries", c.DocumentExpiryController.FindExpiring)
+
+	// customer
+	customers := c.App.Group("/api/customers")
+	customers.Get("/", c.CustomerController.FindAll)
+	customers.Post("/", c.CustomerController.Create)
+	customers.Put("/:id", c.CustomerController.Update)
+	customers.Delete("/:id", c.CustomerController.Delete)
+
+	// receivable & collection
+	c.App.Post("/api/receivables", c.CollectionController.CreateReceivable)
+	collections := c.App.Group("/api/collections")
+	collections.Post("/", c.CollectionController.CreateCollection)
+	collections.Get("/report", c.CollectionController.CollectionReport)
+
+	// cash deposit (setoran)
+	deposits := c.App.Group("/api/deposits")
+	deposits.Post("/", c.CollectionController.CreateCashDeposit)
+	deposits.Get("/reconciliation", c.CollectionController.DepositReconciliation)
+
+	// trip (dispatch)
+	trips := c.App.Group("/api/trips")
+	trips.Get("/", c.TripController.FindAll)
+	trips.Get("/:id", c.TripController.FindById)
+	trips.Post("/", c.TripController.Create)
+	trips.Delete("/:id", c.TripController.Delete)
+
+	// period
+	periods := c.App.Group("/api/periods")
+	periods.Get("/", c.PeriodController.FindAll)
+	periods.Post("/generate", c.PeriodController.Generate)
+	periods.Get("/:id", c.PeriodController.FindById)
+	periods.Post("/:id/deactivate", c.PeriodController.Deactivate)
+
+	// payroll
+	payRules := c.App.Group("/api/pay-rules")
+	payRules.Get("/", c.PayrollController.FindAllPayRules)
+	payRules.Put("/", c.PayrollController.UpsertPayRule)
+
+	payrolls := c.App.Group("/api/payrolls")
+	payrolls.Get("/", c.PayrollController.FindAll)
+	payrolls.Post("/generate", c.PayrollController.Generate)
+	payrolls.Post("/pay", c.PayrollController.PayByPeriod)
+	payrolls.Get("/payslip", c.PayrollController.Payslip)
+	payrolls.Post("/:id/pay", c.PayrollController.Pay)
+
+	// cash advance (kasbon)
+	cashAdvances := c.App.Group("/api/cash-advances")
+	cashAdvances.Get("/", c.CashAdvanceController.FindAll)
+	cashAdvances.Post("/", c.CashAdvanceController.Create)
+	cashAdvances.Post("/:id/repayments", c.CashAdvanceController.CreateRepayment)
+	cashAdvances.Get("/statement/:employeeId", c.CashAdvanceController.Statement)
 }
@@ -110,3 +110,22 @@ func (c *RouteController) Delete(ctx *fiber.Ctx) error {
 
 	return ctx.JSON(model.WebResponse[bool]{Data: true})
 }
+
+func (c *RouteController) FindById(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request := &model.FindByIdRouteRequest{
+		ID: id,
+	}
+
+	response, err := c.RouteUseCase.FindById(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting route")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.RouteResponse]{Data: response})
+}
@@ -0,0 +1,111 @@
+package http
+
+import (
+	"api/internal/model"
+	"api/internal/usecase"
+	"math"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type TripController struct {
+	Log         *logrus.Logger
+	TripUseCase usecase.TripUseCase
+}
+
+func NewTripController(useCase usecase.TripUseCase, logger *logrus.Logger) *TripController {
+	return &TripController{
+		TripUseCase: useCase,
+		Log:         logger,
+	}
+}
+
+func (c *TripController) FindAll(ctx *fiber.Ctx) error {
+
+	request := &model.FindAllTripRequest{
+		Page:      ctx.QueryInt("page"),
+		PerPage:   ctx.QueryInt("perPage"),
+		StartDate: ctx.Query("startDate"),
+		EndDate:   ctx.Query("endDate"),
+		VehicleId: int64(ctx.QueryInt("vehicleId")),
+		DriverId:  ctx.QueryInt("driverId"),
+	}
+
+	response, total, err := c.TripUseCase.FindAll(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting trips")
+		return err
+	}
+
+	var paging *model.PageMetadata
+	if request.Page > 0 && request.PerPage > 0 {
+		paging = &model.PageMetadata{
+			Page:      request.Page,
+			PerPage:   request.PerPage,
+			TotalItem: total,
+			TotalPage: int64(math.Ceil(float64(total) / float64(request.PerPage))),
+		}
+	}
+
+	return ctx.JSON(model.WebResponse[[]model.TripResponse]{
+		Data:   response,
+		Paging: paging,
+	})
+}
+
+func (c *TripController) FindById(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request := &model.FindByIdTripRequest{
+		ID: id,
+	}
+
+	response, err := c.TripUseCase.FindById(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("error getting trip")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[*model.TripResponse]{Data: response})
+}
+
+func (c *TripController) Create(ctx *fiber.Ctx) error {
+	request := new(model.CreateTripRequest)
+	err := ctx.BodyParser(request)
+	if err != nil {
+		c.Log.Warnf("Failed to parse request body : %+v", err)
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	response, err := c.TripUseCase.Create(ctx.UserContext(), request)
+	if err != nil {
+		c.Log.Warnf("Failed to create trip : %+v", err)
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).
+		JSON(model.WebResponse[*model.TripResponse]{Data: response})
+}
+
+func (c *TripController) Delete(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid id parameter")
+	}
+
+	request := &model.DeleteTripRequest{
+		ID: id,
+	}
+
+	if err := c.TripUseCase.Delete(ctx.UserContext(), request); err != nil {
+		c.Log.WithError(err).Error("error deleting trip")
+		return err
+	}
+
+	return ctx.JSON(model.WebResponse[bool]{Data: true})
+}
@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"api/internal/entity/money"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CashAdvance is an employee kasbon repaid through installments
+type CashAdvance struct {
+	ID          int         `gorm:"primaryKey;autoIncrement"`
+	Date        time.Time   `gorm:"type:date;column:date;not null"`
+	Amount      money.Money `gorm:"column:amount;type:decimal(12,2);not null"`
+	Installment money.Money `gorm:"column:installment;type:decimal(12,2);not null"`
+	Notes       *string     `gorm:"column:notes;type:text"`
+
+	EmployeeId int       `gorm:"column:employee_id;not null"`
+	Employee   *Employee `gorm:"foreignKey:EmployeeId;references:ID"`
+
+	Repayments []CashAdvanceRepayment `gorm:"foreignKey:CashAdvanceId;references:ID"`
+
+	CreatedAt time.Time      `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt time.Time      `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
+}
+
+func (ca *CashAdvance) TableName() string {
+	return "cash_advances"
+}
+
+// Outstanding is the amount not yet repaid, requires Repayments to be loaded
+func (ca *CashAdvance) Outstanding() money.Money {
+	outstanding := ca.Amount
+	for _, repayment := range ca.Repayments {
+		outstanding -= repayment.Amount
+	}
+	return outstanding
+}
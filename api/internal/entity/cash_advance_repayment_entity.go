@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"api/internal/entity/money"
+	"time"
+)
+
+// CashAdvanceRepayment is paid in cash or deducted from a payroll
+type CashAdvanceRepayment struct {
+	ID     int         `gorm:"primaryKey;autoIncrement"`
+	Date   time.Time   `gorm:"type:date;column:date;not null"`
+	Amount money.Money `gorm:"column:amount;type:decimal(12,2);not null"`
+	Notes  *string     `gorm:"column:notes;type:text"`
+
+	CashAdvanceId int          `gorm:"column:cash_advance_id;not null"`
+	CashAdvance   *CashAdvance `gorm:"foreignKey:CashAdvanceId;references:ID"`
+	PayrollId     *int         `gorm:"column:payroll_id"`
+	Payroll       *Payroll     `gorm:"foreignKey:PayrollId;references:ID"`
+
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt time.Time `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+}
+
+func (r *CashAdvanceRepayment) TableName() string {
+	return "cash_advance_repayments"
+}
@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"api/internal/entity/money"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CashDeposit is the daily cash handover (setoran) from a salesperson
+type CashDeposit struct {
+	ID     int         `gorm:"primaryKey;autoIncrement"`
+	Date   time.Time   `gorm:"type:date;column:date;not null"`
+	Amount money.Money `gorm:"column:amount;type:decimal(12,2);not null"`
+	Notes  *string     `gorm:"column:notes;type:text"`
+
+	SalesId        int        `gorm:"column:sales_id;not null"`
+	Sales          *Sales     `gorm:"foreignKey:SalesId;references:ID"`
+	ReceivedBy     *uuid.UUID `gorm:"type:uuid;column:received_by"`
+	ReceivedByUser *User      `gorm:"foreignKey:ReceivedBy;references:ID"`
+
+	CreatedAt time.Time      `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt time.Time      `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
+}
+
+func (cd *CashDeposit) TableName() string {
+	return "cash_deposits"
+}
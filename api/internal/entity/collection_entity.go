@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"api/internal/entity/money"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Collection is a payment receipt collected from a customer by a salesperson
+type Collection struct {
+	ID     int         `gorm:"primaryKey;autoIncrement"`
+	Date   time.Time   `gorm:"type:date;column:date;not null"`
+	Amount money.Money `gorm:"column:amount;type:decimal(12,2);not null"`
+	Notes  *string     `gorm:"column:notes;type:text"`
+
+	CustomerId int       `gorm:"column:customer_id;not null"`
+	Customer   *Customer `gorm:"foreignKey:CustomerId;references:ID"`
+	SalesId    int       `gorm:"column:sales_id;not null"`
+	Sales      *Sales    `gorm:"foreignKey:SalesId;references:ID"`
+	PeriodId   int       `gorm:"column:period_id;not null"`
+	Period     *Period   `gorm:"foreignKey:PeriodId;references:ID"`
+
+	CreatedAt time.Time      `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt time.Time      `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
+}
+
+func (c *Collection) TableName() string {
+	return "collections"
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Crew is a fixed team of a driver and helpers, usually on the same vehicle
+type Crew struct {
+	ID       int    `gorm:"primaryKey;autoIncrement"`
+	Name     string `gorm:"column:name;not null"`
+	IsActive bool   `gorm:"column:is_active;not null;default:true"`
+
+	VehicleId *int64   `gorm:"column:vehicle_id"`
+	Vehicle   *Vehicle `gorm:"foreignKey:VehicleId;references:ID"`
+
+	Members []CrewMember `gorm:"foreignKey:CrewId;references:ID"`
+
+	CreatedAt time.Time      `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt time.Time      `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
+}
+
+func (c *Crew) TableName() string {
+	return "crews"
+}
+
+// CrewMember assigns an employee to a crew from StartDate until EndDate, an
+// open EndDate is the current assignment
+type CrewMember struct {
+	ID        int        `gorm:"primaryKey;autoIncrement"`
+	StartDate time.Time  `gorm:"type:date;column:start_date;not null"`
+	EndDate   *time.Time `gorm:"type:date;column:end_date"`
+
+	CrewId     int       `gorm:"column:crew_id;not null"`
+	Crew       *Crew     `gorm:"foreignKey:CrewId;references:ID"`
+	EmployeeId int       `gorm:"column:employee_id;not null"`
+	Employee   *Employee `gorm:"foreignKey:EmployeeId;references:ID"`
+
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt time.Time `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+}
+
+func (m *CrewMember) TableName() string {
+	return "crew_members"
+}
@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Customer struct {
+	ID      int     `gorm:"primaryKey;autoIncrement"`
+	Name    string  `gorm:"column:name;type:varchar(100);not null"`
+	Phone   *string `gorm:"column:phone;type:varchar(20)"`
+	Address *string `gorm:"column:address;type:text"`
+
+	RouteId int    `gorm:"column:route_id;not null"`
+	Route   *Route `gorm:"foreignKey:RouteId;references:ID"`
+
+	CreatedAt time.Time      `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt time.Time      `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
+
+	Receivables []Receivable `gorm:"foreignKey:CustomerId;references:ID"`
+	Collections []Collection `gorm:"foreignKey:CustomerId;references:ID"`
+}
+
+func (c *Customer) TableName() string {
+	return "customers"
+}
@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"api/internal/entity/enum"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DriverLicense is a SIM held by a driver, renewals are stored as new records
+type DriverLicense struct {
+	ID         int               `gorm:"primaryKey;autoIncrement"`
+	Number     string            `gorm:"column:number;not null"`
+	Class      enum.LicenseClass `gorm:"column:class;not null"`
+	ExpiryDate time.Time         `gorm:"type:date;column:expiry_date;not null"`
+
+	EmployeeId int       `gorm:"column:employee_id;not null"`
+	Employee   *Employee `gorm:"foreignKey:EmployeeId;references:ID"`
+
+	CreatedAt time.Time      `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt time.Time      `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
+}
+
+func (l *DriverLicense) TableName() string {
+	return "driver_licenses"
+}
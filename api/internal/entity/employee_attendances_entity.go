@@ -10,7 +10,7 @@ import (
 type EmployeeAttendance struct {
 	ID     int                   `gorm:"primaryKey"`
 	Date   time.Time             `gorm:"type:date;not null;employee_attendances_date_employee_id_key"`
-	Status enum.AttendanceStatus `gorm:"type:absen_status;column:status;not null"`
+	Status enum.AttendanceStatus `gorm:"type:AttendanceStatus;column:status;not null"`
 
 	EmployeeId int       `gorm:"column:employee_id;not null;employee_attendances_date_employee_id_key"`
 	Employee   *Employee `gorm:"foreignKey:EmployeeId;references:ID"`
@@ -18,6 +18,14 @@ type EmployeeAttendance struct {
 	PeriodId int     `gorm:"column:period_id;not null"`
 	Period   *Period `gorm:"foreignKey:PeriodId;references:ID"`
 
+	// raw self check-in location, kept for audit
+	CheckInAt        *time.Time `gorm:"column:check_in_at"`
+	Latitude         *float64   `gorm:"column:latitude;type:decimal(10,7)"`
+	Longitude        *float64   `gorm:"column:longitude;type:decimal(10,7)"`
+	LocationAccuracy *float64   `gorm:"column:location_accuracy;type:decimal(8,2)"`
+	GeofenceId       *int       `gorm:"column:geofence_id"`
+	Geofence         *Geofence  `gorm:"foreignKey:GeofenceId;references:ID"`
+
 	CreatedAt time.Time      `gorm:"column:created_at;autoCreateTime:milli"`
 	UpdatedAt time.Time      `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
 	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"api/internal/entity/enum"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EmployeeDocument is the metadata of a file kept in the file storage
+type EmployeeDocument struct {
+	ID          int                       `gorm:"primaryKey;autoIncrement"`
+	Type        enum.EmployeeDocumentType `gorm:"column:type;not null"`
+	FileName    string                    `gorm:"column:file_name;not null"`
+	StorageKey  string                    `gorm:"column:storage_key;not null"`
+	ContentType string                    `gorm:"column:content_type;not null"`
+	Size        int64                     `gorm:"column:size;not null"`
+	Notes       *string                   `gorm:"column:notes;type:text"`
+
+	EmployeeId int       `gorm:"column:employee_id;not null"`
+	Employee   *Employee `gorm:"foreignKey:EmployeeId;references:ID"`
+
+	UploadedBy     *uuid.UUID `gorm:"type:uuid;column:uploaded_by"`
+	UploadedByUser *User      `gorm:"foreignKey:UploadedBy;references:ID"`
+
+	CreatedAt time.Time      `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt time.Time      `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
+}
+
+func (d *EmployeeDocument) TableName() string {
+	return "employee_documents"
+}
@@ -2,6 +2,7 @@ package entity
 
 import (
 	"api/internal/entity/enum"
+	"api/internal/entity/money"
 	"time"
 
 	"gorm.io/gorm"
@@ -10,10 +11,22 @@ import (
 type Employee struct {
 	ID       int               `gorm:"primaryKey;autoIncrement"`
 	Name     string            `gorm:"column:name;not null"`
-	Salary   float64           `gorm:"column:salary;not null"`
+	Salary   money.Money       `gorm:"column:salary;type:decimal(12,2);not null"`
 	Role     enum.EmployeeRole `gorm:"column:role;not null"`
 	JoinDate time.Time         `gorm:"column:join_date;not null"`
 
+	Status enum.EmployeeStatus `gorm:"column:status;not null;default:ACTIVE"`
+
+	NIK                   *string    `gorm:"column:nik"`
+	BirthDate             *time.Time `gorm:"type:date;column:birth_date"`
+	Address               *string    `gorm:"column:address;type:text"`
+	Phone                 *string    `gorm:"column:phone"`
+	EmergencyContactName  *string    `gorm:"column:emergency_contact_name"`
+	EmergencyContactPhone *string    `gorm:"column:emergency_contact_phone"`
+	BankName              *string    `gorm:"column:bank_name"`
+	BankAccountNumber     *string    `gorm:"column:bank_account_number"`
+	BankAccountName       *string    `gorm:"column:bank_account_name"`
+
 	SupervisorId *int       `gorm:"column:supervisor_id"`
 	Supervisor   *Employee  `gorm:"foreignKey:SupervisorId;references:ID"`
 	Subordinates []Employee `gorm:"foreignKey:SupervisorId;references:ID"`
@@ -25,6 +38,9 @@ type Employee struct {
 	EmployeeAttendance []EmployeeAttendance `gorm:"foreignKey:EmployeeId;references:ID"`
 	Sales              *Sales               `gorm:"foreignKey:EmployeeId;references:ID"`
 	Payrolls           []Payroll            `gorm:"foreignKey:EmployeeId;references:ID"`
+
+	StatusHistories []EmployeeStatusHistory `gorm:"foreignKey:EmployeeId;references:ID"`
+	Documents       []EmployeeDocument      `gorm:"foreignKey:EmployeeId;references:ID"`
 }
 
 func (e *Employee) TableName() string {
@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"api/internal/entity/enum"
+	"time"
+)
+
+// EmployeeStatusHistory records every lifecycle change of an employee
+type EmployeeStatusHistory struct {
+	ID            int                 `gorm:"primaryKey;autoIncrement"`
+	Status        enum.EmployeeStatus `gorm:"column:status;not null"`
+	EffectiveDate time.Time           `gorm:"type:date;column:effective_date;not null"`
+	Reason        *string             `gorm:"column:reason;type:text"`
+
+	EmployeeId int       `gorm:"column:employee_id;not null"`
+	Employee   *Employee `gorm:"foreignKey:EmployeeId;references:ID"`
+
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt time.Time `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+}
+
+func (h *EmployeeStatusHistory) TableName() string {
+	return "employee_status_histories"
+}
@@ -3,3 +3,41 @@ package entity
 type Entity interface {
 	TableName() string
 }
+
+// Entities lists every entity persisted in its own table
+func Entities() []Entity {
+	return []Entity{
+		&User{},
+		&Period{},
+		&PeriodClosure{},
+		&Employee{},
+		&EmployeeAttendance{},
+		&EmployeeStatusHistory{},
+		&EmployeeDocument{},
+		&DriverLicense{},
+		&Payroll{},
+		&PayrollItem{},
+		&PayRule{},
+		&CashAdvance{},
+		&CashAdvanceRepayment{},
+		&Sales{},
+		&SalesRoutes{},
+		&Route{},
+		&RouteStop{},
+		&Customer{},
+		&Receivable{},
+		&Collection{},
+		&CashDeposit{},
+		&Factory{},
+		&Geofence{},
+		&Vehicle{},
+		&VehicleHistory{},
+		&VehicleDocument{},
+		&MaintenancePlan{},
+		&ServiceRecord{},
+		&FuelLog{},
+		&Trip{},
+		&Crew{},
+		&CrewMember{},
+	}
+}
@@ -4,5 +4,7 @@ type AttendanceStatus string
 
 const (
 	PRESENT AttendanceStatus = "PRESENT"
-	PERMIT  AttendanceStatus = "PERMIT"
+	LEAVE   AttendanceStatus = "LEAVE"
+	SICK    AttendanceStatus = "SICK"
+	ABSENT  AttendanceStatus = "ABSENT"
 )
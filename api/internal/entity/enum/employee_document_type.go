@@ -0,0 +1,11 @@
+package enum
+
+type EmployeeDocumentType string
+
+const (
+	DOCUMENT_KTP   EmployeeDocumentType = "KTP"
+	DOCUMENT_SIM   EmployeeDocumentType = "SIM"
+	DOCUMENT_KK    EmployeeDocumentType = "KK"
+	DOCUMENT_NPWP  EmployeeDocumentType = "NPWP"
+	DOCUMENT_OTHER EmployeeDocumentType = "OTHER"
+)
@@ -1,5 +1,7 @@
 package enum
 
+import "slices"
+
 type EmployeeRole string
 
 const (
@@ -10,3 +12,21 @@ const (
 	EMPLOYEE_TREASURER      EmployeeRole = "TREASURER"
 	STAFF                   EmployeeRole = "STAFF"
 )
+
+// SupervisorRoles returns the roles allowed to supervise the role, roles
+// without supervisor roles sit at the top of the organisation
+func (r EmployeeRole) SupervisorRoles() []EmployeeRole {
+	switch r {
+	case HELPER:
+		return []EmployeeRole{DRIVER, SALES}
+	case DRIVER:
+		return []EmployeeRole{SALES}
+	case SALES, EMPLOYEE_TREASURER, STAFF:
+		return []EmployeeRole{EMPLOYEE_WAREHOUSE_HEAD}
+	}
+	return nil
+}
+
+func (r EmployeeRole) CanBeSupervisedBy(supervisor EmployeeRole) bool {
+	return slices.Contains(r.SupervisorRoles(), supervisor)
+}
@@ -0,0 +1,15 @@
+package enum
+
+type EmployeeStatus string
+
+const (
+	EMPLOYEE_ACTIVE     EmployeeStatus = "ACTIVE"
+	EMPLOYEE_ON_LEAVE   EmployeeStatus = "ON_LEAVE"
+	EMPLOYEE_RESIGNED   EmployeeStatus = "RESIGNED"
+	EMPLOYEE_TERMINATED EmployeeStatus = "TERMINATED"
+)
+
+// IsEnded reports whether the status ends the employment
+func (s EmployeeStatus) IsEnded() bool {
+	return s == EMPLOYEE_RESIGNED || s == EMPLOYEE_TERMINATED
+}
@@ -0,0 +1,30 @@
+package enum
+
+// DatabaseTypes maps the postgres enum types to the values declared in go,
+// enums only computed by the application are left out
+func DatabaseTypes() map[string][]string {
+	return map[string][]string{
+		"UserRole":             values(SUPER_ADMIN, OWNER, WAREHOUSE_HEAD, TREASURER, USER_EMPLOYEE),
+		"PeriodType":           values(WEEKLY, BIWEEKLY, MONTHLY),
+		"PeriodMonthRule":      values(MONTH_RULE_START, MONTH_RULE_END, MONTH_RULE_MAJORITY),
+		"EmployeeRole":         values(EMPLOYEE_WAREHOUSE_HEAD, SALES, DRIVER, HELPER, EMPLOYEE_TREASURER, STAFF),
+		"EmployeeStatus":       values(EMPLOYEE_ACTIVE, EMPLOYEE_ON_LEAVE, EMPLOYEE_RESIGNED, EMPLOYEE_TERMINATED),
+		"AttendanceStatus":     values(PRESENT, LEAVE, SICK, ABSENT),
+		"PayrollModule":        values(SALES_INCENTIVE, OPERATIONAL),
+		"PayrollItemType":      values(ITEM_DAILY, ITEM_TRIP, ITEM_SACK, ITEM_BONUS, ITEM_DEDUCTION),
+		"PaymentMethod":        values(CASH, TRANSFER),
+		"VehicleType":          values(TRONTON, TRUCK, PICKUP),
+		"VehicleHistoryType":   values(INCOME, EXPENSE),
+		"EmployeeDocumentType": values(DOCUMENT_KTP, DOCUMENT_SIM, DOCUMENT_KK, DOCUMENT_NPWP, DOCUMENT_OTHER),
+		"LicenseClass":         values(LICENSE_A, LICENSE_A_UMUM, LICENSE_B1, LICENSE_B1_UMUM, LICENSE_B2, LICENSE_B2_UMUM),
+		"VehicleDocumentType":  values(VEHICLE_DOCUMENT_STNK, VEHICLE_DOCUMENT_KIR, VEHICLE_DOCUMENT_INSURANCE),
+	}
+}
+
+func values[T ~string](items ...T) []string {
+	result := make([]string, len(items))
+	for i, item := range items {
+		result[i] = string(item)
+	}
+	return result
+}
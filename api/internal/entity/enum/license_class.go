@@ -0,0 +1,31 @@
+package enum
+
+type LicenseClass string
+
+const (
+	LICENSE_A       LicenseClass = "A"
+	LICENSE_A_UMUM  LicenseClass = "A_UMUM"
+	LICENSE_B1      LicenseClass = "B1"
+	LICENSE_B1_UMUM LicenseClass = "B1_UMUM"
+	LICENSE_B2      LicenseClass = "B2"
+	LICENSE_B2_UMUM LicenseClass = "B2_UMUM"
+)
+
+// level ranks the classes, a higher class may drive the vehicles of a lower one
+func (c LicenseClass) level() int {
+	switch c {
+	case LICENSE_A, LICENSE_A_UMUM:
+		return 1
+	case LICENSE_B1, LICENSE_B1_UMUM:
+		return 2
+	case LICENSE_B2, LICENSE_B2_UMUM:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// Covers reports whether the licence may be used for the required class
+func (c LicenseClass) Covers(required LicenseClass) bool {
+	return c.level() >= required.level()
+}
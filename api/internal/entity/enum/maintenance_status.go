@@ -0,0 +1,9 @@
+package enum
+
+type MaintenanceStatus string
+
+const (
+	MAINTENANCE_OK       MaintenanceStatus = "OK"
+	MAINTENANCE_DUE_SOON MaintenanceStatus = "DUE_SOON"
+	MAINTENANCE_OVERDUE  MaintenanceStatus = "OVERDUE"
+)
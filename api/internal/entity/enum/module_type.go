@@ -10,4 +10,5 @@ const (
 
 const (
 	EMPLOYEE ModuleType = "EMPLOYEE"
+	PAYROLL  ModuleType = "PAYROLL"
 )
@@ -0,0 +1,8 @@
+package enum
+
+type PaymentMethod string
+
+const (
+	CASH     PaymentMethod = "CASH"
+	TRANSFER PaymentMethod = "TRANSFER"
+)
@@ -0,0 +1,11 @@
+package enum
+
+type PayrollItemType string
+
+const (
+	ITEM_DAILY     PayrollItemType = "DAILY"
+	ITEM_TRIP      PayrollItemType = "TRIP"
+	ITEM_SACK      PayrollItemType = "SACK"
+	ITEM_BONUS     PayrollItemType = "BONUS"
+	ITEM_DEDUCTION PayrollItemType = "DEDUCTION"
+)
@@ -0,0 +1,10 @@
+package enum
+
+// PeriodMonthRule decides the month of a period that spans two months
+type PeriodMonthRule string
+
+const (
+	MONTH_RULE_START    PeriodMonthRule = "START"
+	MONTH_RULE_END      PeriodMonthRule = "END"
+	MONTH_RULE_MAJORITY PeriodMonthRule = "MAJORITY"
+)
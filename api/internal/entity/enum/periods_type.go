@@ -3,6 +3,7 @@ package enum
 type PeriodType string
 
 const (
-	WEEKLY  PeriodType = "WEEKLY"
-	MONTHLY PeriodType = "MONTHLY"
+	WEEKLY   PeriodType = "WEEKLY"
+	BIWEEKLY PeriodType = "BIWEEKLY"
+	MONTHLY  PeriodType = "MONTHLY"
 )
@@ -7,4 +7,5 @@ const (
 	OWNER          UserRole = "OWNER"
 	WAREHOUSE_HEAD UserRole = "WAREHOUSE_HEAD"
 	TREASURER      UserRole = "TREASURER"
+	USER_EMPLOYEE  UserRole = "EMPLOYEE"
 )
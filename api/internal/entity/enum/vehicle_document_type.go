@@ -0,0 +1,9 @@
+package enum
+
+type VehicleDocumentType string
+
+const (
+	VEHICLE_DOCUMENT_STNK      VehicleDocumentType = "STNK"
+	VEHICLE_DOCUMENT_KIR       VehicleDocumentType = "KIR"
+	VEHICLE_DOCUMENT_INSURANCE VehicleDocumentType = "INSURANCE"
+)
@@ -4,5 +4,5 @@ type VehicleHistoryType string
 
 const (
 	INCOME  VehicleHistoryType = "INCOME"
-	EXPANSE VehicleHistoryType = "EXPANSE"
+	EXPENSE VehicleHistoryType = "EXPENSE"
 )
@@ -7,3 +7,15 @@ const (
 	TRONTON VehicleType = "TRONTON"
 	TRUCK   VehicleType = "TRUCK"
 )
+
+// RequiredLicense is the minimum SIM class for the vehicle type
+func (v VehicleType) RequiredLicense() LicenseClass {
+	switch v {
+	case TRUCK:
+		return LICENSE_B1
+	case TRONTON:
+		return LICENSE_B2
+	default:
+		return LICENSE_A
+	}
+}
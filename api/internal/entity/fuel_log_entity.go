@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"api/internal/entity/money"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// FuelLog is a full tank fill-up, its cost is posted as an EXPENSE history
+type FuelLog struct {
+	ID            int         `gorm:"primaryKey;autoIncrement"`
+	Date          time.Time   `gorm:"type:date;column:date;not null"`
+	Liters        float64     `gorm:"column:liters;type:decimal(8,2);not null"`
+	PricePerLiter money.Money `gorm:"column:price_per_liter;type:decimal(12,2);not null"`
+	TotalCost     money.Money `gorm:"column:total_cost;type:decimal(12,2);not null"`
+	Odometer      int         `gorm:"column:odometer;not null"`
+	Notes         *string     `gorm:"column:notes;type:text"`
+
+	VehicleId        int64           `gorm:"column:vehicle_id;not null"`
+	Vehicle          *Vehicle        `gorm:"foreignKey:VehicleId;references:ID"`
+	DriverId         *int            `gorm:"column:driver_id"`
+	Driver           *Employee       `gorm:"foreignKey:DriverId;references:ID"`
+	VehicleHistoryId *int64          `gorm:"column:vehicle_history_id"`
+	VehicleHistory   *VehicleHistory `gorm:"foreignKey:VehicleHistoryId;references:ID"`
+
+	CreatedAt time.Time      `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt time.Time      `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
+}
+
+func (f *FuelLog) TableName() string {
+	return "fuel_logs"
+}
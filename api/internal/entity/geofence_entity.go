@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Geofence is a circular area where self check-in is allowed
+type Geofence struct {
+	ID        int     `gorm:"primaryKey;autoIncrement"`
+	Name      string  `gorm:"column:name;type:varchar(100);not null"`
+	Latitude  float64 `gorm:"column:latitude;type:decimal(10,7);not null"`
+	Longitude float64 `gorm:"column:longitude;type:decimal(10,7);not null"`
+	Radius    int     `gorm:"column:radius;not null"`
+	IsActive  bool    `gorm:"column:is_active;not null;default:true"`
+
+	FactoryId *int64   `gorm:"column:factory_id"`
+	Factory   *Factory `gorm:"foreignKey:FactoryId;references:ID"`
+
+	CreatedAt time.Time      `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt time.Time      `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
+}
+
+func (g *Geofence) TableName() string {
+	return "geofences"
+}
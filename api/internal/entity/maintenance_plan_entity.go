@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MaintenancePlan is a recurring service of a vehicle, due every IntervalKm and/or IntervalDays
+type MaintenancePlan struct {
+	ID            int       `gorm:"primaryKey;autoIncrement"`
+	ServiceType   string    `gorm:"column:service_type;not null"`
+	IntervalKm    *int      `gorm:"column:interval_km"`
+	IntervalDays  *int      `gorm:"column:interval_days"`
+	StartDate     time.Time `gorm:"type:date;column:start_date;not null"`
+	StartOdometer int       `gorm:"column:start_odometer;not null;default:0"`
+	IsActive      bool      `gorm:"column:is_active;not null;default:true"`
+
+	VehicleId int64    `gorm:"column:vehicle_id;not null"`
+	Vehicle   *Vehicle `gorm:"foreignKey:VehicleId;references:ID"`
+
+	CreatedAt time.Time      `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt time.Time      `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
+}
+
+func (p *MaintenancePlan) TableName() string {
+	return "maintenance_plans"
+}
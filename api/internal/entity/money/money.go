@@ -0,0 +1,281 @@
+package money
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Scale is the number of sen in a rupiah, matching the DECIMAL(12,2) columns
+const Scale = 100
+
+var ErrInvalid = errors.New("invalid money amount")
+
+// Money is a fixed-point rupiah amount counted in sen. Every operation that
+// can produce a fraction of a sen rounds half away from zero.
+type Money int64
+
+// New returns whole rupiah
+func New(rupiah int64) Money {
+	return Money(rupiah * Scale)
+}
+
+// FromFloat converts a float amount in rupiah, rounded to the sen
+func FromFloat(rupiah float64) Money {
+	return Money(math.Round(rupiah * Scale))
+}
+
+// Parse reads a decimal amount such as "-1250.5" without going through a
+// float, digits past the sen are rounded
+func Parse(value string) (Money, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, ErrInvalid
+	}
+
+	negative := false
+	switch value[0] {
+	case '-':
+		negative = true
+		value = value[1:]
+	case '+':
+		value = value[1:]
+	}
+
+	whole, fraction, _ := strings.Cut(value, ".")
+	if whole == "" && fraction == "" {
+		return 0, ErrInvalid
+	}
+	if whole == "" {
+		whole = "0"
+	}
+
+	if strings.ContainsAny(whole, "+-") {
+		return 0, ErrInvalid
+	}
+
+	rupiah, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || rupiah > math.MaxInt64/Scale-1 {
+		return 0, ErrInvalid
+	}
+
+	sen := int64(0)
+	for i, digit := range fraction {
+		if digit < '0' || digit > '9' {
+			return 0, ErrInvalid
+		}
+		switch {
+		case i < 2:
+			sen = sen*10 + int64(digit-'0')
+		case i == 2 && digit >= '5':
+			sen++
+		}
+	}
+	if len(fraction) == 1 {
+		sen *= 10
+	}
+
+	amount := Money(rupiah*Scale + sen)
+	if negative {
+		amount = -amount
+	}
+
+	return amount, nil
+}
+
+// MustParse is Parse for constants known to be valid
+func MustParse(value string) Money {
+	amount, err := Parse(value)
+	if err != nil {
+		panic(fmt.Sprintf("money: %q: %v", value, err))
+	}
+	return amount
+}
+
+func Sum(amounts ...Money) Money {
+	var total Money
+	for _, amount := range amounts {
+		total += amount
+	}
+	return total
+}
+
+func Min(a, b Money) Money {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func Max(a, b Money) Money {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func (m Money) Add(other Money) Money {
+	return m + other
+}
+
+func (m Money) Sub(other Money) Money {
+	return m - other
+}
+
+// Mul multiplies by a whole quantity, such as days or trips
+func (m Money) Mul(quantity int64) Money {
+	return m * Money(quantity)
+}
+
+// MulFloat multiplies by a fractional quantity, such as liters, rounded to the sen
+func (m Money) MulFloat(quantity float64) Money {
+	return Money(math.Round(float64(m) * quantity))
+}
+
+// Div splits the amount in n parts rounded to the sen, n must not be zero
+func (m Money) Div(n int64) Money {
+	quotient, remainder := int64(m)/n, int64(m)%n
+	if 2*abs(remainder) >= abs(n) {
+		if (m < 0) != (n < 0) {
+			quotient--
+		} else {
+			quotient++
+		}
+	}
+	return Money(quotient)
+}
+
+// RoundTo rounds to a multiple of unit, New(1) rounds to whole rupiah
+func (m Money) RoundTo(unit Money) Money {
+	if unit <= 0 {
+		return m
+	}
+	return m.Div(int64(unit)) * unit
+}
+
+func (m Money) Neg() Money {
+	return -m
+}
+
+func (m Money) Abs() Money {
+	if m < 0 {
+		return -m
+	}
+	return m
+}
+
+func (m Money) IsZero() bool {
+	return m == 0
+}
+
+func (m Money) IsPositive() bool {
+	return m > 0
+}
+
+func (m Money) IsNegative() bool {
+	return m < 0
+}
+
+// Rupiah returns the whole rupiah, rounded
+func (m Money) Rupiah() int64 {
+	return int64(m.RoundTo(Scale)) / Scale
+}
+
+// Float64 is meant for ratios and display, never for further sums
+func (m Money) Float64() float64 {
+	return float64(m) / Scale
+}
+
+// String formats the amount with two decimals, as stored in postgres
+func (m Money) String() string {
+	sign := ""
+	value := int64(m)
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, value/Scale, value%Scale)
+}
+
+// MarshalJSON writes a JSON number without trailing zero decimals
+func (m Money) MarshalJSON() ([]byte, error) {
+	text := m.String()
+	text = strings.TrimRight(text, "0")
+	text = strings.TrimSuffix(text, ".")
+	if text == "" || text == "-" {
+		text = "0"
+	}
+	return []byte(text), nil
+}
+
+// UnmarshalJSON accepts a JSON number or a numeric string
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		return nil
+	}
+
+	text := string(data)
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		text = unquoted
+	} else if strings.ContainsAny(text, "eE") {
+		// exponent notation is not fixed-point, go through a float
+		value, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return ErrInvalid
+		}
+		*m = FromFloat(value)
+		return nil
+	}
+
+	amount, err := Parse(text)
+	if err != nil {
+		return err
+	}
+
+	*m = amount
+	return nil
+}
+
+// Scan reads postgres numeric columns, sums included
+func (m *Money) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*m = 0
+	case string:
+		amount, err := Parse(v)
+		if err != nil {
+			return err
+		}
+		*m = amount
+	case []byte:
+		amount, err := Parse(string(v))
+		if err != nil {
+			return err
+		}
+		*m = amount
+	case int64:
+		*m = New(v)
+	case float64:
+		*m = FromFloat(v)
+	default:
+		return fmt.Errorf("money: cannot scan %T", value)
+	}
+	return nil
+}
+
+// Value writes the exact decimal text, postgres casts it to numeric
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+func abs(value int64) int64 {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
@@ -0,0 +1,81 @@
+package money_test
+
+import (
+	"api/internal/entity/money"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoneyParse(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected money.Money
+	}{
+		{"150000", money.New(150000)},
+		{"1250.5", 125050},
+		{"1250.50", 125050},
+		{"0.10", 10},
+		{".5", 50},
+		{"-75.25", -7525},
+		{"0.125", 13},
+		{"-0.125", -13},
+		{"0.994", 99},
+		{"0.995", 100},
+	}
+
+	for _, c := range cases {
+		amount, err := money.Parse(c.input)
+		assert.Nil(t, err, c.input)
+		assert.Equal(t, c.expected, amount, c.input)
+	}
+
+	for _, input := range []string{"", "-", "abc", "1.2.3", "1,5", "--5", "1.5x"} {
+		_, err := money.Parse(input)
+		assert.ErrorIs(t, err, money.ErrInvalid, input)
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	// 0.1 + 0.2 drifts in float64, not in sen
+	assert.Equal(t, money.MustParse("0.30"), money.MustParse("0.10")+money.MustParse("0.20"))
+
+	assert.Equal(t, money.New(700000), money.New(100000).Mul(7))
+	assert.Equal(t, money.MustParse("272000"), money.New(6800).MulFloat(40))
+	assert.Equal(t, money.MustParse("34.58"), money.MustParse("12.345").MulFloat(2.8))
+
+	assert.Equal(t, money.MustParse("33333.33"), money.New(100000).Div(3))
+	assert.Equal(t, money.MustParse("66666.67"), money.New(200000).Div(3))
+	assert.Equal(t, money.MustParse("-66666.67"), money.New(-200000).Div(3))
+
+	assert.Equal(t, money.New(1001), money.MustParse("1000.50").RoundTo(money.New(1)))
+	assert.Equal(t, money.New(-1001), money.MustParse("-1000.50").RoundTo(money.New(1)))
+	assert.Equal(t, money.New(1400), money.New(1449).RoundTo(money.New(100)))
+	assert.Equal(t, int64(1000), money.MustParse("1000.49").Rupiah())
+
+	assert.Equal(t, money.New(600), money.Sum(money.New(100), money.New(200), money.New(300)))
+	assert.Equal(t, money.New(100), money.Min(money.New(100), money.New(200)))
+	assert.Equal(t, money.New(200), money.Max(money.New(100), money.New(200)))
+	assert.Equal(t, "-1250.05", money.MustParse("-1250.05").String())
+}
+
+func TestMoneyJSON(t *testing.T) {
+	bytes, err := json.Marshal(map[string]money.Money{"whole": money.New(150000), "fraction": money.MustParse("1250.50"), "zero": 0})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"whole":150000,"fraction":1250.5,"zero":0}`, string(bytes))
+
+	var body struct {
+		Number money.Money  `json:"number"`
+		Text   money.Money  `json:"text"`
+		Null   *money.Money `json:"null"`
+	}
+	err = json.Unmarshal([]byte(`{"number":1250.75,"text":"99.99","null":null}`), &body)
+	assert.Nil(t, err)
+	assert.Equal(t, money.MustParse("1250.75"), body.Number)
+	assert.Equal(t, money.MustParse("99.99"), body.Text)
+	assert.Nil(t, body.Null)
+
+	err = json.Unmarshal([]byte(`{"number":"12a"}`), &body)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"api/internal/entity/enum"
+	"api/internal/entity/money"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PayRule configures how an employee role is paid in operational payroll.
+// A nil DailyRate falls back to the employee salary.
+type PayRule struct {
+	ID        int               `gorm:"primaryKey;autoIncrement"`
+	Role      enum.EmployeeRole `gorm:"column:role;type:EmployeeRole;not null;unique"`
+	DailyRate *money.Money      `gorm:"column:daily_rate;type:decimal(12,2)"`
+	TripRate  money.Money       `gorm:"column:trip_rate;type:decimal(12,2);not null;default:0"`
+	SackRate  money.Money       `gorm:"column:sack_rate;type:decimal(12,2);not null;default:0"`
+
+	CreatedAt time.Time      `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt time.Time      `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
+}
+
+func (pr *PayRule) TableName() string {
+	return "pay_rules"
+}
@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"api/internal/entity/enum"
+	"api/internal/entity/money"
+	"time"
+)
+
+type PayrollItem struct {
+	ID          int                  `gorm:"primaryKey;autoIncrement"`
+	Type        enum.PayrollItemType `gorm:"column:type;type:PayrollItemType;not null"`
+	Description string               `gorm:"column:description;type:text;not null"`
+	Quantity    float64              `gorm:"column:quantity;type:decimal(12,2);not null;default:0"`
+	Rate        money.Money          `gorm:"column:rate;type:decimal(12,2);not null;default:0"`
+	Amount      money.Money          `gorm:"column:amount;type:decimal(12,2);not null;default:0"`
+
+	PayrollId int      `gorm:"column:payroll_id;not null"`
+	Payroll   *Payroll `gorm:"foreignKey:PayrollId;references:ID"`
+
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime:milli"`
+}
+
+func (pi *PayrollItem) TableName() string {
+	return "payroll_items"
+}
@@ -18,6 +18,10 @@ type Period struct {
 	Year       int             `gorm:"column:year;not null"`
 	IsActive   bool            `gorm:"column:is_active;not null;default:true"`
 
+	// policy the period was calculated with
+	WeekStart time.Weekday         `gorm:"column:week_start;not null;default:0"`
+	MonthRule enum.PeriodMonthRule `gorm:"column:month_rule;not null;default:START"`
+
 	IsClosed     bool       `gorm:"column:is_closed;not null;default:false"`
 	ClosedBy     *uuid.UUID `gorm:"type:uuid;column:closed_by"`
 	ClosedAt     *time.Time `gorm:"column:closed_at"`
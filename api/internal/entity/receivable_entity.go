@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"api/internal/entity/money"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Receivable struct {
+	ID          int         `gorm:"primaryKey;autoIncrement"`
+	Date        time.Time   `gorm:"type:date;column:date;not null"`
+	Amount      money.Money `gorm:"column:amount;type:decimal(12,2);not null"`
+	Description *string     `gorm:"column:description;type:text"`
+
+	CustomerId int       `gorm:"column:customer_id;not null"`
+	Customer   *Customer `gorm:"foreignKey:CustomerId;references:ID"`
+
+	CreatedAt time.Time      `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt time.Time      `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
+}
+
+func (r *Receivable) TableName() string {
+	return "receivables"
+}
@@ -11,7 +11,11 @@ type Route struct {
 	Name        string `gorm:"column:name"`
 	Description string `gorm:"column:description"`
 
-	Sales []Sales `gorm:"many2many:sales_routes;"`
+	// scheduled visit days as time.Weekday, 0 is Sunday
+	VisitDays []int `gorm:"column:visit_days;type:jsonb;serializer:json"`
+
+	Sales []Sales     `gorm:"many2many:sales_routes;"`
+	Stops []RouteStop `gorm:"foreignKey:RouteId;references:ID"`
 
 	CreatedAt time.Time      `gorm:"column:created_at;autoCreateTime:milli"`
 	UpdatedAt time.Time      `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
@@ -21,3 +25,23 @@ type Route struct {
 func (r *Route) TableName() string {
 	return "routes"
 }
+
+// RouteStop is a point visited on a route, stops are visited by Sequence
+type RouteStop struct {
+	ID        int     `gorm:"primaryKey;autoIncrement"`
+	Sequence  int     `gorm:"column:sequence;not null"`
+	Name      string  `gorm:"column:name;not null"`
+	Address   *string `gorm:"column:address;type:text"`
+	Latitude  float64 `gorm:"column:latitude;type:decimal(10,7);not null"`
+	Longitude float64 `gorm:"column:longitude;type:decimal(10,7);not null"`
+
+	RouteId int    `gorm:"column:route_id;not null"`
+	Route   *Route `gorm:"foreignKey:RouteId;references:ID"`
+
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt time.Time `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+}
+
+func (s *RouteStop) TableName() string {
+	return "route_stops"
+}
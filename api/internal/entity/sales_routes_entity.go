@@ -7,9 +7,9 @@ import (
 )
 
 type SalesRoutes struct {
-	ID      int `gorm:"type:number;primaryKey"`
-	SalesId int `gorm:"column:sales_id;type:number"`
-	RouteId int `gorm:"column:route_id;type:number"`
+	ID      int `gorm:"primaryKey;autoIncrement"`
+	SalesId int `gorm:"column:sales_id;not null"`
+	RouteId int `gorm:"column:route_id;not null"`
 
 	CreatedAt time.Time      `gorm:"column:created_at;autoCreateTime:milli"`
 	UpdatedAt time.Time      `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
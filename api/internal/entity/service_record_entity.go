@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"api/internal/entity/money"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ServiceRecord is a performed vehicle service, its cost is posted as an EXPENSE history
+type ServiceRecord struct {
+	ID          int         `gorm:"primaryKey;autoIncrement"`
+	Date        time.Time   `gorm:"type:date;column:date;not null"`
+	Odometer    int         `gorm:"column:odometer;not null"`
+	ServiceType string      `gorm:"column:service_type;not null"`
+	Parts       *string     `gorm:"column:parts;type:text"`
+	Workshop    *string     `gorm:"column:workshop"`
+	Cost        money.Money `gorm:"column:cost;type:decimal(12,2);not null"`
+	Notes       *string     `gorm:"column:notes;type:text"`
+
+	VehicleId         int64            `gorm:"column:vehicle_id;not null"`
+	Vehicle           *Vehicle         `gorm:"foreignKey:VehicleId;references:ID"`
+	MaintenancePlanId *int             `gorm:"column:maintenance_plan_id"`
+	MaintenancePlan   *MaintenancePlan `gorm:"foreignKey:MaintenancePlanId;references:ID"`
+	VehicleHistoryId  *int64           `gorm:"column:vehicle_history_id"`
+	VehicleHistory    *VehicleHistory  `gorm:"foreignKey:VehicleHistoryId;references:ID"`
+
+	CreatedAt time.Time      `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt time.Time      `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
+}
+
+func (s *ServiceRecord) TableName() string {
+	return "service_records"
+}
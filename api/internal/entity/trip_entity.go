@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"api/internal/entity/money"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Trip is a day's dispatch of a vehicle with its crew over one or more routes
+type Trip struct {
+	ID            int         `gorm:"primaryKey;autoIncrement"`
+	Date          time.Time   `gorm:"type:date;column:date;not null"`
+	SacksLoaded   int         `gorm:"column:sacks_loaded;not null;default:0"`
+	SacksReturned int         `gorm:"column:sacks_returned;not null;default:0"`
+	Income        money.Money `gorm:"column:income;type:decimal(12,2);not null;default:0"`
+	Notes         *string     `gorm:"column:notes;type:text"`
+
+	VehicleId        int64           `gorm:"column:vehicle_id;not null"`
+	Vehicle          *Vehicle        `gorm:"foreignKey:VehicleId;references:ID"`
+	DriverId         int             `gorm:"column:driver_id;not null"`
+	Driver           *Employee       `gorm:"foreignKey:DriverId;references:ID"`
+	VehicleHistoryId *int64          `gorm:"column:vehicle_history_id"`
+	VehicleHistory   *VehicleHistory `gorm:"foreignKey:VehicleHistoryId;references:ID"`
+
+	CrewId *int  `gorm:"column:crew_id"`
+	Crew   *Crew `gorm:"foreignKey:CrewId;references:ID"`
+
+	Helpers []Employee `gorm:"many2many:trip_helpers;"`
+	Routes  []Route    `gorm:"many2many:trip_routes;"`
+
+	CreatedAt time.Time      `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt time.Time      `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
+}
+
+func (t *Trip) TableName() string {
+	return "trips"
+}
+
+// SacksDelivered is the number of sacks that did not come back
+func (t *Trip) SacksDelivered() int {
+	return t.SacksLoaded - t.SacksReturned
+}
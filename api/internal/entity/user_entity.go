@@ -19,6 +19,10 @@ type User struct {
 	UpdatedAt time.Time      `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
 	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
 
+	// linked payroll staff, required for the EMPLOYEE role
+	EmployeeId *int      `gorm:"column:employee_id"`
+	Employee   *Employee `gorm:"foreignKey:EmployeeId;references:ID"`
+
 	Periods        []Period        `gorm:"foreignKey:ClosedBy;references:ID"`
 	PeriodClosures []PeriodClosure `gorm:"foreignKey:ClosedBy;references:ID"`
 }
@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"api/internal/entity/enum"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// VehicleDocument is a STNK, KIR or insurance record, renewals are stored as new records
+type VehicleDocument struct {
+	ID         int                      `gorm:"primaryKey;autoIncrement"`
+	Type       enum.VehicleDocumentType `gorm:"column:type;not null"`
+	Number     string                   `gorm:"column:number;not null"`
+	ExpiryDate time.Time                `gorm:"type:date;column:expiry_date;not null"`
+
+	VehicleId int64    `gorm:"column:vehicle_id;not null"`
+	Vehicle   *Vehicle `gorm:"foreignKey:VehicleId;references:ID"`
+
+	CreatedAt time.Time      `gorm:"column:created_at;autoCreateTime:milli"`
+	UpdatedAt time.Time      `gorm:"column:updated_at;autoCreateTime:milli;autoUpdateTime:milli"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
+}
+
+func (d *VehicleDocument) TableName() string {
+	return "vehicle_documents"
+}
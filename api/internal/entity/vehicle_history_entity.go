@@ -2,6 +2,7 @@ package entity
 
 import (
 	"api/internal/entity/enum"
+	"api/internal/entity/money"
 	"time"
 
 	"gorm.io/gorm"
@@ -13,9 +14,9 @@ type VehicleHistory struct {
 	Description string                  `gorm:"column:description;type:text;not null"`
 	Type        enum.VehicleHistoryType `gorm:"column:type;type:VehicleHistoryType;not null"`
 
-	Amount float64 `gorm:"column:amount;type:decimal(12,2);not null;default:0"`
-	Profit *int    `gorm:"column:profit"`
-	Sack   *int    `gorm:"column:sack"`
+	Amount money.Money `gorm:"column:amount;type:decimal(12,2);not null;default:0"`
+	Profit *int        `gorm:"column:profit"`
+	Sack   *int        `gorm:"column:sack"`
 
 	VehicleID int64   `gorm:"column:vehicle_id;not null"`
 	Vehicle   Vehicle `gorm:"foreignKey:VehicleID;references:ID;constraint:OnDelete:RESTRICT"`
@@ -0,0 +1,302 @@
+package migration
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/enum"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	DRIFT_TABLE  = "TABLE"
+	DRIFT_COLUMN = "COLUMN"
+	DRIFT_TYPE   = "TYPE"
+	DRIFT_ENUM   = "ENUM"
+)
+
+var sqlType = regexp.MustCompile(`^([A-Za-z_ ]+?)\s*(?:\((\d+)(?:\s*,\s*(\d+))?\))?$`)
+
+// builtinTypes maps the type tags used by the entities to information_schema data types,
+// any other tag is taken as the name of a postgres enum
+var builtinTypes = map[string]string{
+	"varchar":     "character varying",
+	"text":        "text",
+	"decimal":     "numeric",
+	"numeric":     "numeric",
+	"int":         "integer",
+	"integer":     "integer",
+	"bigint":      "bigint",
+	"smallint":    "smallint",
+	"bool":        "boolean",
+	"boolean":     "boolean",
+	"date":        "date",
+	"timestamp":   "timestamp without time zone",
+	"timestamptz": "timestamp with time zone",
+	"json":        "json",
+	"jsonb":       "jsonb",
+	"uuid":        "uuid",
+	"bytea":       "bytea",
+}
+
+// compatibleTypes are the data types accepted for fields without a type tag
+var compatibleTypes = map[schema.DataType][]string{
+	schema.Bool:   {"boolean"},
+	schema.Int:    {"smallint", "integer", "bigint"},
+	schema.Uint:   {"smallint", "integer", "bigint"},
+	schema.Float:  {"real", "double precision", "numeric"},
+	schema.String: {"character varying", "character", "text", "USER-DEFINED"},
+	schema.Time:   {"timestamp without time zone", "timestamp with time zone", "date"},
+	schema.Bytes:  {"bytea"},
+}
+
+// Drift is a difference between the go declarations and the live schema
+type Drift struct {
+	Kind    string
+	Table   string
+	Column  string
+	Message string
+}
+
+func (d Drift) String() string {
+	if d.Column == "" {
+		return fmt.Sprintf("[%s] %s: %s", d.Kind, d.Table, d.Message)
+	}
+	return fmt.Sprintf("[%s] %s.%s: %s", d.Kind, d.Table, d.Column, d.Message)
+}
+
+type catalogColumn struct {
+	TableName              string
+	ColumnName             string
+	DataType               string
+	UdtName                string
+	CharacterMaximumLength *int
+	NumericPrecision       *int
+	NumericScale           *int
+	IsNullable             string
+	ColumnDefault          *string
+}
+
+func (c catalogColumn) describe() string {
+	switch {
+	case c.DataType == "USER-DEFINED":
+		return c.UdtName
+	case c.CharacterMaximumLength != nil:
+		return fmt.Sprintf("%s(%d)", c.DataType, *c.CharacterMaximumLength)
+	case c.DataType == "numeric" && c.NumericPrecision != nil && c.NumericScale != nil:
+		return fmt.Sprintf("numeric(%d,%d)", *c.NumericPrecision, *c.NumericScale)
+	default:
+		return c.DataType
+	}
+}
+
+type DriftChecker struct {
+	DB       *gorm.DB
+	Log      *logrus.Logger
+	Entities []entity.Entity
+	Enums    map[string][]string
+}
+
+func NewDriftChecker(db *gorm.DB, log *logrus.Logger) *DriftChecker {
+	return &DriftChecker{
+		DB:       db,
+		Log:      log,
+		Entities: entity.Entities(),
+		Enums:    enum.DatabaseTypes(),
+	}
+}
+
+// Check compares the entities and enums against the postgres catalog of the
+// current schema, the result is sorted by table and column
+func (c *DriftChecker) Check() ([]Drift, error) {
+	var columns []catalogColumn
+	if err := c.DB.Raw(`
+		SELECT table_name, column_name, data_type, udt_name, character_maximum_length,
+			numeric_precision, numeric_scale, is_nullable, column_default
+		FROM information_schema.columns
+		WHERE table_schema = current_schema()
+		ORDER BY table_name, ordinal_position`).Scan(&columns).Error; err != nil {
+		return nil, fmt.Errorf("read columns: %w", err)
+	}
+
+	catalog := make(map[string][]catalogColumn)
+	for _, column := range columns {
+		catalog[column.TableName] = append(catalog[column.TableName], column)
+	}
+
+	var labels []struct {
+		TypeName  string
+		EnumLabel string
+	}
+	if err := c.DB.Raw(`
+		SELECT t.typname AS type_name, e.enumlabel AS enum_label
+		FROM pg_type t
+		JOIN pg_enum e ON e.enumtypid = t.oid
+		JOIN pg_namespace n ON n.oid = t.typnamespace
+		WHERE n.nspname = current_schema()
+		ORDER BY t.typname, e.enumsortorder`).Scan(&labels).Error; err != nil {
+		return nil, fmt.Errorf("read enums: %w", err)
+	}
+
+	enums := make(map[string][]string)
+	for _, label := range labels {
+		enums[label.TypeName] = append(enums[label.TypeName], label.EnumLabel)
+	}
+
+	var drifts []Drift
+	cache := &sync.Map{}
+	for _, model := range c.Entities {
+		sch, err := schema.Parse(model, cache, c.DB.NamingStrategy)
+		if err != nil {
+			return nil, fmt.Errorf("parse %T: %w", model, err)
+		}
+
+		drifts = append(drifts, c.checkTable(sch, catalog[sch.Table], enums)...)
+	}
+
+	drifts = append(drifts, c.checkEnums(enums)...)
+
+	sort.SliceStable(drifts, func(i, j int) bool {
+		if drifts[i].Table != drifts[j].Table {
+			return drifts[i].Table < drifts[j].Table
+		}
+		return drifts[i].Column < drifts[j].Column
+	})
+
+	for _, drift := range drifts {
+		c.Log.Warnf("Schema drift %s", drift)
+	}
+
+	return drifts, nil
+}
+
+// Helper fuction
+func (c *DriftChecker) checkTable(sch *schema.Schema, columns []catalogColumn, enums map[string][]string) []Drift {
+	if len(columns) == 0 {
+		return []Drift{{Kind: DRIFT_TABLE, Table: sch.Table, Message: "table does not exist"}}
+	}
+
+	byName := make(map[string]catalogColumn, len(columns))
+	for _, column := range columns {
+		byName[column.ColumnName] = column
+	}
+
+	var drifts []Drift
+	for _, field := range sch.Fields {
+		if field.DBName == "" {
+			continue
+		}
+
+		column, ok := byName[field.DBName]
+		if !ok {
+			drifts = append(drifts, Drift{Kind: DRIFT_COLUMN, Table: sch.Table, Column: field.DBName, Message: fmt.Sprintf("column of %s.%s does not exist", sch.Name, field.Name)})
+			continue
+		}
+
+		if expected, ok := matchType(field, column); !ok {
+			drifts = append(drifts, Drift{Kind: DRIFT_TYPE, Table: sch.Table, Column: field.DBName, Message: fmt.Sprintf("entity declares %s, database has %s", expected, column.describe())})
+			continue
+		}
+
+		if column.DataType == "USER-DEFINED" {
+			if _, ok := enums[column.UdtName]; ok {
+				if _, ok := c.Enums[column.UdtName]; !ok {
+					drifts = append(drifts, Drift{Kind: DRIFT_ENUM, Table: sch.Table, Column: field.DBName, Message: fmt.Sprintf("enum %s has no go values", column.UdtName)})
+				}
+			}
+		}
+	}
+
+	// inserts fail on required columns the entity never writes
+	for _, column := range columns {
+		if _, ok := sch.FieldsByDBName[column.ColumnName]; ok {
+			continue
+		}
+		if column.IsNullable == "NO" && column.ColumnDefault == nil {
+			drifts = append(drifts, Drift{Kind: DRIFT_COLUMN, Table: sch.Table, Column: column.ColumnName, Message: "required column without default is not mapped"})
+		}
+	}
+
+	return drifts
+}
+
+// Helper fuction
+func (c *DriftChecker) checkEnums(enums map[string][]string) []Drift {
+	var drifts []Drift
+	for name, values := range c.Enums {
+		labels, ok := enums[name]
+		if !ok {
+			drifts = append(drifts, Drift{Kind: DRIFT_ENUM, Table: name, Message: "enum does not exist"})
+			continue
+		}
+
+		for _, value := range values {
+			if !slices.Contains(labels, value) {
+				drifts = append(drifts, Drift{Kind: DRIFT_ENUM, Table: name, Message: fmt.Sprintf("go value %s is missing from the database", value)})
+			}
+		}
+
+		for _, label := range labels {
+			if !slices.Contains(values, label) {
+				drifts = append(drifts, Drift{Kind: DRIFT_ENUM, Table: name, Message: fmt.Sprintf("database value %s is not declared in go", label)})
+			}
+		}
+	}
+
+	return drifts
+}
+
+// matchType reports whether the column satisfies the field, together with the
+// type expected from the entity
+func matchType(field *schema.Field, column catalogColumn) (string, bool) {
+	tag, ok := field.TagSettings["TYPE"]
+	if !ok {
+		accepted, ok := compatibleTypes[field.DataType]
+		if !ok {
+			// custom data types are checked through their type tag only
+			return string(field.DataType), true
+		}
+		return strings.Join(accepted, " or "), slices.Contains(accepted, column.DataType)
+	}
+
+	match := sqlType.FindStringSubmatch(strings.TrimSpace(tag))
+	if match == nil {
+		return tag, false
+	}
+
+	dataType, ok := builtinTypes[strings.ToLower(match[1])]
+	if !ok {
+		return tag, column.DataType == "USER-DEFINED" && column.UdtName == tag
+	}
+
+	if column.DataType != dataType {
+		return tag, false
+	}
+
+	if match[2] == "" {
+		return tag, true
+	}
+
+	size, _ := strconv.Atoi(match[2])
+	switch dataType {
+	case "character varying":
+		return tag, column.CharacterMaximumLength != nil && *column.CharacterMaximumLength == size
+	case "numeric":
+		scale := 0
+		if match[3] != "" {
+			scale, _ = strconv.Atoi(match[3])
+		}
+		return tag, column.NumericPrecision != nil && *column.NumericPrecision == size &&
+			column.NumericScale != nil && *column.NumericScale == scale
+	}
+
+	return tag, true
+}
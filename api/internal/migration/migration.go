@@ -0,0 +1,345 @@
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// lockKey serialises migrators running against the same database
+const lockKey = 20261018
+
+var fileName = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Migration is a pair of up and down scripts sharing a version
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// SchemaMigration records an applied migration version
+type SchemaMigration struct {
+	Version   int64     `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"column:name;not null"`
+	AppliedAt time.Time `gorm:"column:applied_at;not null"`
+}
+
+func (m *SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+type Migrator struct {
+	DB  *gorm.DB
+	Log *logrus.Logger
+	FS  fs.FS
+}
+
+func NewMigrator(db *gorm.DB, log *logrus.Logger, files fs.FS) *Migrator {
+	return &Migrator{
+		DB:  db,
+		Log: log,
+		FS:  files,
+	}
+}
+
+// Load reads the migrations ordered by version
+func (m *Migrator) Load() ([]Migration, error) {
+	entries, err := fs.ReadDir(m.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("read migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse version of %s: %w", entry.Name(), err)
+		}
+
+		content, err := fs.ReadFile(m.FS, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("version %d is used by %s and %s", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up script", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// ensureTable creates the tracking table, adopting the single version table
+// left by golang-migrate when the schema was migrated by hand
+func (m *Migrator) ensureTable() error {
+	migrator := m.DB.Migrator()
+
+	if migrator.HasTable(&SchemaMigration{}) && migrator.HasColumn(&SchemaMigration{}, "dirty") {
+		var legacy struct {
+			Version int64
+			Dirty   bool
+		}
+
+		if err := m.DB.Raw(`SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&legacy).Error; err != nil {
+			return fmt.Errorf("read legacy schema_migrations: %w", err)
+		}
+
+		if legacy.Dirty {
+			return fmt.Errorf("legacy schema_migrations is dirty at version %d, fix it by hand first", legacy.Version)
+		}
+
+		migrations, err := m.Load()
+		if err != nil {
+			return err
+		}
+
+		return m.DB.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(`DROP TABLE schema_migrations`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Migrator().CreateTable(&SchemaMigration{}); err != nil {
+				return err
+			}
+
+			now := time.Now()
+			for _, migration := range migrations {
+				if migration.Version > legacy.Version {
+					break
+				}
+
+				if err := tx.Create(&SchemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: now}).Error; err != nil {
+					return err
+				}
+			}
+
+			m.Log.Infof("Adopted legacy schema_migrations at version %d", legacy.Version)
+			return nil
+		})
+	}
+
+	if migrator.HasTable(&SchemaMigration{}) {
+		return nil
+	}
+
+	return migrator.CreateTable(&SchemaMigration{})
+}
+
+// Status lists every migration with the time it was applied
+func (m *Migrator) Status() ([]Status, error) {
+	if err := m.ensureTable(); err != nil {
+		return nil, err
+	}
+
+	migrations, err := m.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []SchemaMigration
+	if err := m.DB.Find(&applied).Error; err != nil {
+		return nil, fmt.Errorf("read schema_migrations: %w", err)
+	}
+
+	appliedAt := make(map[int64]time.Time)
+	for _, migration := range applied {
+		appliedAt[migration.Version] = migration.AppliedAt
+	}
+
+	statuses := make([]Status, len(migrations))
+	for i, migration := range migrations {
+		statuses[i].Migration = migration
+		if at, ok := appliedAt[migration.Version]; ok {
+			statuses[i].AppliedAt = &at
+		}
+	}
+
+	return statuses, nil
+}
+
+// Pending returns the migrations not applied yet
+func (m *Migrator) Pending() ([]Migration, error) {
+	statuses, err := m.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			pending = append(pending, status.Migration)
+		}
+	}
+
+	return pending, nil
+}
+
+// Up applies the pending migrations in version order, each in its own transaction
+func (m *Migrator) Up() (int, error) {
+	pending, err := m.Pending()
+	if err != nil {
+		return 0, err
+	}
+
+	for i, migration := range pending {
+		err := m.DB.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(`SELECT pg_advisory_xact_lock(?)`, lockKey).Error; err != nil {
+				return err
+			}
+
+			// another migrator may have applied it while we waited for the lock
+			var count int64
+			if err := tx.Model(&SchemaMigration{}).Where("version = ?", migration.Version).Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				return nil
+			}
+
+			if err := tx.Exec(migration.Up).Error; err != nil {
+				return err
+			}
+
+			return tx.Create(&SchemaMigration{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				AppliedAt: time.Now(),
+			}).Error
+		})
+		if err != nil {
+			return i, fmt.Errorf("apply %d_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		m.Log.Infof("Applied migration %d_%s", migration.Version, migration.Name)
+	}
+
+	return len(pending), nil
+}
+
+// Down rolls back the last n applied migrations, newest first
+func (m *Migrator) Down(n int) (int, error) {
+	if n <= 0 {
+		return 0, errors.New("number of migrations to roll back must be positive")
+	}
+
+	statuses, err := m.Status()
+	if err != nil {
+		return 0, err
+	}
+
+	var applied []Migration
+	for i := len(statuses) - 1; i >= 0 && len(applied) < n; i-- {
+		if statuses[i].AppliedAt != nil {
+			applied = append(applied, statuses[i].Migration)
+		}
+	}
+
+	for i, migration := range applied {
+		if migration.Down == "" {
+			return i, fmt.Errorf("migration %d_%s has no down script", migration.Version, migration.Name)
+		}
+
+		err := m.DB.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(`SELECT pg_advisory_xact_lock(?)`, lockKey).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(migration.Down).Error; err != nil {
+				return err
+			}
+
+			return tx.Delete(&SchemaMigration{}, migration.Version).Error
+		})
+		if err != nil {
+			return i, fmt.Errorf("roll back %d_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		m.Log.Infof("Rolled back migration %d_%s", migration.Version, migration.Name)
+	}
+
+	return len(applied), nil
+}
+
+// Baseline marks the migrations up to version as applied without running them,
+// for databases migrated by hand before the tracking table existed
+func (m *Migrator) Baseline(version int64) (int, error) {
+	statuses, err := m.Status()
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, status := range statuses {
+		if status.Version > version || status.AppliedAt != nil {
+			continue
+		}
+
+		if err := m.DB.Create(&SchemaMigration{
+			Version:   status.Version,
+			Name:      status.Name,
+			AppliedAt: time.Now(),
+		}).Error; err != nil {
+			return total, fmt.Errorf("baseline %d_%s: %w", status.Version, status.Name, err)
+		}
+		total++
+	}
+
+	return total, nil
+}
+
+// EnsureUpToDate fails when migrations are pending
+func (m *Migrator) EnsureUpToDate() error {
+	pending, err := m.Pending()
+	if err != nil {
+		return err
+	}
+
+	if len(pending) > 0 {
+		return fmt.Errorf("database schema is behind by %d migrations, first pending is %d_%s", len(pending), pending[0].Version, pending[0].Name)
+	}
+
+	return nil
+}
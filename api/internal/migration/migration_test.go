@@ -0,0 +1,73 @@
+package migration_test
+
+import (
+	"api/db/migrations"
+	"api/internal/migration"
+	"testing"
+	"testing/fstest"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func load(files fstest.MapFS) ([]migration.Migration, error) {
+	return migration.NewMigrator(nil, logrus.New(), files).Load()
+}
+
+func TestLoadOrdersByVersion(t *testing.T) {
+	migrations, err := load(fstest.MapFS{
+		"3_create_trips.up.sql":     {Data: []byte("CREATE TABLE trips ()")},
+		"1_create_users.up.sql":     {Data: []byte("CREATE TABLE users ()")},
+		"1_create_users.down.sql":   {Data: []byte("DROP TABLE users")},
+		"20_create_fuel.up.sql":     {Data: []byte("CREATE TABLE fuel ()")},
+		"20_create_fuel.down.sql":   {Data: []byte("DROP TABLE fuel")},
+		"README.md":                 {Data: []byte("not a migration")},
+		"2_notes.sql":               {Data: []byte("not a migration")},
+		"4_nested.up.sql/ignored":   {Data: []byte("a directory is skipped")},
+		"3_create_trips.down.sql":   {Data: []byte("DROP TABLE trips")},
+		"10_create_vehicles.up.sql": {Data: []byte("CREATE TABLE vehicles ()")},
+	})
+	assert.Nil(t, err)
+
+	// ordered by number, not by file name
+	assert.Len(t, migrations, 4)
+	assert.Equal(t, []int64{1, 3, 10, 20}, []int64{migrations[0].Version, migrations[1].Version, migrations[2].Version, migrations[3].Version})
+
+	assert.Equal(t, "create_users", migrations[0].Name)
+	assert.Equal(t, "CREATE TABLE users ()", migrations[0].Up)
+	assert.Equal(t, "DROP TABLE users", migrations[0].Down)
+
+	// the down script is optional
+	assert.Equal(t, "create_vehicles", migrations[2].Name)
+	assert.Empty(t, migrations[2].Down)
+}
+
+func TestLoadMissingUpScript(t *testing.T) {
+	_, err := load(fstest.MapFS{
+		"1_create_users.up.sql":   {Data: []byte("CREATE TABLE users ()")},
+		"2_create_trips.down.sql": {Data: []byte("DROP TABLE trips")},
+	})
+	assert.EqualError(t, err, "migration 2_create_trips has no up script")
+}
+
+func TestLoadVersionClash(t *testing.T) {
+	_, err := load(fstest.MapFS{
+		"1_create_users.up.sql": {Data: []byte("CREATE TABLE users ()")},
+		"1_create_trips.up.sql": {Data: []byte("CREATE TABLE trips ()")},
+	})
+	assert.ErrorContains(t, err, "version 1 is used by")
+}
+
+func TestLoadEmbedded(t *testing.T) {
+	loaded, err := migration.NewMigrator(nil, logrus.New(), migrations.FS).Load()
+	assert.Nil(t, err)
+	assert.NotEmpty(t, loaded)
+
+	for i := range loaded {
+		assert.NotEmpty(t, loaded[i].Up)
+		assert.NotEmpty(t, loaded[i].Down, "migration %d_%s has no down script", loaded[i].Version, loaded[i].Name)
+		if i > 0 {
+			assert.Less(t, loaded[i-1].Version, loaded[i].Version)
+		}
+	}
+}
@@ -1,8 +1,14 @@
 package model
 
-import "github.com/google/uuid"
+import (
+	"api/internal/entity/enum"
+
+	"github.com/google/uuid"
+)
 
 type Auth struct {
 	// Login user id
 	ID uuid.UUID `json:"id"`
+	// Login user role, reloaded from the user row on every request
+	Role enum.UserRole `json:"role"`
 }
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"api/internal/entity/money"
+	"time"
+)
+
+type FindAllCashAdvanceRequest struct {
+	EmployeeId      int  `json:"employeeId" validate:"omitempty,gt=0"`
+	OnlyOutstanding bool `json:"onlyOutstanding"`
+	Page            int  `json:"page"`
+	PerPage         int  `json:"perPage" validate:"max=100"`
+}
+
+type CreateCashAdvanceRequest struct {
+	EmployeeId  int         `json:"employeeId" validate:"required,gt=0"`
+	Date        string      `json:"date" validate:"required,datetime=2006-01-02"`
+	Amount      money.Money `json:"amount" validate:"required,gt=0"`
+	Installment money.Money `json:"installment" validate:"required,gt=0,ltefield=Amount"`
+	Notes       *string     `json:"notes,omitempty"`
+}
+
+type CreateCashAdvanceRepaymentRequest struct {
+	CashAdvanceId int         `json:"cashAdvanceId" validate:"required,gt=0"`
+	Date          string      `json:"date" validate:"required,datetime=2006-01-02"`
+	Amount        money.Money `json:"amount" validate:"required,gt=0"`
+	Notes         *string     `json:"notes,omitempty"`
+}
+
+type CashAdvanceStatementRequest struct {
+	EmployeeId int `json:"employeeId" validate:"required,gt=0"`
+}
+
+type CashAdvanceResponse struct {
+	ID          int               `json:"id"`
+	EmployeeId  int               `json:"employeeId"`
+	Date        time.Time         `json:"date"`
+	Amount      money.Money       `json:"amount"`
+	Installment money.Money       `json:"installment"`
+	Repaid      money.Money       `json:"repaid"`
+	Outstanding money.Money       `json:"outstanding"`
+	Notes       *string           `json:"notes"`
+	Employee    *EmployeeResponse `json:"Employee,omitempty"`
+}
+
+type CashAdvanceRepaymentResponse struct {
+	ID            int         `json:"id"`
+	CashAdvanceId int         `json:"cashAdvanceId"`
+	PayrollId     *int        `json:"payrollId"`
+	Date          time.Time   `json:"date"`
+	Amount        money.Money `json:"amount"`
+	Notes         *string     `json:"notes"`
+}
+
+type CashAdvanceStatementEntry struct {
+	Date          time.Time   `json:"date"`
+	Type          string      `json:"type"`
+	CashAdvanceId int         `json:"cashAdvanceId"`
+	PayrollId     *int        `json:"payrollId"`
+	Notes         *string     `json:"notes"`
+	Debit         money.Money `json:"debit"`
+	Credit        money.Money `json:"credit"`
+	Balance       money.Money `json:"balance"`
+}
+
+type CashAdvanceStatementResponse struct {
+	EmployeeId   int                         `json:"employeeId"`
+	EmployeeName string                      `json:"employeeName"`
+	TotalAdvance money.Money                 `json:"totalAdvance"`
+	TotalRepaid  money.Money                 `json:"totalRepaid"`
+	Outstanding  money.Money                 `json:"outstanding"`
+	Entries      []CashAdvanceStatementEntry `json:"entries"`
+}
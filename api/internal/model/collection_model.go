@@ -0,0 +1,89 @@
+package model
+
+import (
+	"api/internal/entity/money"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateReceivableRequest struct {
+	CustomerId  int         `json:"customerId" validate:"required,gt=0"`
+	Date        string      `json:"date" validate:"required,datetime=2006-01-02"`
+	Amount      money.Money `json:"amount" validate:"required,gt=0"`
+	Description *string     `json:"description,omitempty"`
+}
+
+type ReceivableResponse struct {
+	ID          int         `json:"id"`
+	CustomerId  int         `json:"customerId"`
+	Date        time.Time   `json:"date"`
+	Amount      money.Money `json:"amount"`
+	Description *string     `json:"description"`
+}
+
+type CreateCollectionRequest struct {
+	CustomerId int         `json:"customerId" validate:"required,gt=0"`
+	SalesId    int         `json:"salesId" validate:"required,gt=0"`
+	Date       string      `json:"date" validate:"required,datetime=2006-01-02"`
+	Amount     money.Money `json:"amount" validate:"required,gt=0"`
+	Notes      *string     `json:"notes,omitempty"`
+}
+
+type CollectionResponse struct {
+	ID         int         `json:"id"`
+	CustomerId int         `json:"customerId"`
+	SalesId    int         `json:"salesId"`
+	PeriodId   int         `json:"periodId"`
+	Date       time.Time   `json:"date"`
+	Amount     money.Money `json:"amount"`
+	Notes      *string     `json:"notes"`
+}
+
+type CollectionReportRequest struct {
+	PeriodId int `json:"periodId" validate:"required,gt=0"`
+}
+
+type CollectionReportResponse struct {
+	SalesId          int         `json:"salesId"`
+	SalesName        string      `json:"salesName"`
+	TotalCollected   money.Money `json:"totalCollected"`
+	TransactionCount int64       `json:"transactionCount"`
+}
+
+type CreateCashDepositRequest struct {
+	SalesId    int         `json:"salesId" validate:"required,gt=0"`
+	Date       string      `json:"date" validate:"required,datetime=2006-01-02"`
+	Amount     money.Money `json:"amount" validate:"required,gt=0"`
+	Notes      *string     `json:"notes,omitempty"`
+	ReceivedBy uuid.UUID   `json:"-"`
+}
+
+type CashDepositResponse struct {
+	ID         int         `json:"id"`
+	SalesId    int         `json:"salesId"`
+	Date       time.Time   `json:"date"`
+	Amount     money.Money `json:"amount"`
+	Notes      *string     `json:"notes"`
+	ReceivedBy *uuid.UUID  `json:"receivedBy"`
+}
+
+type DepositReconciliationRequest struct {
+	Date string `json:"date" validate:"required,datetime=2006-01-02"`
+}
+
+type DepositReconciliationResponse struct {
+	SalesId    int         `json:"salesId"`
+	SalesName  string      `json:"salesName"`
+	Expected   money.Money `json:"expected"`
+	HandedOver money.Money `json:"handedOver"`
+	Difference money.Money `json:"difference"`
+}
+
+// SalesAmount is an aggregated amount per salesperson
+type SalesAmount struct {
+	SalesId   int
+	SalesName string
+	Total     money.Money
+	Count     int64
+}
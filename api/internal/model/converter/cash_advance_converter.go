@@ -0,0 +1,38 @@
+package converter
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+)
+
+func ToCashAdvanceResponse(advance *entity.CashAdvance) *model.CashAdvanceResponse {
+	outstanding := advance.Outstanding()
+
+	response := &model.CashAdvanceResponse{
+		ID:          advance.ID,
+		EmployeeId:  advance.EmployeeId,
+		Date:        advance.Date,
+		Amount:      advance.Amount,
+		Installment: advance.Installment,
+		Repaid:      advance.Amount - outstanding,
+		Outstanding: outstanding,
+		Notes:       advance.Notes,
+	}
+
+	if advance.Employee != nil {
+		response.Employee = ToEmployeeResponse(advance.Employee)
+	}
+
+	return response
+}
+
+func ToCashAdvanceRepaymentResponse(repayment *entity.CashAdvanceRepayment) *model.CashAdvanceRepaymentResponse {
+	return &model.CashAdvanceRepaymentResponse{
+		ID:            repayment.ID,
+		CashAdvanceId: repayment.CashAdvanceId,
+		PayrollId:     repayment.PayrollId,
+		Date:          repayment.Date,
+		Amount:        repayment.Amount,
+		Notes:         repayment.Notes,
+	}
+}
@@ -0,0 +1,39 @@
+package converter
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+)
+
+func ToReceivableResponse(receivable *entity.Receivable) *model.ReceivableResponse {
+	return &model.ReceivableResponse{
+		ID:          receivable.ID,
+		CustomerId:  receivable.CustomerId,
+		Date:        receivable.Date,
+		Amount:      receivable.Amount,
+		Description: receivable.Description,
+	}
+}
+
+func ToCollectionResponse(collection *entity.Collection) *model.CollectionResponse {
+	return &model.CollectionResponse{
+		ID:         collection.ID,
+		CustomerId: collection.CustomerId,
+		SalesId:    collection.SalesId,
+		PeriodId:   collection.PeriodId,
+		Date:       collection.Date,
+		Amount:     collection.Amount,
+		Notes:      collection.Notes,
+	}
+}
+
+func ToCashDepositResponse(deposit *entity.CashDeposit) *model.CashDepositResponse {
+	return &model.CashDepositResponse{
+		ID:         deposit.ID,
+		SalesId:    deposit.SalesId,
+		Date:       deposit.Date,
+		Amount:     deposit.Amount,
+		Notes:      deposit.Notes,
+		ReceivedBy: deposit.ReceivedBy,
+	}
+}
@@ -0,0 +1,42 @@
+package converter
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+)
+
+func ToCrewResponse(crew *entity.Crew) *model.CrewResponse {
+	response := &model.CrewResponse{
+		ID:        crew.ID,
+		Name:      crew.Name,
+		IsActive:  crew.IsActive,
+		VehicleId: crew.VehicleId,
+		Members:   make([]model.CrewMemberResponse, len(crew.Members)),
+	}
+
+	if crew.Vehicle != nil {
+		response.Vehicle = ToVehicleResponse(crew.Vehicle)
+	}
+
+	for i, member := range crew.Members {
+		response.Members[i] = *ToCrewMemberResponse(&member)
+	}
+
+	return response
+}
+
+func ToCrewMemberResponse(member *entity.CrewMember) *model.CrewMemberResponse {
+	response := &model.CrewMemberResponse{
+		ID:         member.ID,
+		EmployeeId: member.EmployeeId,
+		StartDate:  member.StartDate,
+		EndDate:    member.EndDate,
+	}
+
+	if member.Employee != nil {
+		response.Name = member.Employee.Name
+		response.Role = string(member.Employee.Role)
+	}
+
+	return response
+}
@@ -0,0 +1,22 @@
+package converter
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+)
+
+func ToCustomerResponse(customer *entity.Customer) *model.CustomerResponse {
+	response := &model.CustomerResponse{
+		ID:      customer.ID,
+		Name:    customer.Name,
+		Phone:   customer.Phone,
+		Address: customer.Address,
+		RouteId: customer.RouteId,
+	}
+
+	if customer.Route != nil {
+		response.Route = ToRouteResponse(customer.Route)
+	}
+
+	return response
+}
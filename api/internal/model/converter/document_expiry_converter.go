@@ -0,0 +1,26 @@
+package converter
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+)
+
+func ToDriverLicenseResponse(license *entity.DriverLicense) *model.DriverLicenseResponse {
+	return &model.DriverLicenseResponse{
+		ID:         license.ID,
+		EmployeeId: license.EmployeeId,
+		Number:     license.Number,
+		Class:      string(license.Class),
+		ExpiryDate: license.ExpiryDate,
+	}
+}
+
+func ToVehicleDocumentResponse(document *entity.VehicleDocument) *model.VehicleDocumentResponse {
+	return &model.VehicleDocumentResponse{
+		ID:         document.ID,
+		VehicleId:  document.VehicleId,
+		Type:       string(document.Type),
+		Number:     document.Number,
+		ExpiryDate: document.ExpiryDate,
+	}
+}
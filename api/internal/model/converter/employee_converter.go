@@ -11,6 +11,28 @@ func ToEmployeeResponse(employee *entity.Employee) *model.EmployeeResponse {
 		Name:         employee.Name,
 		Salary:       employee.Salary,
 		Role:         string(employee.Role),
+		Status:       string(employee.Status),
 		SupervisorId: employee.SupervisorId,
+
+		NIK:                   employee.NIK,
+		BirthDate:             employee.BirthDate,
+		Address:               employee.Address,
+		Phone:                 employee.Phone,
+		EmergencyContactName:  employee.EmergencyContactName,
+		EmergencyContactPhone: employee.EmergencyContactPhone,
+		BankName:              employee.BankName,
+		BankAccountNumber:     employee.BankAccountNumber,
+		BankAccountName:       employee.BankAccountName,
+	}
+}
+
+func ToEmployeeStatusHistoryResponse(history *entity.EmployeeStatusHistory) *model.EmployeeStatusHistoryResponse {
+	return &model.EmployeeStatusHistoryResponse{
+		ID:            history.ID,
+		EmployeeId:    history.EmployeeId,
+		Status:        string(history.Status),
+		EffectiveDate: history.EffectiveDate,
+		Reason:        history.Reason,
+		CreatedAt:     history.CreatedAt,
 	}
 }
@@ -0,0 +1,20 @@
+package converter
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+)
+
+func ToEmployeeDocumentResponse(document *entity.EmployeeDocument) *model.EmployeeDocumentResponse {
+	return &model.EmployeeDocumentResponse{
+		ID:          document.ID,
+		EmployeeId:  document.EmployeeId,
+		Type:        string(document.Type),
+		FileName:    document.FileName,
+		ContentType: document.ContentType,
+		Size:        document.Size,
+		Notes:       document.Notes,
+		UploadedBy:  document.UploadedBy,
+		CreatedAt:   document.CreatedAt,
+	}
+}
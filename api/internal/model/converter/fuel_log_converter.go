@@ -0,0 +1,20 @@
+package converter
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+)
+
+func ToFuelLogResponse(log *entity.FuelLog) *model.FuelLogResponse {
+	return &model.FuelLogResponse{
+		ID:            log.ID,
+		VehicleId:     log.VehicleId,
+		DriverId:      log.DriverId,
+		Date:          log.Date,
+		Liters:        log.Liters,
+		PricePerLiter: log.PricePerLiter,
+		TotalCost:     log.TotalCost,
+		Odometer:      log.Odometer,
+		Notes:         log.Notes,
+	}
+}
@@ -0,0 +1,18 @@
+package converter
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+)
+
+func ToGeofenceResponse(geofence *entity.Geofence) *model.GeofenceResponse {
+	return &model.GeofenceResponse{
+		ID:        geofence.ID,
+		Name:      geofence.Name,
+		Latitude:  geofence.Latitude,
+		Longitude: geofence.Longitude,
+		Radius:    geofence.Radius,
+		IsActive:  geofence.IsActive,
+		FactoryId: geofence.FactoryId,
+	}
+}
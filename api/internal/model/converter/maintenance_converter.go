@@ -0,0 +1,35 @@
+package converter
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+)
+
+func ToMaintenancePlanResponse(plan *entity.MaintenancePlan) *model.MaintenancePlanResponse {
+	return &model.MaintenancePlanResponse{
+		ID:            plan.ID,
+		VehicleId:     plan.VehicleId,
+		ServiceType:   plan.ServiceType,
+		IntervalKm:    plan.IntervalKm,
+		IntervalDays:  plan.IntervalDays,
+		StartDate:     plan.StartDate,
+		StartOdometer: plan.StartOdometer,
+		IsActive:      plan.IsActive,
+	}
+}
+
+func ToServiceRecordResponse(record *entity.ServiceRecord) *model.ServiceRecordResponse {
+	return &model.ServiceRecordResponse{
+		ID:                record.ID,
+		VehicleId:         record.VehicleId,
+		MaintenancePlanId: record.MaintenancePlanId,
+		VehicleHistoryId:  record.VehicleHistoryId,
+		Date:              record.Date,
+		Odometer:          record.Odometer,
+		ServiceType:       record.ServiceType,
+		Parts:             record.Parts,
+		Workshop:          record.Workshop,
+		Cost:              record.Cost,
+		Notes:             record.Notes,
+	}
+}
@@ -0,0 +1,54 @@
+package converter
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+)
+
+func ToPayRuleResponse(rule *entity.PayRule) *model.PayRuleResponse {
+	return &model.PayRuleResponse{
+		ID:        rule.ID,
+		Role:      rule.Role,
+		DailyRate: rule.DailyRate,
+		TripRate:  rule.TripRate,
+		SackRate:  rule.SackRate,
+	}
+}
+
+func ToPayrollResponse(payroll *entity.Payroll) *model.PayrollResponse {
+	response := &model.PayrollResponse{
+		ID:             payroll.ID,
+		EmployeeId:     payroll.EmployeeId,
+		PeriodId:       payroll.PeriodID,
+		ModuleType:     payroll.ModuleType,
+		BaseSalary:     payroll.BaseSalary,
+		AttendanceDays: payroll.AttendanceDays,
+		Bonuses:        payroll.Bonuses,
+		Deductions:     payroll.Deductions,
+		NetSalary:      payroll.BaseSalary + payroll.Bonuses - payroll.Deductions,
+		Notes:          payroll.Notes,
+		IsPaid:         payroll.IsPaid,
+		PaidAt:         payroll.PaidAt,
+		PaymentMethod:  payroll.PaymentMethod,
+		PaidBy:         payroll.PaidBy,
+	}
+
+	if payroll.Employee != nil {
+		response.Employee = ToEmployeeResponse(payroll.Employee)
+	}
+
+	if len(payroll.Items) > 0 {
+		response.Items = make([]model.PayrollItemResponse, len(payroll.Items))
+		for i, item := range payroll.Items {
+			response.Items[i] = model.PayrollItemResponse{
+				Type:        item.Type,
+				Description: item.Description,
+				Quantity:    item.Quantity,
+				Rate:        item.Rate,
+				Amount:      item.Amount,
+			}
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,39 @@
+package converter
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/enum"
+	"api/internal/model"
+)
+
+func ToPeriodResponse(period *entity.Period) *model.PeriodResponse {
+	return &model.PeriodResponse{
+		ID:         period.ID,
+		Type:       period.Type,
+		StartDate:  period.StartDate,
+		EndDate:    period.EndDate,
+		WeekNumber: period.WeekNumber,
+		Month:      period.Month,
+		Year:       period.Year,
+		WeekStart:  period.WeekStart.String(),
+		MonthRule:  period.MonthRule,
+		IsActive:   period.IsActive,
+		IsClosed:   period.IsClosed,
+		ClosedAt:   period.ClosedAt,
+	}
+}
+
+func ToPeriodModuleResponse(module enum.ModuleType, closure *entity.PeriodClosure) *model.PeriodModuleResponse {
+	response := &model.PeriodModuleResponse{
+		Module: module,
+	}
+
+	if closure != nil {
+		response.IsClosed = closure.IsClosed
+		response.ClosedAt = closure.ClosedAt
+		response.PrintCount = closure.PrintCount
+		response.Notes = closure.Notes
+	}
+
+	return response
+}
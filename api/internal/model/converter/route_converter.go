@@ -3,13 +3,46 @@ package converter
 import (
 	"api/internal/entity"
 	"api/internal/model"
+	"api/internal/utils"
+	"math"
 )
 
 func ToRouteResponse(route *entity.Route) *model.RouteResponse {
-	return &model.RouteResponse{
+	response := &model.RouteResponse{
 		ID:          route.ID,
 		Name:        route.Name,
 		Description: route.Description,
+		VisitDays:   route.VisitDays,
+	}
+
+	if len(route.Stops) > 0 {
+		var total float64
+		response.Stops = make([]model.RouteStopResponse, len(route.Stops))
+		for i, stop := range route.Stops {
+			response.Stops[i] = *ToRouteStopResponse(&stop)
+			if i > 0 {
+				previous := route.Stops[i-1]
+				distance := utils.HaversineDistance(previous.Latitude, previous.Longitude, stop.Latitude, stop.Longitude) / 1000
+				response.Stops[i].DistanceFromPrevious = roundKm(distance)
+				total += distance
+			}
+		}
+
+		total = roundKm(total)
+		response.Distance = &total
+	}
+
+	return response
+}
+
+func ToRouteStopResponse(stop *entity.RouteStop) *model.RouteStopResponse {
+	return &model.RouteStopResponse{
+		ID:        stop.ID,
+		Sequence:  stop.Sequence,
+		Name:      stop.Name,
+		Address:   stop.Address,
+		Latitude:  stop.Latitude,
+		Longitude: stop.Longitude,
 	}
 }
 
@@ -21,3 +54,7 @@ func ToRouteResponseWithSalesCount(route *entity.Route, salesCount int) *model.R
 		SalesCount:  salesCount,
 	}
 }
+
+func roundKm(distance float64) float64 {
+	return math.Round(distance*100) / 100
+}
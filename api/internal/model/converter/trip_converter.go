@@ -0,0 +1,58 @@
+package converter
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+)
+
+func ToTripResponse(trip *entity.Trip) *model.TripResponse {
+	response := &model.TripResponse{
+		ID:               trip.ID,
+		Date:             trip.Date,
+		SacksLoaded:      trip.SacksLoaded,
+		SacksReturned:    trip.SacksReturned,
+		Income:           trip.Income,
+		Notes:            trip.Notes,
+		VehicleId:        trip.VehicleId,
+		DriverId:         trip.DriverId,
+		VehicleHistoryId: trip.VehicleHistoryId,
+		CrewId:           trip.CrewId,
+	}
+
+	if trip.Vehicle != nil {
+		response.Vehicle = ToVehicleResponse(trip.Vehicle)
+	}
+
+	if trip.Driver != nil {
+		response.Driver = ToEmployeeResponse(trip.Driver)
+	}
+
+	if len(trip.Helpers) > 0 {
+		response.Helpers = make([]model.EmployeeResponse, len(trip.Helpers))
+		for i, helper := range trip.Helpers {
+			response.Helpers[i] = *ToEmployeeResponse(&helper)
+		}
+	}
+
+	if len(trip.Routes) > 0 {
+		response.Routes = make([]model.RouteResponse, len(trip.Routes))
+		var estimated *float64
+		for i, route := range trip.Routes {
+			response.Routes[i] = *ToRouteResponse(&route)
+
+			if distance := response.Routes[i].Distance; distance != nil {
+				if estimated == nil {
+					estimated = new(float64)
+				}
+				*estimated += *distance
+			}
+		}
+
+		if estimated != nil {
+			*estimated = roundKm(*estimated)
+		}
+		response.EstimatedDistance = estimated
+	}
+
+	return response
+}
@@ -7,11 +7,12 @@ import (
 
 func ToUserResponse(user *entity.User) *model.UserResponse {
 	return &model.UserResponse{
-		ID:        user.ID,
-		Name:      user.Name,
-		Username:  user.Username,
-		Phone:     user.Phone,
-		Role:      user.Role,
-		CreatedAt: user.CreatedAt,
+		ID:         user.ID,
+		Name:       user.Name,
+		Username:   user.Username,
+		Phone:      user.Phone,
+		Role:       user.Role,
+		EmployeeId: user.EmployeeId,
+		CreatedAt:  user.CreatedAt,
 	}
 }
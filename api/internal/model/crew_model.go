@@ -0,0 +1,70 @@
+package model
+
+import "time"
+
+type CrewResponse struct {
+	ID        int                  `json:"id"`
+	Name      string               `json:"name"`
+	IsActive  bool                 `json:"isActive"`
+	VehicleId *int64               `json:"vehicleId"`
+	Vehicle   *VehicleResponse     `json:"Vehicle,omitempty"`
+	Members   []CrewMemberResponse `json:"members"`
+}
+
+type CrewMemberResponse struct {
+	ID         int        `json:"id"`
+	EmployeeId int        `json:"employeeId"`
+	Name       string     `json:"name"`
+	Role       string     `json:"role"`
+	StartDate  time.Time  `json:"startDate"`
+	EndDate    *time.Time `json:"endDate"`
+}
+
+type FindAllCrewRequest struct {
+	// members are the ones assigned on this date, defaults to today
+	Date       string `json:"date" validate:"omitempty,datetime=2006-01-02"`
+	OnlyActive bool   `json:"onlyActive"`
+}
+
+type FindByIdCrewRequest struct {
+	ID   int    `json:"id" validate:"required,gt=0"`
+	Date string `json:"date" validate:"omitempty,datetime=2006-01-02"`
+}
+
+type CreateCrewRequest struct {
+	Name      string `json:"name" validate:"required,max=100"`
+	VehicleId *int64 `json:"vehicleId" validate:"omitempty,gt=0"`
+	DriverId  int    `json:"driverId" validate:"required,gt=0"`
+	HelperIds []int  `json:"helperIds" validate:"required,min=1,max=2,unique,dive,gt=0"`
+	StartDate string `json:"startDate" validate:"required,datetime=2006-01-02"`
+}
+
+type UpdateCrewRequest struct {
+	ID        int    `json:"id" validate:"required,gt=0"`
+	Name      string `json:"name" validate:"required,max=100"`
+	VehicleId *int64 `json:"vehicleId" validate:"omitempty,gt=0"`
+	IsActive  *bool  `json:"isActive" validate:"required"`
+}
+
+type DeleteCrewRequest struct {
+	ID int `json:"id" validate:"required,gt=0"`
+}
+
+// AssignCrewMembersRequest replaces the crew members from EffectiveDate onwards
+type AssignCrewMembersRequest struct {
+	ID            int    `json:"id" validate:"required,gt=0"`
+	DriverId      int    `json:"driverId" validate:"required,gt=0"`
+	HelperIds     []int  `json:"helperIds" validate:"required,min=1,max=2,unique,dive,gt=0"`
+	EffectiveDate string `json:"effectiveDate" validate:"required,datetime=2006-01-02"`
+}
+
+type FindCrewMemberHistoryRequest struct {
+	ID int `json:"id" validate:"required,gt=0"`
+}
+
+// MarkCrewAttendanceRequest writes the attendance of every member of the crews
+type MarkCrewAttendanceRequest struct {
+	CrewIds []int  `json:"crewIds" validate:"required,min=1,unique,dive,gt=0"`
+	Date    string `json:"date" validate:"required,datetime=2006-01-02"`
+	Status  string `json:"status" validate:"required,oneof=PRESENT ABSENT LEAVE SICK"`
+}
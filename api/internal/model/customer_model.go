@@ -0,0 +1,48 @@
+package model
+
+import "api/internal/entity/money"
+
+type FindAllCustomerRequest struct {
+	Search  string `json:"search" validate:"omitempty,max=100"`
+	RouteId int    `json:"routeId" validate:"omitempty,gt=0"`
+	Page    int    `json:"page"`
+	PerPage int    `json:"perPage" validate:"max=100"`
+}
+
+type CreateCustomerRequest struct {
+	Name    string  `json:"name" validate:"required,max=100"`
+	Phone   *string `json:"phone,omitempty" validate:"omitempty,numeric,max=20"`
+	Address *string `json:"address,omitempty"`
+	RouteId int     `json:"routeId" validate:"required,gt=0"`
+}
+
+type UpdateCustomerRequest struct {
+	ID      int     `json:"id" validate:"required,gt=0"`
+	Name    string  `json:"name" validate:"required,max=100"`
+	Phone   *string `json:"phone,omitempty" validate:"omitempty,numeric,max=20"`
+	Address *string `json:"address,omitempty"`
+	RouteId int     `json:"routeId" validate:"required,gt=0"`
+}
+
+type DeleteCustomerRequest struct {
+	ID int `json:"id" validate:"required,gt=0"`
+}
+
+type CustomerResponse struct {
+	ID              int            `json:"id"`
+	Name            string         `json:"name"`
+	Phone           *string        `json:"phone"`
+	Address         *string        `json:"address"`
+	RouteId         int            `json:"routeId"`
+	Route           *RouteResponse `json:"Route,omitempty"`
+	TotalReceivable money.Money    `json:"totalReceivable"`
+	TotalCollected  money.Money    `json:"totalCollected"`
+	Outstanding     money.Money    `json:"outstanding"`
+}
+
+// CustomerBalance is the aggregated receivable and collection total of a customer
+type CustomerBalance struct {
+	CustomerId      int
+	TotalReceivable money.Money
+	TotalCollected  money.Money
+}
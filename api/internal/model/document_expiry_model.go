@@ -0,0 +1,69 @@
+package model
+
+import (
+	"api/internal/entity/enum"
+	"time"
+)
+
+type DriverLicenseResponse struct {
+	ID         int       `json:"id"`
+	EmployeeId int       `json:"employeeId"`
+	Number     string    `json:"number"`
+	Class      string    `json:"class"`
+	ExpiryDate time.Time `json:"expiryDate"`
+}
+
+type FindAllDriverLicenseRequest struct {
+	EmployeeId int `json:"employeeId" validate:"required,gt=0"`
+}
+
+type CreateDriverLicenseRequest struct {
+	EmployeeId int               `json:"employeeId" validate:"required,gt=0"`
+	Number     string            `json:"number" validate:"required,max=30"`
+	Class      enum.LicenseClass `json:"class" validate:"required,oneof='A' 'A_UMUM' 'B1' 'B1_UMUM' 'B2' 'B2_UMUM'"`
+	ExpiryDate string            `json:"expiryDate" validate:"required,datetime=2006-01-02"`
+}
+
+type DeleteDriverLicenseRequest struct {
+	EmployeeId int `json:"employeeId" validate:"required,gt=0"`
+	ID         int `json:"id" validate:"required,gt=0"`
+}
+
+type VehicleDocumentResponse struct {
+	ID         int       `json:"id"`
+	VehicleId  int64     `json:"vehicleId"`
+	Type       string    `json:"type"`
+	Number     string    `json:"number"`
+	ExpiryDate time.Time `json:"expiryDate"`
+}
+
+type FindAllVehicleDocumentRequest struct {
+	VehicleId int64 `json:"vehicleId" validate:"required,gt=0"`
+}
+
+type CreateVehicleDocumentRequest struct {
+	VehicleId  int64                    `json:"vehicleId" validate:"required,gt=0"`
+	Type       enum.VehicleDocumentType `json:"type" validate:"required,oneof='STNK' 'KIR' 'INSURANCE'"`
+	Number     string                   `json:"number" validate:"required,max=50"`
+	ExpiryDate string                   `json:"expiryDate" validate:"required,datetime=2006-01-02"`
+}
+
+type DeleteVehicleDocumentRequest struct {
+	VehicleId int64 `json:"vehicleId" validate:"required,gt=0"`
+	ID        int   `json:"id" validate:"required,gt=0"`
+}
+
+type FindExpiringDocumentRequest struct {
+	Days int `json:"days" validate:"min=0,max=365"`
+}
+
+// ExpiringDocumentResponse is the latest SIM or vehicle document expiring within the window
+type ExpiringDocumentResponse struct {
+	Owner      string    `json:"owner"`
+	OwnerId    int64     `json:"ownerId"`
+	OwnerName  string    `json:"ownerName"`
+	Type       string    `json:"type"`
+	Number     string    `json:"number"`
+	ExpiryDate time.Time `json:"expiryDate"`
+	DaysLeft   int       `json:"daysLeft"`
+}
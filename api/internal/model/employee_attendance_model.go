@@ -29,3 +29,27 @@ type FindAllEmployeeAttendanceRequest struct {
 	StartDate string `json:"startDate" validate:"required"`
 	EndDate   string `json:"endDate" validate:"required"`
 }
+
+type CheckInRequest struct {
+	// EmployeeId is taken from the logged in user, never from the body
+	EmployeeId int      `json:"-" validate:"required,gt=0"`
+	Latitude   float64  `json:"latitude" validate:"latitude"`
+	Longitude  float64  `json:"longitude" validate:"longitude"`
+	Accuracy   *float64 `json:"accuracy,omitempty" validate:"omitempty,gte=0"`
+}
+
+type CheckInResponse struct {
+	EmployeeId   int       `json:"employeeId"`
+	Date         time.Time `json:"date"`
+	Status       string    `json:"status"`
+	CheckInAt    time.Time `json:"checkInAt"`
+	GeofenceId   int       `json:"geofenceId"`
+	GeofenceName string    `json:"geofenceName"`
+	Distance     float64   `json:"distance"`
+}
+
+type FindAttendanceByEmployeeRequest struct {
+	EmployeeId int    `json:"employeeId" validate:"required,gt=0"`
+	StartDate  string `json:"startDate" validate:"required,datetime=2006-01-02"`
+	EndDate    string `json:"endDate" validate:"required,datetime=2006-01-02"`
+}
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"api/internal/entity/enum"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EmployeeDocumentResponse struct {
+	ID          int        `json:"id"`
+	EmployeeId  int        `json:"employeeId"`
+	Type        string     `json:"type"`
+	FileName    string     `json:"fileName"`
+	ContentType string     `json:"contentType"`
+	Size        int64      `json:"size"`
+	Notes       *string    `json:"notes"`
+	UploadedBy  *uuid.UUID `json:"uploadedBy"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+type FindAllEmployeeDocumentRequest struct {
+	EmployeeId int `json:"employeeId" validate:"required,gt=0"`
+}
+
+type UploadEmployeeDocumentRequest struct {
+	EmployeeId  int                       `json:"employeeId" validate:"required,gt=0"`
+	Type        enum.EmployeeDocumentType `json:"type" validate:"required,oneof='KTP' 'SIM' 'KK' 'NPWP' 'OTHER'"`
+	Notes       *string                   `json:"notes,omitempty" validate:"omitempty,max=500"`
+	FileName    string                    `json:"fileName" validate:"required,max=255"`
+	ContentType string                    `json:"contentType" validate:"required,oneof='image/jpeg' 'image/png' 'application/pdf'"`
+	Size        int64                     `json:"size" validate:"required,gt=0,max=2097152"`
+	Content     io.Reader                 `json:"-"`
+	UploadedBy  uuid.UUID                 `json:"-"`
+}
+
+type EmployeeDocumentRequest struct {
+	EmployeeId int `json:"employeeId" validate:"required,gt=0"`
+	ID         int `json:"id" validate:"required,gt=0"`
+}
+
+// EmployeeDocumentFile is an opened document ready to be streamed
+type EmployeeDocumentFile struct {
+	FileName    string
+	ContentType string
+	Size        int64
+	Content     io.ReadCloser
+}
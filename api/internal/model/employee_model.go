@@ -1,43 +1,76 @@
 package model
 
-import "api/internal/entity/enum"
+import (
+	"api/internal/entity/enum"
+	"api/internal/entity/money"
+	"time"
+)
 
 type EmployeeResponse struct {
 	ID           int                          `json:"id,omitempty"`
 	Name         string                       `json:"name"`
-	Salary       float64                      `json:"salary,omitempty"`
+	Salary       money.Money                  `json:"salary,omitempty"`
 	SupervisorId *int                         `json:"supervisorId,omitempty"`
 	Role         string                       `json:"role,omitempty"`
+	Status       string                       `json:"status,omitempty"`
 	Sales        *SalesResponse               `json:"Sales,omitempty"`
 	Attendaces   []EmployeeAttendanceResponse `json:"Attendaces,omitempty"`
+
+	NIK                   *string    `json:"nik,omitempty"`
+	BirthDate             *time.Time `json:"birthDate,omitempty"`
+	Address               *string    `json:"address,omitempty"`
+	Phone                 *string    `json:"phone,omitempty"`
+	EmergencyContactName  *string    `json:"emergencyContactName,omitempty"`
+	EmergencyContactPhone *string    `json:"emergencyContactPhone,omitempty"`
+	BankName              *string    `json:"bankName,omitempty"`
+	BankAccountNumber     *string    `json:"bankAccountNumber,omitempty"`
+	BankAccountName       *string    `json:"bankAccountName,omitempty"`
+}
+
+// EmployeeProfileRequest holds the optional identity, contact and bank details
+type EmployeeProfileRequest struct {
+	NIK                   *string `json:"nik" validate:"omitempty,len=16,numeric"`
+	BirthDate             *string `json:"birthDate" validate:"omitempty,datetime=2006-01-02"`
+	Address               *string `json:"address" validate:"omitempty,max=500"`
+	EmergencyContactName  *string `json:"emergencyContactName" validate:"omitempty,max=100"`
+	EmergencyContactPhone *string `json:"emergencyContactPhone" validate:"omitempty,max=20"`
+	BankName              *string `json:"bankName" validate:"required_with=BankAccountNumber,omitempty,max=50"`
+	BankAccountNumber     *string `json:"bankAccountNumber" validate:"required_with=BankName,omitempty,max=30,numeric"`
+	BankAccountName       *string `json:"bankAccountName" validate:"required_with=BankAccountNumber,omitempty,max=100"`
 }
 
 type FindAllEmployeeRequest struct {
-	Page    int     `json:"page" validate:"omitempty,max=100"`
-	PerPage int     `json:"perPage" validate:"omitempty"`
-	Name    string  `json:"name" validate:"omitempty,max=100"`
-	Salary  float64 `json:"salary" validate:"omitempty"`
+	Page    int         `json:"page" validate:"omitempty,max=100"`
+	PerPage int         `json:"perPage" validate:"omitempty"`
+	Name    string      `json:"name" validate:"omitempty,max=100"`
+	Salary  money.Money `json:"salary" validate:"omitempty"`
 	// TODO: Create EmployeeRole validation
-	Roles []enum.EmployeeRole `json:"roles" validate:"omitempty"`
+	Roles    []enum.EmployeeRole   `json:"roles" validate:"omitempty"`
+	Statuses []enum.EmployeeStatus `json:"statuses" validate:"omitempty,dive,oneof='ACTIVE' 'ON_LEAVE' 'RESIGNED' 'TERMINATED'"`
+
+	// keeps only the direct and indirect subordinates of the employee
+	SubordinateOf int `json:"subordinateOf" validate:"omitempty,gt=0"`
 }
 
 type CreateEmployeeRequest struct {
 	Name         string            `json:"name" validate:"required,max=100"`
-	Salary       float64           `json:"salary" validate:"required"`
+	Salary       money.Money       `json:"salary" validate:"required"`
 	Role         enum.EmployeeRole `json:"role" validate:"required,oneof='WAREHOUSE_HEAD' 'SALES' 'DRIVER' 'HELPER' 'TREASURER' 'STAFF'"`
 	SupervisorId int               `json:"supervisorId" validate:"required_if=Role HELPER,required_if=Role DRIVER"`
-	Phone        string            `json:"phone" validate:"required_if=Role SALES"`
+	Phone        string            `json:"phone" validate:"required_if=Role SALES,max=20"`
 	RouteIDs     []int             `json:"routeIds" validate:"required_if=Role SALES"`
+	EmployeeProfileRequest
 }
 
 type UpdateEmployeeRequest struct {
 	ID           int               `json:"id" validate:"required,gt=0"`
 	Name         string            `json:"name" validate:"required,max=100"`
-	Salary       float64           `json:"salary" validate:"required"`
+	Salary       money.Money       `json:"salary" validate:"required"`
 	Role         enum.EmployeeRole `json:"role" validate:"required,oneof='WAREHOUSE_HEAD' 'SALES' 'DRIVER' 'HELPER' 'TREASURER' 'STAFF'"`
 	SupervisorId int               `json:"supervisorId" validate:"required_if=Role HELPER,required_if=Role DRIVER"`
-	Phone        string            `json:"phone" validate:"required_if=Role SALES"`
+	Phone        string            `json:"phone" validate:"required_if=Role SALES,max=20"`
 	RouteIDs     *[]int            `json:"routeIds" validate:"required_if=Role SALES"`
+	EmployeeProfileRequest
 }
 
 type DeleteEmployeeRequest struct {
@@ -51,4 +84,40 @@ type FindByIdEmployeeRequest struct {
 type FindAllEmployeeWithAttendanceRequest struct {
 	StartDate string `json:"startDate" validate:"required"`
 	EndDate   string `json:"endDate" validate:"required"`
+
+	SubordinateOf int `json:"subordinateOf" validate:"omitempty,gt=0"`
+}
+
+type FindEmployeeTreeRequest struct {
+	RootId int `json:"rootId" validate:"omitempty,gt=0"`
+}
+
+// EmployeeTreeResponse is an employee with the whole hierarchy below it
+type EmployeeTreeResponse struct {
+	ID           int                    `json:"id"`
+	Name         string                 `json:"name"`
+	Role         string                 `json:"role"`
+	Status       string                 `json:"status"`
+	SupervisorId *int                   `json:"supervisorId"`
+	Subordinates []EmployeeTreeResponse `json:"subordinates"`
+}
+
+type ChangeEmployeeStatusRequest struct {
+	ID            int                 `json:"id" validate:"required,gt=0"`
+	Status        enum.EmployeeStatus `json:"status" validate:"required,oneof='ACTIVE' 'ON_LEAVE' 'RESIGNED' 'TERMINATED'"`
+	EffectiveDate string              `json:"effectiveDate" validate:"required,datetime=2006-01-02"`
+	Reason        *string             `json:"reason,omitempty" validate:"required_if=Status RESIGNED,required_if=Status TERMINATED,omitempty,max=500"`
+}
+
+type FindEmployeeStatusHistoryRequest struct {
+	ID int `json:"id" validate:"required,gt=0"`
+}
+
+type EmployeeStatusHistoryResponse struct {
+	ID            int       `json:"id"`
+	EmployeeId    int       `json:"employeeId"`
+	Status        string    `json:"status"`
+	EffectiveDate time.Time `json:"effectiveDate"`
+	Reason        *string   `json:"reason"`
+	CreatedAt     time.Time `json:"createdAt"`
 }
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"api/internal/entity/money"
+	"time"
+)
+
+type FuelLogResponse struct {
+	ID            int         `json:"id"`
+	VehicleId     int64       `json:"vehicleId"`
+	DriverId      *int        `json:"driverId"`
+	Date          time.Time   `json:"date"`
+	Liters        float64     `json:"liters"`
+	PricePerLiter money.Money `json:"pricePerLiter"`
+	TotalCost     money.Money `json:"totalCost"`
+	Odometer      int         `json:"odometer"`
+	Distance      *int        `json:"distance"`
+	KmPerLiter    *float64    `json:"kmPerLiter"`
+	IsFlagged     bool        `json:"isFlagged"`
+	Notes         *string     `json:"notes"`
+}
+
+type FindAllFuelLogRequest struct {
+	VehicleId   int64  `json:"vehicleId" validate:"required,gt=0"`
+	StartDate   string `json:"startDate" validate:"omitempty,datetime=2006-01-02"`
+	EndDate     string `json:"endDate" validate:"omitempty,datetime=2006-01-02"`
+	OnlyFlagged bool   `json:"onlyFlagged"`
+}
+
+type CreateFuelLogRequest struct {
+	VehicleId     int64       `json:"vehicleId" validate:"required,gt=0"`
+	DriverId      *int        `json:"driverId" validate:"omitempty,gt=0"`
+	Date          string      `json:"date" validate:"required,datetime=2006-01-02"`
+	Liters        float64     `json:"liters" validate:"required,gt=0"`
+	PricePerLiter money.Money `json:"pricePerLiter" validate:"required,gt=0"`
+	Odometer      int         `json:"odometer" validate:"required,gt=0"`
+	Notes         *string     `json:"notes,omitempty"`
+}
+
+type DeleteFuelLogRequest struct {
+	VehicleId int64 `json:"vehicleId" validate:"required,gt=0"`
+	ID        int   `json:"id" validate:"required,gt=0"`
+}
+
+type FuelConsumptionRequest struct {
+	StartDate string `json:"startDate" validate:"required,datetime=2006-01-02"`
+	EndDate   string `json:"endDate" validate:"required,datetime=2006-01-02"`
+	GroupBy   string `json:"groupBy" validate:"required,oneof=vehicle driver"`
+}
+
+// FuelConsumptionResponse is the consumption of a vehicle or a driver over the range
+type FuelConsumptionResponse struct {
+	ID           int64       `json:"id"`
+	Name         string      `json:"name"`
+	FillCount    int         `json:"fillCount"`
+	FlaggedCount int         `json:"flaggedCount"`
+	Distance     int         `json:"distance"`
+	Liters       float64     `json:"liters"`
+	TotalCost    money.Money `json:"totalCost"`
+	KmPerLiter   float64     `json:"kmPerLiter"`
+}
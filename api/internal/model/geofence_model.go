@@ -0,0 +1,40 @@
+package model
+
+type FindAllGeofenceRequest struct {
+	Search  string `json:"search" validate:"omitempty,max=100"`
+	Page    int    `json:"page"`
+	PerPage int    `json:"perPage" validate:"max=100"`
+}
+
+type CreateGeofenceRequest struct {
+	Name      string  `json:"name" validate:"required,max=100"`
+	Latitude  float64 `json:"latitude" validate:"latitude"`
+	Longitude float64 `json:"longitude" validate:"longitude"`
+	Radius    int     `json:"radius" validate:"required,gt=0,lte=5000"`
+	IsActive  *bool   `json:"isActive,omitempty"`
+	FactoryId *int64  `json:"factoryId,omitempty" validate:"omitempty,gt=0"`
+}
+
+type UpdateGeofenceRequest struct {
+	ID        int     `json:"id" validate:"required,gt=0"`
+	Name      string  `json:"name" validate:"required,max=100"`
+	Latitude  float64 `json:"latitude" validate:"latitude"`
+	Longitude float64 `json:"longitude" validate:"longitude"`
+	Radius    int     `json:"radius" validate:"required,gt=0,lte=5000"`
+	IsActive  *bool   `json:"isActive,omitempty"`
+	FactoryId *int64  `json:"factoryId,omitempty" validate:"omitempty,gt=0"`
+}
+
+type DeleteGeofenceRequest struct {
+	ID int `json:"id" validate:"required,gt=0"`
+}
+
+type GeofenceResponse struct {
+	ID        int     `json:"id"`
+	Name      string  `json:"name"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Radius    int     `json:"radius"`
+	IsActive  bool    `json:"isActive"`
+	FactoryId *int64  `json:"factoryId"`
+}
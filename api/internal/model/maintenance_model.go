@@ -0,0 +1,107 @@
+package model
+
+import (
+	"api/internal/entity/enum"
+	"api/internal/entity/money"
+	"time"
+)
+
+type MaintenancePlanResponse struct {
+	ID            int       `json:"id"`
+	VehicleId     int64     `json:"vehicleId"`
+	ServiceType   string    `json:"serviceType"`
+	IntervalKm    *int      `json:"intervalKm"`
+	IntervalDays  *int      `json:"intervalDays"`
+	StartDate     time.Time `json:"startDate"`
+	StartOdometer int       `json:"startOdometer"`
+	IsActive      bool      `json:"isActive"`
+}
+
+type FindAllMaintenancePlanRequest struct {
+	VehicleId int64 `json:"vehicleId" validate:"required,gt=0"`
+}
+
+type CreateMaintenancePlanRequest struct {
+	VehicleId     int64  `json:"vehicleId" validate:"required,gt=0"`
+	ServiceType   string `json:"serviceType" validate:"required,max=100"`
+	IntervalKm    *int   `json:"intervalKm" validate:"required_without=IntervalDays,omitempty,gt=0"`
+	IntervalDays  *int   `json:"intervalDays" validate:"required_without=IntervalKm,omitempty,gt=0"`
+	StartDate     string `json:"startDate" validate:"required,datetime=2006-01-02"`
+	StartOdometer int    `json:"startOdometer" validate:"gte=0"`
+}
+
+type UpdateMaintenancePlanRequest struct {
+	VehicleId     int64  `json:"vehicleId" validate:"required,gt=0"`
+	ID            int    `json:"id" validate:"required,gt=0"`
+	ServiceType   string `json:"serviceType" validate:"required,max=100"`
+	IntervalKm    *int   `json:"intervalKm" validate:"required_without=IntervalDays,omitempty,gt=0"`
+	IntervalDays  *int   `json:"intervalDays" validate:"required_without=IntervalKm,omitempty,gt=0"`
+	StartDate     string `json:"startDate" validate:"required,datetime=2006-01-02"`
+	StartOdometer int    `json:"startOdometer" validate:"gte=0"`
+	IsActive      bool   `json:"isActive"`
+}
+
+type DeleteMaintenancePlanRequest struct {
+	VehicleId int64 `json:"vehicleId" validate:"required,gt=0"`
+	ID        int   `json:"id" validate:"required,gt=0"`
+}
+
+type ServiceRecordResponse struct {
+	ID                int         `json:"id"`
+	VehicleId         int64       `json:"vehicleId"`
+	MaintenancePlanId *int        `json:"maintenancePlanId"`
+	VehicleHistoryId  *int64      `json:"vehicleHistoryId"`
+	Date              time.Time   `json:"date"`
+	Odometer          int         `json:"odometer"`
+	ServiceType       string      `json:"serviceType"`
+	Parts             *string     `json:"parts"`
+	Workshop          *string     `json:"workshop"`
+	Cost              money.Money `json:"cost"`
+	Notes             *string     `json:"notes"`
+}
+
+type FindAllServiceRecordRequest struct {
+	VehicleId int64 `json:"vehicleId" validate:"required,gt=0"`
+	Page      int   `json:"page"`
+	PerPage   int   `json:"perPage" validate:"max=100"`
+}
+
+type CreateServiceRecordRequest struct {
+	VehicleId         int64       `json:"vehicleId" validate:"required,gt=0"`
+	MaintenancePlanId *int        `json:"maintenancePlanId" validate:"omitempty,gt=0"`
+	Date              string      `json:"date" validate:"required,datetime=2006-01-02"`
+	Odometer          int         `json:"odometer" validate:"gte=0"`
+	ServiceType       string      `json:"serviceType" validate:"required_without=MaintenancePlanId,max=100"`
+	Parts             *string     `json:"parts,omitempty"`
+	Workshop          *string     `json:"workshop,omitempty" validate:"omitempty,max=100"`
+	Cost              money.Money `json:"cost" validate:"required,gt=0"`
+	Notes             *string     `json:"notes,omitempty"`
+}
+
+type DeleteServiceRecordRequest struct {
+	VehicleId int64 `json:"vehicleId" validate:"required,gt=0"`
+	ID        int   `json:"id" validate:"required,gt=0"`
+}
+
+type FindMaintenanceDueRequest struct {
+	VehicleId int64                  `json:"vehicleId" validate:"omitempty,gt=0"`
+	Status    enum.MaintenanceStatus `json:"status" validate:"omitempty,oneof='OK' 'DUE_SOON' 'OVERDUE'"`
+}
+
+type MaintenanceDueResponse struct {
+	PlanId              int        `json:"planId"`
+	VehicleId           int64      `json:"vehicleId"`
+	Plate               string     `json:"plate"`
+	ServiceType         string     `json:"serviceType"`
+	LastServiceDate     *time.Time `json:"lastServiceDate"`
+	LastServiceOdometer *int       `json:"lastServiceOdometer"`
+	CurrentOdometer     int        `json:"currentOdometer"`
+	NextDueDate         *time.Time `json:"nextDueDate"`
+	NextDueOdometer     *int       `json:"nextDueOdometer"`
+	Status              string     `json:"status"`
+}
+
+type VehicleOdometer struct {
+	VehicleId int64
+	Odometer  int
+}
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"api/internal/entity/enum"
+	"api/internal/entity/money"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PayRuleResponse struct {
+	ID        int               `json:"id"`
+	Role      enum.EmployeeRole `json:"role"`
+	DailyRate *money.Money      `json:"dailyRate"`
+	TripRate  money.Money       `json:"tripRate"`
+	SackRate  money.Money       `json:"sackRate"`
+}
+
+type UpsertPayRuleRequest struct {
+	Role      enum.EmployeeRole `json:"role" validate:"required,oneof='WAREHOUSE_HEAD' 'SALES' 'DRIVER' 'HELPER' 'TREASURER' 'STAFF'"`
+	DailyRate *money.Money      `json:"dailyRate,omitempty" validate:"omitempty,gte=0"`
+	TripRate  money.Money       `json:"tripRate" validate:"gte=0"`
+	SackRate  money.Money       `json:"sackRate" validate:"gte=0"`
+}
+
+type GeneratePayrollRequest struct {
+	PeriodId int `json:"periodId" validate:"required,gt=0"`
+}
+
+type FindAllPayrollRequest struct {
+	PeriodId int `json:"periodId" validate:"required,gt=0"`
+}
+
+type FindPayrollByEmployeeRequest struct {
+	EmployeeId int `json:"employeeId" validate:"required,gt=0"`
+	PeriodId   int `json:"periodId" validate:"omitempty,gt=0"`
+}
+
+type PayPayrollRequest struct {
+	ID            int                `json:"id" validate:"required,gt=0"`
+	PaymentMethod enum.PaymentMethod `json:"paymentMethod" validate:"required,oneof='CASH' 'TRANSFER'"`
+	PaidBy        uuid.UUID          `json:"-"`
+}
+
+type PayPayrollsByPeriodRequest struct {
+	PeriodId      int                `json:"periodId" validate:"required,gt=0"`
+	PaymentMethod enum.PaymentMethod `json:"paymentMethod" validate:"required,oneof='CASH' 'TRANSFER'"`
+	PaidBy        uuid.UUID          `json:"-"`
+}
+
+type PayslipRequest struct {
+	PeriodId   int `json:"periodId" validate:"required,gt=0"`
+	EmployeeId int `json:"employeeId" validate:"required,gt=0"`
+}
+
+type PayrollItemResponse struct {
+	Type        enum.PayrollItemType `json:"type"`
+	Description string               `json:"description"`
+	Quantity    float64              `json:"quantity"`
+	Rate        money.Money          `json:"rate"`
+	Amount      money.Money          `json:"amount"`
+}
+
+type PayrollResponse struct {
+	ID             int                   `json:"id"`
+	EmployeeId     int                   `json:"employeeId"`
+	PeriodId       int                   `json:"periodId"`
+	ModuleType     enum.PayrollModule    `json:"moduleType"`
+	BaseSalary     money.Money           `json:"baseSalary"`
+	AttendanceDays int                   `json:"attendanceDays"`
+	Bonuses        money.Money           `json:"bonuses"`
+	Deductions     money.Money           `json:"deductions"`
+	NetSalary      money.Money           `json:"netSalary"`
+	Notes          string                `json:"notes"`
+	IsPaid         bool                  `json:"isPaid"`
+	PaidAt         *time.Time            `json:"paidAt"`
+	PaymentMethod  *enum.PaymentMethod   `json:"paymentMethod"`
+	PaidBy         *uuid.UUID            `json:"paidBy"`
+	Employee       *EmployeeResponse     `json:"Employee,omitempty"`
+	Items          []PayrollItemResponse `json:"Items,omitempty"`
+}
+
+// EmployeeCount is an aggregated count per employee
+type EmployeeCount struct {
+	EmployeeId int
+	Total      int64
+}
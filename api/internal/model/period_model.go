@@ -1,6 +1,9 @@
 package model
 
-import "time"
+import (
+	"api/internal/entity/enum"
+	"time"
+)
 
 type WeekInfo struct {
 	Month      int
@@ -9,3 +12,75 @@ type WeekInfo struct {
 	StartDate  time.Time
 	EndDate    time.Time
 }
+
+type PeriodResponse struct {
+	ID         int                  `json:"id"`
+	Type       enum.PeriodType      `json:"type"`
+	StartDate  time.Time            `json:"startDate"`
+	EndDate    time.Time            `json:"endDate"`
+	WeekNumber int                  `json:"weekNumber"`
+	Month      int                  `json:"month"`
+	Year       int                  `json:"year"`
+	WeekStart  string               `json:"weekStart"`
+	MonthRule  enum.PeriodMonthRule `json:"monthRule"`
+	IsActive   bool                 `json:"isActive"`
+	IsClosed   bool                 `json:"isClosed"`
+	ClosedAt   *time.Time           `json:"closedAt"`
+}
+
+// PeriodDetailResponse shows what has been recorded in the period
+type PeriodDetailResponse struct {
+	Period      PeriodResponse          `json:"period"`
+	Attendance  PeriodAttendanceSummary `json:"attendance"`
+	Payroll     PeriodPayrollSummary    `json:"payroll"`
+	Collections int64                   `json:"collections"`
+	Modules     []PeriodModuleResponse  `json:"modules"`
+}
+
+type PeriodAttendanceSummary struct {
+	Employees int64 `json:"employees"`
+	Present   int64 `json:"present"`
+	Leave     int64 `json:"leave"`
+	Sick      int64 `json:"sick"`
+	Absent    int64 `json:"absent"`
+}
+
+type PeriodPayrollSummary struct {
+	Total  int64 `json:"total"`
+	Paid   int64 `json:"paid"`
+	Unpaid int64 `json:"unpaid"`
+}
+
+// PeriodModuleResponse is the closure state of one module, a module never
+// closed has no closure row and is reported open
+type PeriodModuleResponse struct {
+	Module     enum.ModuleType `json:"module"`
+	IsClosed   bool            `json:"isClosed"`
+	ClosedAt   *time.Time      `json:"closedAt"`
+	PrintCount int             `json:"printCount"`
+	Notes      string          `json:"notes"`
+}
+
+type FindAllPeriodRequest struct {
+	Year            int    `json:"year" validate:"omitempty,min=2000,max=2100"`
+	Month           int    `json:"month" validate:"omitempty,min=1,max=12"`
+	Type            string `json:"type" validate:"omitempty,oneof=WEEKLY BIWEEKLY MONTHLY"`
+	Status          string `json:"status" validate:"omitempty,oneof=open closed"`
+	IncludeInactive bool   `json:"includeInactive"`
+	Page            int    `json:"page"`
+	PerPage         int    `json:"perPage" validate:"max=100"`
+}
+
+type FindByIdPeriodRequest struct {
+	ID int `json:"id" validate:"required,gt=0"`
+}
+
+// GeneratePeriodRequest creates the periods of the coming weeks ahead of any attendance
+type GeneratePeriodRequest struct {
+	StartDate string `json:"startDate" validate:"omitempty,datetime=2006-01-02"`
+	Count     int    `json:"count" validate:"required,min=1,max=26"`
+}
+
+type DeactivatePeriodRequest struct {
+	ID int `json:"id" validate:"required,gt=0"`
+}
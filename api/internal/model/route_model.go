@@ -4,17 +4,52 @@ type RouteResponse struct {
 	ID          int    `json:"id"`
 	Name        string `json:"name"`
 	Description string `json:"description,omitempty"`
+
+	VisitDays []int               `json:"visitDays,omitempty"`
+	Stops     []RouteStopResponse `json:"stops,omitempty"`
+	// total distance in km along the stops
+	Distance *float64 `json:"distance,omitempty"`
+}
+
+type RouteStopResponse struct {
+	ID        int     `json:"id"`
+	Sequence  int     `json:"sequence"`
+	Name      string  `json:"name"`
+	Address   *string `json:"address"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	// distance in km from the previous stop
+	DistanceFromPrevious float64 `json:"distanceFromPrevious"`
+}
+
+// RouteStopRequest is a stop of the route, stops are visited in the order they are sent
+type RouteStopRequest struct {
+	Name      string  `json:"name" validate:"required,max=100"`
+	Address   *string `json:"address" validate:"omitempty,max=500"`
+	Latitude  float64 `json:"latitude" validate:"latitude"`
+	Longitude float64 `json:"longitude" validate:"longitude"`
 }
 
 type CreateRouteRequest struct {
 	Name        string `json:"name" validate:"required,max=100"`
 	Description string `json:"description,omitempty"`
+
+	VisitDays []int              `json:"visitDays" validate:"omitempty,unique,dive,min=0,max=6"`
+	Stops     []RouteStopRequest `json:"stops" validate:"omitempty,dive"`
 }
 
 type UpdateRouteRequest struct {
 	ID          int    `json:"id" validate:"required,gt=0"`
 	Name        string `json:"name" validate:"omitempty,max=100"`
 	Description string `json:"description,omitempty"`
+
+	// omitted fields keep their current value, stops are replaced as a whole
+	VisitDays *[]int              `json:"visitDays" validate:"omitempty,unique,dive,min=0,max=6"`
+	Stops     *[]RouteStopRequest `json:"stops" validate:"omitempty,dive"`
+}
+
+type FindByIdRouteRequest struct {
+	ID int `json:"id" validate:"required,gt=0"`
 }
 
 type DeleteRouteRequest struct {
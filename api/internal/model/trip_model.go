@@ -0,0 +1,57 @@
+package model
+
+import (
+	"api/internal/entity/money"
+	"time"
+)
+
+type FindAllTripRequest struct {
+	StartDate string `json:"startDate" validate:"omitempty,datetime=2006-01-02"`
+	EndDate   string `json:"endDate" validate:"omitempty,datetime=2006-01-02"`
+	VehicleId int64  `json:"vehicleId" validate:"omitempty,gt=0"`
+	DriverId  int    `json:"driverId" validate:"omitempty,gt=0"`
+	Page      int    `json:"page"`
+	PerPage   int    `json:"perPage" validate:"max=100"`
+}
+
+type CreateTripRequest struct {
+	Date           string      `json:"date" validate:"required,datetime=2006-01-02"`
+	CrewId         int         `json:"crewId" validate:"omitempty,gt=0"`
+	VehicleId      int64       `json:"vehicleId" validate:"required_without=CrewId,omitempty,gt=0"`
+	DriverId       int         `json:"driverId" validate:"required_without=CrewId,omitempty,gt=0"`
+	HelperIds      []int       `json:"helperIds" validate:"omitempty,dive,gt=0"`
+	RouteIds       []int       `json:"routeIds" validate:"required,min=1,dive,gt=0"`
+	SacksLoaded    int         `json:"sacksLoaded" validate:"gte=0"`
+	SacksReturned  int         `json:"sacksReturned" validate:"gte=0,ltefield=SacksLoaded"`
+	Income         money.Money `json:"income" validate:"gte=0"`
+	Notes          *string     `json:"notes,omitempty"`
+	MarkAttendance bool        `json:"markAttendance"`
+}
+
+type FindByIdTripRequest struct {
+	ID int `json:"id" validate:"required,gt=0"`
+}
+
+type DeleteTripRequest struct {
+	ID int `json:"id" validate:"required,gt=0"`
+}
+
+type TripResponse struct {
+	ID               int                `json:"id"`
+	Date             time.Time          `json:"date"`
+	SacksLoaded      int                `json:"sacksLoaded"`
+	SacksReturned    int                `json:"sacksReturned"`
+	Income           money.Money        `json:"income"`
+	Notes            *string            `json:"notes"`
+	VehicleId        int64              `json:"vehicleId"`
+	DriverId         int                `json:"driverId"`
+	VehicleHistoryId *int64             `json:"vehicleHistoryId"`
+	CrewId           *int               `json:"crewId"`
+	Vehicle          *VehicleResponse   `json:"Vehicle,omitempty"`
+	Driver           *EmployeeResponse  `json:"Driver,omitempty"`
+	Helpers          []EmployeeResponse `json:"Helpers,omitempty"`
+	Routes           []RouteResponse    `json:"Routes,omitempty"`
+
+	// sum of the route distances in km, when the routes have stops
+	EstimatedDistance *float64 `json:"estimatedDistance,omitempty"`
+}
@@ -20,6 +20,8 @@ type RegisterUserRequest struct {
 	Password string        `json:"password" validate:"required,min=6"`
 	Phone    string        `json:"phone" validate:"required,numeric,max=15"`
 	Role     enum.UserRole `json:"role" validate:"required,userrole"`
+	// EmployeeId links the account to payroll staff, required for EMPLOYEE role
+	EmployeeId *int `json:"employeeId,omitempty" validate:"omitempty,gt=0"`
 }
 
 type UpdateUserRequest struct {
@@ -28,6 +30,8 @@ type UpdateUserRequest struct {
 	Username string        `json:"username" validate:"required"`
 	Phone    string        `json:"phone" validate:"required,numeric,max=15"`
 	Role     enum.UserRole `json:"role" validate:"required,userrole"`
+	// EmployeeId links the account to payroll staff, required for EMPLOYEE role
+	EmployeeId *int `json:"employeeId,omitempty" validate:"omitempty,gt=0"`
 }
 
 type DeleteUserRequest struct {
@@ -44,10 +48,11 @@ type VerifyUserRequest struct {
 }
 
 type UserResponse struct {
-	ID        uuid.UUID     `json:"id,omitempty"`
-	Name      string        `json:"name"`
-	Username  string        `json:"username"`
-	Phone     string        `json:"phone"`
-	Role      enum.UserRole `json:"role"`
-	CreatedAt time.Time     `json:"createdAt"`
+	ID         uuid.UUID     `json:"id,omitempty"`
+	Name       string        `json:"name"`
+	Username   string        `json:"username"`
+	Phone      string        `json:"phone"`
+	Role       enum.UserRole `json:"role"`
+	EmployeeId *int          `json:"employeeId"`
+	CreatedAt  time.Time     `json:"createdAt"`
 }
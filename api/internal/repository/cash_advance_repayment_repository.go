@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"api/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type CashAdvanceRepaymentRepository interface {
+	Create(db *gorm.DB, repayment *entity.CashAdvanceRepayment) error
+	DeleteByPayrollId(db *gorm.DB, payrollId int) error
+}
+
+type cashAdvanceRepaymentRepositoryImpl struct {
+	Log *logrus.Logger
+}
+
+func NewCashAdvanceRepaymentRepository(log *logrus.Logger) CashAdvanceRepaymentRepository {
+	return &cashAdvanceRepaymentRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *cashAdvanceRepaymentRepositoryImpl) Create(db *gorm.DB, repayment *entity.CashAdvanceRepayment) error {
+	return db.Create(repayment).Error
+}
+
+func (r *cashAdvanceRepaymentRepositoryImpl) DeleteByPayrollId(db *gorm.DB, payrollId int) error {
+	return db.Where("payroll_id = ?", payrollId).Delete(&entity.CashAdvanceRepayment{}).Error
+}
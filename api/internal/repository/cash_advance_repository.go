@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type CashAdvanceRepository interface {
+	FindAll(db *gorm.DB, request *model.FindAllCashAdvanceRequest) ([]entity.CashAdvance, int64, error)
+	Create(db *gorm.DB, advance *entity.CashAdvance) error
+	FindById(db *gorm.DB, id int) (*entity.CashAdvance, error)
+	FindByEmployeeId(db *gorm.DB, employeeId int) ([]entity.CashAdvance, error)
+	FindOutstanding(db *gorm.DB, date time.Time) ([]entity.CashAdvance, error)
+}
+
+type cashAdvanceRepositoryImpl struct {
+	Log *logrus.Logger
+}
+
+func NewCashAdvanceRepository(log *logrus.Logger) CashAdvanceRepository {
+	return &cashAdvanceRepositoryImpl{
+		Log: log,
+	}
+}
+
+const outstandingCashAdvance = `cash_advances.amount > COALESCE((SELECT SUM(amount) FROM cash_advance_repayments WHERE cash_advance_repayments.cash_advance_id = cash_advances.id), 0)`
+
+func (r *cashAdvanceRepositoryImpl) Create(db *gorm.DB, advance *entity.CashAdvance) error {
+	return db.Create(advance).Error
+}
+
+func (r *cashAdvanceRepositoryImpl) FindById(db *gorm.DB, id int) (*entity.CashAdvance, error) {
+	var advance entity.CashAdvance
+
+	err := db.Preload("Repayments").First(&advance, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &advance, nil
+}
+
+func (r *cashAdvanceRepositoryImpl) FindAll(db *gorm.DB, request *model.FindAllCashAdvanceRequest) ([]entity.CashAdvance, int64, error) {
+	var advances []entity.CashAdvance
+	var total int64
+
+	countQuery := db.Model(new(entity.CashAdvance)).Scopes(r.FilterCashAdvance(request))
+	if err := countQuery.Count(&total).Error; err != nil {
+		r.Log.WithError(err).Error("failed to count cash advances")
+		return nil, 0, err
+	}
+
+	query := db.Model(new(entity.CashAdvance)).
+		Scopes(r.FilterCashAdvance(request)).
+		Preload("Employee").
+		Preload("Repayments").
+		Order("date DESC, id DESC")
+
+	if request.Page > 0 && request.PerPage > 0 {
+		offset := (request.Page - 1) * request.PerPage
+		query = query.Offset(offset).Limit(request.PerPage)
+	}
+
+	if err := query.Find(&advances).Error; err != nil {
+		r.Log.WithError(err).Error("failed to find cash advances")
+		return nil, 0, err
+	}
+
+	return advances, total, nil
+}
+
+func (r *cashAdvanceRepositoryImpl) FilterCashAdvance(request *model.FindAllCashAdvanceRequest) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if request.EmployeeId > 0 {
+			tx = tx.Where("employee_id = ?", request.EmployeeId)
+		}
+
+		if request.OnlyOutstanding {
+			tx = tx.Where(outstandingCashAdvance)
+		}
+
+		return tx
+	}
+}
+
+func (r *cashAdvanceRepositoryImpl) FindByEmployeeId(db *gorm.DB, employeeId int) ([]entity.CashAdvance, error) {
+	var advances []entity.CashAdvance
+
+	err := db.Preload("Repayments").
+		Where("employee_id = ?", employeeId).
+		Order("date ASC, id ASC").
+		Find(&advances).Error
+	if err != nil {
+		r.Log.WithError(err).Error("failed to find cash advances")
+		return nil, err
+	}
+
+	return advances, nil
+}
+
+func (r *cashAdvanceRepositoryImpl) FindOutstanding(db *gorm.DB, date time.Time) ([]entity.CashAdvance, error) {
+	var advances []entity.CashAdvance
+
+	err := db.Preload("Repayments").
+		Where("date <= ?", date).
+		Where(outstandingCashAdvance).
+		Order("date ASC, id ASC").
+		Find(&advances).Error
+	if err != nil {
+		r.Log.WithError(err).Error("failed to find outstanding cash advances")
+		return nil, err
+	}
+
+	return advances, nil
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type CashDepositRepository interface {
+	Create(db *gorm.DB, deposit *entity.CashDeposit) error
+	SumBySalesOnDate(db *gorm.DB, date time.Time) ([]model.SalesAmount, error)
+}
+
+type cashDepositRepositoryImpl struct {
+	Log *logrus.Logger
+}
+
+func NewCashDepositRepository(log *logrus.Logger) CashDepositRepository {
+	return &cashDepositRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *cashDepositRepositoryImpl) Create(db *gorm.DB, deposit *entity.CashDeposit) error {
+	return db.Create(deposit).Error
+}
+
+func (r *cashDepositRepositoryImpl) SumBySalesOnDate(db *gorm.DB, date time.Time) ([]model.SalesAmount, error) {
+	var amounts []model.SalesAmount
+
+	err := db.Model(new(entity.CashDeposit)).
+		Select("cash_deposits.sales_id, employees.name AS sales_name, SUM(cash_deposits.amount) AS total, COUNT(cash_deposits.id) AS count").
+		Joins("JOIN sales ON sales.id = cash_deposits.sales_id").
+		Joins("JOIN employees ON employees.id = sales.employee_id").
+		Where("cash_deposits.date = ?", date).
+		Group("cash_deposits.sales_id, employees.name").
+		Scan(&amounts).Error
+
+	if err != nil {
+		r.Log.WithError(err).Error("failed to sum cash deposits by date")
+		return nil, err
+	}
+
+	return amounts, nil
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type CollectionRepository interface {
+	Create(db *gorm.DB, collection *entity.Collection) error
+	FindByCustomerId(db *gorm.DB, customerId int) ([]entity.Collection, error)
+	SumBySalesInPeriod(db *gorm.DB, periodId int) ([]model.SalesAmount, error)
+	SumBySalesOnDate(db *gorm.DB, date time.Time) ([]model.SalesAmount, error)
+}
+
+type collectionRepositoryImpl struct {
+	Log *logrus.Logger
+}
+
+func NewCollectionRepository(log *logrus.Logger) CollectionRepository {
+	return &collectionRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *collectionRepositoryImpl) Create(db *gorm.DB, collection *entity.Collection) error {
+	return db.Create(collection).Error
+}
+
+func (r *collectionRepositoryImpl) FindByCustomerId(db *gorm.DB, customerId int) ([]entity.Collection, error) {
+	var collections []entity.Collection
+	if err := db.Where("customer_id = ?", customerId).Order("date ASC").Find(&collections).Error; err != nil {
+		r.Log.WithError(err).Error("failed to find collections")
+		return nil, err
+	}
+	return collections, nil
+}
+
+func (r *collectionRepositoryImpl) SumBySalesInPeriod(db *gorm.DB, periodId int) ([]model.SalesAmount, error) {
+	var amounts []model.SalesAmount
+
+	err := db.Model(new(entity.Collection)).
+		Select("collections.sales_id, employees.name AS sales_name, SUM(collections.amount) AS total, COUNT(collections.id) AS count").
+		Joins("JOIN sales ON sales.id = collections.sales_id").
+		Joins("JOIN employees ON employees.id = sales.employee_id").
+		Where("collections.period_id = ?", periodId).
+		Group("collections.sales_id, employees.name").
+		Order("employees.name ASC").
+		Scan(&amounts).Error
+
+	if err != nil {
+		r.Log.WithError(err).Error("failed to sum collections by sales")
+		return nil, err
+	}
+
+	return amounts, nil
+}
+
+func (r *collectionRepositoryImpl) SumBySalesOnDate(db *gorm.DB, date time.Time) ([]model.SalesAmount, error) {
+	var amounts []model.SalesAmount
+
+	err := db.Model(new(entity.Collection)).
+		Select("collections.sales_id, employees.name AS sales_name, SUM(collections.amount) AS total, COUNT(collections.id) AS count").
+		Joins("JOIN sales ON sales.id = collections.sales_id").
+		Joins("JOIN employees ON employees.id = sales.employee_id").
+		Where("collections.date = ?", date).
+		Group("collections.sales_id, employees.name").
+		Scan(&amounts).Error
+
+	if err != nil {
+		r.Log.WithError(err).Error("failed to sum collections by date")
+		return nil, err
+	}
+
+	return amounts, nil
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"api/internal/entity"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type CrewMemberRepository interface {
+	Create(db *gorm.DB, members []*entity.CrewMember) error
+	FindByCrewId(db *gorm.DB, crewId int) ([]entity.CrewMember, error)
+	FindOpen(db *gorm.DB, crewId int) ([]entity.CrewMember, error)
+	FindActive(db *gorm.DB, crewIds []int, date time.Time) ([]entity.CrewMember, error)
+	FindOtherCrews(db *gorm.DB, employeeIds []int, crewId int, date time.Time) ([]entity.CrewMember, error)
+	End(db *gorm.DB, ids []int, endDate time.Time) error
+}
+
+type crewMemberRepositoryImpl struct {
+	Log *logrus.Logger
+}
+
+func NewCrewMemberRepository(log *logrus.Logger) CrewMemberRepository {
+	return &crewMemberRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *crewMemberRepositoryImpl) Create(db *gorm.DB, members []*entity.CrewMember) error {
+	return db.Create(members).Error
+}
+
+// FindByCrewId returns the whole assignment history of the crew, latest first
+func (r *crewMemberRepositoryImpl) FindByCrewId(db *gorm.DB, crewId int) ([]entity.CrewMember, error) {
+	var members []entity.CrewMember
+
+	if err := db.Joins("Employee").
+		Where("crew_members.crew_id = ?", crewId).
+		Order("crew_members.start_date DESC, crew_members.id DESC").
+		Find(&members).Error; err != nil {
+		r.Log.WithError(err).Error("failed to find crew members")
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// FindOpen returns the assignments of the crew without an end date
+func (r *crewMemberRepositoryImpl) FindOpen(db *gorm.DB, crewId int) ([]entity.CrewMember, error) {
+	var members []entity.CrewMember
+
+	if err := db.Where("crew_id = ? AND end_date IS NULL", crewId).Find(&members).Error; err != nil {
+		r.Log.WithError(err).Error("failed to find crew members")
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// FindActive returns the members of the crews assigned on the date
+func (r *crewMemberRepositoryImpl) FindActive(db *gorm.DB, crewIds []int, date time.Time) ([]entity.CrewMember, error) {
+	var members []entity.CrewMember
+
+	if err := db.Joins("Employee").
+		Where("crew_members.crew_id IN ?", crewIds).
+		Where("crew_members.start_date <= ? AND (crew_members.end_date IS NULL OR crew_members.end_date >= ?)", date, date).
+		Find(&members).Error; err != nil {
+		r.Log.WithError(err).Error("failed to find crew members")
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// FindOtherCrews returns assignments of the employees to other crews that are
+// still running on or after the date
+func (r *crewMemberRepositoryImpl) FindOtherCrews(db *gorm.DB, employeeIds []int, crewId int, date time.Time) ([]entity.CrewMember, error) {
+	var members []entity.CrewMember
+
+	if err := db.Joins("Employee").Joins("JOIN crews ON crews.id = crew_members.crew_id AND crews.deleted_at IS NULL").
+		Where("crew_members.employee_id IN ? AND crew_members.crew_id <> ?", employeeIds, crewId).
+		Where("(crew_members.end_date IS NULL OR crew_members.end_date >= ?)", date).
+		Find(&members).Error; err != nil {
+		r.Log.WithError(err).Error("failed to find crew members")
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (r *crewMemberRepositoryImpl) End(db *gorm.DB, ids []int, endDate time.Time) error {
+	return db.Model(&entity.CrewMember{}).Where("id IN ?", ids).Update("end_date", endDate).Error
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"api/internal/entity"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type CrewRepository interface {
+	FindAll(db *gorm.DB, onlyActive bool, date time.Time) ([]entity.Crew, error)
+	FindById(db *gorm.DB, id int, date time.Time) (*entity.Crew, error)
+	Create(db *gorm.DB, crew *entity.Crew) error
+	Update(db *gorm.DB, id int, updates any) error
+	Delete(db *gorm.DB, id int) error
+	CountByName(db *gorm.DB, name string, excludeId int) (int64, error)
+}
+
+type crewRepositoryImpl struct {
+	Log *logrus.Logger
+}
+
+func NewCrewRepository(log *logrus.Logger) CrewRepository {
+	return &crewRepositoryImpl{
+		Log: log,
+	}
+}
+
+// membersOn preloads the members assigned on the date with their employee
+func membersOn(date time.Time) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		return tx.Preload("Members", func(db *gorm.DB) *gorm.DB {
+			return db.Joins("Employee").
+				Where("crew_members.start_date <= ? AND (crew_members.end_date IS NULL OR crew_members.end_date >= ?)", date, date).
+				Order("\"Employee\".role ASC, \"Employee\".name ASC")
+		})
+	}
+}
+
+func (r *crewRepositoryImpl) FindAll(db *gorm.DB, onlyActive bool, date time.Time) ([]entity.Crew, error) {
+	var crews []entity.Crew
+
+	query := db.Preload("Vehicle").Scopes(membersOn(date))
+	if onlyActive {
+		query = query.Where("is_active = ?", true)
+	}
+
+	if err := query.Order("name ASC").Find(&crews).Error; err != nil {
+		r.Log.WithError(err).Error("failed to find crews")
+		return nil, err
+	}
+
+	return crews, nil
+}
+
+func (r *crewRepositoryImpl) FindById(db *gorm.DB, id int, date time.Time) (*entity.Crew, error) {
+	var crew entity.Crew
+
+	err := db.Preload("Vehicle").Scopes(membersOn(date)).First(&crew, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &crew, nil
+}
+
+func (r *crewRepositoryImpl) Create(db *gorm.DB, crew *entity.Crew) error {
+	return db.Create(crew).Error
+}
+
+func (r *crewRepositoryImpl) Update(db *gorm.DB, id int, updates any) error {
+	return db.Model(&entity.Crew{}).Where("id = ?", id).Updates(updates).Error
+}
+
+func (r *crewRepositoryImpl) Delete(db *gorm.DB, id int) error {
+	return db.Delete(&entity.Crew{}, id).Error
+}
+
+func (r *crewRepositoryImpl) CountByName(db *gorm.DB, name string, excludeId int) (int64, error) {
+	var count int64
+
+	err := db.Model(new(entity.Crew)).Where("name = ? AND id <> ?", name, excludeId).Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CustomerRepository interface {
+	FindAll(db *gorm.DB, request *model.FindAllCustomerRequest) ([]entity.Customer, int64, error)
+	Create(db *gorm.DB, customer *entity.Customer) error
+	Update(db *gorm.DB, id int, updates any) error
+	Delete(db *gorm.DB, id int) error
+	FindById(db *gorm.DB, id int) (*entity.Customer, error)
+	FindByIdForUpdate(db *gorm.DB, id int) (*entity.Customer, error)
+	FindBalances(db *gorm.DB, ids []int) ([]model.CustomerBalance, error)
+}
+
+type customerRepositoryImpl struct {
+	Log *logrus.Logger
+}
+
+func NewCustomerRepository(log *logrus.Logger) CustomerRepository {
+	return &customerRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *customerRepositoryImpl) Create(db *gorm.DB, customer *entity.Customer) error {
+	return db.Create(customer).Error
+}
+
+func (r *customerRepositoryImpl) Update(db *gorm.DB, id int, updates any) error {
+	return db.Model(&entity.Customer{}).Where("id = ?", id).Updates(updates).Error
+}
+
+func (r *customerRepositoryImpl) Delete(db *gorm.DB, id int) error {
+	return db.Delete(&entity.Customer{}, id).Error
+}
+
+func (r *customerRepositoryImpl) FindById(db *gorm.DB, id int) (*entity.Customer, error) {
+	var customer entity.Customer
+
+	err := db.Preload("Route").First(&customer, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &customer, nil
+}
+
+// FindByIdForUpdate locks the customer row until the transaction ends, so
+// payments checked against the same outstanding balance run one at a time
+func (r *customerRepositoryImpl) FindByIdForUpdate(db *gorm.DB, id int) (*entity.Customer, error) {
+	var customer entity.Customer
+
+	err := db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&customer, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &customer, nil
+}
+
+func (r *customerRepositoryImpl) FindAll(db *gorm.DB, request *model.FindAllCustomerRequest) ([]entity.Customer, int64, error) {
+	var customers []entity.Customer
+	var total int64
+
+	countQuery := db.Model(new(entity.Customer)).Scopes(r.FilterCustomer(request))
+	if err := countQuery.Count(&total).Error; err != nil {
+		r.Log.WithError(err).Error("failed to count customers")
+		return nil, 0, err
+	}
+
+	query := db.Model(new(entity.Customer)).
+		Scopes(r.FilterCustomer(request)).
+		Preload("Route").
+		Order("name ASC")
+
+	if request.Page > 0 && request.PerPage > 0 {
+		offset := (request.Page - 1) * request.PerPage
+		query = query.Offset(offset).Limit(request.PerPage)
+	}
+
+	if err := query.Find(&customers).Error; err != nil {
+		r.Log.WithError(err).Error("failed to find customers")
+		return nil, 0, err
+	}
+
+	return customers, total, nil
+}
+
+func (r *customerRepositoryImpl) FilterCustomer(request *model.FindAllCustomerRequest) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if search := request.Search; search != "" {
+			search = "%" + search + "%"
+			tx = tx.Where("name ILIKE ? OR phone ILIKE ?", search, search)
+		}
+
+		if request.RouteId > 0 {
+			tx = tx.Where("route_id = ?", request.RouteId)
+		}
+
+		return tx
+	}
+}
+
+func (r *customerRepositoryImpl) FindBalances(db *gorm.DB, ids []int) ([]model.CustomerBalance, error) {
+	var balances []model.CustomerBalance
+
+	err := db.Model(new(entity.Customer)).
+		Select(`customers.id AS customer_id,
+			COALESCE((SELECT SUM(amount) FROM receivables WHERE receivables.customer_id = customers.id AND receivables.deleted_at IS NULL), 0) AS total_receivable,
+			COALESCE((SELECT SUM(amount) FROM collections WHERE collections.customer_id = customers.id AND collections.deleted_at IS NULL), 0) AS total_collected`).
+		Where("customers.id IN ?", ids).
+		Scan(&balances).Error
+
+	if err != nil {
+		r.Log.WithError(err).Error("failed to find customer balances")
+		return nil, err
+	}
+
+	return balances, nil
+}
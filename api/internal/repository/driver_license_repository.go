@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"api/internal/entity"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type DriverLicenseRepository interface {
+	Create(db *gorm.DB, license *entity.DriverLicense) error
+	Delete(db *gorm.DB, id int) error
+	FindById(db *gorm.DB, employeeId, id int) (*entity.DriverLicense, error)
+	FindByEmployeeId(db *gorm.DB, employeeId int) ([]entity.DriverLicense, error)
+	FindExpiring(db *gorm.DB, date time.Time) ([]entity.DriverLicense, error)
+}
+
+type driverLicenseRepositoryImpl struct {
+	Log *logrus.Logger
+}
+
+func NewDriverLicenseRepository(log *logrus.Logger) DriverLicenseRepository {
+	return &driverLicenseRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *driverLicenseRepositoryImpl) Create(db *gorm.DB, license *entity.DriverLicense) error {
+	return db.Create(license).Error
+}
+
+func (r *driverLicenseRepositoryImpl) Delete(db *gorm.DB, id int) error {
+	return db.Delete(&entity.DriverLicense{}, id).Error
+}
+
+func (r *driverLicenseRepositoryImpl) FindById(db *gorm.DB, employeeId, id int) (*entity.DriverLicense, error) {
+	var license entity.DriverLicense
+
+	err := db.Where("employee_id = ?", employeeId).First(&license, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &license, nil
+}
+
+func (r *driverLicenseRepositoryImpl) FindByEmployeeId(db *gorm.DB, employeeId int) ([]entity.DriverLicense, error) {
+	var licenses []entity.DriverLicense
+
+	if err := db.Where("employee_id = ?", employeeId).
+		Order("expiry_date DESC").
+		Find(&licenses).Error; err != nil {
+		r.Log.WithError(err).Error("failed to find driver licenses")
+		return nil, err
+	}
+
+	return licenses, nil
+}
+
+// FindExpiring returns the latest licence of each working driver expiring on or before date
+func (r *driverLicenseRepositoryImpl) FindExpiring(db *gorm.DB, date time.Time) ([]entity.DriverLicense, error) {
+	var licenses []entity.DriverLicense
+
+	latest := db.Model(new(entity.DriverLicense)).
+		Select("DISTINCT ON (driver_licenses.employee_id) driver_licenses.*").
+		Joins("JOIN employees ON employees.id = driver_licenses.employee_id AND employees.deleted_at IS NULL").
+		Where("employees.status NOT IN ('RESIGNED', 'TERMINATED')").
+		Order("driver_licenses.employee_id, driver_licenses.expiry_date DESC")
+
+	if err := db.Table("(?) AS driver_licenses", latest).
+		Preload("Employee").
+		Where("expiry_date <= ?", date).
+		Order("expiry_date ASC").
+		Find(&licenses).Error; err != nil {
+		r.Log.WithError(err).Error("failed to find expiring driver licenses")
+		return nil, err
+	}
+
+	return licenses, nil
+}
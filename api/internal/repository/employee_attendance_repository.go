@@ -2,6 +2,8 @@ package repository
 
 import (
 	"api/internal/entity"
+	"api/internal/entity/enum"
+	"api/internal/model"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -12,6 +14,8 @@ import (
 type EmployeeAttendanceRepository interface {
 	BatchUpsert(db *gorm.DB, employee []*entity.EmployeeAttendance) error
 	BatchDeleteByDate(db *gorm.DB, date []time.Time) error
+	CountByPeriod(db *gorm.DB, periodId int, status enum.AttendanceStatus) ([]model.EmployeeCount, error)
+	FindByEmployeeId(db *gorm.DB, employeeId int, startDate, endDate time.Time) ([]entity.EmployeeAttendance, error)
 }
 
 type employeeAttendanceRepositoryImpl struct {
@@ -35,6 +39,12 @@ func (r *employeeAttendanceRepositoryImpl) BatchUpsert(db *gorm.DB, attendances
 			"period_id":  gorm.Expr("EXCLUDED.period_id"),
 			"updated_at": gorm.Expr("EXCLUDED.updated_at"),
 			"deleted_at": nil, // Restore soft deleted
+			// Keep check-in location when updated from the attendance sheet
+			"check_in_at":       gorm.Expr("COALESCE(EXCLUDED.check_in_at, employee_attendances.check_in_at)"),
+			"latitude":          gorm.Expr("COALESCE(EXCLUDED.latitude, employee_attendances.latitude)"),
+			"longitude":         gorm.Expr("COALESCE(EXCLUDED.longitude, employee_attendances.longitude)"),
+			"location_accuracy": gorm.Expr("COALESCE(EXCLUDED.location_accuracy, employee_attendances.location_accuracy)"),
+			"geofence_id":       gorm.Expr("COALESCE(EXCLUDED.geofence_id, employee_attendances.geofence_id)"),
 		}),
 	}).Create(&attendances).Error
 }
@@ -42,3 +52,29 @@ func (r *employeeAttendanceRepositoryImpl) BatchUpsert(db *gorm.DB, attendances
 func (r *employeeAttendanceRepositoryImpl) BatchDeleteByDate(db *gorm.DB, date []time.Time) error {
 	return db.Where("date IN ?", date).Delete(&entity.EmployeeAttendance{}).Error
 }
+
+func (r *employeeAttendanceRepositoryImpl) CountByPeriod(db *gorm.DB, periodId int, status enum.AttendanceStatus) ([]model.EmployeeCount, error) {
+	var counts []model.EmployeeCount
+
+	err := db.Model(new(entity.EmployeeAttendance)).
+		Select("employee_id, COUNT(id) AS total").
+		Where("period_id = ? AND status = ?", periodId, status).
+		Group("employee_id").
+		Scan(&counts).Error
+
+	return counts, err
+}
+
+func (r *employeeAttendanceRepositoryImpl) FindByEmployeeId(db *gorm.DB, employeeId int, startDate, endDate time.Time) ([]entity.EmployeeAttendance, error) {
+	var attendances []entity.EmployeeAttendance
+
+	err := db.Where("employee_id = ? AND date BETWEEN ? AND ?", employeeId, startDate, endDate).
+		Order("date ASC").
+		Find(&attendances).Error
+	if err != nil {
+		r.Log.WithError(err).Error("failed to find employee attendances")
+		return nil, err
+	}
+
+	return attendances, nil
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"api/internal/entity"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type EmployeeDocumentRepository interface {
+	Create(db *gorm.DB, document *entity.EmployeeDocument) error
+	FindByEmployeeId(db *gorm.DB, employeeId int) ([]entity.EmployeeDocument, error)
+	FindById(db *gorm.DB, employeeId, id int) (*entity.EmployeeDocument, error)
+	Delete(db *gorm.DB, id int) error
+}
+
+type employeeDocumentRepositoryImpl struct {
+	Log *logrus.Logger
+}
+
+func NewEmployeeDocumentRepository(log *logrus.Logger) EmployeeDocumentRepository {
+	return &employeeDocumentRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *employeeDocumentRepositoryImpl) Create(db *gorm.DB, document *entity.EmployeeDocument) error {
+	return db.Create(document).Error
+}
+
+func (r *employeeDocumentRepositoryImpl) FindByEmployeeId(db *gorm.DB, employeeId int) ([]entity.EmployeeDocument, error) {
+	var documents []entity.EmployeeDocument
+
+	if err := db.Where("employee_id = ?", employeeId).
+		Order("created_at DESC").
+		Find(&documents).Error; err != nil {
+		r.Log.WithError(err).Error("failed to find employee documents")
+		return nil, err
+	}
+
+	return documents, nil
+}
+
+func (r *employeeDocumentRepositoryImpl) FindById(db *gorm.DB, employeeId, id int) (*entity.EmployeeDocument, error) {
+	var document entity.EmployeeDocument
+
+	err := db.Where("employee_id = ?", employeeId).First(&document, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &document, nil
+}
+
+func (r *employeeDocumentRepositoryImpl) Delete(db *gorm.DB, id int) error {
+	return db.Delete(&entity.EmployeeDocument{}, id).Error
+}
@@ -2,8 +2,11 @@ package repository
 
 import (
 	"api/internal/entity"
+	"api/internal/entity/enum"
 	"api/internal/model"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
@@ -15,8 +18,16 @@ type EmployeeRepository interface {
 	Update(db *gorm.DB, employee *entity.Employee) error
 	Delete(db *gorm.DB, id int) error
 	FindById(db *gorm.DB, id int) (*entity.Employee, error)
+	FindByArryId(db *gorm.DB, ids []int) ([]entity.Employee, error)
 	FindByIdWithSubordinates(db *gorm.DB, id int) (*entity.Employee, error)
 	FindAllWithAttendances(db *gorm.DB, request *model.FindAllEmployeeWithAttendanceRequest) ([]entity.Employee, error)
+	FindAllEmployedBetween(db *gorm.DB, start, end time.Time) ([]entity.Employee, error)
+	UpdateStatus(db *gorm.DB, id int, status enum.EmployeeStatus) error
+	UpdateSupervisor(db *gorm.DB, id int, supervisorId *int) error
+	HasHistory(db *gorm.DB, id int) (bool, error)
+	CountByNIK(db *gorm.DB, nik string, excludeId int) (int64, error)
+	FindTree(db *gorm.DB, rootId int) ([]entity.Employee, error)
+	IsSubordinate(db *gorm.DB, id int, supervisorId int) (bool, error)
 }
 
 type employeeRepositoryImpl struct {
@@ -55,6 +66,15 @@ func (r *employeeRepositoryImpl) FindById(db *gorm.DB, id int) (*entity.Employee
 	return &employee, nil
 }
 
+func (r *employeeRepositoryImpl) FindByArryId(db *gorm.DB, ids []int) ([]entity.Employee, error) {
+	var employees []entity.Employee
+	if err := db.Where("id in (?)", ids).Find(&employees).Error; err != nil {
+		r.Log.WithError(err).Error("error getting employees")
+		return nil, err
+	}
+	return employees, nil
+}
+
 func (r *employeeRepositoryImpl) FindAll(db *gorm.DB, request *model.FindAllEmployeeRequest) ([]entity.Employee, int64, error) {
 	var employees []entity.Employee
 	var total int64
@@ -95,6 +115,14 @@ func (r *employeeRepositoryImpl) FilterEmployee(request *model.FindAllEmployeeRe
 			tx = tx.Where("role IN ?", request.Roles)
 		}
 
+		if len(request.Statuses) > 0 {
+			tx = tx.Where("status IN ?", request.Statuses)
+		}
+
+		if request.SubordinateOf > 0 {
+			tx = tx.Scopes(subordinatesOf(request.SubordinateOf))
+		}
+
 		return tx
 	}
 }
@@ -113,6 +141,41 @@ func (r *employeeRepositoryImpl) FindByIdWithSubordinates(db *gorm.DB, id int) (
 	return &employee, nil
 }
 
+// employedBetween keeps employees who joined before end and were not resigned
+// or terminated before start, unless they were rehired within the range
+func employedBetween(start, end any) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("employees.join_date <= ?", end).
+			Where(`COALESCE((
+				SELECT h.status FROM employee_status_histories h
+				WHERE h.employee_id = employees.id AND h.effective_date < ?
+				ORDER BY h.effective_date DESC, h.id DESC LIMIT 1
+			), 'ACTIVE') NOT IN ('RESIGNED', 'TERMINATED') OR EXISTS (
+				SELECT 1 FROM employee_status_histories h
+				WHERE h.employee_id = employees.id AND h.status = 'ACTIVE'
+				AND h.effective_date BETWEEN ? AND ?
+			)`, start, start, end)
+	}
+}
+
+// subordinateTree walks down the supervisor chain from the employees matched by
+// the anchor, the path guards against cycles left in older data
+const subordinateTree = `WITH RECURSIVE tree AS (
+	SELECT e.id, 0 AS depth, ARRAY[e.id] AS path FROM employees e
+	WHERE e.deleted_at IS NULL AND %s
+	UNION ALL
+	SELECT e.id, t.depth + 1, t.path || e.id FROM employees e
+	JOIN tree t ON e.supervisor_id = t.id
+	WHERE e.deleted_at IS NULL AND NOT e.id = ANY(t.path)
+)`
+
+// subordinatesOf keeps the direct and indirect subordinates of the employee
+func subordinatesOf(id int) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("employees.id IN ("+fmt.Sprintf(subordinateTree, "e.supervisor_id = ?")+" SELECT id FROM tree)", id)
+	}
+}
+
 func (r *employeeRepositoryImpl) FindAllWithAttendances(db *gorm.DB, request *model.FindAllEmployeeWithAttendanceRequest) ([]entity.Employee, error) {
 	var employees []entity.Employee
 
@@ -123,7 +186,11 @@ func (r *employeeRepositoryImpl) FindAllWithAttendances(db *gorm.DB, request *mo
 		return db
 	})
 
-	if err := query.Where("join_date <= ?", request.EndDate).
+	if request.SubordinateOf > 0 {
+		query = query.Scopes(subordinatesOf(request.SubordinateOf))
+	}
+
+	if err := query.Scopes(employedBetween(request.StartDate, request.EndDate)).
 		Find(&employees).Error; err != nil {
 		r.Log.WithError(err).Error("failed to find employees")
 		return nil, err
@@ -131,3 +198,82 @@ func (r *employeeRepositoryImpl) FindAllWithAttendances(db *gorm.DB, request *mo
 
 	return employees, nil
 }
+
+func (r *employeeRepositoryImpl) FindAllEmployedBetween(db *gorm.DB, start, end time.Time) ([]entity.Employee, error) {
+	var employees []entity.Employee
+
+	if err := db.Scopes(employedBetween(start, end)).Order("name ASC").Find(&employees).Error; err != nil {
+		r.Log.WithError(err).Error("failed to find employees")
+		return nil, err
+	}
+
+	return employees, nil
+}
+
+func (r *employeeRepositoryImpl) UpdateStatus(db *gorm.DB, id int, status enum.EmployeeStatus) error {
+	return db.Model(&entity.Employee{ID: id}).Update("status", status).Error
+}
+
+func (r *employeeRepositoryImpl) UpdateSupervisor(db *gorm.DB, id int, supervisorId *int) error {
+	return db.Model(&entity.Employee{ID: id}).Update("supervisor_id", supervisorId).Error
+}
+
+// HasHistory reports whether the employee already has attendances or payrolls
+func (r *employeeRepositoryImpl) HasHistory(db *gorm.DB, id int) (bool, error) {
+	var exists bool
+
+	err := db.Raw(`SELECT EXISTS (SELECT 1 FROM employee_attendances WHERE employee_id = ? AND deleted_at IS NULL)
+		OR EXISTS (SELECT 1 FROM payrolls WHERE employee_id = ? AND deleted_at IS NULL)`, id, id).
+		Scan(&exists).Error
+	if err != nil {
+		r.Log.WithError(err).Error("failed to check employee history")
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// FindTree returns the hierarchy below the root ordered by depth, without a root
+// it starts from employees without an active supervisor
+func (r *employeeRepositoryImpl) FindTree(db *gorm.DB, rootId int) ([]entity.Employee, error) {
+	var employees []entity.Employee
+
+	anchor := `(e.supervisor_id IS NULL OR NOT EXISTS (
+		SELECT 1 FROM employees s WHERE s.id = e.supervisor_id AND s.deleted_at IS NULL))`
+	var args []any
+	if rootId > 0 {
+		anchor = "e.id = ?"
+		args = append(args, rootId)
+	}
+
+	query := fmt.Sprintf(subordinateTree, anchor) + `
+		SELECT employees.* FROM tree JOIN employees ON employees.id = tree.id
+		ORDER BY tree.depth, employees.name`
+
+	if err := db.Raw(query, args...).Scan(&employees).Error; err != nil {
+		r.Log.WithError(err).Error("failed to find employee tree")
+		return nil, err
+	}
+
+	return employees, nil
+}
+
+// IsSubordinate reports whether the employee sits anywhere below the supervisor
+func (r *employeeRepositoryImpl) IsSubordinate(db *gorm.DB, id int, supervisorId int) (bool, error) {
+	var exists bool
+
+	query := fmt.Sprintf(subordinateTree, "e.supervisor_id = ?") + ` SELECT EXISTS (SELECT 1 FROM tree WHERE id = ?)`
+	if err := db.Raw(query, supervisorId, id).Scan(&exists).Error; err != nil {
+		r.Log.WithError(err).Error("failed to check employee subordinate")
+		return false, err
+	}
+
+	return exists, nil
+}
+
+func (r *employeeRepositoryImpl) CountByNIK(db *gorm.DB, nik string, excludeId int) (int64, error) {
+	var count int64
+
+	err := db.Unscoped().Model(new(entity.Employee)).Where("nik = ? AND id <> ?", nik, excludeId).Count(&count).Error
+	return count, err
+}
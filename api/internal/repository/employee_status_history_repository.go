@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"api/internal/entity"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type EmployeeStatusHistoryRepository interface {
+	Create(db *gorm.DB, history *entity.EmployeeStatusHistory) error
+	FindByEmployeeId(db *gorm.DB, employeeId int) ([]entity.EmployeeStatusHistory, error)
+	FindLatest(db *gorm.DB, employeeId int) (*entity.EmployeeStatusHistory, error)
+}
+
+type employeeStatusHistoryRepositoryImpl struct {
+	Log *logrus.Logger
+}
+
+func NewEmployeeStatusHistoryRepository(log *logrus.Logger) EmployeeStatusHistoryRepository {
+	return &employeeStatusHistoryRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *employeeStatusHistoryRepositoryImpl) Create(db *gorm.DB, history *entity.EmployeeStatusHistory) error {
+	return db.Create(history).Error
+}
+
+func (r *employeeStatusHistoryRepositoryImpl) FindByEmployeeId(db *gorm.DB, employeeId int) ([]entity.EmployeeStatusHistory, error) {
+	var histories []entity.EmployeeStatusHistory
+
+	if err := db.Where("employee_id = ?", employeeId).
+		Order("effective_date ASC, id ASC").
+		Find(&histories).Error; err != nil {
+		r.Log.WithError(err).Error("failed to find employee status histories")
+		return nil, err
+	}
+
+	return histories, nil
+}
+
+func (r *employeeStatusHistoryRepositoryImpl) FindLatest(db *gorm.DB, employeeId int) (*entity.EmployeeStatusHistory, error) {
+	var history entity.EmployeeStatusHistory
+
+	err := db.Where("employee_id = ?", employeeId).
+		Order("effective_date DESC, id DESC").
+		First(&history).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &history, nil
+}
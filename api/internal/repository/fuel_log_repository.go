@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type FuelLogRepository interface {
+	Create(db *gorm.DB, log *entity.FuelLog) error
+	Delete(db *gorm.DB, id int) error
+	FindById(db *gorm.DB, vehicleId int64, id int) (*entity.FuelLog, error)
+	FindUntil(db *gorm.DB, vehicleId int64, date time.Time) ([]entity.FuelLog, error)
+	FindOdometerBounds(db *gorm.DB, vehicleId int64, date time.Time) (int, int, error)
+	FindOdometers(db *gorm.DB, vehicleIds []int64) ([]model.VehicleOdometer, error)
+}
+
+type fuelLogRepositoryImpl struct {
+	Log *logrus.Logger
+}
+
+func NewFuelLogRepository(log *logrus.Logger) FuelLogRepository {
+	return &fuelLogRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *fuelLogRepositoryImpl) Create(db *gorm.DB, log *entity.FuelLog) error {
+	return db.Create(log).Error
+}
+
+func (r *fuelLogRepositoryImpl) Delete(db *gorm.DB, id int) error {
+	return db.Delete(&entity.FuelLog{}, id).Error
+}
+
+func (r *fuelLogRepositoryImpl) FindById(db *gorm.DB, vehicleId int64, id int) (*entity.FuelLog, error) {
+	var log entity.FuelLog
+
+	err := db.Where("vehicle_id = ?", vehicleId).First(&log, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &log, nil
+}
+
+// FindUntil returns the fill-ups up to date ordered by vehicle and odometer,
+// vehicleId 0 means every vehicle
+func (r *fuelLogRepositoryImpl) FindUntil(db *gorm.DB, vehicleId int64, date time.Time) ([]entity.FuelLog, error) {
+	var logs []entity.FuelLog
+
+	query := db.Preload("Vehicle").Preload("Driver").Where("date <= ?", date)
+	if vehicleId > 0 {
+		query = query.Where("vehicle_id = ?", vehicleId)
+	}
+
+	if err := query.Order("vehicle_id ASC, odometer ASC").Find(&logs).Error; err != nil {
+		r.Log.WithError(err).Error("failed to find fuel logs")
+		return nil, err
+	}
+
+	return logs, nil
+}
+
+// FindOdometerBounds returns the highest odometer on or before date and the
+// lowest odometer after date, 0 when there is none
+func (r *fuelLogRepositoryImpl) FindOdometerBounds(db *gorm.DB, vehicleId int64, date time.Time) (int, int, error) {
+	var bounds struct {
+		Before int
+		After  int
+	}
+
+	err := db.Model(new(entity.FuelLog)).
+		Select(`COALESCE(MAX(odometer) FILTER (WHERE date <= ?), 0) AS before,
+			COALESCE(MIN(odometer) FILTER (WHERE date > ?), 0) AS after`, date, date).
+		Where("vehicle_id = ?", vehicleId).
+		Scan(&bounds).Error
+	if err != nil {
+		r.Log.WithError(err).Error("failed to find fuel log odometer")
+		return 0, 0, err
+	}
+
+	return bounds.Before, bounds.After, nil
+}
+
+// FindOdometers returns the highest recorded odometer of each vehicle
+func (r *fuelLogRepositoryImpl) FindOdometers(db *gorm.DB, vehicleIds []int64) ([]model.VehicleOdometer, error) {
+	var odometers []model.VehicleOdometer
+
+	if len(vehicleIds) == 0 {
+		return odometers, nil
+	}
+
+	if err := db.Model(new(entity.FuelLog)).
+		Select("vehicle_id, MAX(odometer) AS odometer").
+		Where("vehicle_id IN ?", vehicleIds).
+		Group("vehicle_id").
+		Scan(&odometers).Error; err != nil {
+		r.Log.WithError(err).Error("failed to find vehicle odometers")
+		return nil, err
+	}
+
+	return odometers, nil
+}
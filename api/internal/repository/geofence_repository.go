@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type GeofenceRepository interface {
+	FindAll(db *gorm.DB, request *model.FindAllGeofenceRequest) ([]entity.Geofence, int64, error)
+	FindAllActive(db *gorm.DB) ([]entity.Geofence, error)
+	Create(db *gorm.DB, geofence *entity.Geofence) error
+	Update(db *gorm.DB, id int, updates any) error
+	Delete(db *gorm.DB, id int) error
+	FindById(db *gorm.DB, id int) (*entity.Geofence, error)
+}
+
+type geofenceRepositoryImpl struct {
+	Log *logrus.Logger
+}
+
+func NewGeofenceRepository(log *logrus.Logger) GeofenceRepository {
+	return &geofenceRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *geofenceRepositoryImpl) Create(db *gorm.DB, geofence *entity.Geofence) error {
+	return db.Create(geofence).Error
+}
+
+func (r *geofenceRepositoryImpl) Update(db *gorm.DB, id int, updates any) error {
+	return db.Model(&entity.Geofence{}).Where("id = ?", id).Updates(updates).Error
+}
+
+func (r *geofenceRepositoryImpl) Delete(db *gorm.DB, id int) error {
+	return db.Delete(&entity.Geofence{}, id).Error
+}
+
+func (r *geofenceRepositoryImpl) FindById(db *gorm.DB, id int) (*entity.Geofence, error) {
+	var geofence entity.Geofence
+
+	err := db.First(&geofence, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &geofence, nil
+}
+
+func (r *geofenceRepositoryImpl) FindAll(db *gorm.DB, request *model.FindAllGeofenceRequest) ([]entity.Geofence, int64, error) {
+	var geofences []entity.Geofence
+	var total int64
+
+	countQuery := db.Model(new(entity.Geofence)).Scopes(r.FilterGeofence(request))
+	if err := countQuery.Count(&total).Error; err != nil {
+		r.Log.WithError(err).Error("failed to count geofences")
+		return nil, 0, err
+	}
+
+	query := db.Model(new(entity.Geofence)).Scopes(r.FilterGeofence(request)).Order("name ASC")
+
+	if request.Page > 0 && request.PerPage > 0 {
+		offset := (request.Page - 1) * request.PerPage
+		query = query.Offset(offset).Limit(request.PerPage)
+	}
+
+	if err := query.Find(&geofences).Error; err != nil {
+		r.Log.WithError(err).Error("failed to find geofences")
+		return nil, 0, err
+	}
+
+	return geofences, total, nil
+}
+
+func (r *geofenceRepositoryImpl) FilterGeofence(request *model.FindAllGeofenceRequest) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if search := request.Search; search != "" {
+			tx = tx.Where("name ILIKE ?", "%"+search+"%")
+		}
+
+		return tx
+	}
+}
+
+func (r *geofenceRepositoryImpl) FindAllActive(db *gorm.DB) ([]entity.Geofence, error) {
+	var geofences []entity.Geofence
+	if err := db.Where("is_active = ?", true).Find(&geofences).Error; err != nil {
+		r.Log.WithError(err).Error("failed to find active geofences")
+		return nil, err
+	}
+	return geofences, nil
+}
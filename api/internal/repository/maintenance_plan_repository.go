@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"api/internal/entity"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type MaintenancePlanRepository interface {
+	Create(db *gorm.DB, plan *entity.MaintenancePlan) error
+	Update(db *gorm.DB, id int, updates any) error
+	Delete(db *gorm.DB, id int) error
+	FindById(db *gorm.DB, vehicleId int64, id int) (*entity.MaintenancePlan, error)
+	FindByVehicleId(db *gorm.DB, vehicleId int64) ([]entity.MaintenancePlan, error)
+	FindAllActive(db *gorm.DB, vehicleId int64) ([]entity.MaintenancePlan, error)
+}
+
+type maintenancePlanRepositoryImpl struct {
+	Log *logrus.Logger
+}
+
+func NewMaintenancePlanRepository(log *logrus.Logger) MaintenancePlanRepository {
+	return &maintenancePlanRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *maintenancePlanRepositoryImpl) Create(db *gorm.DB, plan *entity.MaintenancePlan) error {
+	return db.Create(plan).Error
+}
+
+func (r *maintenancePlanRepositoryImpl) Update(db *gorm.DB, id int, updates any) error {
+	return db.Model(&entity.MaintenancePlan{}).Where("id = ?", id).Updates(updates).Error
+}
+
+func (r *maintenancePlanRepositoryImpl) Delete(db *gorm.DB, id int) error {
+	return db.Delete(&entity.MaintenancePlan{}, id).Error
+}
+
+func (r *maintenancePlanRepositoryImpl) FindById(db *gorm.DB, vehicleId int64, id int) (*entity.MaintenancePlan, error) {
+	var plan entity.MaintenancePlan
+
+	err := db.Where("vehicle_id = ?", vehicleId).First(&plan, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &plan, nil
+}
+
+func (r *maintenancePlanRepositoryImpl) FindByVehicleId(db *gorm.DB, vehicleId int64) ([]entity.MaintenancePlan, error) {
+	var plans []entity.MaintenancePlan
+
+	if err := db.Where("vehicle_id = ?", vehicleId).
+		Order("service_type ASC").
+		Find(&plans).Error; err != nil {
+		r.Log.WithError(err).Error("failed to find maintenance plans")
+		return nil, err
+	}
+
+	return plans, nil
+}
+
+// FindAllActive returns the active plans with their vehicle, vehicleId 0 means every vehicle
+func (r *maintenancePlanRepositoryImpl) FindAllActive(db *gorm.DB, vehicleId int64) ([]entity.MaintenancePlan, error) {
+	var plans []entity.MaintenancePlan
+
+	query := db.Joins("Vehicle").Where("maintenance_plans.is_active = ?", true)
+	if vehicleId > 0 {
+		query = query.Where("maintenance_plans.vehicle_id = ?", vehicleId)
+	}
+
+	if err := query.Order("maintenance_plans.vehicle_id ASC, maintenance_plans.service_type ASC").
+		Find(&plans).Error; err != nil {
+		r.Log.WithError(err).Error("failed to find maintenance plans")
+		return nil, err
+	}
+
+	return plans, nil
+}
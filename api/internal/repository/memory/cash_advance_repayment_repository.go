@@ -0,0 +1,30 @@
+package memory
+
+import (
+	"api/internal/entity"
+	"api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type cashAdvanceRepaymentRepository struct {
+	Store *Store
+}
+
+func NewCashAdvanceRepaymentRepository(store *Store) repository.CashAdvanceRepaymentRepository {
+	return &cashAdvanceRepaymentRepository{
+		Store: store,
+	}
+}
+
+func (r *cashAdvanceRepaymentRepository) Create(db *gorm.DB, repayment *entity.CashAdvanceRepayment) error {
+	r.Store.cashAdvanceRepayments.insert(repayment)
+	return nil
+}
+
+func (r *cashAdvanceRepaymentRepository) DeleteByPayrollId(db *gorm.DB, payrollId int) error {
+	r.Store.cashAdvanceRepayments.delete(func(repayment *entity.CashAdvanceRepayment) bool {
+		return repayment.PayrollId != nil && *repayment.PayrollId == payrollId
+	})
+	return nil
+}
@@ -0,0 +1,96 @@
+package memory
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+	"api/internal/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type cashAdvanceRepository struct {
+	Store *Store
+}
+
+func NewCashAdvanceRepository(store *Store) repository.CashAdvanceRepository {
+	return &cashAdvanceRepository{
+		Store: store,
+	}
+}
+
+func (r *cashAdvanceRepository) FindAll(db *gorm.DB, request *model.FindAllCashAdvanceRequest) ([]entity.CashAdvance, int64, error) {
+	advances := r.withRepayments(r.Store.cashAdvances.find(func(advance *entity.CashAdvance) bool {
+		return request.EmployeeId <= 0 || advance.EmployeeId == request.EmployeeId
+	}))
+	if request.OnlyOutstanding {
+		advances = outstanding(advances)
+	}
+
+	orderBy(advances,
+		func(a, b *entity.CashAdvance) int { return compareTime(b.Date, a.Date) },
+		func(a, b *entity.CashAdvance) int { return b.ID - a.ID },
+	)
+
+	total := int64(len(advances))
+	advances = page(advances, request.Page, request.PerPage)
+	for i := range advances {
+		advances[i].Employee = r.Store.employee(advances[i].EmployeeId)
+	}
+
+	return advances, total, nil
+}
+
+func (r *cashAdvanceRepository) Create(db *gorm.DB, advance *entity.CashAdvance) error {
+	r.Store.cashAdvances.insert(advance)
+	return nil
+}
+
+func (r *cashAdvanceRepository) FindById(db *gorm.DB, id int) (*entity.CashAdvance, error) {
+	advance := r.Store.cashAdvances.first(func(advance *entity.CashAdvance) bool { return advance.ID == id })
+	if advance != nil {
+		advance.Repayments = r.Store.repayments(advance.ID)
+	}
+	return advance, nil
+}
+
+func (r *cashAdvanceRepository) FindByEmployeeId(db *gorm.DB, employeeId int) ([]entity.CashAdvance, error) {
+	advances := r.withRepayments(r.Store.cashAdvances.find(func(advance *entity.CashAdvance) bool {
+		return advance.EmployeeId == employeeId
+	}))
+	orderBy(advances,
+		func(a, b *entity.CashAdvance) int { return compareTime(a.Date, b.Date) },
+		func(a, b *entity.CashAdvance) int { return a.ID - b.ID },
+	)
+	return advances, nil
+}
+
+func (r *cashAdvanceRepository) FindOutstanding(db *gorm.DB, date time.Time) ([]entity.CashAdvance, error) {
+	advances := outstanding(r.withRepayments(r.Store.cashAdvances.find(func(advance *entity.CashAdvance) bool {
+		return !day(advance.Date).After(date)
+	})))
+	orderBy(advances,
+		func(a, b *entity.CashAdvance) int { return compareTime(a.Date, b.Date) },
+		func(a, b *entity.CashAdvance) int { return a.ID - b.ID },
+	)
+	return advances, nil
+}
+
+// Helper fuction
+func (r *cashAdvanceRepository) withRepayments(advances []entity.CashAdvance) []entity.CashAdvance {
+	for i := range advances {
+		advances[i].Repayments = r.Store.repayments(advances[i].ID)
+	}
+	return advances
+}
+
+// outstanding keeps the advances not fully repaid, repayments must be loaded
+func outstanding(advances []entity.CashAdvance) []entity.CashAdvance {
+	kept := make([]entity.CashAdvance, 0, len(advances))
+	for _, advance := range advances {
+		if advance.Outstanding().IsPositive() {
+			kept = append(kept, advance)
+		}
+	}
+	return kept
+}
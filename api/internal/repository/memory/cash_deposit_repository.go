@@ -0,0 +1,34 @@
+package memory
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/money"
+	"api/internal/model"
+	"api/internal/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type cashDepositRepository struct {
+	Store *Store
+}
+
+func NewCashDepositRepository(store *Store) repository.CashDepositRepository {
+	return &cashDepositRepository{
+		Store: store,
+	}
+}
+
+func (r *cashDepositRepository) Create(db *gorm.DB, deposit *entity.CashDeposit) error {
+	r.Store.cashDeposits.insert(deposit)
+	return nil
+}
+
+func (r *cashDepositRepository) SumBySalesOnDate(db *gorm.DB, date time.Time) ([]model.SalesAmount, error) {
+	deposits := r.Store.cashDeposits.find(func(deposit *entity.CashDeposit) bool { return day(deposit.Date).Equal(date) })
+
+	return r.Store.sumBySales(len(deposits), func(i int) (int, money.Money) {
+		return deposits[i].SalesId, deposits[i].Amount
+	}), nil
+}
@@ -0,0 +1,51 @@
+package memory
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/money"
+	"api/internal/model"
+	"api/internal/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type collectionRepository struct {
+	Store *Store
+}
+
+func NewCollectionRepository(store *Store) repository.CollectionRepository {
+	return &collectionRepository{
+		Store: store,
+	}
+}
+
+func (r *collectionRepository) Create(db *gorm.DB, collection *entity.Collection) error {
+	r.Store.collections.insert(collection)
+	return nil
+}
+
+func (r *collectionRepository) FindByCustomerId(db *gorm.DB, customerId int) ([]entity.Collection, error) {
+	collections := r.Store.collections.find(func(collection *entity.Collection) bool { return collection.CustomerId == customerId })
+	orderBy(collections, func(a, b *entity.Collection) int { return compareTime(a.Date, b.Date) })
+	return collections, nil
+}
+
+func (r *collectionRepository) SumBySalesInPeriod(db *gorm.DB, periodId int) ([]model.SalesAmount, error) {
+	collections := r.Store.collections.find(func(collection *entity.Collection) bool { return collection.PeriodId == periodId })
+
+	amounts := r.Store.sumBySales(len(collections), func(i int) (int, money.Money) {
+		return collections[i].SalesId, collections[i].Amount
+	})
+	orderBy(amounts, func(a, b *model.SalesAmount) int { return compareString(a.SalesName, b.SalesName) })
+
+	return amounts, nil
+}
+
+func (r *collectionRepository) SumBySalesOnDate(db *gorm.DB, date time.Time) ([]model.SalesAmount, error) {
+	collections := r.Store.collections.find(func(collection *entity.Collection) bool { return day(collection.Date).Equal(date) })
+
+	return r.Store.sumBySales(len(collections), func(i int) (int, money.Money) {
+		return collections[i].SalesId, collections[i].Amount
+	}), nil
+}
@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/enum"
+	"api/internal/repository"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type crewMemberRepository struct {
+	Store *Store
+}
+
+func NewCrewMemberRepository(store *Store) repository.CrewMemberRepository {
+	return &crewMemberRepository{
+		Store: store,
+	}
+}
+
+func (r *crewMemberRepository) Create(db *gorm.DB, members []*entity.CrewMember) error {
+	for _, member := range members {
+		r.Store.crewMembers.insert(member)
+	}
+	return nil
+}
+
+func (r *crewMemberRepository) FindByCrewId(db *gorm.DB, crewId int) ([]entity.CrewMember, error) {
+	members := r.withEmployee(r.Store.crewMembers.find(func(member *entity.CrewMember) bool { return member.CrewId == crewId }))
+	orderBy(members,
+		func(a, b *entity.CrewMember) int { return compareTime(b.StartDate, a.StartDate) },
+		func(a, b *entity.CrewMember) int { return b.ID - a.ID },
+	)
+	return members, nil
+}
+
+func (r *crewMemberRepository) FindOpen(db *gorm.DB, crewId int) ([]entity.CrewMember, error) {
+	return r.Store.crewMembers.find(func(member *entity.CrewMember) bool {
+		return member.CrewId == crewId && member.EndDate == nil
+	}), nil
+}
+
+func (r *crewMemberRepository) FindActive(db *gorm.DB, crewIds []int, date time.Time) ([]entity.CrewMember, error) {
+	return r.withEmployee(r.Store.crewMembers.find(func(member *entity.CrewMember) bool {
+		return slices.Contains(crewIds, member.CrewId) && assignedOn(member, date)
+	})), nil
+}
+
+func (r *crewMemberRepository) FindOtherCrews(db *gorm.DB, employeeIds []int, crewId int, date time.Time) ([]entity.CrewMember, error) {
+	return r.withEmployee(r.Store.crewMembers.find(func(member *entity.CrewMember) bool {
+		if !slices.Contains(employeeIds, member.EmployeeId) || member.CrewId == crewId {
+			return false
+		}
+		if r.Store.crews.first(func(crew *entity.Crew) bool { return crew.ID == member.CrewId }) == nil {
+			return false
+		}
+		return member.EndDate == nil || !day(*member.EndDate).Before(date)
+	})), nil
+}
+
+func (r *crewMemberRepository) End(db *gorm.DB, ids []int, endDate time.Time) error {
+	r.Store.crewMembers.update(func(member *entity.CrewMember) {
+		member.EndDate = &endDate
+	}, func(member *entity.CrewMember) bool { return slices.Contains(ids, member.ID) })
+	return nil
+}
+
+// Helper fuction
+func (r *crewMemberRepository) withEmployee(members []entity.CrewMember) []entity.CrewMember {
+	for i := range members {
+		members[i].Employee = r.Store.employee(members[i].EmployeeId)
+	}
+	return members
+}
+
+// assignedOn reports whether the assignment runs on the date
+func assignedOn(member *entity.CrewMember, date time.Time) bool {
+	return !day(member.StartDate).After(date) && (member.EndDate == nil || !day(*member.EndDate).Before(date))
+}
+
+func roleOf(member *entity.CrewMember) enum.EmployeeRole {
+	if member.Employee == nil {
+		return ""
+	}
+	return member.Employee.Role
+}
+
+func nameOf(member *entity.CrewMember) string {
+	return employeeName(member.Employee)
+}
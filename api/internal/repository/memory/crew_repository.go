@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"api/internal/entity"
+	"api/internal/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type crewRepository struct {
+	Store *Store
+}
+
+func NewCrewRepository(store *Store) repository.CrewRepository {
+	return &crewRepository{
+		Store: store,
+	}
+}
+
+func (r *crewRepository) FindAll(db *gorm.DB, onlyActive bool, date time.Time) ([]entity.Crew, error) {
+	crews := r.Store.crews.find(func(crew *entity.Crew) bool { return !onlyActive || crew.IsActive })
+	orderBy(crews, func(a, b *entity.Crew) int { return compareString(a.Name, b.Name) })
+
+	for i := range crews {
+		r.load(&crews[i], date)
+	}
+
+	return crews, nil
+}
+
+func (r *crewRepository) FindById(db *gorm.DB, id int, date time.Time) (*entity.Crew, error) {
+	crew := r.Store.crews.first(func(crew *entity.Crew) bool { return crew.ID == id })
+	if crew != nil {
+		r.load(crew, date)
+	}
+	return crew, nil
+}
+
+func (r *crewRepository) Create(db *gorm.DB, crew *entity.Crew) error {
+	r.Store.crews.insert(crew)
+	return nil
+}
+
+func (r *crewRepository) Update(db *gorm.DB, id int, updates any) error {
+	return r.Store.crews.updates(updates, func(crew *entity.Crew) bool { return crew.ID == id })
+}
+
+func (r *crewRepository) Delete(db *gorm.DB, id int) error {
+	r.Store.crews.delete(func(crew *entity.Crew) bool { return crew.ID == id })
+	return nil
+}
+
+func (r *crewRepository) CountByName(db *gorm.DB, name string, excludeId int) (int64, error) {
+	return r.Store.crews.count(func(crew *entity.Crew) bool { return crew.Name == name && crew.ID != excludeId }), nil
+}
+
+// load fills the vehicle and the members assigned on the date with their employee
+func (r *crewRepository) load(crew *entity.Crew, date time.Time) {
+	crew.Vehicle = nil
+	if crew.VehicleId != nil {
+		crew.Vehicle = r.Store.vehicle(*crew.VehicleId)
+	}
+
+	crew.Members = r.Store.crewMembers.find(func(member *entity.CrewMember) bool {
+		return member.CrewId == crew.ID && assignedOn(member, date)
+	})
+	for i := range crew.Members {
+		crew.Members[i].Employee = r.Store.employee(crew.Members[i].EmployeeId)
+	}
+
+	orderBy(crew.Members,
+		func(a, b *entity.CrewMember) int { return compareString(string(roleOf(a)), string(roleOf(b))) },
+		func(a, b *entity.CrewMember) int { return compareString(nameOf(a), nameOf(b)) },
+	)
+}
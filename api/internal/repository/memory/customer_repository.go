@@ -0,0 +1,89 @@
+package memory
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+	"api/internal/repository"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type customerRepository struct {
+	Store *Store
+}
+
+func NewCustomerRepository(store *Store) repository.CustomerRepository {
+	return &customerRepository{
+		Store: store,
+	}
+}
+
+func (r *customerRepository) Create(db *gorm.DB, customer *entity.Customer) error {
+	r.Store.customers.insert(customer)
+	return nil
+}
+
+func (r *customerRepository) Update(db *gorm.DB, id int, updates any) error {
+	return r.Store.customers.updates(updates, byCustomerId(id))
+}
+
+func (r *customerRepository) Delete(db *gorm.DB, id int) error {
+	r.Store.customers.delete(byCustomerId(id))
+	return nil
+}
+
+func (r *customerRepository) FindById(db *gorm.DB, id int) (*entity.Customer, error) {
+	customer := r.Store.customers.first(byCustomerId(id))
+	if customer != nil {
+		customer.Route = r.Store.route(customer.RouteId)
+	}
+	return customer, nil
+}
+
+// FindByIdForUpdate takes no lock, the memory store has no rows to lock
+func (r *customerRepository) FindByIdForUpdate(db *gorm.DB, id int) (*entity.Customer, error) {
+	return r.Store.customers.first(byCustomerId(id)), nil
+}
+
+func (r *customerRepository) FindAll(db *gorm.DB, request *model.FindAllCustomerRequest) ([]entity.Customer, int64, error) {
+	search := strings.ToLower(request.Search)
+	customers := r.Store.customers.find(func(customer *entity.Customer) bool {
+		if search != "" && !contains(customer.Name, search) && !containsPtr(customer.Phone, search) {
+			return false
+		}
+		return request.RouteId <= 0 || customer.RouteId == request.RouteId
+	})
+
+	orderBy(customers, func(a, b *entity.Customer) int { return compareString(a.Name, b.Name) })
+
+	total := int64(len(customers))
+	customers = page(customers, request.Page, request.PerPage)
+	for i := range customers {
+		customers[i].Route = r.Store.route(customers[i].RouteId)
+	}
+
+	return customers, total, nil
+}
+
+func (r *customerRepository) FindBalances(db *gorm.DB, ids []int) ([]model.CustomerBalance, error) {
+	balances := make([]model.CustomerBalance, 0, len(ids))
+
+	for _, customer := range r.Store.customers.find(func(customer *entity.Customer) bool { return slices.Contains(ids, customer.ID) }) {
+		balance := model.CustomerBalance{CustomerId: customer.ID}
+		for _, receivable := range r.Store.receivables.find(func(receivable *entity.Receivable) bool { return receivable.CustomerId == customer.ID }) {
+			balance.TotalReceivable = balance.TotalReceivable.Add(receivable.Amount)
+		}
+		for _, collection := range r.Store.collections.find(func(collection *entity.Collection) bool { return collection.CustomerId == customer.ID }) {
+			balance.TotalCollected = balance.TotalCollected.Add(collection.Amount)
+		}
+		balances = append(balances, balance)
+	}
+
+	return balances, nil
+}
+
+func byCustomerId(id int) func(*entity.Customer) bool {
+	return func(customer *entity.Customer) bool { return customer.ID == id }
+}
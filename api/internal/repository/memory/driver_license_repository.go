@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"api/internal/entity"
+	"api/internal/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type driverLicenseRepository struct {
+	Store *Store
+}
+
+func NewDriverLicenseRepository(store *Store) repository.DriverLicenseRepository {
+	return &driverLicenseRepository{
+		Store: store,
+	}
+}
+
+func (r *driverLicenseRepository) Create(db *gorm.DB, license *entity.DriverLicense) error {
+	r.Store.driverLicenses.insert(license)
+	return nil
+}
+
+func (r *driverLicenseRepository) Delete(db *gorm.DB, id int) error {
+	r.Store.driverLicenses.delete(func(license *entity.DriverLicense) bool { return license.ID == id })
+	return nil
+}
+
+func (r *driverLicenseRepository) FindById(db *gorm.DB, employeeId, id int) (*entity.DriverLicense, error) {
+	return r.Store.driverLicenses.first(func(license *entity.DriverLicense) bool {
+		return license.EmployeeId == employeeId && license.ID == id
+	}), nil
+}
+
+func (r *driverLicenseRepository) FindByEmployeeId(db *gorm.DB, employeeId int) ([]entity.DriverLicense, error) {
+	licenses := r.Store.driverLicenses.find(func(license *entity.DriverLicense) bool { return license.EmployeeId == employeeId })
+	orderBy(licenses, func(a, b *entity.DriverLicense) int { return compareTime(b.ExpiryDate, a.ExpiryDate) })
+	return licenses, nil
+}
+
+// FindExpiring returns the latest licence of each working driver expiring on or before date
+func (r *driverLicenseRepository) FindExpiring(db *gorm.DB, date time.Time) ([]entity.DriverLicense, error) {
+	licenses := r.Store.driverLicenses.find(func(license *entity.DriverLicense) bool {
+		employee := r.Store.employee(license.EmployeeId)
+		return employee != nil && !employee.Status.IsEnded()
+	})
+	orderBy(licenses, func(a, b *entity.DriverLicense) int { return compareTime(b.ExpiryDate, a.ExpiryDate) })
+	licenses = distinctOn(licenses, func(license *entity.DriverLicense) int { return license.EmployeeId })
+
+	expiring := make([]entity.DriverLicense, 0, len(licenses))
+	for _, license := range licenses {
+		if !day(license.ExpiryDate).After(date) {
+			license.Employee = r.Store.employee(license.EmployeeId)
+			expiring = append(expiring, license)
+		}
+	}
+	orderBy(expiring, func(a, b *entity.DriverLicense) int { return compareTime(a.ExpiryDate, b.ExpiryDate) })
+
+	return expiring, nil
+}
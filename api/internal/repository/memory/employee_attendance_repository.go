@@ -0,0 +1,89 @@
+package memory
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/enum"
+	"api/internal/model"
+	"api/internal/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type employeeAttendanceRepository struct {
+	Store *Store
+}
+
+func NewEmployeeAttendanceRepository(store *Store) repository.EmployeeAttendanceRepository {
+	return &employeeAttendanceRepository{
+		Store: store,
+	}
+}
+
+// BatchUpsert follows the (date, employee_id) key, a deleted attendance is
+// restored and the check-in location is kept when the new row has none
+func (r *employeeAttendanceRepository) BatchUpsert(db *gorm.DB, attendances []*entity.EmployeeAttendance) error {
+	for _, attendance := range attendances {
+		r.Store.employeeAttendances.upsert(attendance, func(a, b *entity.EmployeeAttendance) bool {
+			return a.EmployeeId == b.EmployeeId && day(a.Date).Equal(day(b.Date))
+		}, func(stored, row *entity.EmployeeAttendance) {
+			stored.Status = row.Status
+			stored.PeriodId = row.PeriodId
+			if row.CheckInAt != nil {
+				stored.CheckInAt = row.CheckInAt
+			}
+			if row.Latitude != nil {
+				stored.Latitude = row.Latitude
+			}
+			if row.Longitude != nil {
+				stored.Longitude = row.Longitude
+			}
+			if row.LocationAccuracy != nil {
+				stored.LocationAccuracy = row.LocationAccuracy
+			}
+			if row.GeofenceId != nil {
+				stored.GeofenceId = row.GeofenceId
+			}
+		})
+	}
+	return nil
+}
+
+func (r *employeeAttendanceRepository) BatchDeleteByDate(db *gorm.DB, dates []time.Time) error {
+	r.Store.employeeAttendances.delete(func(attendance *entity.EmployeeAttendance) bool {
+		for _, date := range dates {
+			if day(attendance.Date).Equal(day(date)) {
+				return true
+			}
+		}
+		return false
+	})
+	return nil
+}
+
+func (r *employeeAttendanceRepository) CountByPeriod(db *gorm.DB, periodId int, status enum.AttendanceStatus) ([]model.EmployeeCount, error) {
+	var counts []model.EmployeeCount
+	index := make(map[int]int)
+
+	for _, attendance := range r.Store.employeeAttendances.find(func(attendance *entity.EmployeeAttendance) bool {
+		return attendance.PeriodId == periodId && attendance.Status == status
+	}) {
+		i, ok := index[attendance.EmployeeId]
+		if !ok {
+			i = len(counts)
+			index[attendance.EmployeeId] = i
+			counts = append(counts, model.EmployeeCount{EmployeeId: attendance.EmployeeId})
+		}
+		counts[i].Total++
+	}
+
+	return counts, nil
+}
+
+func (r *employeeAttendanceRepository) FindByEmployeeId(db *gorm.DB, employeeId int, startDate, endDate time.Time) ([]entity.EmployeeAttendance, error) {
+	attendances := r.Store.employeeAttendances.find(func(attendance *entity.EmployeeAttendance) bool {
+		return attendance.EmployeeId == employeeId && between(attendance.Date, startDate, endDate)
+	})
+	orderBy(attendances, func(a, b *entity.EmployeeAttendance) int { return compareTime(a.Date, b.Date) })
+	return attendances, nil
+}
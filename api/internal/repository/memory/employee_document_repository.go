@@ -0,0 +1,40 @@
+package memory
+
+import (
+	"api/internal/entity"
+	"api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type employeeDocumentRepository struct {
+	Store *Store
+}
+
+func NewEmployeeDocumentRepository(store *Store) repository.EmployeeDocumentRepository {
+	return &employeeDocumentRepository{
+		Store: store,
+	}
+}
+
+func (r *employeeDocumentRepository) Create(db *gorm.DB, document *entity.EmployeeDocument) error {
+	r.Store.employeeDocuments.insert(document)
+	return nil
+}
+
+func (r *employeeDocumentRepository) FindByEmployeeId(db *gorm.DB, employeeId int) ([]entity.EmployeeDocument, error) {
+	documents := r.Store.employeeDocuments.find(func(document *entity.EmployeeDocument) bool { return document.EmployeeId == employeeId })
+	orderBy(documents, func(a, b *entity.EmployeeDocument) int { return compareTime(b.CreatedAt, a.CreatedAt) })
+	return documents, nil
+}
+
+func (r *employeeDocumentRepository) FindById(db *gorm.DB, employeeId, id int) (*entity.EmployeeDocument, error) {
+	return r.Store.employeeDocuments.first(func(document *entity.EmployeeDocument) bool {
+		return document.EmployeeId == employeeId && document.ID == id
+	}), nil
+}
+
+func (r *employeeDocumentRepository) Delete(db *gorm.DB, id int) error {
+	r.Store.employeeDocuments.delete(func(document *entity.EmployeeDocument) bool { return document.ID == id })
+	return nil
+}
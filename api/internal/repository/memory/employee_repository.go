@@ -0,0 +1,226 @@
+package memory
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/enum"
+	"api/internal/model"
+	"api/internal/repository"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type employeeRepository struct {
+	Store *Store
+}
+
+func NewEmployeeRepository(store *Store) repository.EmployeeRepository {
+	return &employeeRepository{
+		Store: store,
+	}
+}
+
+func (r *employeeRepository) FindAll(db *gorm.DB, request *model.FindAllEmployeeRequest) ([]entity.Employee, int64, error) {
+	var subordinates []int
+	if request.SubordinateOf > 0 {
+		subordinates = r.Store.subordinatesOf(request.SubordinateOf)
+	}
+
+	employees := r.Store.employees.find(func(employee *entity.Employee) bool {
+		if name := strings.ToLower(request.Name); name != "" && !contains(employee.Name, name) {
+			return false
+		}
+		if request.Salary > 0 && employee.Salary != request.Salary {
+			return false
+		}
+		if len(request.Roles) > 0 && !slices.Contains(request.Roles, employee.Role) {
+			return false
+		}
+		if len(request.Statuses) > 0 && !slices.Contains(request.Statuses, employee.Status) {
+			return false
+		}
+		return request.SubordinateOf <= 0 || slices.Contains(subordinates, employee.ID)
+	})
+
+	orderBy(employees, func(a, b *entity.Employee) int { return compareString(a.Name, b.Name) })
+
+	return page(employees, request.Page, request.PerPage), int64(len(employees)), nil
+}
+
+func (r *employeeRepository) Create(db *gorm.DB, employee *entity.Employee) error {
+	r.Store.employees.insert(employee)
+	return nil
+}
+
+func (r *employeeRepository) Update(db *gorm.DB, employee *entity.Employee) error {
+	return r.Store.employees.updates(employee, byEmployeeId(employee.ID))
+}
+
+func (r *employeeRepository) Delete(db *gorm.DB, id int) error {
+	r.Store.employees.delete(byEmployeeId(id))
+	return nil
+}
+
+func (r *employeeRepository) FindById(db *gorm.DB, id int) (*entity.Employee, error) {
+	employee := r.Store.employee(id)
+	if employee != nil {
+		employee.Sales = r.Store.salesOfEmployee(id)
+	}
+	return employee, nil
+}
+
+func (r *employeeRepository) FindByArryId(db *gorm.DB, ids []int) ([]entity.Employee, error) {
+	return r.Store.employees.find(func(employee *entity.Employee) bool { return slices.Contains(ids, employee.ID) }), nil
+}
+
+func (r *employeeRepository) FindByIdWithSubordinates(db *gorm.DB, id int) (*entity.Employee, error) {
+	employee := r.Store.employee(id)
+	if employee != nil {
+		employee.Subordinates = r.Store.employees.find(func(subordinate *entity.Employee) bool {
+			return subordinate.SupervisorId != nil && *subordinate.SupervisorId == id
+		})
+	}
+	return employee, nil
+}
+
+func (r *employeeRepository) FindAllWithAttendances(db *gorm.DB, request *model.FindAllEmployeeWithAttendanceRequest) ([]entity.Employee, error) {
+	start, _ := parseDate(request.StartDate)
+	end, _ := parseDate(request.EndDate)
+
+	var subordinates []int
+	if request.SubordinateOf > 0 {
+		subordinates = r.Store.subordinatesOf(request.SubordinateOf)
+	}
+
+	employees := r.Store.employees.find(func(employee *entity.Employee) bool {
+		if request.SubordinateOf > 0 && !slices.Contains(subordinates, employee.ID) {
+			return false
+		}
+		return r.employedBetween(employee, start, end)
+	})
+
+	for i := range employees {
+		employees[i].EmployeeAttendance = r.Store.employeeAttendances.find(func(attendance *entity.EmployeeAttendance) bool {
+			if attendance.EmployeeId != employees[i].ID {
+				return false
+			}
+			return request.StartDate == "" || request.EndDate == "" || between(attendance.Date, start, end)
+		})
+	}
+
+	return employees, nil
+}
+
+func (r *employeeRepository) FindAllEmployedBetween(db *gorm.DB, start, end time.Time) ([]entity.Employee, error) {
+	employees := r.Store.employees.find(func(employee *entity.Employee) bool { return r.employedBetween(employee, start, end) })
+	orderBy(employees, func(a, b *entity.Employee) int { return compareString(a.Name, b.Name) })
+	return employees, nil
+}
+
+func (r *employeeRepository) UpdateStatus(db *gorm.DB, id int, status enum.EmployeeStatus) error {
+	r.Store.employees.update(func(employee *entity.Employee) { employee.Status = status }, byEmployeeId(id))
+	return nil
+}
+
+func (r *employeeRepository) UpdateSupervisor(db *gorm.DB, id int, supervisorId *int) error {
+	r.Store.employees.update(func(employee *entity.Employee) { employee.SupervisorId = supervisorId }, byEmployeeId(id))
+	return nil
+}
+
+func (r *employeeRepository) HasHistory(db *gorm.DB, id int) (bool, error) {
+	attendances := r.Store.employeeAttendances.count(func(attendance *entity.EmployeeAttendance) bool { return attendance.EmployeeId == id })
+	payrolls := r.Store.payrolls.count(func(payroll *entity.Payroll) bool { return payroll.EmployeeId == id })
+	return attendances > 0 || payrolls > 0, nil
+}
+
+func (r *employeeRepository) CountByNIK(db *gorm.DB, nik string, excludeId int) (int64, error) {
+	return int64(len(r.Store.employees.unscoped(func(employee *entity.Employee) bool {
+		return employee.NIK != nil && *employee.NIK == nik && employee.ID != excludeId
+	}))), nil
+}
+
+// FindTree returns the hierarchy below the root ordered by depth and name
+func (r *employeeRepository) FindTree(db *gorm.DB, rootId int) ([]entity.Employee, error) {
+	var anchors []int
+	for _, employee := range r.Store.employees.find() {
+		switch {
+		case rootId > 0:
+			if employee.ID == rootId {
+				anchors = append(anchors, employee.ID)
+			}
+		case employee.SupervisorId == nil || r.Store.employee(*employee.SupervisorId) == nil:
+			anchors = append(anchors, employee.ID)
+		}
+	}
+
+	depth := make(map[int]int)
+	for _, id := range anchors {
+		depth[id] = 0
+	}
+	for level := anchors; len(level) > 0; {
+		var next []int
+		for _, employee := range r.Store.employees.find() {
+			if _, ok := depth[employee.ID]; ok || employee.SupervisorId == nil || !slices.Contains(level, *employee.SupervisorId) {
+				continue
+			}
+			depth[employee.ID] = depth[*employee.SupervisorId] + 1
+			next = append(next, employee.ID)
+		}
+		level = next
+	}
+
+	employees := r.Store.employees.find(func(employee *entity.Employee) bool {
+		_, ok := depth[employee.ID]
+		return ok
+	})
+	orderBy(employees,
+		func(a, b *entity.Employee) int { return depth[a.ID] - depth[b.ID] },
+		func(a, b *entity.Employee) int { return compareString(a.Name, b.Name) },
+	)
+
+	return employees, nil
+}
+
+func (r *employeeRepository) IsSubordinate(db *gorm.DB, id int, supervisorId int) (bool, error) {
+	return slices.Contains(r.Store.subordinatesOf(supervisorId), id), nil
+}
+
+// employedBetween keeps employees who joined before end and were not resigned
+// or terminated before start, unless they were rehired within the range
+func (r *employeeRepository) employedBetween(employee *entity.Employee, start, end time.Time) bool {
+	if employee.JoinDate.After(end) {
+		return false
+	}
+
+	histories := r.Store.employeeStatusHistory.find(func(history *entity.EmployeeStatusHistory) bool {
+		return history.EmployeeId == employee.ID
+	})
+	orderBy(histories,
+		func(a, b *entity.EmployeeStatusHistory) int { return compareTime(b.EffectiveDate, a.EffectiveDate) },
+		func(a, b *entity.EmployeeStatusHistory) int { return b.ID - a.ID },
+	)
+
+	status := enum.EMPLOYEE_ACTIVE
+	for _, history := range histories {
+		if day(history.EffectiveDate).Before(start) {
+			status = history.Status
+			break
+		}
+	}
+	if !status.IsEnded() {
+		return true
+	}
+
+	for _, history := range histories {
+		if history.Status == enum.EMPLOYEE_ACTIVE && between(history.EffectiveDate, start, end) {
+			return true
+		}
+	}
+	return false
+}
+
+func byEmployeeId(id int) func(*entity.Employee) bool {
+	return func(employee *entity.Employee) bool { return employee.ID == id }
+}
@@ -0,0 +1,41 @@
+package memory
+
+import (
+	"api/internal/entity"
+	"api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type employeeStatusHistoryRepository struct {
+	Store *Store
+}
+
+func NewEmployeeStatusHistoryRepository(store *Store) repository.EmployeeStatusHistoryRepository {
+	return &employeeStatusHistoryRepository{
+		Store: store,
+	}
+}
+
+func (r *employeeStatusHistoryRepository) Create(db *gorm.DB, history *entity.EmployeeStatusHistory) error {
+	r.Store.employeeStatusHistory.insert(history)
+	return nil
+}
+
+func (r *employeeStatusHistoryRepository) FindByEmployeeId(db *gorm.DB, employeeId int) ([]entity.EmployeeStatusHistory, error) {
+	histories := r.Store.employeeStatusHistory.find(func(history *entity.EmployeeStatusHistory) bool { return history.EmployeeId == employeeId })
+	orderBy(histories,
+		func(a, b *entity.EmployeeStatusHistory) int { return compareTime(a.EffectiveDate, b.EffectiveDate) },
+		func(a, b *entity.EmployeeStatusHistory) int { return a.ID - b.ID },
+	)
+	return histories, nil
+}
+
+func (r *employeeStatusHistoryRepository) FindLatest(db *gorm.DB, employeeId int) (*entity.EmployeeStatusHistory, error) {
+	histories := r.Store.employeeStatusHistory.find(func(history *entity.EmployeeStatusHistory) bool { return history.EmployeeId == employeeId })
+	orderBy(histories,
+		func(a, b *entity.EmployeeStatusHistory) int { return compareTime(b.EffectiveDate, a.EffectiveDate) },
+		func(a, b *entity.EmployeeStatusHistory) int { return b.ID - a.ID },
+	)
+	return firstOf(histories), nil
+}
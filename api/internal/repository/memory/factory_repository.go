@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+	"api/internal/repository"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type factoryRepository struct {
+	Store *Store
+}
+
+func NewFactoryRepository(store *Store) repository.FactoryRepository {
+	return &factoryRepository{
+		Store: store,
+	}
+}
+
+func (r *factoryRepository) Create(db *gorm.DB, factory *entity.Factory) error {
+	r.Store.factories.insert(factory)
+	return nil
+}
+
+func (r *factoryRepository) Update(db *gorm.DB, id int64, updates any) error {
+	return r.Store.factories.updates(updates, byFactoryId(id))
+}
+
+func (r *factoryRepository) FindAll(db *gorm.DB, request *model.FindAllFactoryRequest) ([]entity.Factory, int64, error) {
+	search := strings.ToLower(request.Search)
+	factories := r.Store.factories.find(func(factory *entity.Factory) bool {
+		return search == "" || contains(factory.Name, search) || contains(factory.Phone, search)
+	})
+
+	orderBy(factories, func(a, b *entity.Factory) int { return compareString(b.Name, a.Name) })
+
+	return page(factories, request.Page, request.PerPage), int64(len(factories)), nil
+}
+
+func (r *factoryRepository) Delete(db *gorm.DB, id int64) error {
+	r.Store.factories.delete(byFactoryId(id))
+	return nil
+}
+
+func (r *factoryRepository) FindById(db *gorm.DB, id int64) (*entity.Factory, error) {
+	return r.Store.factories.first(byFactoryId(id)), nil
+}
+
+func (r *factoryRepository) CountByPhone(db *gorm.DB, phone string) (int64, error) {
+	return r.Store.factories.count(func(factory *entity.Factory) bool { return factory.Phone == phone }), nil
+}
+
+func byFactoryId(id int64) func(*entity.Factory) bool {
+	return func(factory *entity.Factory) bool { return factory.ID == id }
+}
@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+	"api/internal/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type fuelLogRepository struct {
+	Store *Store
+}
+
+func NewFuelLogRepository(store *Store) repository.FuelLogRepository {
+	return &fuelLogRepository{
+		Store: store,
+	}
+}
+
+func (r *fuelLogRepository) Create(db *gorm.DB, log *entity.FuelLog) error {
+	r.Store.fuelLogs.insert(log)
+	return nil
+}
+
+func (r *fuelLogRepository) Delete(db *gorm.DB, id int) error {
+	r.Store.fuelLogs.delete(func(log *entity.FuelLog) bool { return log.ID == id })
+	return nil
+}
+
+func (r *fuelLogRepository) FindById(db *gorm.DB, vehicleId int64, id int) (*entity.FuelLog, error) {
+	return r.Store.fuelLogs.first(func(log *entity.FuelLog) bool { return log.VehicleId == vehicleId && log.ID == id }), nil
+}
+
+// FindUntil returns the fill-ups up to date ordered by vehicle and odometer,
+// vehicleId 0 means every vehicle
+func (r *fuelLogRepository) FindUntil(db *gorm.DB, vehicleId int64, date time.Time) ([]entity.FuelLog, error) {
+	logs := r.Store.fuelLogs.find(func(log *entity.FuelLog) bool {
+		return !day(log.Date).After(date) && (vehicleId <= 0 || log.VehicleId == vehicleId)
+	})
+
+	orderBy(logs,
+		func(a, b *entity.FuelLog) int { return int(a.VehicleId - b.VehicleId) },
+		func(a, b *entity.FuelLog) int { return a.Odometer - b.Odometer },
+	)
+
+	for i := range logs {
+		logs[i].Vehicle = r.Store.vehicle(logs[i].VehicleId)
+		if logs[i].DriverId != nil {
+			logs[i].Driver = r.Store.employee(*logs[i].DriverId)
+		}
+	}
+
+	return logs, nil
+}
+
+// FindOdometerBounds returns the highest odometer on or before date and the
+// lowest odometer after date, 0 when there is none
+func (r *fuelLogRepository) FindOdometerBounds(db *gorm.DB, vehicleId int64, date time.Time) (int, int, error) {
+	var before, after int
+	for _, log := range r.Store.fuelLogs.find(func(log *entity.FuelLog) bool { return log.VehicleId == vehicleId }) {
+		if !day(log.Date).After(date) {
+			before = max(before, log.Odometer)
+		} else if after == 0 || log.Odometer < after {
+			after = log.Odometer
+		}
+	}
+	return before, after, nil
+}
+
+// FindOdometers returns the highest recorded odometer of each vehicle
+func (r *fuelLogRepository) FindOdometers(db *gorm.DB, vehicleIds []int64) ([]model.VehicleOdometer, error) {
+	logs := r.Store.fuelLogs.find()
+	return maxOdometers(vehicleIds, len(logs), func(i int) (int64, int) { return logs[i].VehicleId, logs[i].Odometer }), nil
+}
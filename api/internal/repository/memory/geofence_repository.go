@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+	"api/internal/repository"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type geofenceRepository struct {
+	Store *Store
+}
+
+func NewGeofenceRepository(store *Store) repository.GeofenceRepository {
+	return &geofenceRepository{
+		Store: store,
+	}
+}
+
+func (r *geofenceRepository) Create(db *gorm.DB, geofence *entity.Geofence) error {
+	r.Store.geofences.insert(geofence)
+	return nil
+}
+
+func (r *geofenceRepository) Update(db *gorm.DB, id int, updates any) error {
+	return r.Store.geofences.updates(updates, byGeofenceId(id))
+}
+
+func (r *geofenceRepository) Delete(db *gorm.DB, id int) error {
+	r.Store.geofences.delete(byGeofenceId(id))
+	return nil
+}
+
+func (r *geofenceRepository) FindById(db *gorm.DB, id int) (*entity.Geofence, error) {
+	return r.Store.geofences.first(byGeofenceId(id)), nil
+}
+
+func (r *geofenceRepository) FindAll(db *gorm.DB, request *model.FindAllGeofenceRequest) ([]entity.Geofence, int64, error) {
+	search := strings.ToLower(request.Search)
+	geofences := r.Store.geofences.find(func(geofence *entity.Geofence) bool {
+		return search == "" || contains(geofence.Name, search)
+	})
+
+	orderBy(geofences, func(a, b *entity.Geofence) int { return compareString(a.Name, b.Name) })
+
+	return page(geofences, request.Page, request.PerPage), int64(len(geofences)), nil
+}
+
+func (r *geofenceRepository) FindAllActive(db *gorm.DB) ([]entity.Geofence, error) {
+	return r.Store.geofences.find(func(geofence *entity.Geofence) bool { return geofence.IsActive }), nil
+}
+
+func byGeofenceId(id int) func(*entity.Geofence) bool {
+	return func(geofence *entity.Geofence) bool { return geofence.ID == id }
+}
@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"api/internal/entity"
+	"api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type maintenancePlanRepository struct {
+	Store *Store
+}
+
+func NewMaintenancePlanRepository(store *Store) repository.MaintenancePlanRepository {
+	return &maintenancePlanRepository{
+		Store: store,
+	}
+}
+
+func (r *maintenancePlanRepository) Create(db *gorm.DB, plan *entity.MaintenancePlan) error {
+	r.Store.maintenancePlans.insert(plan)
+	return nil
+}
+
+func (r *maintenancePlanRepository) Update(db *gorm.DB, id int, updates any) error {
+	return r.Store.maintenancePlans.updates(updates, byMaintenancePlanId(id))
+}
+
+func (r *maintenancePlanRepository) Delete(db *gorm.DB, id int) error {
+	r.Store.maintenancePlans.delete(byMaintenancePlanId(id))
+	return nil
+}
+
+func (r *maintenancePlanRepository) FindById(db *gorm.DB, vehicleId int64, id int) (*entity.MaintenancePlan, error) {
+	return r.Store.maintenancePlans.first(func(plan *entity.MaintenancePlan) bool {
+		return plan.VehicleId == vehicleId && plan.ID == id
+	}), nil
+}
+
+func (r *maintenancePlanRepository) FindByVehicleId(db *gorm.DB, vehicleId int64) ([]entity.MaintenancePlan, error) {
+	plans := r.Store.maintenancePlans.find(func(plan *entity.MaintenancePlan) bool { return plan.VehicleId == vehicleId })
+	orderBy(plans, func(a, b *entity.MaintenancePlan) int { return compareString(a.ServiceType, b.ServiceType) })
+	return plans, nil
+}
+
+// FindAllActive returns the active plans with their vehicle, vehicleId 0 means every vehicle
+func (r *maintenancePlanRepository) FindAllActive(db *gorm.DB, vehicleId int64) ([]entity.MaintenancePlan, error) {
+	plans := r.Store.maintenancePlans.find(func(plan *entity.MaintenancePlan) bool {
+		return plan.IsActive && (vehicleId <= 0 || plan.VehicleId == vehicleId)
+	})
+
+	orderBy(plans,
+		func(a, b *entity.MaintenancePlan) int { return int(a.VehicleId - b.VehicleId) },
+		func(a, b *entity.MaintenancePlan) int { return compareString(a.ServiceType, b.ServiceType) },
+	)
+
+	for i := range plans {
+		plans[i].Vehicle = r.Store.vehicle(plans[i].VehicleId)
+	}
+
+	return plans, nil
+}
+
+func byMaintenancePlanId(id int) func(*entity.MaintenancePlan) bool {
+	return func(plan *entity.MaintenancePlan) bool { return plan.ID == id }
+}
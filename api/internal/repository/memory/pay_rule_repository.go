@@ -0,0 +1,35 @@
+package memory
+
+import (
+	"api/internal/entity"
+	"api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type payRuleRepository struct {
+	Store *Store
+}
+
+func NewPayRuleRepository(store *Store) repository.PayRuleRepository {
+	return &payRuleRepository{
+		Store: store,
+	}
+}
+
+func (r *payRuleRepository) FindAll(db *gorm.DB) ([]entity.PayRule, error) {
+	rules := r.Store.payRules.find()
+	orderBy(rules, func(a, b *entity.PayRule) int { return compareString(string(a.Role), string(b.Role)) })
+	return rules, nil
+}
+
+func (r *payRuleRepository) Upsert(db *gorm.DB, rule *entity.PayRule) error {
+	r.Store.payRules.upsert(rule, func(a, b *entity.PayRule) bool {
+		return a.Role == b.Role
+	}, func(stored, row *entity.PayRule) {
+		stored.DailyRate = row.DailyRate
+		stored.TripRate = row.TripRate
+		stored.SackRate = row.SackRate
+	})
+	return nil
+}
@@ -0,0 +1,119 @@
+package memory
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+	"api/internal/repository"
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+type payrollRepository struct {
+	Store *Store
+}
+
+func NewPayrollRepository(store *Store) repository.PayrollRepository {
+	return &payrollRepository{
+		Store: store,
+	}
+}
+
+func (r *payrollRepository) FindAll(db *gorm.DB, request *model.FindAllPayrollRequest) ([]entity.Payroll, error) {
+	payrolls := r.Store.payrolls.find(func(payroll *entity.Payroll) bool { return payroll.PeriodID == request.PeriodId })
+	for i := range payrolls {
+		payrolls[i].Employee = r.Store.employee(payrolls[i].EmployeeId)
+		payrolls[i].Items = r.Store.itemsOf(payrolls[i].ID)
+	}
+
+	orderBy(payrolls, func(a, b *entity.Payroll) int {
+		return compareString(employeeName(a.Employee), employeeName(b.Employee))
+	})
+
+	return payrolls, nil
+}
+
+func (r *payrollRepository) FindByPeriodId(db *gorm.DB, periodId int) ([]entity.Payroll, error) {
+	return r.Store.payrolls.find(func(payroll *entity.Payroll) bool { return payroll.PeriodID == periodId }), nil
+}
+
+func (r *payrollRepository) FindById(db *gorm.DB, id int) (*entity.Payroll, error) {
+	payroll := r.Store.payrolls.first(func(payroll *entity.Payroll) bool { return payroll.ID == id })
+	if payroll != nil {
+		payroll.Employee = r.Store.employee(payroll.EmployeeId)
+		payroll.Items = r.Store.itemsOf(payroll.ID)
+	}
+	return payroll, nil
+}
+
+func (r *payrollRepository) FindByEmployeeId(db *gorm.DB, request *model.FindPayrollByEmployeeRequest) ([]entity.Payroll, error) {
+	payrolls := r.Store.payrolls.find(func(payroll *entity.Payroll) bool {
+		return payroll.EmployeeId == request.EmployeeId && (request.PeriodId <= 0 || payroll.PeriodID == request.PeriodId)
+	})
+	for i := range payrolls {
+		payrolls[i].Items = r.Store.itemsOf(payrolls[i].ID)
+	}
+
+	orderBy(payrolls, func(a, b *entity.Payroll) int { return b.PeriodID - a.PeriodID })
+
+	return payrolls, nil
+}
+
+func (r *payrollRepository) FindByEmployeeAndPeriod(db *gorm.DB, employeeId, periodId int) (*entity.Payroll, error) {
+	payroll := r.Store.payrolls.first(func(payroll *entity.Payroll) bool {
+		return payroll.EmployeeId == employeeId && payroll.PeriodID == periodId
+	})
+	if payroll != nil {
+		payroll.Employee = r.Store.deletedEmployee(payroll.EmployeeId)
+		payroll.Period = r.Store.period(payroll.PeriodID)
+		payroll.Items = r.Store.itemsOf(payroll.ID)
+	}
+	return payroll, nil
+}
+
+func (r *payrollRepository) UpdatePayment(db *gorm.DB, ids []int, updates any) error {
+	return r.Store.payrolls.updates(updates, func(payroll *entity.Payroll) bool {
+		return slices.Contains(ids, payroll.ID) && !payroll.IsPaid
+	})
+}
+
+// Upsert follows the (employee_id, period_id) key, the items are left out
+func (r *payrollRepository) Upsert(db *gorm.DB, payroll *entity.Payroll) error {
+	items := payroll.Items
+	payroll.Items = nil
+
+	r.Store.payrolls.upsert(payroll, func(a, b *entity.Payroll) bool {
+		return a.EmployeeId == b.EmployeeId && a.PeriodID == b.PeriodID
+	}, func(stored, row *entity.Payroll) {
+		stored.BaseSalary = row.BaseSalary
+		stored.AttendanceDays = row.AttendanceDays
+		stored.Bonuses = row.Bonuses
+		stored.Deductions = row.Deductions
+		stored.ModuleType = row.ModuleType
+	})
+
+	payroll.Items = items
+	return nil
+}
+
+func (r *payrollRepository) ReplaceItems(db *gorm.DB, payrollId int, items []entity.PayrollItem) error {
+	r.Store.payrollItems.delete(func(item *entity.PayrollItem) bool { return item.PayrollId == payrollId })
+
+	for i := range items {
+		items[i].PayrollId = payrollId
+		r.Store.payrollItems.insert(&items[i])
+	}
+	return nil
+}
+
+func (r *payrollRepository) Delete(db *gorm.DB, id int) error {
+	r.Store.payrolls.delete(func(payroll *entity.Payroll) bool { return payroll.ID == id })
+	return nil
+}
+
+func employeeName(employee *entity.Employee) string {
+	if employee == nil {
+		return ""
+	}
+	return employee.Name
+}
@@ -0,0 +1,37 @@
+package memory
+
+import (
+	"api/internal/entity"
+	"api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type periodClosureRepository struct {
+	Store *Store
+}
+
+func NewPeriodClosureRepository(store *Store) repository.PeriodClosureRepository {
+	return &periodClosureRepository{
+		Store: store,
+	}
+}
+
+func (r *periodClosureRepository) FindByPeriodAndModule(db *gorm.DB, periodId int, moduleName string) (*entity.PeriodClosure, error) {
+	return r.Store.periodClosures.first(func(closure *entity.PeriodClosure) bool {
+		return closure.PeriodID == periodId && closure.ModuleName == moduleName
+	}), nil
+}
+
+func (r *periodClosureRepository) IncrementPrintCount(db *gorm.DB, id int) error {
+	r.Store.periodClosures.update(func(closure *entity.PeriodClosure) { closure.PrintCount++ }, func(closure *entity.PeriodClosure) bool {
+		return closure.ID == id
+	})
+	return nil
+}
+
+func (r *periodClosureRepository) FindByPeriod(db *gorm.DB, periodId int) ([]entity.PeriodClosure, error) {
+	closures := r.Store.periodClosures.find(func(closure *entity.PeriodClosure) bool { return closure.PeriodID == periodId })
+	orderBy(closures, func(a, b *entity.PeriodClosure) int { return compareString(a.ModuleName, b.ModuleName) })
+	return closures, nil
+}
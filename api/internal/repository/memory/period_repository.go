@@ -0,0 +1,161 @@
+package memory
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/enum"
+	"api/internal/model"
+	"api/internal/repository"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type periodRepository struct {
+	Store *Store
+}
+
+func NewPeriodRepository(store *Store) repository.PeriodRepository {
+	return &periodRepository{
+		Store: store,
+	}
+}
+
+func (r *periodRepository) FindByDate(db *gorm.DB, periodTypes []enum.PeriodType, date time.Time) (*entity.Period, error) {
+	periods := r.Store.periods.find(func(period *entity.Period) bool {
+		return slices.Contains(periodTypes, period.Type) && !period.StartDate.After(date) && !period.EndDate.Before(date) && period.IsActive
+	})
+	orderBy(periods, func(a, b *entity.Period) int { return compareTime(b.StartDate, a.StartDate) })
+	return firstOf(periods), nil
+}
+
+func (r *periodRepository) FindLastClosedMonthly(db *gorm.DB, month, year int) (*entity.Period, error) {
+	periods := r.Store.periods.find(func(period *entity.Period) bool {
+		return period.Type == enum.MONTHLY && period.Month == month && period.Year == year && period.IsClosed
+	})
+	orderBy(periods, func(a, b *entity.Period) int { return b.WeekNumber - a.WeekNumber })
+	return firstOf(periods), nil
+}
+
+func (r *periodRepository) FindLastBefore(db *gorm.DB, periodTypes []enum.PeriodType, date time.Time) (*entity.Period, error) {
+	periods := r.Store.periods.find(func(period *entity.Period) bool {
+		return slices.Contains(periodTypes, period.Type) && period.StartDate.Before(date) && period.IsActive
+	})
+	orderBy(periods, func(a, b *entity.Period) int { return compareTime(b.StartDate, a.StartDate) })
+	return firstOf(periods), nil
+}
+
+func (r *periodRepository) Create(db *gorm.DB, period *entity.Period) (*entity.Period, error) {
+	r.Store.periods.insert(period)
+	return period, nil
+}
+
+// FirstOrCreate follows the (type, start_date) key, a deleted or deactivated
+// period is returned as it is
+func (r *periodRepository) FirstOrCreate(db *gorm.DB, period *entity.Period) (*entity.Period, error) {
+	existing := r.Store.periods.unscoped(func(stored *entity.Period) bool {
+		return stored.Type == period.Type && stored.StartDate.Equal(period.StartDate)
+	})
+	if len(existing) > 0 {
+		period.ID = existing[0].ID
+		return &existing[0], nil
+	}
+
+	stored := *period
+	r.Store.periods.insert(&stored)
+	period.ID = stored.ID
+	return &stored, nil
+}
+
+func (r *periodRepository) FindByStartDate(db *gorm.DB, periodType enum.PeriodType, startDate time.Time) (*entity.Period, error) {
+	period := r.Store.periods.first(func(period *entity.Period) bool {
+		return period.Type == periodType && period.StartDate.Equal(startDate)
+	})
+	if period == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return period, nil
+}
+
+func (r *periodRepository) FindById(db *gorm.DB, id int) (*entity.Period, error) {
+	return r.Store.period(id), nil
+}
+
+func (r *periodRepository) FindAll(db *gorm.DB, request *model.FindAllPeriodRequest) ([]entity.Period, int64, error) {
+	periods := r.Store.periods.find(func(period *entity.Period) bool {
+		if request.Year > 0 && period.Year != request.Year {
+			return false
+		}
+		if request.Month > 0 && period.Month != request.Month {
+			return false
+		}
+		if request.Type != "" && string(period.Type) != request.Type {
+			return false
+		}
+		if (request.Status == "open" && period.IsClosed) || (request.Status == "closed" && !period.IsClosed) {
+			return false
+		}
+		return request.IncludeInactive || period.IsActive
+	})
+
+	orderBy(periods,
+		func(a, b *entity.Period) int { return compareTime(b.StartDate, a.StartDate) },
+		func(a, b *entity.Period) int { return b.ID - a.ID },
+	)
+
+	return page(periods, request.Page, request.PerPage), int64(len(periods)), nil
+}
+
+func (r *periodRepository) SummarizeAttendance(db *gorm.DB, id int) (*model.PeriodAttendanceSummary, error) {
+	summary := new(model.PeriodAttendanceSummary)
+	employees := make(map[int]bool)
+
+	for _, attendance := range r.Store.employeeAttendances.find(func(attendance *entity.EmployeeAttendance) bool { return attendance.PeriodId == id }) {
+		employees[attendance.EmployeeId] = true
+		switch attendance.Status {
+		case enum.PRESENT:
+			summary.Present++
+		case enum.LEAVE:
+			summary.Leave++
+		case enum.SICK:
+			summary.Sick++
+		case enum.ABSENT:
+			summary.Absent++
+		}
+	}
+	summary.Employees = int64(len(employees))
+
+	return summary, nil
+}
+
+func (r *periodRepository) SummarizePayroll(db *gorm.DB, id int) (*model.PeriodPayrollSummary, error) {
+	summary := new(model.PeriodPayrollSummary)
+
+	for _, payroll := range r.Store.payrolls.find(func(payroll *entity.Payroll) bool { return payroll.PeriodID == id }) {
+		summary.Total++
+		if payroll.IsPaid {
+			summary.Paid++
+		} else {
+			summary.Unpaid++
+		}
+	}
+
+	return summary, nil
+}
+
+func (r *periodRepository) CountCollections(db *gorm.DB, id int) (int64, error) {
+	return r.Store.collections.count(func(collection *entity.Collection) bool { return collection.PeriodId == id }), nil
+}
+
+func (r *periodRepository) HasData(db *gorm.DB, id int) (bool, error) {
+	total := r.Store.employeeAttendances.count(func(attendance *entity.EmployeeAttendance) bool { return attendance.PeriodId == id }) +
+		r.Store.payrolls.count(func(payroll *entity.Payroll) bool { return payroll.PeriodID == id }) +
+		r.Store.collections.count(func(collection *entity.Collection) bool { return collection.PeriodId == id }) +
+		r.Store.periodClosures.count(func(closure *entity.PeriodClosure) bool { return closure.PeriodID == id })
+	return total > 0, nil
+}
+
+func (r *periodRepository) Deactivate(db *gorm.DB, id int) error {
+	r.Store.periods.update(func(period *entity.Period) { period.IsActive = false }, func(period *entity.Period) bool { return period.ID == id })
+	return nil
+}
@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"cmp"
+	"strings"
+	"time"
+)
+
+// contains is ILIKE '%search%', search must already be lower case
+func contains(value, search string) bool {
+	return strings.Contains(strings.ToLower(value), search)
+}
+
+func containsPtr(value *string, search string) bool {
+	return value != nil && contains(*value, search)
+}
+
+// day is the value of a DATE column, postgres drops the time of day
+func day(value time.Time) time.Time {
+	return time.Date(value.Year(), value.Month(), value.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// between is the inclusive BETWEEN of a DATE column
+func between(value, start, end time.Time) bool {
+	value = day(value)
+	return !value.Before(start) && !value.After(end)
+}
+
+// parseDate reads the YYYY-MM-DD filters of the list requests, an empty or
+// invalid value is no filter
+func parseDate(value string) (time.Time, bool) {
+	date, err := time.Parse("2006-01-02", value)
+	return date, err == nil
+}
+
+func compareTime(a, b time.Time) int {
+	return a.Compare(b)
+}
+
+func compareString(a, b string) int {
+	return cmp.Compare(a, b)
+}
+
+// firstOf is First after an Order, nil when nothing matched
+func firstOf[T any](rows []T) *T {
+	if len(rows) == 0 {
+		return nil
+	}
+	return &rows[0]
+}
+
+// distinctOn is DISTINCT ON the key, the rows must already be ordered so the
+// wanted row of each key comes first
+func distinctOn[T any, K comparable](rows []T, key func(*T) K) []T {
+	seen := make(map[K]bool)
+	kept := make([]T, 0, len(rows))
+	for i := range rows {
+		if k := key(&rows[i]); !seen[k] {
+			seen[k] = true
+			kept = append(kept, rows[i])
+		}
+	}
+	return kept
+}
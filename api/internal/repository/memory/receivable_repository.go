@@ -0,0 +1,29 @@
+package memory
+
+import (
+	"api/internal/entity"
+	"api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type receivableRepository struct {
+	Store *Store
+}
+
+func NewReceivableRepository(store *Store) repository.ReceivableRepository {
+	return &receivableRepository{
+		Store: store,
+	}
+}
+
+func (r *receivableRepository) Create(db *gorm.DB, receivable *entity.Receivable) error {
+	r.Store.receivables.insert(receivable)
+	return nil
+}
+
+func (r *receivableRepository) FindByCustomerId(db *gorm.DB, customerId int) ([]entity.Receivable, error) {
+	receivables := r.Store.receivables.find(func(receivable *entity.Receivable) bool { return receivable.CustomerId == customerId })
+	orderBy(receivables, func(a, b *entity.Receivable) int { return compareTime(a.Date, b.Date) })
+	return receivables, nil
+}
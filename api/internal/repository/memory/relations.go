@@ -0,0 +1,169 @@
+package memory
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/money"
+	"api/internal/model"
+	"slices"
+)
+
+// The helpers below load relations the way the gorm repositories preload
+// them, a missing or deleted row leaves the relation empty.
+
+func (s *Store) employee(id int) *entity.Employee {
+	return s.employees.first(func(employee *entity.Employee) bool { return employee.ID == id })
+}
+
+// deletedEmployee also finds a deleted employee, for relations preloaded unscoped
+func (s *Store) deletedEmployee(id int) *entity.Employee {
+	return firstOf(s.employees.unscoped(func(employee *entity.Employee) bool { return employee.ID == id }))
+}
+
+func (s *Store) vehicle(id int64) *entity.Vehicle {
+	return s.vehicles.first(func(vehicle *entity.Vehicle) bool { return vehicle.ID == id })
+}
+
+func (s *Store) period(id int) *entity.Period {
+	return s.periods.first(func(period *entity.Period) bool { return period.ID == id })
+}
+
+func (s *Store) route(id int) *entity.Route {
+	return s.routes.first(func(route *entity.Route) bool { return route.ID == id })
+}
+
+// stops returns the stops of the route by sequence
+func (s *Store) stops(routeId int) []entity.RouteStop {
+	stops := s.routeStops.find(func(stop *entity.RouteStop) bool { return stop.RouteId == routeId })
+	orderBy(stops, func(a, b *entity.RouteStop) int { return a.Sequence - b.Sequence })
+	return stops
+}
+
+// routesOf reloads many2many routes, only their ids are kept on the owner row
+func (s *Store) routesOf(routes []entity.Route, withStops bool) []entity.Route {
+	loaded := make([]entity.Route, 0, len(routes))
+	for _, route := range routes {
+		if stored := s.route(route.ID); stored != nil {
+			if withStops {
+				stored.Stops = s.stops(stored.ID)
+			}
+			loaded = append(loaded, *stored)
+		}
+	}
+	return loaded
+}
+
+// employeesOf reloads many2many employees, only their ids are kept on the owner row
+func (s *Store) employeesOf(employees []entity.Employee) []entity.Employee {
+	loaded := make([]entity.Employee, 0, len(employees))
+	for _, employee := range employees {
+		if stored := s.employee(employee.ID); stored != nil {
+			loaded = append(loaded, *stored)
+		}
+	}
+	return loaded
+}
+
+func (s *Store) salesOfEmployee(employeeId int) *entity.Sales {
+	sales := s.sales.first(func(sales *entity.Sales) bool { return sales.EmployeeId == employeeId })
+	if sales != nil {
+		sales.Routes = s.routesOf(sales.Routes, false)
+	}
+	return sales
+}
+
+func (s *Store) repayments(cashAdvanceId int) []entity.CashAdvanceRepayment {
+	return s.cashAdvanceRepayments.find(func(repayment *entity.CashAdvanceRepayment) bool {
+		return repayment.CashAdvanceId == cashAdvanceId
+	})
+}
+
+func (s *Store) itemsOf(payrollId int) []entity.PayrollItem {
+	return s.payrollItems.find(func(item *entity.PayrollItem) bool { return item.PayrollId == payrollId })
+}
+
+// subordinates walks down the supervisor chain from the anchors and returns the
+// ids by depth, the visited set guards against cycles like the recursive query
+func (s *Store) subordinates(anchors []int) []int {
+	employees := s.employees.find()
+
+	tree := append([]int(nil), anchors...)
+	for level := anchors; len(level) > 0; {
+		var next []int
+		for _, employee := range employees {
+			if employee.SupervisorId != nil && slices.Contains(level, *employee.SupervisorId) && !slices.Contains(tree, employee.ID) {
+				next = append(next, employee.ID)
+			}
+		}
+		tree = append(tree, next...)
+		level = next
+	}
+
+	return tree
+}
+
+// subordinatesOf returns the direct and indirect subordinates of the supervisor
+func (s *Store) subordinatesOf(supervisorId int) []int {
+	var anchors []int
+	for _, employee := range s.employees.find() {
+		if employee.SupervisorId != nil && *employee.SupervisorId == supervisorId {
+			anchors = append(anchors, employee.ID)
+		}
+	}
+	return s.subordinates(anchors)
+}
+
+// sumBySales groups the n amounts by sales like the SUM/COUNT joins on
+// sales and employees, the joins do not skip deleted rows
+func (s *Store) sumBySales(n int, amountOf func(i int) (int, money.Money)) []model.SalesAmount {
+	amounts := make([]model.SalesAmount, 0)
+	index := make(map[int]int)
+
+	for i := 0; i < n; i++ {
+		salesId, amount := amountOf(i)
+		sales := firstOf(s.sales.unscoped(func(sales *entity.Sales) bool { return sales.ID == salesId }))
+		if sales == nil {
+			continue
+		}
+		employee := firstOf(s.employees.unscoped(func(employee *entity.Employee) bool { return employee.ID == sales.EmployeeId }))
+		if employee == nil {
+			continue
+		}
+
+		at, ok := index[salesId]
+		if !ok {
+			at = len(amounts)
+			index[salesId] = at
+			amounts = append(amounts, model.SalesAmount{SalesId: salesId, SalesName: employee.Name})
+		}
+		amounts[at].Total = amounts[at].Total.Add(amount)
+		amounts[at].Count++
+	}
+
+	return amounts
+}
+
+// maxOdometers is the MAX(odometer) GROUP BY vehicle_id of the n readings
+func maxOdometers(vehicleIds []int64, n int, readingOf func(i int) (int64, int)) []model.VehicleOdometer {
+	odometers := make([]model.VehicleOdometer, 0)
+	if len(vehicleIds) == 0 {
+		return odometers
+	}
+
+	index := make(map[int64]int)
+	for i := 0; i < n; i++ {
+		vehicleId, odometer := readingOf(i)
+		if !slices.Contains(vehicleIds, vehicleId) {
+			continue
+		}
+
+		at, ok := index[vehicleId]
+		if !ok {
+			index[vehicleId] = len(odometers)
+			odometers = append(odometers, model.VehicleOdometer{VehicleId: vehicleId, Odometer: odometer})
+			continue
+		}
+		odometers[at].Odometer = max(odometers[at].Odometer, odometer)
+	}
+
+	return odometers
+}
@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+	"api/internal/repository"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type routeRepository struct {
+	Store *Store
+}
+
+func NewRouteRepository(store *Store) repository.RouteRepository {
+	return &routeRepository{
+		Store: store,
+	}
+}
+
+func (r *routeRepository) FindByArryId(db *gorm.DB, ids []int) ([]entity.Route, error) {
+	return r.Store.routes.find(func(route *entity.Route) bool { return slices.Contains(ids, route.ID) }), nil
+}
+
+func (r *routeRepository) FindAll(db *gorm.DB, request *model.FindAllRouteRequest) ([]entity.Route, int64, error) {
+	routes := r.Store.routes.find(func(route *entity.Route) bool {
+		return request.Search == "" || strings.Contains(route.Name, request.Search)
+	})
+
+	orderBy(routes, func(a, b *entity.Route) int { return compareString(b.Name, a.Name) })
+
+	total := int64(len(routes))
+	routes = page(routes, request.Page, request.PerPage)
+	for i := range routes {
+		routes[i].Stops = r.Store.stops(routes[i].ID)
+	}
+
+	return routes, total, nil
+}
+
+func (r *routeRepository) Create(db *gorm.DB, route *entity.Route) error {
+	r.Store.routes.insert(route)
+	return nil
+}
+
+func (r *routeRepository) Update(db *gorm.DB, route *entity.Route) error {
+	return r.Store.routes.updates(route, byRouteId(route.ID))
+}
+
+func (r *routeRepository) Delete(db *gorm.DB, id int) error {
+	r.Store.routes.delete(byRouteId(id))
+	return nil
+}
+
+func (r *routeRepository) CountByName(db *gorm.DB, name string) (int64, error) {
+	return r.Store.routes.count(func(route *entity.Route) bool { return route.Name == name }), nil
+}
+
+func (r *routeRepository) FindById(db *gorm.DB, id int) (*entity.Route, error) {
+	route := r.Store.route(id)
+	if route != nil {
+		route.Sales = r.Store.sales.find(func(sales *entity.Sales) bool {
+			return slices.ContainsFunc(sales.Routes, func(route entity.Route) bool { return route.ID == id })
+		})
+		route.Stops = r.Store.stops(id)
+	}
+	return route, nil
+}
+
+// ReplaceStops replaces the stops of the route, sequences follow the slice order
+func (r *routeRepository) ReplaceStops(db *gorm.DB, routeId int, stops []entity.RouteStop) error {
+	r.Store.routeStops.delete(func(stop *entity.RouteStop) bool { return stop.RouteId == routeId })
+
+	for i := range stops {
+		stops[i].RouteId = routeId
+		stops[i].Sequence = i + 1
+		r.Store.routeStops.insert(&stops[i])
+	}
+
+	return nil
+}
+
+func byRouteId(id int) func(*entity.Route) bool {
+	return func(route *entity.Route) bool { return route.ID == id }
+}
@@ -0,0 +1,109 @@
+package memory
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+	"api/internal/repository"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type salesRepository struct {
+	Store *Store
+}
+
+func NewSalesRepository(store *Store) repository.SalesRepository {
+	return &salesRepository{
+		Store: store,
+	}
+}
+
+// Upsert follows the employee_id key, a deleted sales is brought back
+func (r *salesRepository) Upsert(db *gorm.DB, sales *entity.Sales) error {
+	r.Store.sales.upsert(sales, func(a, b *entity.Sales) bool {
+		return a.EmployeeId == b.EmployeeId
+	}, func(stored, row *entity.Sales) {
+		stored.Phone = row.Phone
+	})
+	return nil
+}
+
+func (r *salesRepository) Update(db *gorm.DB, sales *entity.Sales) error {
+	return r.Store.sales.updates(sales, bySalesId(sales.ID))
+}
+
+func (r *salesRepository) Delete(db *gorm.DB, id int) error {
+	r.Store.sales.delete(bySalesId(id))
+	return nil
+}
+
+func (r *salesRepository) DeleteByEmployeeId(db *gorm.DB, employeeID int) error {
+	r.Store.sales.delete(func(sales *entity.Sales) bool { return sales.EmployeeId == employeeID })
+	return nil
+}
+
+func (r *salesRepository) FindById(db *gorm.DB, id int) (*entity.Sales, error) {
+	sales := r.Store.sales.first(bySalesId(id))
+	if sales != nil {
+		sales.Employee = r.employeeOf(sales)
+		sales.Routes = nil
+	}
+	return sales, nil
+}
+
+func (r *salesRepository) FindByEmployeeId(db *gorm.DB, employeeId int) (*entity.Sales, error) {
+	sales := r.Store.sales.first(func(sales *entity.Sales) bool { return sales.EmployeeId == employeeId })
+	if sales != nil {
+		sales.Routes = nil
+	}
+	return sales, nil
+}
+
+func (r *salesRepository) FindAll(db *gorm.DB, request *model.FindAllSalesRequest) ([]entity.Sales, int64, error) {
+	salesList := r.Store.sales.find(func(sales *entity.Sales) bool {
+		if request.Search != "" && !strings.Contains(r.employeeOf(sales).Name, request.Search) && !strings.Contains(sales.Phone, request.Search) {
+			return false
+		}
+		return len(request.RouteIDs) == 0 || slices.ContainsFunc(r.Store.routesOf(sales.Routes, false), func(route entity.Route) bool {
+			return slices.Contains(request.RouteIDs, route.ID)
+		})
+	})
+
+	for i := range salesList {
+		salesList[i].Employee = r.employeeOf(&salesList[i])
+		salesList[i].Routes = r.Store.routesOf(salesList[i].Routes, false)
+	}
+	orderBy(salesList, func(a, b *entity.Sales) int { return compareString(b.Employee.Name, a.Employee.Name) })
+
+	return page(salesList, request.Page, request.PerPage), int64(len(salesList)), nil
+}
+
+func (r *salesRepository) CountByPhone(db *gorm.DB, phone string) (int64, error) {
+	return r.Store.sales.count(func(sales *entity.Sales) bool { return sales.Phone == phone }), nil
+}
+
+// ReplaceRoutes keeps the route ids on the row, reads reload them
+func (r *salesRepository) ReplaceRoutes(db *gorm.DB, sales *entity.Sales, routeIDs []entity.Route) error {
+	routes := make([]entity.Route, 0, len(routeIDs))
+	for _, route := range routeIDs {
+		routes = append(routes, entity.Route{ID: route.ID})
+	}
+
+	sales.Routes = routeIDs
+	r.Store.sales.update(func(stored *entity.Sales) { stored.Routes = routes }, bySalesId(sales.ID))
+	return nil
+}
+
+// Helper fuction
+func (r *salesRepository) employeeOf(sales *entity.Sales) entity.Employee {
+	if employee := r.Store.employee(sales.EmployeeId); employee != nil {
+		return *employee
+	}
+	return entity.Employee{}
+}
+
+func bySalesId(id int) func(*entity.Sales) bool {
+	return func(sales *entity.Sales) bool { return sales.ID == id }
+}
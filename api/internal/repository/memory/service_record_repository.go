@@ -0,0 +1,68 @@
+package memory
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+	"api/internal/repository"
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+type serviceRecordRepository struct {
+	Store *Store
+}
+
+func NewServiceRecordRepository(store *Store) repository.ServiceRecordRepository {
+	return &serviceRecordRepository{
+		Store: store,
+	}
+}
+
+func (r *serviceRecordRepository) FindAll(db *gorm.DB, request *model.FindAllServiceRecordRequest) ([]entity.ServiceRecord, int64, error) {
+	records := r.Store.serviceRecords.find(func(record *entity.ServiceRecord) bool { return record.VehicleId == request.VehicleId })
+
+	orderBy(records,
+		func(a, b *entity.ServiceRecord) int { return compareTime(b.Date, a.Date) },
+		func(a, b *entity.ServiceRecord) int { return b.ID - a.ID },
+	)
+
+	return page(records, request.Page, request.PerPage), int64(len(records)), nil
+}
+
+func (r *serviceRecordRepository) Create(db *gorm.DB, record *entity.ServiceRecord) error {
+	r.Store.serviceRecords.insert(record)
+	return nil
+}
+
+func (r *serviceRecordRepository) Delete(db *gorm.DB, id int) error {
+	r.Store.serviceRecords.delete(func(record *entity.ServiceRecord) bool { return record.ID == id })
+	return nil
+}
+
+func (r *serviceRecordRepository) FindById(db *gorm.DB, vehicleId int64, id int) (*entity.ServiceRecord, error) {
+	return r.Store.serviceRecords.first(func(record *entity.ServiceRecord) bool {
+		return record.VehicleId == vehicleId && record.ID == id
+	}), nil
+}
+
+// FindLatestByPlanIds returns the last service of each plan
+func (r *serviceRecordRepository) FindLatestByPlanIds(db *gorm.DB, planIds []int) ([]entity.ServiceRecord, error) {
+	records := r.Store.serviceRecords.find(func(record *entity.ServiceRecord) bool {
+		return record.MaintenancePlanId != nil && slices.Contains(planIds, *record.MaintenancePlanId)
+	})
+
+	orderBy(records,
+		func(a, b *entity.ServiceRecord) int { return *a.MaintenancePlanId - *b.MaintenancePlanId },
+		func(a, b *entity.ServiceRecord) int { return compareTime(b.Date, a.Date) },
+		func(a, b *entity.ServiceRecord) int { return b.Odometer - a.Odometer },
+	)
+
+	return distinctOn(records, func(record *entity.ServiceRecord) int { return *record.MaintenancePlanId }), nil
+}
+
+// FindOdometers returns the highest recorded odometer of each vehicle
+func (r *serviceRecordRepository) FindOdometers(db *gorm.DB, vehicleIds []int64) ([]model.VehicleOdometer, error) {
+	records := r.Store.serviceRecords.find()
+	return maxOdometers(vehicleIds, len(records), func(i int) (int64, int) { return records[i].VehicleId, records[i].Odometer }), nil
+}
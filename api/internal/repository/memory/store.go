@@ -0,0 +1,197 @@
+package memory
+
+import (
+	"api/internal/entity"
+	"fmt"
+)
+
+// Store keeps every table in memory for the use-case unit tests. The
+// repositories built on the same store see each other's rows, relations are
+// loaded from the store the way the gorm repositories preload them. A store
+// serves one test at a time, it is not safe for concurrent use.
+type Store struct {
+	users                 *table[entity.User]
+	periods               *table[entity.Period]
+	periodClosures        *table[entity.PeriodClosure]
+	employees             *table[entity.Employee]
+	employeeAttendances   *table[entity.EmployeeAttendance]
+	employeeStatusHistory *table[entity.EmployeeStatusHistory]
+	employeeDocuments     *table[entity.EmployeeDocument]
+	driverLicenses        *table[entity.DriverLicense]
+	payrolls              *table[entity.Payroll]
+	payrollItems          *table[entity.PayrollItem]
+	payRules              *table[entity.PayRule]
+	cashAdvances          *table[entity.CashAdvance]
+	cashAdvanceRepayments *table[entity.CashAdvanceRepayment]
+	sales                 *table[entity.Sales]
+	routes                *table[entity.Route]
+	routeStops            *table[entity.RouteStop]
+	customers             *table[entity.Customer]
+	receivables           *table[entity.Receivable]
+	collections           *table[entity.Collection]
+	cashDeposits          *table[entity.CashDeposit]
+	factories             *table[entity.Factory]
+	geofences             *table[entity.Geofence]
+	vehicles              *table[entity.Vehicle]
+	vehicleHistories      *table[entity.VehicleHistory]
+	vehicleDocuments      *table[entity.VehicleDocument]
+	maintenancePlans      *table[entity.MaintenancePlan]
+	serviceRecords        *table[entity.ServiceRecord]
+	fuelLogs              *table[entity.FuelLog]
+	trips                 *table[entity.Trip]
+	crews                 *table[entity.Crew]
+	crewMembers           *table[entity.CrewMember]
+}
+
+func NewStore() *Store {
+	return &Store{
+		users:                 newTable[entity.User](),
+		periods:               newTable[entity.Period](),
+		periodClosures:        newTable[entity.PeriodClosure](),
+		employees:             newTable[entity.Employee](),
+		employeeAttendances:   newTable[entity.EmployeeAttendance](),
+		employeeStatusHistory: newTable[entity.EmployeeStatusHistory](),
+		employeeDocuments:     newTable[entity.EmployeeDocument](),
+		driverLicenses:        newTable[entity.DriverLicense](),
+		payrolls:              newTable[entity.Payroll](),
+		payrollItems:          newTable[entity.PayrollItem](),
+		payRules:              newTable[entity.PayRule](),
+		cashAdvances:          newTable[entity.CashAdvance](),
+		cashAdvanceRepayments: newTable[entity.CashAdvanceRepayment](),
+		sales:                 newTable[entity.Sales](),
+		routes:                newTable[entity.Route](),
+		routeStops:            newTable[entity.RouteStop](),
+		customers:             newTable[entity.Customer](),
+		receivables:           newTable[entity.Receivable](),
+		collections:           newTable[entity.Collection](),
+		cashDeposits:          newTable[entity.CashDeposit](),
+		factories:             newTable[entity.Factory](),
+		geofences:             newTable[entity.Geofence](),
+		vehicles:              newTable[entity.Vehicle](),
+		vehicleHistories:      newTable[entity.VehicleHistory](),
+		vehicleDocuments:      newTable[entity.VehicleDocument](),
+		maintenancePlans:      newTable[entity.MaintenancePlan](),
+		serviceRecords:        newTable[entity.ServiceRecord](),
+		fuelLogs:              newTable[entity.FuelLog](),
+		trips:                 newTable[entity.Trip](),
+		crews:                 newTable[entity.Crew](),
+		crewMembers:           newTable[entity.CrewMember](),
+	}
+}
+
+// clone copies every table, used as the snapshot a rollback restores
+func (s *Store) clone() *Store {
+	return &Store{
+		users:                 s.users.clone(),
+		periods:               s.periods.clone(),
+		periodClosures:        s.periodClosures.clone(),
+		employees:             s.employees.clone(),
+		employeeAttendances:   s.employeeAttendances.clone(),
+		employeeStatusHistory: s.employeeStatusHistory.clone(),
+		employeeDocuments:     s.employeeDocuments.clone(),
+		driverLicenses:        s.driverLicenses.clone(),
+		payrolls:              s.payrolls.clone(),
+		payrollItems:          s.payrollItems.clone(),
+		payRules:              s.payRules.clone(),
+		cashAdvances:          s.cashAdvances.clone(),
+		cashAdvanceRepayments: s.cashAdvanceRepayments.clone(),
+		sales:                 s.sales.clone(),
+		routes:                s.routes.clone(),
+		routeStops:            s.routeStops.clone(),
+		customers:             s.customers.clone(),
+		receivables:           s.receivables.clone(),
+		collections:           s.collections.clone(),
+		cashDeposits:          s.cashDeposits.clone(),
+		factories:             s.factories.clone(),
+		geofences:             s.geofences.clone(),
+		vehicles:              s.vehicles.clone(),
+		vehicleHistories:      s.vehicleHistories.clone(),
+		vehicleDocuments:      s.vehicleDocuments.clone(),
+		maintenancePlans:      s.maintenancePlans.clone(),
+		serviceRecords:        s.serviceRecords.clone(),
+		fuelLogs:              s.fuelLogs.clone(),
+		trips:                 s.trips.clone(),
+		crews:                 s.crews.clone(),
+		crewMembers:           s.crewMembers.clone(),
+	}
+}
+
+// Insert seeds rows the way the database would store them, ids, defaults and
+// timestamps are filled in. It is for the tables a test cannot reach through a
+// repository, like closed periods or period closures.
+func (s *Store) Insert(rows ...any) {
+	for _, row := range rows {
+		switch row := row.(type) {
+		case *entity.User:
+			s.users.insert(row)
+		case *entity.Period:
+			s.periods.insert(row)
+		case *entity.PeriodClosure:
+			s.periodClosures.insert(row)
+		case *entity.Employee:
+			s.employees.insert(row)
+		case *entity.EmployeeAttendance:
+			s.employeeAttendances.insert(row)
+		case *entity.EmployeeStatusHistory:
+			s.employeeStatusHistory.insert(row)
+		case *entity.EmployeeDocument:
+			s.employeeDocuments.insert(row)
+		case *entity.DriverLicense:
+			s.driverLicenses.insert(row)
+		case *entity.Payroll:
+			s.payrolls.insert(row)
+		case *entity.PayrollItem:
+			s.payrollItems.insert(row)
+		case *entity.PayRule:
+			s.payRules.insert(row)
+		case *entity.CashAdvance:
+			s.cashAdvances.insert(row)
+		case *entity.CashAdvanceRepayment:
+			s.cashAdvanceRepayments.insert(row)
+		case *entity.Sales:
+			s.sales.insert(row)
+		case *entity.Route:
+			s.routes.insert(row)
+		case *entity.RouteStop:
+			s.routeStops.insert(row)
+		case *entity.Customer:
+			s.customers.insert(row)
+		case *entity.Receivable:
+			s.receivables.insert(row)
+		case *entity.Collection:
+			s.collections.insert(row)
+		case *entity.CashDeposit:
+			s.cashDeposits.insert(row)
+		case *entity.Factory:
+			s.factories.insert(row)
+		case *entity.Geofence:
+			s.geofences.insert(row)
+		case *entity.Vehicle:
+			s.vehicles.insert(row)
+		case *entity.VehicleHistory:
+			s.vehicleHistories.insert(row)
+		case *entity.VehicleDocument:
+			s.vehicleDocuments.insert(row)
+		case *entity.MaintenancePlan:
+			s.maintenancePlans.insert(row)
+		case *entity.ServiceRecord:
+			s.serviceRecords.insert(row)
+		case *entity.FuelLog:
+			s.fuelLogs.insert(row)
+		case *entity.Trip:
+			s.trips.insert(row)
+		case *entity.Crew:
+			s.crews.insert(row)
+		case *entity.CrewMember:
+			s.crewMembers.insert(row)
+		default:
+			panic(fmt.Sprintf("memory: no table for %T", row))
+		}
+	}
+}
+
+// VehicleHistories returns the history rows of a vehicle, no repository reads
+// them back since the postings are made by other modules
+func (s *Store) VehicleHistories(vehicleId int64) []entity.VehicleHistory {
+	return s.vehicleHistories.find(func(history *entity.VehicleHistory) bool { return history.VehicleID == vehicleId })
+}
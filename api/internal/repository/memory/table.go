@@ -0,0 +1,280 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+var schemas = &sync.Map{}
+
+// table keeps the rows of one entity in insertion order. Rows are copied in
+// and out so callers never share memory with the store, like with postgres.
+type table[T any] struct {
+	rows []T
+	seq  int64
+}
+
+func newTable[T any]() *table[T] {
+	return &table[T]{}
+}
+
+// insert assigns the id, defaults and timestamps the database would fill in
+func (t *table[T]) insert(row *T) {
+	value := reflect.ValueOf(row).Elem()
+
+	if id := value.FieldByName("ID"); id.IsValid() && id.IsZero() {
+		switch id.Kind() {
+		case reflect.Int, reflect.Int64:
+			t.seq++
+			id.SetInt(t.seq)
+		case reflect.Array:
+			id.Set(reflect.ValueOf(uuid.New()))
+		}
+	} else if id.IsValid() && (id.Kind() == reflect.Int || id.Kind() == reflect.Int64) && id.Int() > t.seq {
+		t.seq = id.Int()
+	}
+
+	// columns left zero get their database default, like gorm reads them back
+	if sch, err := schema.Parse(row, schemas, schema.NamingStrategy{}); err == nil {
+		ctx := context.Background()
+		for _, field := range sch.Fields {
+			if _, zero := field.ValueOf(ctx, value); zero && field.DefaultValueInterface != nil {
+				field.Set(ctx, value, field.DefaultValueInterface)
+			}
+		}
+	}
+	truncateDates(row)
+
+	now := time.Now()
+	for _, name := range []string{"CreatedAt", "UpdatedAt"} {
+		if field := value.FieldByName(name); field.IsValid() && field.IsZero() {
+			field.Set(reflect.ValueOf(now))
+		}
+	}
+
+	t.rows = append(t.rows, *row)
+}
+
+// find returns copies of the rows not soft deleted matching every filter
+func (t *table[T]) find(filters ...func(*T) bool) []T {
+	return t.scan(false, filters...)
+}
+
+// unscoped is find including soft deleted rows
+func (t *table[T]) unscoped(filters ...func(*T) bool) []T {
+	return t.scan(true, filters...)
+}
+
+func (t *table[T]) first(filters ...func(*T) bool) *T {
+	return firstOf(t.find(filters...))
+}
+
+func (t *table[T]) count(filters ...func(*T) bool) int64 {
+	return int64(len(t.find(filters...)))
+}
+
+// update applies fn to the stored rows matching every filter
+func (t *table[T]) update(fn func(*T), filters ...func(*T) bool) {
+	now := reflect.ValueOf(time.Now())
+	for i := range t.rows {
+		if deleted(&t.rows[i]) || !matches(&t.rows[i], filters) {
+			continue
+		}
+
+		fn(&t.rows[i])
+		truncateDates(&t.rows[i])
+		if field := reflect.ValueOf(&t.rows[i]).Elem().FieldByName("UpdatedAt"); field.IsValid() {
+			field.Set(now)
+		}
+	}
+}
+
+// updates works like gorm Updates, a map is keyed by column and a struct only
+// writes its non-zero fields
+func (t *table[T]) updates(values any, filters ...func(*T) bool) error {
+	var err error
+	t.update(func(row *T) {
+		if err == nil {
+			err = assign(row, values)
+		}
+	}, filters...)
+	return err
+}
+
+// delete soft deletes when the entity has a DeletedAt, otherwise removes the rows
+func (t *table[T]) delete(filters ...func(*T) bool) {
+	now := time.Now()
+	kept := t.rows[:0]
+	for i := range t.rows {
+		row := &t.rows[i]
+		if deleted(row) || !matches(row, filters) {
+			kept = append(kept, *row)
+			continue
+		}
+
+		if field := reflect.ValueOf(row).Elem().FieldByName("DeletedAt"); field.IsValid() {
+			field.Set(reflect.ValueOf(gorm.DeletedAt{Time: now, Valid: true}))
+			kept = append(kept, *row)
+		}
+	}
+	t.rows = kept
+}
+
+// upsert updates the stored row sharing the key with row, deleted or not,
+// or inserts it. The stored row is copied back into row.
+func (t *table[T]) upsert(row *T, key func(a, b *T) bool, fn func(stored, row *T)) {
+	for i := range t.rows {
+		stored := &t.rows[i]
+		if !key(stored, row) {
+			continue
+		}
+
+		fn(stored, row)
+		value := reflect.ValueOf(stored).Elem()
+		if field := value.FieldByName("DeletedAt"); field.IsValid() {
+			field.Set(reflect.ValueOf(gorm.DeletedAt{}))
+		}
+		if field := value.FieldByName("UpdatedAt"); field.IsValid() {
+			field.Set(reflect.ValueOf(time.Now()))
+		}
+
+		*row = *stored
+		return
+	}
+
+	t.insert(row)
+}
+
+func (t *table[T]) clone() *table[T] {
+	return &table[T]{
+		rows: append([]T(nil), t.rows...),
+		seq:  t.seq,
+	}
+}
+
+// Helper fuction
+func (t *table[T]) scan(withDeleted bool, filters ...func(*T) bool) []T {
+	rows := make([]T, 0)
+	for i := range t.rows {
+		if (!withDeleted && deleted(&t.rows[i])) || !matches(&t.rows[i], filters) {
+			continue
+		}
+		rows = append(rows, t.rows[i])
+	}
+	return rows
+}
+
+func matches[T any](row *T, filters []func(*T) bool) bool {
+	for _, filter := range filters {
+		if !filter(row) {
+			return false
+		}
+	}
+	return true
+}
+
+func deleted[T any](row *T) bool {
+	field := reflect.ValueOf(row).Elem().FieldByName("DeletedAt")
+	return field.IsValid() && field.Interface().(gorm.DeletedAt).Valid
+}
+
+// assign writes gorm style updates through the schema, so the column names
+// and type conversions are the ones gorm would use
+func assign(row any, values any) error {
+	sch, err := schema.Parse(row, schemas, schema.NamingStrategy{})
+	if err != nil {
+		return err
+	}
+
+	target := reflect.ValueOf(row).Elem()
+	ctx := context.Background()
+
+	if columns, ok := values.(map[string]any); ok {
+		for column, value := range columns {
+			field := sch.LookUpField(column)
+			if field == nil {
+				return fmt.Errorf("memory: unknown column %s.%s", sch.Table, column)
+			}
+			if err := field.Set(ctx, target, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	source := reflect.Indirect(reflect.ValueOf(values))
+	for _, field := range sch.Fields {
+		if field.DBName == "" || field.PrimaryKey {
+			continue
+		}
+
+		value, zero := field.ValueOf(ctx, source)
+		if zero {
+			continue
+		}
+		if err := field.Set(ctx, target, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// truncateDates drops the time of day of the DATE columns, like postgres
+func truncateDates(row any) {
+	sch, err := schema.Parse(row, schemas, schema.NamingStrategy{})
+	if err != nil {
+		return
+	}
+
+	target := reflect.ValueOf(row).Elem()
+	ctx := context.Background()
+	for _, field := range sch.Fields {
+		if !strings.EqualFold(field.TagSettings["TYPE"], "date") {
+			continue
+		}
+
+		switch value := field.ReflectValueOf(ctx, target).Interface().(type) {
+		case time.Time:
+			field.Set(ctx, target, day(value))
+		case *time.Time:
+			if value != nil {
+				field.Set(ctx, target, day(*value))
+			}
+		}
+	}
+}
+
+// page applies the Page/PerPage offset of the list requests
+func page[T any](rows []T, number, perPage int) []T {
+	if number <= 0 || perPage <= 0 {
+		return rows
+	}
+
+	offset := (number - 1) * perPage
+	if offset >= len(rows) {
+		return []T{}
+	}
+	return rows[offset:min(offset+perPage, len(rows))]
+}
+
+// orderBy sorts stably with the given less functions, the first one that
+// tells the rows apart wins
+func orderBy[T any](rows []T, less ...func(a, b *T) int) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		for _, compare := range less {
+			if c := compare(&rows[i], &rows[j]); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+}
@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"api/internal/repository"
+	"context"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// transactor hands out empty gorm handles, the memory repositories never
+// read them. Each transaction snapshots the store and a rollback before the
+// commit puts the snapshot back.
+type transactor struct {
+	Store     *Store
+	mu        sync.Mutex
+	snapshots map[*gorm.DB]*Store
+}
+
+func NewTransactor(store *Store) repository.Transactor {
+	return &transactor{
+		Store:     store,
+		snapshots: make(map[*gorm.DB]*Store),
+	}
+}
+
+func (t *transactor) DB(ctx context.Context) *gorm.DB {
+	return &gorm.DB{}
+}
+
+func (t *transactor) Begin(ctx context.Context) *gorm.DB {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tx := &gorm.DB{}
+	t.snapshots[tx] = t.Store.clone()
+	return tx
+}
+
+func (t *transactor) Commit(tx *gorm.DB) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.snapshots, tx)
+	return nil
+}
+
+func (t *transactor) Rollback(tx *gorm.DB) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if snapshot, ok := t.snapshots[tx]; ok {
+		*t.Store = *snapshot
+		delete(t.snapshots, tx)
+	}
+}
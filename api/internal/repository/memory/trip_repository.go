@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+	"api/internal/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type tripRepository struct {
+	Store *Store
+}
+
+func NewTripRepository(store *Store) repository.TripRepository {
+	return &tripRepository{
+		Store: store,
+	}
+}
+
+func (r *tripRepository) Create(db *gorm.DB, trip *entity.Trip) error {
+	r.Store.trips.insert(trip)
+	return nil
+}
+
+func (r *tripRepository) Delete(db *gorm.DB, id int) error {
+	r.Store.trips.delete(func(trip *entity.Trip) bool { return trip.ID == id })
+	return nil
+}
+
+func (r *tripRepository) FindById(db *gorm.DB, id int) (*entity.Trip, error) {
+	trip := r.Store.trips.first(func(trip *entity.Trip) bool { return trip.ID == id })
+	if trip != nil {
+		r.load(trip)
+	}
+	return trip, nil
+}
+
+func (r *tripRepository) FindAll(db *gorm.DB, request *model.FindAllTripRequest) ([]entity.Trip, int64, error) {
+	trips := r.Store.trips.find(func(trip *entity.Trip) bool {
+		if start, ok := parseDate(request.StartDate); ok && day(trip.Date).Before(start) {
+			return false
+		}
+		if end, ok := parseDate(request.EndDate); ok && day(trip.Date).After(end) {
+			return false
+		}
+		if request.VehicleId > 0 && trip.VehicleId != request.VehicleId {
+			return false
+		}
+		return request.DriverId <= 0 || trip.DriverId == request.DriverId
+	})
+
+	orderBy(trips,
+		func(a, b *entity.Trip) int { return compareTime(b.Date, a.Date) },
+		func(a, b *entity.Trip) int { return b.ID - a.ID },
+	)
+
+	total := int64(len(trips))
+	trips = page(trips, request.Page, request.PerPage)
+	for i := range trips {
+		r.load(&trips[i])
+	}
+
+	return trips, total, nil
+}
+
+func (r *tripRepository) FindByDateRange(db *gorm.DB, startDate, endDate time.Time) ([]entity.Trip, error) {
+	trips := r.Store.trips.find(func(trip *entity.Trip) bool { return between(trip.Date, startDate, endDate) })
+	for i := range trips {
+		trips[i].Helpers = r.Store.employeesOf(trips[i].Helpers)
+		trips[i].Routes = nil
+	}
+	return trips, nil
+}
+
+// Helper fuction
+func (r *tripRepository) load(trip *entity.Trip) {
+	trip.Vehicle = r.Store.vehicle(trip.VehicleId)
+	trip.Driver = r.Store.employee(trip.DriverId)
+	trip.Helpers = r.Store.employeesOf(trip.Helpers)
+	trip.Routes = r.Store.routesOf(trip.Routes, true)
+}
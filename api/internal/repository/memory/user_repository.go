@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/enum"
+	"api/internal/model"
+	"api/internal/repository"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type userRepository struct {
+	Store *Store
+}
+
+func NewUserRepository(store *Store) repository.UserRepository {
+	return &userRepository{
+		Store: store,
+	}
+}
+
+func (r *userRepository) FindAll(db *gorm.DB, request *model.FindAllUserRequest) ([]entity.User, int64, error) {
+	users := r.Store.users.find(func(user *entity.User) bool {
+		if search := strings.ToLower(request.Search); search != "" &&
+			!contains(user.Name, search) && !contains(user.Username, search) && !contains(user.Phone, search) {
+			return false
+		}
+		if len(request.Roles) > 0 && !slices.Contains(request.Roles, user.Role) {
+			return false
+		}
+		return user.Role != enum.SUPER_ADMIN
+	})
+
+	orderBy(users, func(a, b *entity.User) int { return strings.Compare(string(b.Role), string(a.Role)) })
+
+	return page(users, request.Page, request.PerPage), int64(len(users)), nil
+}
+
+func (r *userRepository) Create(db *gorm.DB, user *entity.User) error {
+	r.Store.users.insert(user)
+	return nil
+}
+
+func (r *userRepository) Update(db *gorm.DB, id uuid.UUID, updates any) error {
+	return r.Store.users.updates(updates, func(user *entity.User) bool { return user.ID == id })
+}
+
+func (r *userRepository) Delete(db *gorm.DB, id uuid.UUID) error {
+	r.Store.users.delete(func(user *entity.User) bool { return user.ID == id })
+	return nil
+}
+
+func (r *userRepository) FindById(db *gorm.DB, id uuid.UUID) (*entity.User, error) {
+	return r.Store.users.first(func(user *entity.User) bool { return user.ID == id }), nil
+}
+
+func (r *userRepository) CountByUsername(db *gorm.DB, username string) (int64, error) {
+	return int64(len(r.Store.users.unscoped(func(user *entity.User) bool { return user.Username == username }))), nil
+}
+
+func (r *userRepository) CountByPhone(db *gorm.DB, phone string) (int64, error) {
+	return int64(len(r.Store.users.unscoped(func(user *entity.User) bool { return user.Phone == phone }))), nil
+}
+
+func (r *userRepository) FindByUsername(db *gorm.DB, username string) (*entity.User, error) {
+	return r.Store.users.first(func(user *entity.User) bool { return user.Username == username }), nil
+}
+
+func (r *userRepository) FindByEmployeeId(db *gorm.DB, employeeId int) (*entity.User, error) {
+	return r.Store.users.first(func(user *entity.User) bool {
+		return user.EmployeeId != nil && *user.EmployeeId == employeeId
+	}), nil
+}
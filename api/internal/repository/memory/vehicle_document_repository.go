@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"api/internal/entity"
+	"api/internal/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type vehicleDocumentRepository struct {
+	Store *Store
+}
+
+type vehicleDocumentKey struct {
+	vehicleId    int64
+	documentType string
+}
+
+func NewVehicleDocumentRepository(store *Store) repository.VehicleDocumentRepository {
+	return &vehicleDocumentRepository{
+		Store: store,
+	}
+}
+
+func (r *vehicleDocumentRepository) Create(db *gorm.DB, document *entity.VehicleDocument) error {
+	r.Store.vehicleDocuments.insert(document)
+	return nil
+}
+
+func (r *vehicleDocumentRepository) Delete(db *gorm.DB, id int) error {
+	r.Store.vehicleDocuments.delete(func(document *entity.VehicleDocument) bool { return document.ID == id })
+	return nil
+}
+
+func (r *vehicleDocumentRepository) FindById(db *gorm.DB, vehicleId int64, id int) (*entity.VehicleDocument, error) {
+	return r.Store.vehicleDocuments.first(func(document *entity.VehicleDocument) bool {
+		return document.VehicleId == vehicleId && document.ID == id
+	}), nil
+}
+
+func (r *vehicleDocumentRepository) FindByVehicleId(db *gorm.DB, vehicleId int64) ([]entity.VehicleDocument, error) {
+	documents := r.Store.vehicleDocuments.find(func(document *entity.VehicleDocument) bool { return document.VehicleId == vehicleId })
+	orderBy(documents, byTypeThenLatest)
+	return documents, nil
+}
+
+// FindLatestByVehicleId returns the latest document of each type
+func (r *vehicleDocumentRepository) FindLatestByVehicleId(db *gorm.DB, vehicleId int64) ([]entity.VehicleDocument, error) {
+	documents, _ := r.FindByVehicleId(db, vehicleId)
+	return distinctOn(documents, func(document *entity.VehicleDocument) string { return string(document.Type) }), nil
+}
+
+// FindExpiring returns the latest document of each vehicle and type expiring on or before date
+func (r *vehicleDocumentRepository) FindExpiring(db *gorm.DB, date time.Time) ([]entity.VehicleDocument, error) {
+	documents := r.Store.vehicleDocuments.find(func(document *entity.VehicleDocument) bool {
+		return r.Store.vehicle(document.VehicleId) != nil
+	})
+	orderBy(documents, byTypeThenLatest)
+	documents = distinctOn(documents, func(document *entity.VehicleDocument) vehicleDocumentKey {
+		return vehicleDocumentKey{document.VehicleId, string(document.Type)}
+	})
+
+	expiring := make([]entity.VehicleDocument, 0, len(documents))
+	for _, document := range documents {
+		if !day(document.ExpiryDate).After(date) {
+			document.Vehicle = r.Store.vehicle(document.VehicleId)
+			expiring = append(expiring, document)
+		}
+	}
+	orderBy(expiring, func(a, b *entity.VehicleDocument) int { return compareTime(a.ExpiryDate, b.ExpiryDate) })
+
+	return expiring, nil
+}
+
+func byTypeThenLatest(a, b *entity.VehicleDocument) int {
+	if c := compareString(string(a.Type), string(b.Type)); c != 0 {
+		return c
+	}
+	return compareTime(b.ExpiryDate, a.ExpiryDate)
+}
@@ -0,0 +1,28 @@
+package memory
+
+import (
+	"api/internal/entity"
+	"api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type vehicleHistoryRepository struct {
+	Store *Store
+}
+
+func NewVehicleHistoryRepository(store *Store) repository.VehicleHistoryRepository {
+	return &vehicleHistoryRepository{
+		Store: store,
+	}
+}
+
+func (r *vehicleHistoryRepository) Create(db *gorm.DB, history *entity.VehicleHistory) error {
+	r.Store.vehicleHistories.insert(history)
+	return nil
+}
+
+func (r *vehicleHistoryRepository) Delete(db *gorm.DB, id int64) error {
+	r.Store.vehicleHistories.delete(func(history *entity.VehicleHistory) bool { return history.ID == id })
+	return nil
+}
@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+	"api/internal/repository"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type vehicleRepository struct {
+	Store *Store
+}
+
+func NewVehicleRepository(store *Store) repository.VehicleRepository {
+	return &vehicleRepository{
+		Store: store,
+	}
+}
+
+func (r *vehicleRepository) Create(db *gorm.DB, vehicle *entity.Vehicle) error {
+	r.Store.vehicles.insert(vehicle)
+	return nil
+}
+
+func (r *vehicleRepository) Update(db *gorm.DB, id int64, updates any) error {
+	return r.Store.vehicles.updates(updates, byVehicleId(id))
+}
+
+func (r *vehicleRepository) FindAll(db *gorm.DB, request *model.FindAllVehicleRequest) ([]entity.Vehicle, int64, error) {
+	search := strings.ToLower(request.Search)
+	vehicles := r.Store.vehicles.find(func(vehicle *entity.Vehicle) bool {
+		if search != "" && !contains(vehicle.Plate, search) {
+			return false
+		}
+		return len(request.Types) == 0 || slices.Contains(request.Types, vehicle.Type)
+	})
+
+	orderBy(vehicles, func(a, b *entity.Vehicle) int { return compareString(b.Plate, a.Plate) })
+
+	return page(vehicles, request.Page, request.PerPage), int64(len(vehicles)), nil
+}
+
+func (r *vehicleRepository) Delete(db *gorm.DB, id int64) error {
+	r.Store.vehicles.delete(byVehicleId(id))
+	return nil
+}
+
+func (r *vehicleRepository) FindById(db *gorm.DB, id int64) (*entity.Vehicle, error) {
+	return r.Store.vehicle(id), nil
+}
+
+func (r *vehicleRepository) CountByPlate(db *gorm.DB, plate string) (int64, error) {
+	return r.Store.vehicles.count(func(vehicle *entity.Vehicle) bool { return vehicle.Plate == plate }), nil
+}
+
+func byVehicleId(id int64) func(*entity.Vehicle) bool {
+	return func(vehicle *entity.Vehicle) bool { return vehicle.ID == id }
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"api/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PayRuleRepository interface {
+	FindAll(db *gorm.DB) ([]entity.PayRule, error)
+	Upsert(db *gorm.DB, rule *entity.PayRule) error
+}
+
+type payRuleRepositoryImpl struct {
+	Log *logrus.Logger
+}
+
+func NewPayRuleRepository(log *logrus.Logger) PayRuleRepository {
+	return &payRuleRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *payRuleRepositoryImpl) FindAll(db *gorm.DB) ([]entity.PayRule, error) {
+	var rules []entity.PayRule
+	if err := db.Order("role ASC").Find(&rules).Error; err != nil {
+		r.Log.WithError(err).Error("failed to find pay rules")
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *payRuleRepositoryImpl) Upsert(db *gorm.DB, rule *entity.PayRule) error {
+	return db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "role"},
+		},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"daily_rate": gorm.Expr("EXCLUDED.daily_rate"),
+			"trip_rate":  gorm.Expr("EXCLUDED.trip_rate"),
+			"sack_rate":  gorm.Expr("EXCLUDED.sack_rate"),
+			"updated_at": gorm.Expr("EXCLUDED.updated_at"),
+			"deleted_at": nil,
+		}),
+	}).Create(rule).Error
+}
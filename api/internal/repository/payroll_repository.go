@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PayrollRepository interface {
+	FindAll(db *gorm.DB, request *model.FindAllPayrollRequest) ([]entity.Payroll, error)
+	FindByPeriodId(db *gorm.DB, periodId int) ([]entity.Payroll, error)
+	FindById(db *gorm.DB, id int) (*entity.Payroll, error)
+	FindByEmployeeId(db *gorm.DB, request *model.FindPayrollByEmployeeRequest) ([]entity.Payroll, error)
+	FindByEmployeeAndPeriod(db *gorm.DB, employeeId, periodId int) (*entity.Payroll, error)
+	UpdatePayment(db *gorm.DB, ids []int, updates any) error
+	Upsert(db *gorm.DB, payroll *entity.Payroll) error
+	ReplaceItems(db *gorm.DB, payrollId int, items []entity.PayrollItem) error
+	Delete(db *gorm.DB, id int) error
+}
+
+type payrollRepositoryImpl struct {
+	Log *logrus.Logger
+}
+
+func NewPayrollRepository(log *logrus.Logger) PayrollRepository {
+	return &payrollRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *payrollRepositoryImpl) FindAll(db *gorm.DB, request *model.FindAllPayrollRequest) ([]entity.Payroll, error) {
+	var payrolls []entity.Payroll
+
+	err := db.Joins("Employee").
+		Preload("Items").
+		Where("payrolls.period_id = ?", request.PeriodId).
+		Order(`"Employee".name ASC`).
+		Find(&payrolls).Error
+	if err != nil {
+		r.Log.WithError(err).Error("failed to find payrolls")
+		return nil, err
+	}
+
+	return payrolls, nil
+}
+
+func (r *payrollRepositoryImpl) FindByPeriodId(db *gorm.DB, periodId int) ([]entity.Payroll, error) {
+	var payrolls []entity.Payroll
+	if err := db.Where("period_id = ?", periodId).Find(&payrolls).Error; err != nil {
+		r.Log.WithError(err).Error("failed to find payrolls")
+		return nil, err
+	}
+	return payrolls, nil
+}
+
+func (r *payrollRepositoryImpl) Upsert(db *gorm.DB, payroll *entity.Payroll) error {
+	return db.Omit("Items").Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "employee_id"},
+			{Name: "period_id"},
+		},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"base_salary":     gorm.Expr("EXCLUDED.base_salary"),
+			"attendance_days": gorm.Expr("EXCLUDED.attendance_days"),
+			"bonuses":         gorm.Expr("EXCLUDED.bonuses"),
+			"deductions":      gorm.Expr("EXCLUDED.deductions"),
+			"module_type":     gorm.Expr("EXCLUDED.module_type"),
+			"updated_at":      gorm.Expr("EXCLUDED.updated_at"),
+			"deleted_at":      nil,
+		}),
+	}).Create(payroll).Error
+}
+
+func (r *payrollRepositoryImpl) ReplaceItems(db *gorm.DB, payrollId int, items []entity.PayrollItem) error {
+	if err := db.Where("payroll_id = ?", payrollId).Delete(&entity.PayrollItem{}).Error; err != nil {
+		return err
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	for i := range items {
+		items[i].PayrollId = payrollId
+	}
+
+	return db.Create(&items).Error
+}
+
+func (r *payrollRepositoryImpl) Delete(db *gorm.DB, id int) error {
+	return db.Delete(&entity.Payroll{}, id).Error
+}
+
+func (r *payrollRepositoryImpl) FindById(db *gorm.DB, id int) (*entity.Payroll, error) {
+	var payroll entity.Payroll
+
+	err := db.Preload("Employee").Preload("Items").First(&payroll, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &payroll, nil
+}
+
+func (r *payrollRepositoryImpl) FindByEmployeeAndPeriod(db *gorm.DB, employeeId, periodId int) (*entity.Payroll, error) {
+	var payroll entity.Payroll
+
+	// the payroll outlives its employee, a deleted one is still named on the payslip
+	err := db.Preload("Employee", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("Period").
+		Preload("Items").
+		Where("employee_id = ? AND period_id = ?", employeeId, periodId).
+		First(&payroll).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &payroll, nil
+}
+
+func (r *payrollRepositoryImpl) UpdatePayment(db *gorm.DB, ids []int, updates any) error {
+	return db.Model(&entity.Payroll{}).Where("id IN ? AND is_paid = ?", ids, false).Updates(updates).Error
+}
+
+func (r *payrollRepositoryImpl) FindByEmployeeId(db *gorm.DB, request *model.FindPayrollByEmployeeRequest) ([]entity.Payroll, error) {
+	var payrolls []entity.Payroll
+
+	query := db.Preload("Items").Where("employee_id = ?", request.EmployeeId)
+	if request.PeriodId > 0 {
+		query = query.Where("period_id = ?", request.PeriodId)
+	}
+
+	if err := query.Order("period_id DESC").Find(&payrolls).Error; err != nil {
+		r.Log.WithError(err).Error("failed to find payrolls")
+		return nil, err
+	}
+
+	return payrolls, nil
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"api/internal/entity"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type PeriodClosureRepository interface {
+	FindByPeriodAndModule(db *gorm.DB, periodId int, moduleName string) (*entity.PeriodClosure, error)
+	IncrementPrintCount(db *gorm.DB, id int) error
+	FindByPeriod(db *gorm.DB, periodId int) ([]entity.PeriodClosure, error)
+}
+
+type periodClosureRepositoryImpl struct {
+	Log *logrus.Logger
+}
+
+func NewPeriodClosureRepository(log *logrus.Logger) PeriodClosureRepository {
+	return &periodClosureRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *periodClosureRepositoryImpl) FindByPeriodAndModule(db *gorm.DB, periodId int, moduleName string) (*entity.PeriodClosure, error) {
+	var closure entity.PeriodClosure
+
+	err := db.Where("period_id = ? AND module_name = ?", periodId, moduleName).First(&closure).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &closure, nil
+}
+
+func (r *periodClosureRepositoryImpl) IncrementPrintCount(db *gorm.DB, id int) error {
+	return db.Model(&entity.PeriodClosure{}).
+		Where("id = ?", id).
+		UpdateColumn("print_count", gorm.Expr("print_count + 1")).Error
+}
+
+func (r *periodClosureRepositoryImpl) FindByPeriod(db *gorm.DB, periodId int) ([]entity.PeriodClosure, error) {
+	var closures []entity.PeriodClosure
+
+	err := db.Where("period_id = ?", periodId).Order("module_name ASC").Find(&closures).Error
+	if err != nil {
+		r.Log.WithError(err).Error("failed to find period closures")
+		return nil, err
+	}
+
+	return closures, nil
+}
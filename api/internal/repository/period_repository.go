@@ -3,19 +3,29 @@ package repository
 import (
 	"api/internal/entity"
 	"api/internal/entity/enum"
+	"api/internal/model"
 	"errors"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PeriodRepository interface {
-	FindByDate(db *gorm.DB, periodType enum.PeriodType, date time.Time) (*entity.Period, error)
-	FindLastClosedMothly(db *gorm.DB, month, year int) (*entity.Period, error)
-	FindLastWeeklyInMonth(db *gorm.DB, month, year int) (*entity.Period, error)
+	FindByDate(db *gorm.DB, periodTypes []enum.PeriodType, date time.Time) (*entity.Period, error)
+	FindLastClosedMonthly(db *gorm.DB, month, year int) (*entity.Period, error)
+	FindLastBefore(db *gorm.DB, periodTypes []enum.PeriodType, date time.Time) (*entity.Period, error)
 	Create(db *gorm.DB, period *entity.Period) (*entity.Period, error)
-	FindByStartDate(db *gorm.DB, startDate time.Time) (*entity.Period, error)
+	FirstOrCreate(db *gorm.DB, period *entity.Period) (*entity.Period, error)
+	FindByStartDate(db *gorm.DB, periodType enum.PeriodType, startDate time.Time) (*entity.Period, error)
+	FindById(db *gorm.DB, id int) (*entity.Period, error)
+	FindAll(db *gorm.DB, request *model.FindAllPeriodRequest) ([]entity.Period, int64, error)
+	SummarizeAttendance(db *gorm.DB, id int) (*model.PeriodAttendanceSummary, error)
+	SummarizePayroll(db *gorm.DB, id int) (*model.PeriodPayrollSummary, error)
+	CountCollections(db *gorm.DB, id int) (int64, error)
+	HasData(db *gorm.DB, id int) (bool, error)
+	Deactivate(db *gorm.DB, id int) error
 }
 
 type periodRepositoryImpl struct {
@@ -28,13 +38,13 @@ func NewPeriodRepository(log *logrus.Logger) PeriodRepository {
 	}
 }
 
-func (r *periodRepositoryImpl) FindByDate(db *gorm.DB, periodType enum.PeriodType, date time.Time) (*entity.Period, error) {
+func (r *periodRepositoryImpl) FindByDate(db *gorm.DB, periodTypes []enum.PeriodType, date time.Time) (*entity.Period, error) {
 	var period entity.Period
 
 	err := db.Where(
-		"type = ? AND start_date <= ? AND end_date >= ? AND deleted_at IS NULL",
-		periodType, date, date,
-	).First(&period).Error
+		"type IN ? AND start_date <= ? AND end_date >= ? AND is_active = ? AND deleted_at IS NULL",
+		periodTypes, date, date, true,
+	).Order("start_date DESC").First(&period).Error
 
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -47,7 +57,7 @@ func (r *periodRepositoryImpl) FindByDate(db *gorm.DB, periodType enum.PeriodTyp
 	return &period, nil
 }
 
-func (r *periodRepositoryImpl) FindLastClosedMothly(db *gorm.DB, month, year int) (*entity.Period, error) {
+func (r *periodRepositoryImpl) FindLastClosedMonthly(db *gorm.DB, month, year int) (*entity.Period, error) {
 	var period entity.Period
 
 	err := db.Where(
@@ -74,21 +84,53 @@ func (r *periodRepositoryImpl) Create(db *gorm.DB, period *entity.Period) (*enti
 	return period, nil
 }
 
-func (r *periodRepositoryImpl) FindLastWeeklyInMonth(db *gorm.DB, month, year int) (*entity.Period, error) {
+// FirstOrCreate relies on the (type, start_date) key, the conflicting row is
+// locked until the transaction ends and returned as stored. A deleted or
+// deactivated period is returned as it is, the caller decides what to do with it.
+func (r *periodRepositoryImpl) FirstOrCreate(db *gorm.DB, period *entity.Period) (*entity.Period, error) {
+	err := db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "type"},
+			{Name: "start_date"},
+		},
+		// a no-op update so the stored row is locked and its id returned
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"type": gorm.Expr("EXCLUDED.type"),
+		}),
+	}).Create(period).Error
+	if err != nil {
+		r.Log.WithError(err).Error("error creating period")
+		return nil, err
+	}
+
+	var stored entity.Period
+	if err := db.Unscoped().First(&stored, period.ID).Error; err != nil {
+		r.Log.WithError(err).Error("error finding created period")
+		return nil, err
+	}
+
+	return &stored, nil
+}
+
+func (r *periodRepositoryImpl) FindLastBefore(db *gorm.DB, periodTypes []enum.PeriodType, date time.Time) (*entity.Period, error) {
 	var period entity.Period
-	err := db.Where("type = ? AND month = ? AND year = ?", "WEEKLY", month, year).
-		Order("week_number DESC").
+	err := db.Where("type IN ? AND start_date < ? AND is_active = ?", periodTypes, date, true).
+		Order("start_date DESC").
 		First(&period).Error
 
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.Log.WithError(err).Error("error finding last period")
 		return nil, err
 	}
 	return &period, nil
 }
 
-func (r *periodRepositoryImpl) FindByStartDate(db *gorm.DB, startDate time.Time) (*entity.Period, error) {
+func (r *periodRepositoryImpl) FindByStartDate(db *gorm.DB, periodType enum.PeriodType, startDate time.Time) (*entity.Period, error) {
 	var period entity.Period
-	err := db.Where("type = ? AND start_date = ?", "WEEKLY", startDate).
+	err := db.Where("type = ? AND start_date = ?", periodType, startDate).
 		First(&period).Error
 
 	if err != nil {
@@ -96,3 +138,150 @@ func (r *periodRepositoryImpl) FindByStartDate(db *gorm.DB, startDate time.Time)
 	}
 	return &period, nil
 }
+
+func (r *periodRepositoryImpl) FindById(db *gorm.DB, id int) (*entity.Period, error) {
+	var period entity.Period
+
+	err := db.First(&period, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &period, nil
+}
+
+func (r *periodRepositoryImpl) FindAll(db *gorm.DB, request *model.FindAllPeriodRequest) ([]entity.Period, int64, error) {
+	var periods []entity.Period
+	var total int64
+
+	countQuery := db.Model(new(entity.Period)).Scopes(r.FilterPeriod(request))
+	if err := countQuery.Count(&total).Error; err != nil {
+		r.Log.WithError(err).Error("failed to count periods")
+		return nil, 0, err
+	}
+
+	query := db.Model(new(entity.Period)).
+		Scopes(r.FilterPeriod(request)).
+		Order("start_date DESC, id DESC")
+
+	if request.Page > 0 && request.PerPage > 0 {
+		offset := (request.Page - 1) * request.PerPage
+		query = query.Offset(offset).Limit(request.PerPage)
+	}
+
+	if err := query.Find(&periods).Error; err != nil {
+		r.Log.WithError(err).Error("failed to find periods")
+		return nil, 0, err
+	}
+
+	return periods, total, nil
+}
+
+func (r *periodRepositoryImpl) FilterPeriod(request *model.FindAllPeriodRequest) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if request.Year > 0 {
+			tx = tx.Where("year = ?", request.Year)
+		}
+
+		if request.Month > 0 {
+			tx = tx.Where("month = ?", request.Month)
+		}
+
+		if request.Type != "" {
+			tx = tx.Where("type = ?", request.Type)
+		}
+
+		switch request.Status {
+		case "open":
+			tx = tx.Where("is_closed = ?", false)
+		case "closed":
+			tx = tx.Where("is_closed = ?", true)
+		}
+
+		if !request.IncludeInactive {
+			tx = tx.Where("is_active = ?", true)
+		}
+
+		return tx
+	}
+}
+
+func (r *periodRepositoryImpl) SummarizeAttendance(db *gorm.DB, id int) (*model.PeriodAttendanceSummary, error) {
+	var summary model.PeriodAttendanceSummary
+
+	err := db.Model(new(entity.EmployeeAttendance)).
+		Select(`COUNT(DISTINCT employee_id) AS employees,
+			COUNT(id) FILTER (WHERE status = ?) AS present,
+			COUNT(id) FILTER (WHERE status = ?) AS leave,
+			COUNT(id) FILTER (WHERE status = ?) AS sick,
+			COUNT(id) FILTER (WHERE status = ?) AS absent`,
+			enum.PRESENT, enum.LEAVE, enum.SICK, enum.ABSENT).
+		Where("period_id = ?", id).
+		Scan(&summary).Error
+	if err != nil {
+		r.Log.WithError(err).Error("failed to summarize period attendance")
+		return nil, err
+	}
+
+	return &summary, nil
+}
+
+func (r *periodRepositoryImpl) SummarizePayroll(db *gorm.DB, id int) (*model.PeriodPayrollSummary, error) {
+	var summary model.PeriodPayrollSummary
+
+	err := db.Model(new(entity.Payroll)).
+		Select(`COUNT(id) AS total,
+			COUNT(id) FILTER (WHERE is_paid) AS paid,
+			COUNT(id) FILTER (WHERE NOT is_paid) AS unpaid`).
+		Where("period_id = ?", id).
+		Scan(&summary).Error
+	if err != nil {
+		r.Log.WithError(err).Error("failed to summarize period payroll")
+		return nil, err
+	}
+
+	return &summary, nil
+}
+
+func (r *periodRepositoryImpl) CountCollections(db *gorm.DB, id int) (int64, error) {
+	var total int64
+
+	err := db.Model(new(entity.Collection)).Where("period_id = ?", id).Count(&total).Error
+	if err != nil {
+		r.Log.WithError(err).Error("failed to count period collections")
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// HasData reports whether anything was recorded in the period
+func (r *periodRepositoryImpl) HasData(db *gorm.DB, id int) (bool, error) {
+	for _, table := range []interface{}{
+		new(entity.EmployeeAttendance),
+		new(entity.Payroll),
+		new(entity.Collection),
+		new(entity.PeriodClosure),
+	} {
+		var total int64
+		if err := db.Model(table).Where("period_id = ?", id).Count(&total).Error; err != nil {
+			r.Log.WithError(err).Error("failed to count period data")
+			return false, err
+		}
+
+		if total > 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (r *periodRepositoryImpl) Deactivate(db *gorm.DB, id int) error {
+	return db.Model(&entity.Period{}).
+		Where("id = ?", id).
+		Update("is_active", false).Error
+}
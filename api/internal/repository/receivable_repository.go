@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"api/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ReceivableRepository interface {
+	Create(db *gorm.DB, receivable *entity.Receivable) error
+	FindByCustomerId(db *gorm.DB, customerId int) ([]entity.Receivable, error)
+}
+
+type receivableRepositoryImpl struct {
+	Log *logrus.Logger
+}
+
+func NewReceivableRepository(log *logrus.Logger) ReceivableRepository {
+	return &receivableRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *receivableRepositoryImpl) Create(db *gorm.DB, receivable *entity.Receivable) error {
+	return db.Create(receivable).Error
+}
+
+func (r *receivableRepositoryImpl) FindByCustomerId(db *gorm.DB, customerId int) ([]entity.Receivable, error) {
+	var receivables []entity.Receivable
+	if err := db.Where("customer_id = ?", customerId).Order("date ASC").Find(&receivables).Error; err != nil {
+		r.Log.WithError(err).Error("failed to find receivables")
+		return nil, err
+	}
+	return receivables, nil
+}
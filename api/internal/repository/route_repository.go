@@ -17,6 +17,7 @@ type RouteRepository interface {
 	Delete(db *gorm.DB, id int) error
 	CountByName(db *gorm.DB, name string) (int64, error)
 	FindById(db *gorm.DB, id int) (*entity.Route, error)
+	ReplaceStops(db *gorm.DB, routeId int, stops []entity.RouteStop) error
 }
 
 type routeRepositoryImpl struct {
@@ -52,6 +53,7 @@ func (r *routeRepositoryImpl) FindAll(db *gorm.DB, request *model.FindAllRouteRe
 	// Main query with filters, preload, and pagination
 	query := db.Model(&entity.Route{}).
 		Scopes(r.FilterRoute(request)).
+		Preload("Stops", orderedStops).
 		Order("name DESC")
 
 	// Pagination
@@ -104,7 +106,7 @@ func (r *routeRepositoryImpl) CountByName(db *gorm.DB, name string) (int64, erro
 func (r *routeRepositoryImpl) FindById(db *gorm.DB, id int) (*entity.Route, error) {
 	var route entity.Route
 
-	err := db.Preload("Sales").First(&route, id).Error
+	err := db.Preload("Sales").Preload("Stops", orderedStops).First(&route, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -114,3 +116,25 @@ func (r *routeRepositoryImpl) FindById(db *gorm.DB, id int) (*entity.Route, erro
 
 	return &route, nil
 }
+
+// ReplaceStops replaces the stops of the route, sequences follow the slice order
+func (r *routeRepositoryImpl) ReplaceStops(db *gorm.DB, routeId int, stops []entity.RouteStop) error {
+	if err := db.Where("route_id = ?", routeId).Delete(&entity.RouteStop{}).Error; err != nil {
+		return err
+	}
+
+	if len(stops) == 0 {
+		return nil
+	}
+
+	for i := range stops {
+		stops[i].RouteId = routeId
+		stops[i].Sequence = i + 1
+	}
+
+	return db.Create(&stops).Error
+}
+
+func orderedStops(db *gorm.DB) *gorm.DB {
+	return db.Order("route_stops.sequence ASC")
+}
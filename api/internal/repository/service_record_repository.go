@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ServiceRecordRepository interface {
+	FindAll(db *gorm.DB, request *model.FindAllServiceRecordRequest) ([]entity.ServiceRecord, int64, error)
+	Create(db *gorm.DB, record *entity.ServiceRecord) error
+	Delete(db *gorm.DB, id int) error
+	FindById(db *gorm.DB, vehicleId int64, id int) (*entity.ServiceRecord, error)
+	FindLatestByPlanIds(db *gorm.DB, planIds []int) ([]entity.ServiceRecord, error)
+	FindOdometers(db *gorm.DB, vehicleIds []int64) ([]model.VehicleOdometer, error)
+}
+
+type serviceRecordRepositoryImpl struct {
+	Log *logrus.Logger
+}
+
+func NewServiceRecordRepository(log *logrus.Logger) ServiceRecordRepository {
+	return &serviceRecordRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *serviceRecordRepositoryImpl) FindAll(db *gorm.DB, request *model.FindAllServiceRecordRequest) ([]entity.ServiceRecord, int64, error) {
+	var records []entity.ServiceRecord
+	var total int64
+
+	countQuery := db.Model(new(entity.ServiceRecord)).Where("vehicle_id = ?", request.VehicleId)
+	if err := countQuery.Count(&total).Error; err != nil {
+		r.Log.WithError(err).Error("failed to count service records")
+		return nil, 0, err
+	}
+
+	query := db.Model(new(entity.ServiceRecord)).Where("vehicle_id = ?", request.VehicleId).Order("date DESC, id DESC")
+
+	if request.Page > 0 && request.PerPage > 0 {
+		offset := (request.Page - 1) * request.PerPage
+		query = query.Offset(offset).Limit(request.PerPage)
+	}
+
+	if err := query.Find(&records).Error; err != nil {
+		r.Log.WithError(err).Error("failed to find service records")
+		return nil, 0, err
+	}
+
+	return records, total, nil
+}
+
+func (r *serviceRecordRepositoryImpl) Create(db *gorm.DB, record *entity.ServiceRecord) error {
+	return db.Create(record).Error
+}
+
+func (r *serviceRecordRepositoryImpl) Delete(db *gorm.DB, id int) error {
+	return db.Delete(&entity.ServiceRecord{}, id).Error
+}
+
+func (r *serviceRecordRepositoryImpl) FindById(db *gorm.DB, vehicleId int64, id int) (*entity.ServiceRecord, error) {
+	var record entity.ServiceRecord
+
+	err := db.Where("vehicle_id = ?", vehicleId).First(&record, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+// FindLatestByPlanIds returns the last service of each plan
+func (r *serviceRecordRepositoryImpl) FindLatestByPlanIds(db *gorm.DB, planIds []int) ([]entity.ServiceRecord, error) {
+	var records []entity.ServiceRecord
+
+	if len(planIds) == 0 {
+		return records, nil
+	}
+
+	if err := db.Select("DISTINCT ON (maintenance_plan_id) *").
+		Where("maintenance_plan_id IN ?", planIds).
+		Order("maintenance_plan_id, date DESC, odometer DESC").
+		Find(&records).Error; err != nil {
+		r.Log.WithError(err).Error("failed to find latest service records")
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// FindOdometers returns the highest recorded odometer of each vehicle
+func (r *serviceRecordRepositoryImpl) FindOdometers(db *gorm.DB, vehicleIds []int64) ([]model.VehicleOdometer, error) {
+	var odometers []model.VehicleOdometer
+
+	if len(vehicleIds) == 0 {
+		return odometers, nil
+	}
+
+	if err := db.Model(new(entity.ServiceRecord)).
+		Select("vehicle_id, MAX(odometer) AS odometer").
+		Where("vehicle_id IN ?", vehicleIds).
+		Group("vehicle_id").
+		Scan(&odometers).Error; err != nil {
+		r.Log.WithError(err).Error("failed to find vehicle odometers")
+		return nil, err
+	}
+
+	return odometers, nil
+}
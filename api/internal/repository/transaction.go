@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// Transactor hands out the handles passed to the repositories. Use cases go
+// through it instead of holding a *gorm.DB, so they also run on the in-memory
+// repositories.
+type Transactor interface {
+	DB(ctx context.Context) *gorm.DB
+	Begin(ctx context.Context) *gorm.DB
+	Commit(tx *gorm.DB) error
+	Rollback(tx *gorm.DB)
+}
+
+type gormTransactor struct {
+	db *gorm.DB
+}
+
+func NewGormTransactor(db *gorm.DB) Transactor {
+	return &gormTransactor{
+		db: db,
+	}
+}
+
+func (t *gormTransactor) DB(ctx context.Context) *gorm.DB {
+	return t.db.WithContext(ctx)
+}
+
+func (t *gormTransactor) Begin(ctx context.Context) *gorm.DB {
+	return t.db.WithContext(ctx).Begin()
+}
+
+func (t *gormTransactor) Commit(tx *gorm.DB) error {
+	return tx.Commit().Error
+}
+
+// Rollback is deferred right after Begin, it does nothing once committed
+func (t *gormTransactor) Rollback(tx *gorm.DB) {
+	tx.Rollback()
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type TripRepository interface {
+	FindAll(db *gorm.DB, request *model.FindAllTripRequest) ([]entity.Trip, int64, error)
+	Create(db *gorm.DB, trip *entity.Trip) error
+	Delete(db *gorm.DB, id int) error
+	FindById(db *gorm.DB, id int) (*entity.Trip, error)
+	FindByDateRange(db *gorm.DB, startDate, endDate time.Time) ([]entity.Trip, error)
+}
+
+type tripRepositoryImpl struct {
+	Log *logrus.Logger
+}
+
+func NewTripRepository(log *logrus.Logger) TripRepository {
+	return &tripRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *tripRepositoryImpl) Create(db *gorm.DB, trip *entity.Trip) error {
+	return db.Create(trip).Error
+}
+
+func (r *tripRepositoryImpl) Delete(db *gorm.DB, id int) error {
+	return db.Delete(&entity.Trip{}, id).Error
+}
+
+func (r *tripRepositoryImpl) FindById(db *gorm.DB, id int) (*entity.Trip, error) {
+	var trip entity.Trip
+
+	err := db.Preload("Vehicle").
+		Preload("Driver").
+		Preload("Helpers").
+		Preload("Routes.Stops", orderedStops).
+		First(&trip, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &trip, nil
+}
+
+func (r *tripRepositoryImpl) FindAll(db *gorm.DB, request *model.FindAllTripRequest) ([]entity.Trip, int64, error) {
+	var trips []entity.Trip
+	var total int64
+
+	countQuery := db.Model(new(entity.Trip)).Scopes(r.FilterTrip(request))
+	if err := countQuery.Count(&total).Error; err != nil {
+		r.Log.WithError(err).Error("failed to count trips")
+		return nil, 0, err
+	}
+
+	query := db.Model(new(entity.Trip)).
+		Scopes(r.FilterTrip(request)).
+		Preload("Vehicle").
+		Preload("Driver").
+		Preload("Helpers").
+		Preload("Routes.Stops", orderedStops).
+		Order("date DESC, id DESC")
+
+	if request.Page > 0 && request.PerPage > 0 {
+		offset := (request.Page - 1) * request.PerPage
+		query = query.Offset(offset).Limit(request.PerPage)
+	}
+
+	if err := query.Find(&trips).Error; err != nil {
+		r.Log.WithError(err).Error("failed to find trips")
+		return nil, 0, err
+	}
+
+	return trips, total, nil
+}
+
+func (r *tripRepositoryImpl) FilterTrip(request *model.FindAllTripRequest) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if request.StartDate != "" {
+			tx = tx.Where("date >= ?", request.StartDate)
+		}
+
+		if request.EndDate != "" {
+			tx = tx.Where("date <= ?", request.EndDate)
+		}
+
+		if request.VehicleId > 0 {
+			tx = tx.Where("vehicle_id = ?", request.VehicleId)
+		}
+
+		if request.DriverId > 0 {
+			tx = tx.Where("driver_id = ?", request.DriverId)
+		}
+
+		return tx
+	}
+}
+
+func (r *tripRepositoryImpl) FindByDateRange(db *gorm.DB, startDate, endDate time.Time) ([]entity.Trip, error) {
+	var trips []entity.Trip
+
+	err := db.Preload("Helpers").
+		Where("date BETWEEN ? AND ?", startDate, endDate).
+		Find(&trips).Error
+	if err != nil {
+		r.Log.WithError(err).Error("failed to find trips")
+		return nil, err
+	}
+
+	return trips, nil
+}
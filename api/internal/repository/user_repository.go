@@ -20,6 +20,7 @@ type UserRepository interface {
 	CountByUsername(db *gorm.DB, username string) (int64, error)
 	CountByPhone(db *gorm.DB, phone string) (int64, error)
 	FindByUsername(db *gorm.DB, username string) (*entity.User, error)
+	FindByEmployeeId(db *gorm.DB, employeeId int) (*entity.User, error)
 }
 
 type userRepositoryImpl struct {
@@ -125,3 +126,15 @@ func (r *userRepositoryImpl) FindByUsername(db *gorm.DB, username string) (*enti
 
 	return user, nil
 }
+
+func (r *userRepositoryImpl) FindByEmployeeId(db *gorm.DB, employeeId int) (*entity.User, error) {
+	user := &entity.User{}
+	if err := db.First(&user, "employee_id = ?", employeeId).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"api/internal/entity"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type VehicleDocumentRepository interface {
+	Create(db *gorm.DB, document *entity.VehicleDocument) error
+	Delete(db *gorm.DB, id int) error
+	FindById(db *gorm.DB, vehicleId int64, id int) (*entity.VehicleDocument, error)
+	FindByVehicleId(db *gorm.DB, vehicleId int64) ([]entity.VehicleDocument, error)
+	FindLatestByVehicleId(db *gorm.DB, vehicleId int64) ([]entity.VehicleDocument, error)
+	FindExpiring(db *gorm.DB, date time.Time) ([]entity.VehicleDocument, error)
+}
+
+type vehicleDocumentRepositoryImpl struct {
+	Log *logrus.Logger
+}
+
+func NewVehicleDocumentRepository(log *logrus.Logger) VehicleDocumentRepository {
+	return &vehicleDocumentRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *vehicleDocumentRepositoryImpl) Create(db *gorm.DB, document *entity.VehicleDocument) error {
+	return db.Create(document).Error
+}
+
+func (r *vehicleDocumentRepositoryImpl) Delete(db *gorm.DB, id int) error {
+	return db.Delete(&entity.VehicleDocument{}, id).Error
+}
+
+func (r *vehicleDocumentRepositoryImpl) FindById(db *gorm.DB, vehicleId int64, id int) (*entity.VehicleDocument, error) {
+	var document entity.VehicleDocument
+
+	err := db.Where("vehicle_id = ?", vehicleId).First(&document, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &document, nil
+}
+
+func (r *vehicleDocumentRepositoryImpl) FindByVehicleId(db *gorm.DB, vehicleId int64) ([]entity.VehicleDocument, error) {
+	var documents []entity.VehicleDocument
+
+	if err := db.Where("vehicle_id = ?", vehicleId).
+		Order("type ASC, expiry_date DESC").
+		Find(&documents).Error; err != nil {
+		r.Log.WithError(err).Error("failed to find vehicle documents")
+		return nil, err
+	}
+
+	return documents, nil
+}
+
+// FindLatestByVehicleId returns the latest document of each type
+func (r *vehicleDocumentRepositoryImpl) FindLatestByVehicleId(db *gorm.DB, vehicleId int64) ([]entity.VehicleDocument, error) {
+	var documents []entity.VehicleDocument
+
+	if err := db.Select("DISTINCT ON (type) *").
+		Where("vehicle_id = ?", vehicleId).
+		Order("type, expiry_date DESC").
+		Find(&documents).Error; err != nil {
+		r.Log.WithError(err).Error("failed to find vehicle documents")
+		return nil, err
+	}
+
+	return documents, nil
+}
+
+// FindExpiring returns the latest document of each vehicle and type expiring on or before date
+func (r *vehicleDocumentRepositoryImpl) FindExpiring(db *gorm.DB, date time.Time) ([]entity.VehicleDocument, error) {
+	var documents []entity.VehicleDocument
+
+	latest := db.Model(new(entity.VehicleDocument)).
+		Select("DISTINCT ON (vehicle_documents.vehicle_id, vehicle_documents.type) vehicle_documents.*").
+		Joins("JOIN vehicles ON vehicles.id = vehicle_documents.vehicle_id AND vehicles.deleted_at IS NULL").
+		Order("vehicle_documents.vehicle_id, vehicle_documents.type, vehicle_documents.expiry_date DESC")
+
+	if err := db.Table("(?) AS vehicle_documents", latest).
+		Preload("Vehicle").
+		Where("expiry_date <= ?", date).
+		Order("expiry_date ASC").
+		Find(&documents).Error; err != nil {
+		r.Log.WithError(err).Error("failed to find expiring vehicle documents")
+		return nil, err
+	}
+
+	return documents, nil
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"api/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type VehicleHistoryRepository interface {
+	Create(db *gorm.DB, history *entity.VehicleHistory) error
+	Delete(db *gorm.DB, id int64) error
+}
+
+type vehicleHistoryRepositoryImpl struct {
+	Log *logrus.Logger
+}
+
+func NewVehicleHistoryRepository(log *logrus.Logger) VehicleHistoryRepository {
+	return &vehicleHistoryRepositoryImpl{
+		Log: log,
+	}
+}
+
+func (r *vehicleHistoryRepositoryImpl) Create(db *gorm.DB, history *entity.VehicleHistory) error {
+	return db.Create(history).Error
+}
+
+func (r *vehicleHistoryRepositoryImpl) Delete(db *gorm.DB, id int64) error {
+	return db.Delete(&entity.VehicleHistory{}, id).Error
+}
@@ -0,0 +1,274 @@
+package usecase
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/money"
+	"api/internal/model"
+	"api/internal/model/converter"
+	"api/internal/repository"
+	"api/internal/utils"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	STATEMENT_ADVANCE   = "ADVANCE"
+	STATEMENT_REPAYMENT = "REPAYMENT"
+)
+
+type CashAdvanceUseCase interface {
+	FindAll(ctx context.Context, request *model.FindAllCashAdvanceRequest) ([]model.CashAdvanceResponse, int64, error)
+	Create(ctx context.Context, request *model.CreateCashAdvanceRequest) (*model.CashAdvanceResponse, error)
+	CreateRepayment(ctx context.Context, request *model.CreateCashAdvanceRepaymentRequest) (*model.CashAdvanceRepaymentResponse, error)
+	Statement(ctx context.Context, request *model.CashAdvanceStatementRequest) (*model.CashAdvanceStatementResponse, error)
+}
+
+type CashAdvanceUseCaseImpl struct {
+	Tx                             repository.Transactor
+	Log                            *logrus.Logger
+	Validate                       *validator.Validate
+	CashAdvanceRepository          repository.CashAdvanceRepository
+	CashAdvanceRepaymentRepository repository.CashAdvanceRepaymentRepository
+	EmployeeRepository             repository.EmployeeRepository
+}
+
+func NewCashAdvanceUseCase(
+	tx repository.Transactor,
+	logger *logrus.Logger,
+	validate *validator.Validate,
+	cashAdvanceRepository repository.CashAdvanceRepository,
+	cashAdvanceRepaymentRepository repository.CashAdvanceRepaymentRepository,
+	employeeRepository repository.EmployeeRepository,
+) CashAdvanceUseCase {
+	return &CashAdvanceUseCaseImpl{
+		Tx:                             tx,
+		Log:                            logger,
+		Validate:                       validate,
+		CashAdvanceRepository:          cashAdvanceRepository,
+		CashAdvanceRepaymentRepository: cashAdvanceRepaymentRepository,
+		EmployeeRepository:             employeeRepository,
+	}
+}
+
+// Helper fuction
+func (u *CashAdvanceUseCaseImpl) validateEmployeeExists(tx *gorm.DB, id int) (*entity.Employee, error) {
+	employee, err := u.EmployeeRepository.FindById(tx, id)
+	if err != nil {
+		u.Log.Warnf("Failed find employee to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if employee == nil {
+		u.Log.Warnf("Employee not found : %d", id)
+		return nil, fiber.NewError(fiber.StatusNotFound, "Karyawan tidak ditemukan")
+	}
+
+	return employee, nil
+}
+
+// Usecase
+func (u *CashAdvanceUseCaseImpl) FindAll(ctx context.Context, request *model.FindAllCashAdvanceRequest) ([]model.CashAdvanceResponse, int64, error) {
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, 0, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	advances, total, err := u.CashAdvanceRepository.FindAll(u.Tx.DB(ctx), request)
+	if err != nil {
+		u.Log.WithError(err).Error("error getting cash advances")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.CashAdvanceResponse, len(advances))
+	for i, advance := range advances {
+		responses[i] = *converter.ToCashAdvanceResponse(&advance)
+	}
+
+	return responses, total, nil
+}
+
+func (u *CashAdvanceUseCaseImpl) Create(ctx context.Context, request *model.CreateCashAdvanceRequest) (*model.CashAdvanceResponse, error) {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	employee, err := u.validateEmployeeExists(tx, request.EmployeeId)
+	if err != nil {
+		return nil, err
+	}
+
+	date, err := time.Parse("2006-01-02", request.Date)
+	if err != nil {
+		u.Log.Warnf("Failed to parse date: %+v", err)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Format tanggal tidak valid")
+	}
+
+	advance := &entity.CashAdvance{
+		Date:        date,
+		Amount:      request.Amount,
+		Installment: request.Installment,
+		Notes:       request.Notes,
+		EmployeeId:  employee.ID,
+	}
+
+	if err := u.CashAdvanceRepository.Create(tx, advance); err != nil {
+		u.Log.Warnf("Failed create cash advance to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"employee_id": request.EmployeeId,
+		}).Warnf("Failed commit to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	advance.Employee = employee
+
+	return converter.ToCashAdvanceResponse(advance), nil
+}
+
+func (u *CashAdvanceUseCaseImpl) CreateRepayment(ctx context.Context, request *model.CreateCashAdvanceRepaymentRequest) (*model.CashAdvanceRepaymentResponse, error) {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	advance, err := u.CashAdvanceRepository.FindById(tx, request.CashAdvanceId)
+	if err != nil {
+		u.Log.Warnf("Failed find cash advance to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if advance == nil {
+		u.Log.Warnf("Cash advance not found : %d", request.CashAdvanceId)
+		return nil, fiber.NewError(fiber.StatusNotFound, "Kasbon tidak ditemukan")
+	}
+
+	// repayment can not exceed the outstanding balance
+	outstanding := advance.Outstanding()
+	if request.Amount > outstanding {
+		u.Log.Warnf("Repayment exceeds outstanding : %v > %v", request.Amount, outstanding)
+		errorMessage := fmt.Sprintf("Pembayaran melebihi sisa kasbon (%s)", outstanding)
+		return nil, fiber.NewError(fiber.StatusBadRequest, errorMessage)
+	}
+
+	date, err := time.Parse("2006-01-02", request.Date)
+	if err != nil {
+		u.Log.Warnf("Failed to parse date: %+v", err)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Format tanggal tidak valid")
+	}
+
+	repayment := &entity.CashAdvanceRepayment{
+		Date:          date,
+		Amount:        request.Amount,
+		Notes:         request.Notes,
+		CashAdvanceId: advance.ID,
+	}
+
+	if err := u.CashAdvanceRepaymentRepository.Create(tx, repayment); err != nil {
+		u.Log.Warnf("Failed create cash advance repayment to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"cash_advance_id": request.CashAdvanceId,
+		}).Warnf("Failed commit to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ToCashAdvanceRepaymentResponse(repayment), nil
+}
+
+func (u *CashAdvanceUseCaseImpl) Statement(ctx context.Context, request *model.CashAdvanceStatementRequest) (*model.CashAdvanceStatementResponse, error) {
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	db := u.Tx.DB(ctx)
+
+	employee, err := u.validateEmployeeExists(db, request.EmployeeId)
+	if err != nil {
+		return nil, err
+	}
+
+	advances, err := u.CashAdvanceRepository.FindByEmployeeId(db, employee.ID)
+	if err != nil {
+		u.Log.WithError(err).Error("error getting cash advances")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := &model.CashAdvanceStatementResponse{
+		EmployeeId:   employee.ID,
+		EmployeeName: employee.Name,
+		Entries:      []model.CashAdvanceStatementEntry{},
+	}
+
+	for _, advance := range advances {
+		response.TotalAdvance += advance.Amount
+		response.Entries = append(response.Entries, model.CashAdvanceStatementEntry{
+			Date:          advance.Date,
+			Type:          STATEMENT_ADVANCE,
+			CashAdvanceId: advance.ID,
+			Notes:         advance.Notes,
+			Debit:         advance.Amount,
+		})
+
+		for _, repayment := range advance.Repayments {
+			response.TotalRepaid += repayment.Amount
+			response.Entries = append(response.Entries, model.CashAdvanceStatementEntry{
+				Date:          repayment.Date,
+				Type:          STATEMENT_REPAYMENT,
+				CashAdvanceId: advance.ID,
+				PayrollId:     repayment.PayrollId,
+				Notes:         repayment.Notes,
+				Credit:        repayment.Amount,
+			})
+		}
+	}
+
+	// advances come before repayments on the same day
+	sort.SliceStable(response.Entries, func(i, j int) bool {
+		a, b := response.Entries[i], response.Entries[j]
+		if !a.Date.Equal(b.Date) {
+			return a.Date.Before(b.Date)
+		}
+		return a.Type == STATEMENT_ADVANCE && b.Type != STATEMENT_ADVANCE
+	})
+
+	var balance money.Money
+	for i := range response.Entries {
+		balance += response.Entries[i].Debit - response.Entries[i].Credit
+		response.Entries[i].Balance = balance
+	}
+
+	response.Outstanding = response.TotalAdvance - response.TotalRepaid
+
+	return response, nil
+}
@@ -0,0 +1,357 @@
+package usecase
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/money"
+	"api/internal/model"
+	"api/internal/model/converter"
+	"api/internal/repository"
+	"api/internal/utils"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type CollectionUseCase interface {
+	CreateReceivable(ctx context.Context, request *model.CreateReceivableRequest) (*model.ReceivableResponse, error)
+	CreateCollection(ctx context.Context, request *model.CreateCollectionRequest) (*model.CollectionResponse, error)
+	CollectionReport(ctx context.Context, request *model.CollectionReportRequest) ([]model.CollectionReportResponse, error)
+	CreateCashDeposit(ctx context.Context, request *model.CreateCashDepositRequest) (*model.CashDepositResponse, error)
+	DepositReconciliation(ctx context.Context, request *model.DepositReconciliationRequest) ([]model.DepositReconciliationResponse, error)
+}
+
+type CollectionUseCaseImpl struct {
+	Tx                    repository.Transactor
+	Log                   *logrus.Logger
+	Validate              *validator.Validate
+	CustomerRepository    repository.CustomerRepository
+	SalesRepository       repository.SalesRepository
+	ReceivableRepository  repository.ReceivableRepository
+	CollectionRepository  repository.CollectionRepository
+	CashDepositRepository repository.CashDepositRepository
+	PeriodUseCase         PeriodUseCase
+}
+
+func NewCollectionUseCase(
+	tx repository.Transactor,
+	logger *logrus.Logger,
+	validate *validator.Validate,
+	customerRepository repository.CustomerRepository,
+	salesRepository repository.SalesRepository,
+	receivableRepository repository.ReceivableRepository,
+	collectionRepository repository.CollectionRepository,
+	cashDepositRepository repository.CashDepositRepository,
+	periodUseCase PeriodUseCase,
+) CollectionUseCase {
+	return &CollectionUseCaseImpl{
+		Tx:                    tx,
+		Log:                   logger,
+		Validate:              validate,
+		CustomerRepository:    customerRepository,
+		SalesRepository:       salesRepository,
+		ReceivableRepository:  receivableRepository,
+		CollectionRepository:  collectionRepository,
+		CashDepositRepository: cashDepositRepository,
+		PeriodUseCase:         periodUseCase,
+	}
+}
+
+// Helper fuction
+func (u *CollectionUseCaseImpl) validateCustomerExists(tx *gorm.DB, id int) (*entity.Customer, error) {
+	customer, err := u.CustomerRepository.FindById(tx, id)
+	if err != nil {
+		u.Log.Warnf("Failed find customer to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if customer == nil {
+		u.Log.Warnf("Customer not found : %d", id)
+		return nil, fiber.NewError(fiber.StatusNotFound, "Pelanggan tidak ditemukan")
+	}
+
+	return customer, nil
+}
+
+func (u *CollectionUseCaseImpl) validateSalesExists(tx *gorm.DB, id int) (*entity.Sales, error) {
+	sales, err := u.SalesRepository.FindById(tx, id)
+	if err != nil {
+		u.Log.Warnf("Failed find sales to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if sales == nil {
+		u.Log.Warnf("Sales not found : %d", id)
+		return nil, fiber.NewError(fiber.StatusNotFound, "Sales tidak ditemukan")
+	}
+
+	return sales, nil
+}
+
+// Usecase
+func (u *CollectionUseCaseImpl) CreateReceivable(ctx context.Context, request *model.CreateReceivableRequest) (*model.ReceivableResponse, error) {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	if _, err := u.validateCustomerExists(tx, request.CustomerId); err != nil {
+		return nil, err
+	}
+
+	date, err := time.Parse("2006-01-02", request.Date)
+	if err != nil {
+		u.Log.Warnf("Failed to parse date: %+v", err)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Format tanggal tidak valid")
+	}
+
+	receivable := &entity.Receivable{
+		CustomerId:  request.CustomerId,
+		Date:        date,
+		Amount:      request.Amount,
+		Description: request.Description,
+	}
+
+	if err := u.ReceivableRepository.Create(tx, receivable); err != nil {
+		u.Log.Warnf("Failed create receivable to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"customer_id": request.CustomerId,
+		}).Warnf("Failed commit to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ToReceivableResponse(receivable), nil
+}
+
+func (u *CollectionUseCaseImpl) CreateCollection(ctx context.Context, request *model.CreateCollectionRequest) (*model.CollectionResponse, error) {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	// the customer row is locked so concurrent payments see each other
+	customer, err := u.CustomerRepository.FindByIdForUpdate(tx, request.CustomerId)
+	if err != nil {
+		u.Log.Warnf("Failed find customer to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if customer == nil {
+		u.Log.Warnf("Customer not found : %d", request.CustomerId)
+		return nil, fiber.NewError(fiber.StatusNotFound, "Pelanggan tidak ditemukan")
+	}
+
+	if _, err := u.validateSalesExists(tx, request.SalesId); err != nil {
+		return nil, err
+	}
+
+	date, err := time.Parse("2006-01-02", request.Date)
+	if err != nil {
+		u.Log.Warnf("Failed to parse date: %+v", err)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Format tanggal tidak valid")
+	}
+
+	// payment can not exceed the outstanding balance
+	balances, err := u.CustomerRepository.FindBalances(tx, []int{request.CustomerId})
+	if err != nil {
+		u.Log.WithError(err).Error("error getting customer balances")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	var outstanding money.Money
+	if len(balances) > 0 {
+		outstanding = balances[0].TotalReceivable - balances[0].TotalCollected
+	}
+
+	if request.Amount > outstanding {
+		u.Log.Warnf("Collection exceeds outstanding : %v > %v", request.Amount, outstanding)
+		errorMessage := fmt.Sprintf("Pembayaran melebihi sisa piutang (%s)", outstanding)
+		return nil, fiber.NewError(fiber.StatusBadRequest, errorMessage)
+	}
+
+	periodId, err := u.PeriodUseCase.GetOrCreatePeriodIdByDate(tx, request.Date)
+	if err != nil {
+		u.Log.Warnf("Failed to generate period id: %+v", err)
+		return nil, err
+	}
+
+	collection := &entity.Collection{
+		CustomerId: request.CustomerId,
+		SalesId:    request.SalesId,
+		PeriodId:   periodId,
+		Date:       date,
+		Amount:     request.Amount,
+		Notes:      request.Notes,
+	}
+
+	if err := u.CollectionRepository.Create(tx, collection); err != nil {
+		u.Log.Warnf("Failed create collection to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"customer_id": request.CustomerId,
+			"sales_id":    request.SalesId,
+		}).Warnf("Failed commit to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ToCollectionResponse(collection), nil
+}
+
+func (u *CollectionUseCaseImpl) CollectionReport(ctx context.Context, request *model.CollectionReportRequest) ([]model.CollectionReportResponse, error) {
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	amounts, err := u.CollectionRepository.SumBySalesInPeriod(u.Tx.DB(ctx), request.PeriodId)
+	if err != nil {
+		u.Log.WithError(err).Error("error getting collection report")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.CollectionReportResponse, len(amounts))
+	for i, amount := range amounts {
+		responses[i] = model.CollectionReportResponse{
+			SalesId:          amount.SalesId,
+			SalesName:        amount.SalesName,
+			TotalCollected:   amount.Total,
+			TransactionCount: amount.Count,
+		}
+	}
+
+	return responses, nil
+}
+
+func (u *CollectionUseCaseImpl) CreateCashDeposit(ctx context.Context, request *model.CreateCashDepositRequest) (*model.CashDepositResponse, error) {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	if _, err := u.validateSalesExists(tx, request.SalesId); err != nil {
+		return nil, err
+	}
+
+	date, err := time.Parse("2006-01-02", request.Date)
+	if err != nil {
+		u.Log.Warnf("Failed to parse date: %+v", err)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Format tanggal tidak valid")
+	}
+
+	deposit := &entity.CashDeposit{
+		SalesId:    request.SalesId,
+		Date:       date,
+		Amount:     request.Amount,
+		Notes:      request.Notes,
+		ReceivedBy: &request.ReceivedBy,
+	}
+
+	if err := u.CashDepositRepository.Create(tx, deposit); err != nil {
+		u.Log.Warnf("Failed create cash deposit to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"sales_id": request.SalesId,
+		}).Warnf("Failed commit to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ToCashDepositResponse(deposit), nil
+}
+
+func (u *CollectionUseCaseImpl) DepositReconciliation(ctx context.Context, request *model.DepositReconciliationRequest) ([]model.DepositReconciliationResponse, error) {
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	date, err := time.Parse("2006-01-02", request.Date)
+	if err != nil {
+		u.Log.Warnf("Failed to parse date: %+v", err)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Format tanggal tidak valid")
+	}
+
+	db := u.Tx.DB(ctx)
+
+	expected, err := u.CollectionRepository.SumBySalesOnDate(db, date)
+	if err != nil {
+		u.Log.WithError(err).Error("error getting collections")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	deposited, err := u.CashDepositRepository.SumBySalesOnDate(db, date)
+	if err != nil {
+		u.Log.WithError(err).Error("error getting cash deposits")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// merge expected and handed over amount per sales
+	resultMap := make(map[int]*model.DepositReconciliationResponse)
+	for _, e := range expected {
+		resultMap[e.SalesId] = &model.DepositReconciliationResponse{
+			SalesId:   e.SalesId,
+			SalesName: e.SalesName,
+			Expected:  e.Total,
+		}
+	}
+
+	for _, d := range deposited {
+		result, ok := resultMap[d.SalesId]
+		if !ok {
+			result = &model.DepositReconciliationResponse{
+				SalesId:   d.SalesId,
+				SalesName: d.SalesName,
+			}
+			resultMap[d.SalesId] = result
+		}
+		result.HandedOver = d.Total
+	}
+
+	responses := make([]model.DepositReconciliationResponse, 0, len(resultMap))
+	for _, result := range resultMap {
+		result.Difference = result.HandedOver - result.Expected
+		responses = append(responses, *result)
+	}
+
+	sort.Slice(responses, func(i, j int) bool {
+		return responses[i].SalesName < responses[j].SalesName
+	})
+
+	return responses, nil
+}
@@ -0,0 +1,616 @@
+package usecase
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/enum"
+	"api/internal/model"
+	"api/internal/model/converter"
+	"api/internal/repository"
+	"api/internal/utils"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type CrewUseCase interface {
+	FindAll(ctx context.Context, request *model.FindAllCrewRequest) ([]model.CrewResponse, error)
+	FindById(ctx context.Context, request *model.FindByIdCrewRequest) (*model.CrewResponse, error)
+	Create(ctx context.Context, request *model.CreateCrewRequest) (*model.CrewResponse, error)
+	Update(ctx context.Context, request *model.UpdateCrewRequest) (*model.CrewResponse, error)
+	Delete(ctx context.Context, request *model.DeleteCrewRequest) error
+	AssignMembers(ctx context.Context, request *model.AssignCrewMembersRequest) (*model.CrewResponse, error)
+	FindMemberHistory(ctx context.Context, request *model.FindCrewMemberHistoryRequest) ([]model.CrewMemberResponse, error)
+	MarkAttendance(ctx context.Context, request *model.MarkCrewAttendanceRequest) ([]model.EmployeeAttendanceResponse, error)
+}
+
+type CrewUseCaseImpl struct {
+	Tx                           repository.Transactor
+	Log                          *logrus.Logger
+	Validate                     *validator.Validate
+	CrewRepository               repository.CrewRepository
+	CrewMemberRepository         repository.CrewMemberRepository
+	EmployeeRepository           repository.EmployeeRepository
+	VehicleRepository            repository.VehicleRepository
+	EmployeeAttendanceRepository repository.EmployeeAttendanceRepository
+	PeriodUseCase                PeriodUseCase
+}
+
+func NewCrewUseCase(
+	tx repository.Transactor,
+	logger *logrus.Logger,
+	validate *validator.Validate,
+	crewRepository repository.CrewRepository,
+	crewMemberRepository repository.CrewMemberRepository,
+	employeeRepository repository.EmployeeRepository,
+	vehicleRepository repository.VehicleRepository,
+	employeeAttendanceRepository repository.EmployeeAttendanceRepository,
+	periodUseCase PeriodUseCase,
+) CrewUseCase {
+	return &CrewUseCaseImpl{
+		Tx:                           tx,
+		Log:                          logger,
+		Validate:                     validate,
+		CrewRepository:               crewRepository,
+		CrewMemberRepository:         crewMemberRepository,
+		EmployeeRepository:           employeeRepository,
+		VehicleRepository:            vehicleRepository,
+		EmployeeAttendanceRepository: employeeAttendanceRepository,
+		PeriodUseCase:                periodUseCase,
+	}
+}
+
+// Helper fuction
+func (u *CrewUseCaseImpl) findCrew(tx *gorm.DB, id int, date time.Time) (*entity.Crew, error) {
+	crew, err := u.CrewRepository.FindById(tx, id, date)
+	if err != nil {
+		u.Log.Warnf("Failed find crew to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if crew == nil {
+		u.Log.Warnf("Crew not found : %d", id)
+		return nil, fiber.NewError(fiber.StatusNotFound, "Kru tidak ditemukan")
+	}
+
+	return crew, nil
+}
+
+// Helper fuction
+func (u *CrewUseCaseImpl) validateName(tx *gorm.DB, name string, excludeId int) error {
+	total, err := u.CrewRepository.CountByName(tx, name, excludeId)
+	if err != nil {
+		u.Log.Warnf("Failed count crew by name : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if total > 0 {
+		return fiber.NewError(fiber.StatusConflict, "Nama kru sudah digunakan")
+	}
+
+	return nil
+}
+
+// Helper fuction
+func (u *CrewUseCaseImpl) validateVehicle(tx *gorm.DB, vehicleId *int64) (*entity.Vehicle, error) {
+	if vehicleId == nil {
+		return nil, nil
+	}
+
+	vehicle, err := u.VehicleRepository.FindById(tx, *vehicleId)
+	if err != nil {
+		u.Log.Warnf("Failed find vehicle to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if vehicle == nil {
+		u.Log.Warnf("Vehicle not found : %d", *vehicleId)
+		return nil, fiber.NewError(fiber.StatusNotFound, "Kendaraan tidak ditemukan")
+	}
+
+	return vehicle, nil
+}
+
+// Helper fuction
+// validateMembers checks the roles of the driver and helpers and that none of
+// them is assigned to another crew from the date onwards
+func (u *CrewUseCaseImpl) validateMembers(tx *gorm.DB, crewId int, driverId int, helperIds []int, date time.Time) (map[int]*entity.Employee, error) {
+	if slices.Contains(helperIds, driverId) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Supir tidak boleh menjadi kernet")
+	}
+
+	ids := append([]int{driverId}, helperIds...)
+
+	employees, err := u.EmployeeRepository.FindByArryId(tx, ids)
+	if err != nil {
+		u.Log.Warnf("Failed find employees to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	found := make(map[int]*entity.Employee)
+	for i := range employees {
+		found[employees[i].ID] = &employees[i]
+	}
+
+	var missing []int
+	for _, id := range ids {
+		employee, ok := found[id]
+		if !ok {
+			missing = append(missing, id)
+			continue
+		}
+
+		expected, label := enum.HELPER, "kernet"
+		if id == driverId {
+			expected, label = enum.DRIVER, "supir"
+		}
+
+		if employee.Role != expected {
+			u.Log.Warnf("Employee role mismatch : %d", id)
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Karyawan %s bukan %s", employee.Name, label))
+		}
+
+		if employee.Status.IsEnded() {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Karyawan %s sudah tidak aktif", employee.Name))
+		}
+	}
+
+	if len(missing) > 0 {
+		u.Log.Warnf("Employees not found : %v", missing)
+		return nil, fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("Karyawan dengan ID %v tidak ditemukan", missing))
+	}
+
+	others, err := u.CrewMemberRepository.FindOtherCrews(tx, ids, crewId, date)
+	if err != nil {
+		u.Log.Warnf("Failed find crew members to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if len(others) > 0 {
+		u.Log.Warnf("Employee already in another crew : %d", others[0].EmployeeId)
+		return nil, fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Karyawan %s sudah tergabung di kru lain", others[0].Employee.Name))
+	}
+
+	return found, nil
+}
+
+// Helper fuction
+// todayDate is the current date in UTC, the way date columns are parsed
+func todayDate() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func (u *CrewUseCaseImpl) FindAll(ctx context.Context, request *model.FindAllCrewRequest) ([]model.CrewResponse, error) {
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	date := todayDate()
+	if request.Date != "" {
+		date, _ = time.Parse("2006-01-02", request.Date)
+	}
+
+	crews, err := u.CrewRepository.FindAll(u.Tx.DB(ctx), request.OnlyActive, date)
+	if err != nil {
+		u.Log.Warnf("Failed find crews to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.CrewResponse, len(crews))
+	for i, crew := range crews {
+		responses[i] = *converter.ToCrewResponse(&crew)
+	}
+
+	return responses, nil
+}
+
+func (u *CrewUseCaseImpl) FindById(ctx context.Context, request *model.FindByIdCrewRequest) (*model.CrewResponse, error) {
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	date := todayDate()
+	if request.Date != "" {
+		date, _ = time.Parse("2006-01-02", request.Date)
+	}
+
+	crew, err := u.findCrew(u.Tx.DB(ctx), request.ID, date)
+	if err != nil {
+		return nil, err
+	}
+
+	return converter.ToCrewResponse(crew), nil
+}
+
+func (u *CrewUseCaseImpl) Create(ctx context.Context, request *model.CreateCrewRequest) (*model.CrewResponse, error) {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	startDate, err := time.Parse("2006-01-02", request.StartDate)
+	if err != nil {
+		u.Log.Warnf("Failed to parse date: %+v", err)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Format tanggal tidak valid")
+	}
+
+	if err := u.validateName(tx, request.Name, 0); err != nil {
+		return nil, err
+	}
+
+	vehicle, err := u.validateVehicle(tx, request.VehicleId)
+	if err != nil {
+		return nil, err
+	}
+
+	employees, err := u.validateMembers(tx, 0, request.DriverId, request.HelperIds, startDate)
+	if err != nil {
+		return nil, err
+	}
+
+	crew := &entity.Crew{
+		Name:      request.Name,
+		IsActive:  true,
+		VehicleId: request.VehicleId,
+	}
+
+	if err := u.CrewRepository.Create(tx, crew); err != nil {
+		u.Log.Warnf("Failed create crew to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	members := make([]*entity.CrewMember, 0, len(employees))
+	for _, id := range append([]int{request.DriverId}, request.HelperIds...) {
+		members = append(members, &entity.CrewMember{
+			CrewId:     crew.ID,
+			EmployeeId: id,
+			StartDate:  startDate,
+		})
+	}
+
+	if err := u.CrewMemberRepository.Create(tx, members); err != nil {
+		u.Log.Warnf("Failed create crew members to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"name": request.Name,
+		}).Warnf("Failed commit to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	crew.Vehicle = vehicle
+	for _, member := range members {
+		member.Employee = employees[member.EmployeeId]
+		crew.Members = append(crew.Members, *member)
+	}
+
+	return converter.ToCrewResponse(crew), nil
+}
+
+func (u *CrewUseCaseImpl) Update(ctx context.Context, request *model.UpdateCrewRequest) (*model.CrewResponse, error) {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	crew, err := u.findCrew(tx, request.ID, todayDate())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.validateName(tx, request.Name, crew.ID); err != nil {
+		return nil, err
+	}
+
+	vehicle, err := u.validateVehicle(tx, request.VehicleId)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := map[string]any{
+		"name":       request.Name,
+		"vehicle_id": request.VehicleId,
+		"is_active":  *request.IsActive,
+	}
+
+	if err := u.CrewRepository.Update(tx, crew.ID, updates); err != nil {
+		u.Log.Warnf("Failed update crew to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"id": request.ID,
+		}).Warnf("Failed commit to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	crew.Name = request.Name
+	crew.VehicleId = request.VehicleId
+	crew.Vehicle = vehicle
+	crew.IsActive = *request.IsActive
+
+	return converter.ToCrewResponse(crew), nil
+}
+
+func (u *CrewUseCaseImpl) Delete(ctx context.Context, request *model.DeleteCrewRequest) error {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	crew, err := u.findCrew(tx, request.ID, todayDate())
+	if err != nil {
+		return err
+	}
+
+	// close the running assignments so the history stays readable
+	open, err := u.CrewMemberRepository.FindOpen(tx, crew.ID)
+	if err != nil {
+		u.Log.Warnf("Failed find crew members to database : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	var ended []int
+	for _, member := range open {
+		if !member.StartDate.After(todayDate()) {
+			ended = append(ended, member.ID)
+		}
+	}
+
+	if len(ended) > 0 {
+		if err := u.CrewMemberRepository.End(tx, ended, todayDate()); err != nil {
+			u.Log.Warnf("Failed end crew members to database : %+v", err)
+			return fiber.ErrInternalServerError
+		}
+	}
+
+	if err := u.CrewRepository.Delete(tx, crew.ID); err != nil {
+		u.Log.Warnf("Failed delete crew to database : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"id": request.ID,
+		}).Warnf("Failed commit to database : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (u *CrewUseCaseImpl) AssignMembers(ctx context.Context, request *model.AssignCrewMembersRequest) (*model.CrewResponse, error) {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	effectiveDate, err := time.Parse("2006-01-02", request.EffectiveDate)
+	if err != nil {
+		u.Log.Warnf("Failed to parse date: %+v", err)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Format tanggal tidak valid")
+	}
+
+	crew, err := u.findCrew(tx, request.ID, effectiveDate)
+	if err != nil {
+		return nil, err
+	}
+
+	if !crew.IsActive {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Kru tidak aktif")
+	}
+
+	if _, err := u.validateMembers(tx, crew.ID, request.DriverId, request.HelperIds, effectiveDate); err != nil {
+		return nil, err
+	}
+
+	open, err := u.CrewMemberRepository.FindOpen(tx, crew.ID)
+	if err != nil {
+		u.Log.Warnf("Failed find crew members to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	wanted := append([]int{request.DriverId}, request.HelperIds...)
+
+	// members who stay keep their assignment, the others leave the day before
+	var leaving []int
+	staying := make(map[int]bool)
+	for _, member := range open {
+		if slices.Contains(wanted, member.EmployeeId) {
+			staying[member.EmployeeId] = true
+			continue
+		}
+
+		if !effectiveDate.After(member.StartDate) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Tanggal efektif harus setelah tanggal mulai penugasan sebelumnya")
+		}
+		leaving = append(leaving, member.ID)
+	}
+
+	var joining []*entity.CrewMember
+	for _, id := range wanted {
+		if !staying[id] {
+			joining = append(joining, &entity.CrewMember{
+				CrewId:     crew.ID,
+				EmployeeId: id,
+				StartDate:  effectiveDate,
+			})
+		}
+	}
+
+	if len(leaving) == 0 && len(joining) == 0 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Anggota kru tidak berubah")
+	}
+
+	if len(leaving) > 0 {
+		if err := u.CrewMemberRepository.End(tx, leaving, effectiveDate.AddDate(0, 0, -1)); err != nil {
+			u.Log.Warnf("Failed end crew members to database : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	if len(joining) > 0 {
+		if err := u.CrewMemberRepository.Create(tx, joining); err != nil {
+			u.Log.Warnf("Failed create crew members to database : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	crew, err = u.findCrew(tx, crew.ID, effectiveDate)
+	if err != nil {
+		return nil, err
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"id": request.ID,
+		}).Warnf("Failed commit to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ToCrewResponse(crew), nil
+}
+
+func (u *CrewUseCaseImpl) FindMemberHistory(ctx context.Context, request *model.FindCrewMemberHistoryRequest) ([]model.CrewMemberResponse, error) {
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	db := u.Tx.DB(ctx)
+
+	if _, err := u.findCrew(db, request.ID, todayDate()); err != nil {
+		return nil, err
+	}
+
+	members, err := u.CrewMemberRepository.FindByCrewId(db, request.ID)
+	if err != nil {
+		u.Log.Warnf("Failed find crew members to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.CrewMemberResponse, len(members))
+	for i, member := range members {
+		responses[i] = *converter.ToCrewMemberResponse(&member)
+	}
+
+	return responses, nil
+}
+
+func (u *CrewUseCaseImpl) MarkAttendance(ctx context.Context, request *model.MarkCrewAttendanceRequest) ([]model.EmployeeAttendanceResponse, error) {
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	date, err := time.Parse("2006-01-02", request.Date)
+	if err != nil {
+		u.Log.Warnf("Failed to parse date: %+v", err)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Format tanggal tidak valid")
+	}
+
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	periodId, err := u.PeriodUseCase.GetOrCreatePeriodIdByDate(tx, request.Date)
+	if err != nil {
+		u.Log.Warnf("Failed to generate period id: %+v", err)
+		return nil, err
+	}
+
+	var attendances []*entity.EmployeeAttendance
+	for _, crewId := range request.CrewIds {
+		crew, err := u.findCrew(tx, crewId, date)
+		if err != nil {
+			return nil, err
+		}
+
+		if !crew.IsActive {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Kru %s tidak aktif", crew.Name))
+		}
+
+		if len(crew.Members) == 0 {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Kru %s belum memiliki anggota", crew.Name))
+		}
+
+		for _, member := range crew.Members {
+			// members who have left the company keep their assignment history only
+			if member.Employee == nil || member.Employee.Status.IsEnded() {
+				continue
+			}
+
+			attendances = append(attendances, &entity.EmployeeAttendance{
+				Date:       date,
+				Status:     enum.AttendanceStatus(request.Status),
+				EmployeeId: member.EmployeeId,
+				PeriodId:   periodId,
+			})
+		}
+	}
+
+	if len(attendances) > 0 {
+		if err := u.EmployeeAttendanceRepository.BatchUpsert(tx, attendances); err != nil {
+			u.Log.Warnf("Failed create attendance to database : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"crew_ids": request.CrewIds,
+			"date":     request.Date,
+		}).Warnf("Failed commit to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.EmployeeAttendanceResponse, len(attendances))
+	for i, attendance := range attendances {
+		responses[i] = *converter.ToEmployeeAttendanceResponse(attendance)
+	}
+
+	return responses, nil
+}
@@ -0,0 +1,262 @@
+package usecase
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+	"api/internal/model/converter"
+	"api/internal/repository"
+	"api/internal/utils"
+	"context"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type CustomerUseCase interface {
+	FindAll(ctx context.Context, request *model.FindAllCustomerRequest) ([]model.CustomerResponse, int64, error)
+	Create(ctx context.Context, request *model.CreateCustomerRequest) (*model.CustomerResponse, error)
+	Update(ctx context.Context, request *model.UpdateCustomerRequest) (*model.CustomerResponse, error)
+	Delete(ctx context.Context, request *model.DeleteCustomerRequest) error
+}
+
+type CustomerUseCaseImpl struct {
+	Tx                 repository.Transactor
+	Log                *logrus.Logger
+	Validate           *validator.Validate
+	CustomerRepository repository.CustomerRepository
+	RouteRepository    repository.RouteRepository
+}
+
+func NewCustomerUseCase(
+	tx repository.Transactor,
+	logger *logrus.Logger,
+	validate *validator.Validate,
+	customerRepository repository.CustomerRepository,
+	routeRepository repository.RouteRepository,
+) CustomerUseCase {
+	return &CustomerUseCaseImpl{
+		Tx:                 tx,
+		Log:                logger,
+		Validate:           validate,
+		CustomerRepository: customerRepository,
+		RouteRepository:    routeRepository,
+	}
+}
+
+// Helper fuction
+func (u *CustomerUseCaseImpl) validateCustomerExists(tx *gorm.DB, id int) (*entity.Customer, error) {
+	customer, err := u.CustomerRepository.FindById(tx, id)
+	if err != nil {
+		u.Log.Warnf("Failed find customer to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if customer == nil {
+		u.Log.Warnf("Customer not found : %d", id)
+		return nil, fiber.NewError(fiber.StatusNotFound, "Pelanggan tidak ditemukan")
+	}
+
+	return customer, nil
+}
+
+func (u *CustomerUseCaseImpl) validateRouteExists(tx *gorm.DB, id int) (*entity.Route, error) {
+	route, err := u.RouteRepository.FindById(tx, id)
+	if err != nil {
+		u.Log.Warnf("Failed find route to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if route == nil {
+		u.Log.Warnf("Route not found : %d", id)
+		return nil, fiber.NewError(fiber.StatusNotFound, "Rute tidak ditemukan")
+	}
+
+	return route, nil
+}
+
+// Usecase
+func (u *CustomerUseCaseImpl) FindAll(ctx context.Context, request *model.FindAllCustomerRequest) ([]model.CustomerResponse, int64, error) {
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, 0, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	db := u.Tx.DB(ctx)
+
+	//get customers
+	customers, total, err := u.CustomerRepository.FindAll(db, request)
+	if err != nil {
+		u.Log.WithError(err).Error("error getting customers")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	ids := make([]int, len(customers))
+	for i, customer := range customers {
+		ids[i] = customer.ID
+	}
+
+	// get outstanding balances
+	balanceMap := make(map[int]model.CustomerBalance)
+	if len(ids) > 0 {
+		balances, err := u.CustomerRepository.FindBalances(db, ids)
+		if err != nil {
+			u.Log.WithError(err).Error("error getting customer balances")
+			return nil, 0, fiber.ErrInternalServerError
+		}
+
+		for _, balance := range balances {
+			balanceMap[balance.CustomerId] = balance
+		}
+	}
+
+	// convert to arry response
+	responses := make([]model.CustomerResponse, len(customers))
+	for i, customer := range customers {
+		response := converter.ToCustomerResponse(&customer)
+
+		balance := balanceMap[customer.ID]
+		response.TotalReceivable = balance.TotalReceivable
+		response.TotalCollected = balance.TotalCollected
+		response.Outstanding = balance.TotalReceivable - balance.TotalCollected
+
+		responses[i] = *response
+	}
+
+	return responses, total, nil
+}
+
+func (u *CustomerUseCaseImpl) Create(ctx context.Context, request *model.CreateCustomerRequest) (*model.CustomerResponse, error) {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	// check route
+	if _, err := u.validateRouteExists(tx, request.RouteId); err != nil {
+		return nil, err
+	}
+
+	//set customer
+	customer := &entity.Customer{
+		Name:    request.Name,
+		Phone:   request.Phone,
+		Address: request.Address,
+		RouteId: request.RouteId,
+	}
+
+	if err := u.CustomerRepository.Create(tx, customer); err != nil {
+		u.Log.Warnf("Failed create customer to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"name": request.Name,
+		}).Warnf("Failed commit to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ToCustomerResponse(customer), nil
+}
+
+func (u *CustomerUseCaseImpl) Update(ctx context.Context, request *model.UpdateCustomerRequest) (*model.CustomerResponse, error) {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	// Check if customer exists
+	customer, err := u.validateCustomerExists(tx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// check route
+	if _, err := u.validateRouteExists(tx, request.RouteId); err != nil {
+		return nil, err
+	}
+
+	//set customer
+	updateCustomer := &entity.Customer{
+		ID:      customer.ID,
+		Name:    request.Name,
+		Phone:   request.Phone,
+		Address: request.Address,
+		RouteId: request.RouteId,
+	}
+
+	if err := u.CustomerRepository.Update(tx, customer.ID, updateCustomer); err != nil {
+		u.Log.Warnf("Failed update customer to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"id":   request.ID,
+			"name": request.Name,
+		}).Warnf("Failed commit to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ToCustomerResponse(updateCustomer), nil
+}
+
+func (u *CustomerUseCaseImpl) Delete(ctx context.Context, request *model.DeleteCustomerRequest) error {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	// Check if customer exists
+	if _, err := u.validateCustomerExists(tx, request.ID); err != nil {
+		return err
+	}
+
+	// check outstanding balance
+	balances, err := u.CustomerRepository.FindBalances(tx, []int{request.ID})
+	if err != nil {
+		u.Log.WithError(err).Error("error getting customer balances")
+		return fiber.ErrInternalServerError
+	}
+
+	if len(balances) > 0 && balances[0].TotalReceivable-balances[0].TotalCollected > 0 {
+		u.Log.Warnf("Customer has outstanding receivable : %d", request.ID)
+		return fiber.NewError(fiber.StatusBadRequest, "Pelanggan masih memiliki piutang")
+	}
+
+	if err := u.CustomerRepository.Delete(tx, request.ID); err != nil {
+		u.Log.WithError(err).Error("error deleting customer")
+		return fiber.ErrInternalServerError
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"id": request.ID,
+		}).Warnf("Failed commit to database : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
@@ -0,0 +1,361 @@
+package usecase
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/enum"
+	"api/internal/model"
+	"api/internal/model/converter"
+	"api/internal/repository"
+	"api/internal/utils"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	EXPIRY_OWNER_DRIVER  = "DRIVER"
+	EXPIRY_OWNER_VEHICLE = "VEHICLE"
+
+	DEFAULT_EXPIRY_DAYS = 30
+)
+
+type DocumentExpiryUseCase interface {
+	FindDriverLicenses(ctx context.Context, request *model.FindAllDriverLicenseRequest) ([]model.DriverLicenseResponse, error)
+	CreateDriverLicense(ctx context.Context, request *model.CreateDriverLicenseRequest) (*model.DriverLicenseResponse, error)
+	DeleteDriverLicense(ctx context.Context, request *model.DeleteDriverLicenseRequest) error
+	FindVehicleDocuments(ctx context.Context, request *model.FindAllVehicleDocumentRequest) ([]model.VehicleDocumentResponse, error)
+	CreateVehicleDocument(ctx context.Context, request *model.CreateVehicleDocumentRequest) (*model.VehicleDocumentResponse, error)
+	DeleteVehicleDocument(ctx context.Context, request *model.DeleteVehicleDocumentRequest) error
+	FindExpiring(ctx context.Context, request *model.FindExpiringDocumentRequest) ([]model.ExpiringDocumentResponse, error)
+}
+
+type DocumentExpiryUseCaseImpl struct {
+	Tx                        repository.Transactor
+	Log                       *logrus.Logger
+	Validate                  *validator.Validate
+	EmployeeRepository        repository.EmployeeRepository
+	VehicleRepository         repository.VehicleRepository
+	DriverLicenseRepository   repository.DriverLicenseRepository
+	VehicleDocumentRepository repository.VehicleDocumentRepository
+}
+
+func NewDocumentExpiryUseCase(
+	tx repository.Transactor,
+	logger *logrus.Logger,
+	validate *validator.Validate,
+	employeeRepository repository.EmployeeRepository,
+	vehicleRepository repository.VehicleRepository,
+	driverLicenseRepository repository.DriverLicenseRepository,
+	vehicleDocumentRepository repository.VehicleDocumentRepository,
+) DocumentExpiryUseCase {
+	return &DocumentExpiryUseCaseImpl{
+		Tx:                        tx,
+		Log:                       logger,
+		Validate:                  validate,
+		EmployeeRepository:        employeeRepository,
+		VehicleRepository:         vehicleRepository,
+		DriverLicenseRepository:   driverLicenseRepository,
+		VehicleDocumentRepository: vehicleDocumentRepository,
+	}
+}
+
+func (u *DocumentExpiryUseCaseImpl) FindDriverLicenses(ctx context.Context, request *model.FindAllDriverLicenseRequest) ([]model.DriverLicenseResponse, error) {
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	licenses, err := u.DriverLicenseRepository.FindByEmployeeId(u.Tx.DB(ctx), request.EmployeeId)
+	if err != nil {
+		u.Log.Warnf("Failed find driver licenses to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.DriverLicenseResponse, len(licenses))
+	for i, license := range licenses {
+		responses[i] = *converter.ToDriverLicenseResponse(&license)
+	}
+
+	return responses, nil
+}
+
+func (u *DocumentExpiryUseCaseImpl) CreateDriverLicense(ctx context.Context, request *model.CreateDriverLicenseRequest) (*model.DriverLicenseResponse, error) {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	expiryDate, err := time.Parse("2006-01-02", request.ExpiryDate)
+	if err != nil {
+		u.Log.Warnf("Failed to parse date: %+v", err)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Format tanggal tidak valid")
+	}
+
+	employee, err := u.EmployeeRepository.FindById(tx, request.EmployeeId)
+	if err != nil {
+		u.Log.Warnf("Failed find employee to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if employee == nil {
+		u.Log.Warnf("Employee not found : %d", request.EmployeeId)
+		return nil, fiber.NewError(fiber.StatusNotFound, "Karyawan tidak ditemukan")
+	}
+
+	if employee.Role != enum.DRIVER {
+		u.Log.Warnf("Employee is not a driver : %d", request.EmployeeId)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Karyawan bukan supir")
+	}
+
+	license := &entity.DriverLicense{
+		EmployeeId: employee.ID,
+		Number:     request.Number,
+		Class:      request.Class,
+		ExpiryDate: expiryDate,
+	}
+
+	if err := u.DriverLicenseRepository.Create(tx, license); err != nil {
+		u.Log.Warnf("Failed create driver license to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"employee_id": request.EmployeeId,
+		}).Warnf("Failed commit to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ToDriverLicenseResponse(license), nil
+}
+
+func (u *DocumentExpiryUseCaseImpl) DeleteDriverLicense(ctx context.Context, request *model.DeleteDriverLicenseRequest) error {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	license, err := u.DriverLicenseRepository.FindById(tx, request.EmployeeId, request.ID)
+	if err != nil {
+		u.Log.Warnf("Failed find driver license to database : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if license == nil {
+		u.Log.Warnf("Driver license not found : %d", request.ID)
+		return fiber.NewError(fiber.StatusNotFound, "SIM tidak ditemukan")
+	}
+
+	if err := u.DriverLicenseRepository.Delete(tx, license.ID); err != nil {
+		u.Log.Warnf("Failed delete driver license to database : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"id": request.ID,
+		}).Warnf("Failed commit to database : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (u *DocumentExpiryUseCaseImpl) FindVehicleDocuments(ctx context.Context, request *model.FindAllVehicleDocumentRequest) ([]model.VehicleDocumentResponse, error) {
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	documents, err := u.VehicleDocumentRepository.FindByVehicleId(u.Tx.DB(ctx), request.VehicleId)
+	if err != nil {
+		u.Log.Warnf("Failed find vehicle documents to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.VehicleDocumentResponse, len(documents))
+	for i, document := range documents {
+		responses[i] = *converter.ToVehicleDocumentResponse(&document)
+	}
+
+	return responses, nil
+}
+
+func (u *DocumentExpiryUseCaseImpl) CreateVehicleDocument(ctx context.Context, request *model.CreateVehicleDocumentRequest) (*model.VehicleDocumentResponse, error) {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	expiryDate, err := time.Parse("2006-01-02", request.ExpiryDate)
+	if err != nil {
+		u.Log.Warnf("Failed to parse date: %+v", err)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Format tanggal tidak valid")
+	}
+
+	vehicle, err := u.VehicleRepository.FindById(tx, request.VehicleId)
+	if err != nil {
+		u.Log.Warnf("Failed find vehicle to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if vehicle == nil {
+		u.Log.Warnf("Vehicle not found : %d", request.VehicleId)
+		return nil, fiber.NewError(fiber.StatusNotFound, "Kendaraan tidak ditemukan")
+	}
+
+	document := &entity.VehicleDocument{
+		VehicleId:  vehicle.ID,
+		Type:       request.Type,
+		Number:     request.Number,
+		ExpiryDate: expiryDate,
+	}
+
+	if err := u.VehicleDocumentRepository.Create(tx, document); err != nil {
+		u.Log.Warnf("Failed create vehicle document to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"vehicle_id": request.VehicleId,
+			"type":       request.Type,
+		}).Warnf("Failed commit to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ToVehicleDocumentResponse(document), nil
+}
+
+func (u *DocumentExpiryUseCaseImpl) DeleteVehicleDocument(ctx context.Context, request *model.DeleteVehicleDocumentRequest) error {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	document, err := u.VehicleDocumentRepository.FindById(tx, request.VehicleId, request.ID)
+	if err != nil {
+		u.Log.Warnf("Failed find vehicle document to database : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if document == nil {
+		u.Log.Warnf("Vehicle document not found : %d", request.ID)
+		return fiber.NewError(fiber.StatusNotFound, "Dokumen kendaraan tidak ditemukan")
+	}
+
+	if err := u.VehicleDocumentRepository.Delete(tx, document.ID); err != nil {
+		u.Log.Warnf("Failed delete vehicle document to database : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"id": request.ID,
+		}).Warnf("Failed commit to database : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (u *DocumentExpiryUseCaseImpl) FindExpiring(ctx context.Context, request *model.FindExpiringDocumentRequest) ([]model.ExpiringDocumentResponse, error) {
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	db := u.Tx.DB(ctx)
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	until := today.AddDate(0, 0, request.Days)
+
+	licenses, err := u.DriverLicenseRepository.FindExpiring(db, until)
+	if err != nil {
+		u.Log.Warnf("Failed find expiring driver licenses to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	documents, err := u.VehicleDocumentRepository.FindExpiring(db, until)
+	if err != nil {
+		u.Log.Warnf("Failed find expiring vehicle documents to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.ExpiringDocumentResponse, 0, len(licenses)+len(documents))
+	for _, license := range licenses {
+		response := model.ExpiringDocumentResponse{
+			Owner:      EXPIRY_OWNER_DRIVER,
+			OwnerId:    int64(license.EmployeeId),
+			Type:       "SIM " + string(license.Class),
+			Number:     license.Number,
+			ExpiryDate: license.ExpiryDate,
+			DaysLeft:   daysBetween(today, license.ExpiryDate),
+		}
+		if license.Employee != nil {
+			response.OwnerName = license.Employee.Name
+		}
+		responses = append(responses, response)
+	}
+
+	for _, document := range documents {
+		response := model.ExpiringDocumentResponse{
+			Owner:      EXPIRY_OWNER_VEHICLE,
+			OwnerId:    document.VehicleId,
+			Type:       string(document.Type),
+			Number:     document.Number,
+			ExpiryDate: document.ExpiryDate,
+			DaysLeft:   daysBetween(today, document.ExpiryDate),
+		}
+		if document.Vehicle != nil {
+			response.OwnerName = document.Vehicle.Plate
+		}
+		responses = append(responses, response)
+	}
+
+	sort.SliceStable(responses, func(i, j int) bool {
+		return responses[i].ExpiryDate.Before(responses[j].ExpiryDate)
+	})
+
+	return responses, nil
+}
+
+// Helper fuction
+func daysBetween(from, to time.Time) int {
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from).Hours() / 24)
+}
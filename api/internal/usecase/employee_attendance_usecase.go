@@ -4,6 +4,7 @@ import (
 	"api/internal/entity"
 	"api/internal/entity/enum"
 	"api/internal/model"
+	"api/internal/model/converter"
 	"api/internal/repository"
 	"api/internal/utils"
 	"context"
@@ -17,35 +18,43 @@ import (
 
 type EmployeeAttendanceUseCase interface {
 	Upsert(ctx context.Context, request *model.UpsertEmployeeAttendanceRequest) error
+	CheckIn(ctx context.Context, request *model.CheckInRequest) (*model.CheckInResponse, error)
+	FindByEmployee(ctx context.Context, request *model.FindAttendanceByEmployeeRequest) ([]model.EmployeeAttendanceResponse, error)
 }
 
 type EmployeeAttendanceUseCaseImpl struct {
-	DB                           *gorm.DB
+	Tx                           repository.Transactor
 	Log                          *logrus.Logger
 	Validate                     *validator.Validate
 	EmployeeAttendanceRepository repository.EmployeeAttendanceRepository
+	EmployeeRepository           repository.EmployeeRepository
+	GeofenceRepository           repository.GeofenceRepository
 	PeriodUsecase                PeriodUseCase
 }
 
 func NewEmployeeAttendanceUseCase(
-	db *gorm.DB,
+	tx repository.Transactor,
 	logger *logrus.Logger,
 	validate *validator.Validate,
-	employeeRepository repository.EmployeeAttendanceRepository,
+	employeeAttendanceRepository repository.EmployeeAttendanceRepository,
+	employeeRepository repository.EmployeeRepository,
+	geofenceRepository repository.GeofenceRepository,
 	periodUsecase PeriodUseCase,
 ) EmployeeAttendanceUseCase {
 	return &EmployeeAttendanceUseCaseImpl{
-		DB:                           db,
+		Tx:                           tx,
 		Log:                          logger,
 		Validate:                     validate,
-		EmployeeAttendanceRepository: employeeRepository,
+		EmployeeAttendanceRepository: employeeAttendanceRepository,
+		EmployeeRepository:           employeeRepository,
+		GeofenceRepository:           geofenceRepository,
 		PeriodUsecase:                periodUsecase,
 	}
 }
 
 func (u *EmployeeAttendanceUseCaseImpl) Upsert(ctx context.Context, request *model.UpsertEmployeeAttendanceRequest) error {
-	tx := u.DB.WithContext(ctx).Begin()
-	defer tx.Rollback()
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
 
 	// Validate request
 	details, errorMessage, err := utils.ValidateStruct(request)
@@ -61,10 +70,10 @@ func (u *EmployeeAttendanceUseCaseImpl) Upsert(ctx context.Context, request *mod
 	// Create data partition
 	for _, req := range request.Attendances {
 		if req.Action == "update" {
-			resultData, err := u.CreateUpsertData(ctx, &req)
+			resultData, err := u.CreateUpsertData(tx, &req)
 			if err != nil {
 				u.Log.Warnf("Failed to create array data: %+v", err)
-				return fiber.ErrInternalServerError
+				return err
 			}
 			dataUpsert = append(dataUpsert, resultData...)
 		} else {
@@ -95,7 +104,7 @@ func (u *EmployeeAttendanceUseCaseImpl) Upsert(ctx context.Context, request *mod
 	}
 
 	// Commit transaction
-	if err := tx.Commit().Error; err != nil {
+	if err := u.Tx.Commit(tx); err != nil {
 		u.Log.Warnf("Failed to commit transaction: %+v", err)
 		return fiber.ErrInternalServerError
 	}
@@ -103,7 +112,7 @@ func (u *EmployeeAttendanceUseCaseImpl) Upsert(ctx context.Context, request *mod
 	return nil
 }
 
-func (u *EmployeeAttendanceUseCaseImpl) CreateUpsertData(ctx context.Context, request *model.AttendanceAction) ([]*entity.EmployeeAttendance, error) {
+func (u *EmployeeAttendanceUseCaseImpl) CreateUpsertData(tx *gorm.DB, request *model.AttendanceAction) ([]*entity.EmployeeAttendance, error) {
 	var attendances []*entity.EmployeeAttendance
 
 	// Parse date string to time.Time
@@ -113,7 +122,7 @@ func (u *EmployeeAttendanceUseCaseImpl) CreateUpsertData(ctx context.Context, re
 		return nil, err
 	}
 
-	periodId, err := u.PeriodUsecase.GetOrCreatePeriodIdByDate(ctx, request.Date)
+	periodId, err := u.PeriodUsecase.GetOrCreatePeriodIdByDate(tx, request.Date)
 	if err != nil {
 		u.Log.Warnf("Failed to generate period id: %+v", err)
 		return nil, err
@@ -133,3 +142,132 @@ func (u *EmployeeAttendanceUseCaseImpl) CreateUpsertData(ctx context.Context, re
 
 	return attendances, nil
 }
+
+func (u *EmployeeAttendanceUseCaseImpl) CheckIn(ctx context.Context, request *model.CheckInRequest) (*model.CheckInResponse, error) {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	// Validate request
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	employee, err := u.EmployeeRepository.FindById(tx, request.EmployeeId)
+	if err != nil {
+		u.Log.Warnf("Failed find employee to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if employee == nil {
+		u.Log.Warnf("Employee not found : %d", request.EmployeeId)
+		return nil, fiber.NewError(fiber.StatusNotFound, "Karyawan tidak ditemukan")
+	}
+
+	if employee.Status.IsEnded() {
+		u.Log.Warnf("Employee no longer active : %d", request.EmployeeId)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Karyawan sudah tidak aktif")
+	}
+
+	geofences, err := u.GeofenceRepository.FindAllActive(tx)
+	if err != nil {
+		u.Log.Warnf("Failed find geofences to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// Find the nearest geofence containing the location
+	var matched *entity.Geofence
+	var distance float64
+	for i := range geofences {
+		d := utils.HaversineDistance(request.Latitude, request.Longitude, geofences[i].Latitude, geofences[i].Longitude)
+		if d <= float64(geofences[i].Radius) && (matched == nil || d < distance) {
+			matched = &geofences[i]
+			distance = d
+		}
+	}
+
+	if matched == nil {
+		u.Log.WithFields(logrus.Fields{
+			"employee_id": request.EmployeeId,
+			"latitude":    request.Latitude,
+			"longitude":   request.Longitude,
+		}).Warn("Check-in outside geofence")
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Lokasi di luar area absensi")
+	}
+
+	now := time.Now()
+	dateString := now.Format("2006-01-02")
+	date, err := time.Parse("2006-01-02", dateString)
+	if err != nil {
+		u.Log.Warnf("Failed to parse date: %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	periodId, err := u.PeriodUsecase.GetOrCreatePeriodIdByDate(tx, dateString)
+	if err != nil {
+		u.Log.Warnf("Failed to generate period id: %+v", err)
+		return nil, err
+	}
+
+	attendance := &entity.EmployeeAttendance{
+		Date:             date,
+		Status:           enum.PRESENT,
+		EmployeeId:       employee.ID,
+		PeriodId:         periodId,
+		CheckInAt:        &now,
+		Latitude:         &request.Latitude,
+		Longitude:        &request.Longitude,
+		LocationAccuracy: request.Accuracy,
+		GeofenceId:       &matched.ID,
+	}
+
+	if err := u.EmployeeAttendanceRepository.BatchUpsert(tx, []*entity.EmployeeAttendance{attendance}); err != nil {
+		u.Log.Warnf("Failed create attendance to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// Commit transaction
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"employee_id": request.EmployeeId,
+		}).Warnf("Failed commit to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return &model.CheckInResponse{
+		EmployeeId:   employee.ID,
+		Date:         date,
+		Status:       string(enum.PRESENT),
+		CheckInAt:    now,
+		GeofenceId:   matched.ID,
+		GeofenceName: matched.Name,
+		Distance:     distance,
+	}, nil
+}
+
+func (u *EmployeeAttendanceUseCaseImpl) FindByEmployee(ctx context.Context, request *model.FindAttendanceByEmployeeRequest) ([]model.EmployeeAttendanceResponse, error) {
+	// Validate request
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	startDate, _ := time.Parse("2006-01-02", request.StartDate)
+	endDate, _ := time.Parse("2006-01-02", request.EndDate)
+
+	attendances, err := u.EmployeeAttendanceRepository.FindByEmployeeId(u.Tx.DB(ctx), request.EmployeeId, startDate, endDate)
+	if err != nil {
+		u.Log.WithError(err).Error("error getting employee attendances")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.EmployeeAttendanceResponse, len(attendances))
+	for i, attendance := range attendances {
+		responses[i] = *converter.ToEmployeeAttendanceResponse(&attendance)
+		responses[i].EmployeeId = attendance.EmployeeId
+	}
+
+	return responses, nil
+}
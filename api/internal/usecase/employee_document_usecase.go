@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+	"api/internal/model/converter"
+	"api/internal/repository"
+	"api/internal/utils"
+	"context"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// documentExtensions maps the accepted content types to the stored file extension
+var documentExtensions = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"application/pdf": ".pdf",
+}
+
+type EmployeeDocumentUseCase interface {
+	FindAll(ctx context.Context, request *model.FindAllEmployeeDocumentRequest) ([]model.EmployeeDocumentResponse, error)
+	Upload(ctx context.Context, request *model.UploadEmployeeDocumentRequest) (*model.EmployeeDocumentResponse, error)
+	Download(ctx context.Context, request *model.EmployeeDocumentRequest) (*model.EmployeeDocumentFile, error)
+	Delete(ctx context.Context, request *model.EmployeeDocumentRequest) error
+}
+
+type EmployeeDocumentUseCaseImpl struct {
+	Tx                         repository.Transactor
+	Log                        *logrus.Logger
+	Validate                   *validator.Validate
+	Storage                    utils.FileStorage
+	EmployeeRepository         repository.EmployeeRepository
+	EmployeeDocumentRepository repository.EmployeeDocumentRepository
+}
+
+func NewEmployeeDocumentUseCase(
+	tx repository.Transactor,
+	logger *logrus.Logger,
+	validate *validator.Validate,
+	storage utils.FileStorage,
+	employeeRepository repository.EmployeeRepository,
+	employeeDocumentRepository repository.EmployeeDocumentRepository,
+) EmployeeDocumentUseCase {
+	return &EmployeeDocumentUseCaseImpl{
+		Tx:                         tx,
+		Log:                        logger,
+		Validate:                   validate,
+		Storage:                    storage,
+		EmployeeRepository:         employeeRepository,
+		EmployeeDocumentRepository: employeeDocumentRepository,
+	}
+}
+
+func (u *EmployeeDocumentUseCaseImpl) FindAll(ctx context.Context, request *model.FindAllEmployeeDocumentRequest) ([]model.EmployeeDocumentResponse, error) {
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	documents, err := u.EmployeeDocumentRepository.FindByEmployeeId(u.Tx.DB(ctx), request.EmployeeId)
+	if err != nil {
+		u.Log.Warnf("Failed find employee documents to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.EmployeeDocumentResponse, len(documents))
+	for i, document := range documents {
+		responses[i] = *converter.ToEmployeeDocumentResponse(&document)
+	}
+
+	return responses, nil
+}
+
+func (u *EmployeeDocumentUseCaseImpl) Upload(ctx context.Context, request *model.UploadEmployeeDocumentRequest) (*model.EmployeeDocumentResponse, error) {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	employee, err := u.EmployeeRepository.FindById(tx, request.EmployeeId)
+	if err != nil {
+		u.Log.Warnf("Failed find employee to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if employee == nil {
+		u.Log.Warnf("Employee not found : %d", request.EmployeeId)
+		return nil, fiber.NewError(fiber.StatusNotFound, "Karyawan tidak ditemukan")
+	}
+
+	key := fmt.Sprintf("employees/%d/%s%s", employee.ID, uuid.NewString(), documentExtensions[request.ContentType])
+	if err := u.Storage.Save(key, request.Content); err != nil {
+		u.Log.Warnf("Failed save document to storage : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	document := &entity.EmployeeDocument{
+		EmployeeId:  employee.ID,
+		Type:        request.Type,
+		FileName:    request.FileName,
+		StorageKey:  key,
+		ContentType: request.ContentType,
+		Size:        request.Size,
+		Notes:       request.Notes,
+		UploadedBy:  &request.UploadedBy,
+	}
+
+	if err := u.EmployeeDocumentRepository.Create(tx, document); err != nil {
+		u.Log.Warnf("Failed create employee document to database : %+v", err)
+		u.removeFile(key)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"employee_id": request.EmployeeId,
+			"type":        request.Type,
+		}).Warnf("Failed commit to database : %+v", err)
+		u.removeFile(key)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ToEmployeeDocumentResponse(document), nil
+}
+
+func (u *EmployeeDocumentUseCaseImpl) Download(ctx context.Context, request *model.EmployeeDocumentRequest) (*model.EmployeeDocumentFile, error) {
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	document, err := u.EmployeeDocumentRepository.FindById(u.Tx.DB(ctx), request.EmployeeId, request.ID)
+	if err != nil {
+		u.Log.Warnf("Failed find employee document to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if document == nil {
+		u.Log.Warnf("Employee document not found : %d", request.ID)
+		return nil, fiber.NewError(fiber.StatusNotFound, "Dokumen tidak ditemukan")
+	}
+
+	content, err := u.Storage.Open(document.StorageKey)
+	if err != nil {
+		u.Log.Warnf("Failed open document from storage : %+v", err)
+		return nil, fiber.NewError(fiber.StatusNotFound, "File dokumen tidak ditemukan")
+	}
+
+	return &model.EmployeeDocumentFile{
+		FileName:    document.FileName,
+		ContentType: document.ContentType,
+		Size:        document.Size,
+		Content:     content,
+	}, nil
+}
+
+func (u *EmployeeDocumentUseCaseImpl) Delete(ctx context.Context, request *model.EmployeeDocumentRequest) error {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	document, err := u.EmployeeDocumentRepository.FindById(tx, request.EmployeeId, request.ID)
+	if err != nil {
+		u.Log.Warnf("Failed find employee document to database : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if document == nil {
+		u.Log.Warnf("Employee document not found : %d", request.ID)
+		return fiber.NewError(fiber.StatusNotFound, "Dokumen tidak ditemukan")
+	}
+
+	if err := u.EmployeeDocumentRepository.Delete(tx, document.ID); err != nil {
+		u.Log.Warnf("Failed delete employee document to database : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"id": request.ID,
+		}).Warnf("Failed commit to database : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	// The metadata is soft deleted, the file is no longer reachable
+	u.removeFile(document.StorageKey)
+
+	return nil
+}
+
+// Helper fuction
+func (u *EmployeeDocumentUseCaseImpl) removeFile(key string) {
+	if err := u.Storage.Delete(key); err != nil {
+		u.Log.Warnf("Failed delete document from storage : %+v", err)
+	}
+}
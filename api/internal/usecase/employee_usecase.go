@@ -25,32 +25,40 @@ type EmployeeUseCase interface {
 	FindById(ctx context.Context, request *model.FindByIdEmployeeRequest) (*model.EmployeeResponse, error)
 	validateAndGetRoutes(tx *gorm.DB, routeIDs []int) ([]entity.Route, error)
 	FindAllWithAttendances(ctx context.Context, request *model.FindAllEmployeeWithAttendanceRequest) ([]model.EmployeeResponse, error)
+	ChangeStatus(ctx context.Context, request *model.ChangeEmployeeStatusRequest) (*model.EmployeeStatusHistoryResponse, error)
+	FindStatusHistory(ctx context.Context, request *model.FindEmployeeStatusHistoryRequest) ([]model.EmployeeStatusHistoryResponse, error)
+	FindTree(ctx context.Context, request *model.FindEmployeeTreeRequest) ([]model.EmployeeTreeResponse, error)
 }
 
 type EmployeeUseCaseImpl struct {
-	DB                 *gorm.DB
+	Tx                 repository.Transactor
 	Log                *logrus.Logger
 	Validate           *validator.Validate
 	EmployeeRepository repository.EmployeeRepository
 	RouteRepository    repository.RouteRepository
 	SalesRepository    repository.SalesRepository
+
+	EmployeeStatusHistoryRepository repository.EmployeeStatusHistoryRepository
 }
 
 func NewEmployeeUseCase(
-	db *gorm.DB,
+	tx repository.Transactor,
 	logger *logrus.Logger,
 	validate *validator.Validate,
 	employeeRepository repository.EmployeeRepository,
 	routeRepository repository.RouteRepository,
 	salesRepository repository.SalesRepository,
+	employeeStatusHistoryRepository repository.EmployeeStatusHistoryRepository,
 ) EmployeeUseCase {
 	return &EmployeeUseCaseImpl{
-		DB:                 db,
+		Tx:                 tx,
 		Log:                logger,
 		Validate:           validate,
 		EmployeeRepository: employeeRepository,
 		RouteRepository:    routeRepository,
 		SalesRepository:    salesRepository,
+
+		EmployeeStatusHistoryRepository: employeeStatusHistoryRepository,
 	}
 }
 
@@ -62,7 +70,7 @@ func (u *EmployeeUseCaseImpl) FindAll(ctx context.Context, request *model.FindAl
 		return nil, 0, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
 	}
 
-	employees, total, err := u.EmployeeRepository.FindAll(u.DB.WithContext(ctx), request)
+	employees, total, err := u.EmployeeRepository.FindAll(u.Tx.DB(ctx), request)
 	if err != nil {
 		u.Log.WithError(err).Error("error getting employees")
 		return nil, 0, fiber.ErrInternalServerError
@@ -78,8 +86,8 @@ func (u *EmployeeUseCaseImpl) FindAll(ctx context.Context, request *model.FindAl
 }
 
 func (u *EmployeeUseCaseImpl) Create(ctx context.Context, request *model.CreateEmployeeRequest) (*model.EmployeeResponse, error) {
-	tx := u.DB.WithContext(ctx).Begin()
-	defer tx.Rollback()
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
 
 	// Validate request
 	details, errorMessage, err := utils.ValidateStruct(request)
@@ -96,11 +104,16 @@ func (u *EmployeeUseCaseImpl) Create(ctx context.Context, request *model.CreateE
 		JoinDate: time.Now(),
 	}
 
-	// Set supervisor for driver/helper
-	if request.Role == enum.DRIVER || request.Role == enum.HELPER {
-		if request.SupervisorId != 0 {
-			newEmployee.SupervisorId = &request.SupervisorId
+	if err := u.applyProfile(tx, newEmployee, request.Phone, &request.EmployeeProfileRequest); err != nil {
+		return nil, err
+	}
+
+	// Set supervisor when given, roles without supervisors ignore it
+	if request.SupervisorId != 0 && len(request.Role.SupervisorRoles()) > 0 {
+		if err := u.validateSupervisor(tx, 0, request.Role, request.SupervisorId); err != nil {
+			return nil, err
 		}
+		newEmployee.SupervisorId = &request.SupervisorId
 	}
 
 	// Save Employee first to get ID
@@ -109,6 +122,16 @@ func (u *EmployeeUseCaseImpl) Create(ctx context.Context, request *model.CreateE
 		return nil, fiber.ErrInternalServerError
 	}
 
+	// Start the lifecycle history at the join date
+	if err := u.EmployeeStatusHistoryRepository.Create(tx, &entity.EmployeeStatusHistory{
+		EmployeeId:    newEmployee.ID,
+		Status:        enum.EMPLOYEE_ACTIVE,
+		EffectiveDate: newEmployee.JoinDate,
+	}); err != nil {
+		u.Log.Warnf("Failed create employee status history to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
 	// Create Sales if role is SALES (AFTER employee created)
 	// FIX: move this to sales usecase
 	if request.Role == enum.SALES {
@@ -162,7 +185,7 @@ func (u *EmployeeUseCaseImpl) Create(ctx context.Context, request *model.CreateE
 	}
 
 	// Commit transaction
-	if err := tx.Commit().Error; err != nil {
+	if err := u.Tx.Commit(tx); err != nil {
 		u.Log.WithFields(logrus.Fields{
 			"name": request.Name,
 		}).Warnf("Failed commit to database : %+v", err)
@@ -173,8 +196,8 @@ func (u *EmployeeUseCaseImpl) Create(ctx context.Context, request *model.CreateE
 }
 
 func (u *EmployeeUseCaseImpl) Update(ctx context.Context, request *model.UpdateEmployeeRequest) (*model.EmployeeResponse, error) {
-	tx := u.DB.WithContext(ctx).Begin()
-	defer tx.Rollback()
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
 
 	// Validate request
 	details, errorMessage, err := utils.ValidateStruct(request)
@@ -203,23 +226,42 @@ func (u *EmployeeUseCaseImpl) Update(ctx context.Context, request *model.UpdateE
 	employee.Salary = request.Salary
 	employee.Role = request.Role
 
-	// Handle supervisor for driver/helper
-	if request.Role == enum.DRIVER || request.Role == enum.HELPER {
-		if request.SupervisorId != 0 {
-			employee.SupervisorId = &request.SupervisorId
-		} else {
-			employee.SupervisorId = nil
+	if err := u.applyProfile(tx, employee, request.Phone, &request.EmployeeProfileRequest); err != nil {
+		return nil, err
+	}
+
+	// Handle supervisor, roles without supervisors ignore it
+	if request.SupervisorId != 0 && len(request.Role.SupervisorRoles()) > 0 {
+		if err := u.validateSupervisor(tx, employee.ID, request.Role, request.SupervisorId); err != nil {
+			return nil, err
 		}
+		employee.SupervisorId = &request.SupervisorId
 	} else {
 		employee.SupervisorId = nil
 	}
 
+	// Subordinates must still accept the new role as their supervisor
+	if oldRole != newRole {
+		for _, subordinate := range employee.Subordinates {
+			if !subordinate.Role.CanBeSupervisedBy(newRole) {
+				u.Log.Warnf("Employee has subordinates : %d", request.ID)
+				return nil, fiber.NewError(fiber.StatusBadRequest, "Karyawan masih memiliki bawahan yang tidak dapat dipimpin jabatan baru")
+			}
+		}
+	}
+
 	// Update employee
 	if err := u.EmployeeRepository.Update(tx, employee); err != nil {
 		u.Log.Warnf("Failed update Employee to database : %+v", err)
 		return nil, fiber.ErrInternalServerError
 	}
 
+	// Updates skips nil fields, so the supervisor is written separately
+	if err := u.EmployeeRepository.UpdateSupervisor(tx, employee.ID, employee.SupervisorId); err != nil {
+		u.Log.Warnf("Failed update employee supervisor to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
 	// Handle role change scenarios
 	switch {
 	case (oldRole == enum.DRIVER || oldRole == enum.HELPER) && (newRole != enum.DRIVER && newRole != enum.HELPER):
@@ -298,7 +340,7 @@ func (u *EmployeeUseCaseImpl) Update(ctx context.Context, request *model.UpdateE
 	}
 
 	// Commit transaction
-	if err := tx.Commit().Error; err != nil {
+	if err := u.Tx.Commit(tx); err != nil {
 		u.Log.WithFields(logrus.Fields{
 			"id":   request.ID,
 			"name": request.Name,
@@ -345,9 +387,110 @@ func (u *EmployeeUseCaseImpl) validateAndGetRoutes(tx *gorm.DB, routeIDs []int)
 	return routes, nil
 }
 
+// validateSupervisor checks the supervisor may lead the role and does not sit
+// below the employee, id is zero for a new employee. The role must have
+// supervisor roles.
+func (u *EmployeeUseCaseImpl) validateSupervisor(tx *gorm.DB, id int, role enum.EmployeeRole, supervisorId int) error {
+	if supervisorId == id {
+		return fiber.NewError(fiber.StatusBadRequest, "Karyawan tidak dapat menjadi atasan dirinya sendiri")
+	}
+
+	supervisorRoles := role.SupervisorRoles()
+
+	supervisor, err := u.EmployeeRepository.FindById(tx, supervisorId)
+	if err != nil {
+		u.Log.Warnf("Failed find supervisor to database : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if supervisor == nil {
+		u.Log.Warnf("Supervisor not found : %d", supervisorId)
+		return fiber.NewError(fiber.StatusNotFound, "Atasan tidak ditemukan")
+	}
+
+	if supervisor.Status.IsEnded() {
+		return fiber.NewError(fiber.StatusBadRequest, "Atasan sudah tidak aktif")
+	}
+
+	if !role.CanBeSupervisedBy(supervisor.Role) {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Atasan %s harus %v", role, supervisorRoles))
+	}
+
+	if id == 0 {
+		return nil
+	}
+
+	// the supervisor must not already report to the employee
+	isSubordinate, err := u.EmployeeRepository.IsSubordinate(tx, supervisorId, id)
+	if err != nil {
+		u.Log.Warnf("Failed check employee subordinate : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if isSubordinate {
+		u.Log.Warnf("Supervisor cycle : %d -> %d", id, supervisorId)
+		return fiber.NewError(fiber.StatusBadRequest, "Atasan tidak boleh bawahan dari karyawan ini")
+	}
+
+	return nil
+}
+
+// applyProfile copies the identity, contact and bank details to the employee
+func (u *EmployeeUseCaseImpl) applyProfile(tx *gorm.DB, employee *entity.Employee, phone string, profile *model.EmployeeProfileRequest) error {
+	if profile.NIK != nil {
+		total, err := u.EmployeeRepository.CountByNIK(tx, *profile.NIK, employee.ID)
+		if err != nil {
+			u.Log.Warnf("Failed count employee by nik : %+v", err)
+			return fiber.ErrInternalServerError
+		}
+
+		if total > 0 {
+			return fiber.NewError(fiber.StatusConflict, "NIK sudah digunakan karyawan lain")
+		}
+	}
+
+	if profile.BirthDate != nil {
+		birthDate, err := time.Parse("2006-01-02", *profile.BirthDate)
+		if err != nil {
+			u.Log.Warnf("Failed to parse date: %+v", err)
+			return fiber.NewError(fiber.StatusBadRequest, "Format tanggal tidak valid")
+		}
+		employee.BirthDate = &birthDate
+	}
+
+	if phone != "" {
+		employee.Phone = &phone
+	}
+
+	// Omitted fields keep their current value
+	if profile.NIK != nil {
+		employee.NIK = profile.NIK
+	}
+	if profile.Address != nil {
+		employee.Address = profile.Address
+	}
+	if profile.EmergencyContactName != nil {
+		employee.EmergencyContactName = profile.EmergencyContactName
+	}
+	if profile.EmergencyContactPhone != nil {
+		employee.EmergencyContactPhone = profile.EmergencyContactPhone
+	}
+	if profile.BankName != nil {
+		employee.BankName = profile.BankName
+	}
+	if profile.BankAccountNumber != nil {
+		employee.BankAccountNumber = profile.BankAccountNumber
+	}
+	if profile.BankAccountName != nil {
+		employee.BankAccountName = profile.BankAccountName
+	}
+
+	return nil
+}
+
 func (u *EmployeeUseCaseImpl) Delete(ctx context.Context, request *model.DeleteEmployeeRequest) error {
-	tx := u.DB.WithContext(ctx).Begin()
-	defer tx.Rollback()
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
 
 	//check request validation
 	details, errorMessage, err := utils.ValidateStruct(request)
@@ -374,6 +517,18 @@ func (u *EmployeeUseCaseImpl) Delete(ctx context.Context, request *model.DeleteE
 		return fiber.NewError(fiber.StatusBadRequest, "Sales masih memiliki bawahan")
 	}
 
+	// Employees with attendances or payrolls must be resigned or terminated instead
+	hasHistory, err := u.EmployeeRepository.HasHistory(tx, request.ID)
+	if err != nil {
+		u.Log.Warnf("Failed check employee history : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if hasHistory {
+		u.Log.Warnf("Employee has history : %d", request.ID)
+		return fiber.NewError(fiber.StatusBadRequest, "Karyawan memiliki riwayat absensi atau gaji, ubah status karyawan menjadi keluar")
+	}
+
 	// check if sales
 	if dbEmployee.Role == enum.SALES {
 		if err := u.SalesRepository.DeleteByEmployeeId(tx, dbEmployee.ID); err != nil {
@@ -388,7 +543,7 @@ func (u *EmployeeUseCaseImpl) Delete(ctx context.Context, request *model.DeleteE
 	}
 
 	//commit
-	if err := tx.Commit().Error; err != nil {
+	if err := u.Tx.Commit(tx); err != nil {
 		u.Log.WithFields(logrus.Fields{
 			"id": request.ID,
 		}).Warnf("Failed commit to database : %+v", err)
@@ -407,7 +562,7 @@ func (u *EmployeeUseCaseImpl) FindById(ctx context.Context, request *model.FindB
 		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
 	}
 
-	employee, err := u.EmployeeRepository.FindById(u.DB.WithContext(ctx), request.ID)
+	employee, err := u.EmployeeRepository.FindById(u.Tx.DB(ctx), request.ID)
 	if err != nil {
 		u.Log.WithError(err).Error("error getting employee")
 		return nil, fiber.ErrInternalServerError
@@ -450,7 +605,7 @@ func (u *EmployeeUseCaseImpl) FindAllWithAttendances(ctx context.Context, reques
 		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
 	}
 
-	employees, err := u.EmployeeRepository.FindAllWithAttendances(u.DB.WithContext(ctx), request)
+	employees, err := u.EmployeeRepository.FindAllWithAttendances(u.Tx.DB(ctx), request)
 	if err != nil {
 		u.Log.WithError(err).Error("error getting employees")
 		return nil, fiber.ErrInternalServerError
@@ -470,3 +625,196 @@ func (u *EmployeeUseCaseImpl) FindAllWithAttendances(ctx context.Context, reques
 
 	return responses, nil
 }
+
+func (u *EmployeeUseCaseImpl) ChangeStatus(ctx context.Context, request *model.ChangeEmployeeStatusRequest) (*model.EmployeeStatusHistoryResponse, error) {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	effectiveDate, err := time.Parse("2006-01-02", request.EffectiveDate)
+	if err != nil {
+		u.Log.Warnf("Failed to parse date: %+v", err)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Format tanggal tidak valid")
+	}
+
+	employee, err := u.EmployeeRepository.FindById(tx, request.ID)
+	if err != nil {
+		u.Log.Warnf("Failed find employee to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if employee == nil {
+		u.Log.Warnf("Employee not found : %d", request.ID)
+		return nil, fiber.NewError(fiber.StatusNotFound, "Karyawan tidak ditemukan")
+	}
+
+	latest, err := u.EmployeeStatusHistoryRepository.FindLatest(tx, employee.ID)
+	if err != nil {
+		u.Log.Warnf("Failed find employee status history to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// a change scheduled ahead is the status the new one follows
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	status := employee.Status
+	if latest != nil && latest.EffectiveDate.After(today) {
+		status = latest.Status
+	}
+
+	if status == request.Status {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Status karyawan tidak berubah")
+	}
+
+	// Resigned or terminated employees can only be rehired
+	if status.IsEnded() && request.Status != enum.EMPLOYEE_ACTIVE {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Karyawan yang sudah keluar hanya dapat direkrut kembali")
+	}
+
+	lastDate := time.Date(employee.JoinDate.Year(), employee.JoinDate.Month(), employee.JoinDate.Day(), 0, 0, 0, 0, time.UTC)
+	if latest != nil && latest.EffectiveDate.After(lastDate) {
+		lastDate = latest.EffectiveDate
+	}
+
+	if effectiveDate.Before(lastDate) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Tanggal efektif tidak boleh sebelum perubahan status terakhir")
+	}
+
+	// A rehire must start after the last working day
+	if status.IsEnded() && !effectiveDate.After(lastDate) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Tanggal rekrut kembali harus setelah tanggal keluar")
+	}
+
+	history := &entity.EmployeeStatusHistory{
+		EmployeeId:    employee.ID,
+		Status:        request.Status,
+		EffectiveDate: effectiveDate,
+		Reason:        request.Reason,
+	}
+
+	if err := u.EmployeeStatusHistoryRepository.Create(tx, history); err != nil {
+		u.Log.Warnf("Failed create employee status history to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// a future change stays in the history until its date, periods read it from there
+	if !effectiveDate.After(today) {
+		if err := u.EmployeeRepository.UpdateStatus(tx, employee.ID, request.Status); err != nil {
+			u.Log.Warnf("Failed update employee status to database : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"id":     request.ID,
+			"status": request.Status,
+		}).Warnf("Failed commit to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ToEmployeeStatusHistoryResponse(history), nil
+}
+
+func (u *EmployeeUseCaseImpl) FindStatusHistory(ctx context.Context, request *model.FindEmployeeStatusHistoryRequest) ([]model.EmployeeStatusHistoryResponse, error) {
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	db := u.Tx.DB(ctx)
+
+	employee, err := u.EmployeeRepository.FindById(db, request.ID)
+	if err != nil {
+		u.Log.Warnf("Failed find employee to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if employee == nil {
+		u.Log.Warnf("Employee not found : %d", request.ID)
+		return nil, fiber.NewError(fiber.StatusNotFound, "Karyawan tidak ditemukan")
+	}
+
+	histories, err := u.EmployeeStatusHistoryRepository.FindByEmployeeId(db, request.ID)
+	if err != nil {
+		u.Log.Warnf("Failed find employee status history to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.EmployeeStatusHistoryResponse, len(histories))
+	for i, history := range histories {
+		responses[i] = *converter.ToEmployeeStatusHistoryResponse(&history)
+	}
+
+	return responses, nil
+}
+
+func (u *EmployeeUseCaseImpl) FindTree(ctx context.Context, request *model.FindEmployeeTreeRequest) ([]model.EmployeeTreeResponse, error) {
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	employees, err := u.EmployeeRepository.FindTree(u.Tx.DB(ctx), request.RootId)
+	if err != nil {
+		u.Log.Warnf("Failed find employee tree to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if request.RootId > 0 && len(employees) == 0 {
+		u.Log.Warnf("Employee not found : %d", request.RootId)
+		return nil, fiber.NewError(fiber.StatusNotFound, "Karyawan tidak ditemukan")
+	}
+
+	return buildEmployeeTree(employees, request.RootId), nil
+}
+
+// Helper fuction
+// buildEmployeeTree nests the employees, ordered by depth, under their supervisors
+func buildEmployeeTree(employees []entity.Employee, rootId int) []model.EmployeeTreeResponse {
+	byId := make(map[int]*entity.Employee, len(employees))
+	for i := range employees {
+		byId[employees[i].ID] = &employees[i]
+	}
+
+	var roots []int
+	children := make(map[int][]int)
+	for _, employee := range employees {
+		supervisorId := employee.SupervisorId
+		if employee.ID == rootId || supervisorId == nil || byId[*supervisorId] == nil {
+			roots = append(roots, employee.ID)
+			continue
+		}
+		children[*supervisorId] = append(children[*supervisorId], employee.ID)
+	}
+
+	var build func(ids []int) []model.EmployeeTreeResponse
+	build = func(ids []int) []model.EmployeeTreeResponse {
+		nodes := make([]model.EmployeeTreeResponse, len(ids))
+		for i, id := range ids {
+			employee := byId[id]
+			nodes[i] = model.EmployeeTreeResponse{
+				ID:           employee.ID,
+				Name:         employee.Name,
+				Role:         string(employee.Role),
+				Status:       string(employee.Status),
+				SupervisorId: employee.SupervisorId,
+				Subordinates: build(children[id]),
+			}
+		}
+		return nodes
+	}
+
+	return build(roots)
+}
@@ -23,20 +23,20 @@ type FactoryUseCase interface {
 }
 
 type FactoryUseCaseImpl struct {
-	DB                *gorm.DB
+	Tx                repository.Transactor
 	Log               *logrus.Logger
 	Validate          *validator.Validate
 	FactoryRepository repository.FactoryRepository
 }
 
 func NewFactoryUseCase(
-	db *gorm.DB,
+	tx repository.Transactor,
 	logger *logrus.Logger,
 	validate *validator.Validate,
 	factoryRepository repository.FactoryRepository,
 ) FactoryUseCase {
 	return &FactoryUseCaseImpl{
-		DB:                db,
+		Tx:                tx,
 		Log:               logger,
 		Validate:          validate,
 		FactoryRepository: factoryRepository,
@@ -81,8 +81,8 @@ func (s *FactoryUseCaseImpl) validatePhoneUniqueness(tx *gorm.DB, phone string,
 
 // Usecase
 func (s *FactoryUseCaseImpl) Create(ctx context.Context, request *model.CreateFactoryRequest) (*model.FactoryResponse, error) {
-	tx := s.DB.WithContext(ctx).Begin()
-	defer tx.Rollback()
+	tx := s.Tx.Begin(ctx)
+	defer s.Tx.Rollback(tx)
 
 	//check request validation
 	details, errorMessage, err := utils.ValidateStruct(request)
@@ -110,7 +110,7 @@ func (s *FactoryUseCaseImpl) Create(ctx context.Context, request *model.CreateFa
 	}
 
 	//commit
-	if err := tx.Commit().Error; err != nil {
+	if err := s.Tx.Commit(tx); err != nil {
 		s.Log.WithFields(logrus.Fields{
 			"name": request.Name,
 		}).Warnf("Failed commit to database : %+v", err)
@@ -130,7 +130,7 @@ func (s *FactoryUseCaseImpl) FindAll(ctx context.Context, request *model.FindAll
 	}
 
 	//get factories
-	factories, total, err := s.FactoryRepository.FindAll(s.DB.WithContext(ctx), request)
+	factories, total, err := s.FactoryRepository.FindAll(s.Tx.DB(ctx), request)
 	if err != nil {
 		s.Log.WithError(err).Error("error getting factories")
 		return nil, 0, fiber.ErrInternalServerError
@@ -146,8 +146,8 @@ func (s *FactoryUseCaseImpl) FindAll(ctx context.Context, request *model.FindAll
 }
 
 func (s *FactoryUseCaseImpl) Update(ctx context.Context, request *model.UpdateFactoryRequest) (*model.FactoryResponse, error) {
-	tx := s.DB.WithContext(ctx).Begin()
-	defer tx.Rollback()
+	tx := s.Tx.Begin(ctx)
+	defer s.Tx.Rollback(tx)
 
 	// Check if factory exists
 	factory, err := s.validateFactoryExists(tx, request.ID)
@@ -175,7 +175,7 @@ func (s *FactoryUseCaseImpl) Update(ctx context.Context, request *model.UpdateFa
 	}
 
 	//commit
-	if err := tx.Commit().Error; err != nil {
+	if err := s.Tx.Commit(tx); err != nil {
 		s.Log.WithFields(logrus.Fields{
 			"name": request.Name,
 		}).Warnf("Failed commit to database : %+v", err)
@@ -186,8 +186,8 @@ func (s *FactoryUseCaseImpl) Update(ctx context.Context, request *model.UpdateFa
 }
 
 func (s *FactoryUseCaseImpl) Delete(ctx context.Context, request *model.DeleteFactoryRequest) error {
-	tx := s.DB.WithContext(ctx).Begin()
-	defer tx.Rollback()
+	tx := s.Tx.Begin(ctx)
+	defer s.Tx.Rollback(tx)
 
 	//check request validation
 	details, errorMessage, err := utils.ValidateStruct(request)
@@ -208,7 +208,7 @@ func (s *FactoryUseCaseImpl) Delete(ctx context.Context, request *model.DeleteFa
 	}
 
 	//commit
-	if err := tx.Commit().Error; err != nil {
+	if err := s.Tx.Commit(tx); err != nil {
 		s.Log.WithFields(logrus.Fields{
 			"id": request.ID,
 		}).Warnf("Failed commit to database : %+v", err)
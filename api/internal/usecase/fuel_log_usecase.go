@@ -0,0 +1,409 @@
+package usecase
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/enum"
+	"api/internal/entity/money"
+	"api/internal/model"
+	"api/internal/model/converter"
+	"api/internal/repository"
+	"api/internal/utils"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// a fill-up below this share of the vehicle average km/l is flagged
+	FUEL_THEFT_THRESHOLD = 0.75
+	// the vehicle average needs this many measured fill-ups before flagging
+	FUEL_MIN_SAMPLES = 3
+
+	FUEL_GROUP_VEHICLE = "vehicle"
+	FUEL_GROUP_DRIVER  = "driver"
+)
+
+type FuelLogUseCase interface {
+	FindAll(ctx context.Context, request *model.FindAllFuelLogRequest) ([]model.FuelLogResponse, error)
+	Create(ctx context.Context, request *model.CreateFuelLogRequest) (*model.FuelLogResponse, error)
+	Delete(ctx context.Context, request *model.DeleteFuelLogRequest) error
+	Consumption(ctx context.Context, request *model.FuelConsumptionRequest) ([]model.FuelConsumptionResponse, error)
+}
+
+type FuelLogUseCaseImpl struct {
+	Tx                       repository.Transactor
+	Log                      *logrus.Logger
+	Validate                 *validator.Validate
+	FuelLogRepository        repository.FuelLogRepository
+	VehicleRepository        repository.VehicleRepository
+	VehicleHistoryRepository repository.VehicleHistoryRepository
+	EmployeeRepository       repository.EmployeeRepository
+}
+
+func NewFuelLogUseCase(
+	tx repository.Transactor,
+	logger *logrus.Logger,
+	validate *validator.Validate,
+	fuelLogRepository repository.FuelLogRepository,
+	vehicleRepository repository.VehicleRepository,
+	vehicleHistoryRepository repository.VehicleHistoryRepository,
+	employeeRepository repository.EmployeeRepository,
+) FuelLogUseCase {
+	return &FuelLogUseCaseImpl{
+		Tx:                       tx,
+		Log:                      logger,
+		Validate:                 validate,
+		FuelLogRepository:        fuelLogRepository,
+		VehicleRepository:        vehicleRepository,
+		VehicleHistoryRepository: vehicleHistoryRepository,
+		EmployeeRepository:       employeeRepository,
+	}
+}
+
+// fuelConsumption is a fill-up with the consumption since the previous fill-up
+type fuelConsumption struct {
+	Log        *entity.FuelLog
+	Distance   *int
+	KmPerLiter *float64
+	IsFlagged  bool
+}
+
+// Helper fuction
+// analyzeFuelLogs measures each fill-up full to full, logs must be ordered by
+// vehicle and odometer. The first fill-up of a vehicle has no measurement.
+func analyzeFuelLogs(logs []entity.FuelLog) []fuelConsumption {
+	type total struct {
+		distance int
+		liters   float64
+		samples  int
+	}
+
+	results := make([]fuelConsumption, len(logs))
+	totals := make(map[int64]*total)
+
+	for i := range logs {
+		results[i].Log = &logs[i]
+		if i == 0 || logs[i-1].VehicleId != logs[i].VehicleId {
+			continue
+		}
+
+		distance := logs[i].Odometer - logs[i-1].Odometer
+		kmPerLiter := float64(distance) / logs[i].Liters
+		results[i].Distance = &distance
+		results[i].KmPerLiter = &kmPerLiter
+
+		t, ok := totals[logs[i].VehicleId]
+		if !ok {
+			t = &total{}
+			totals[logs[i].VehicleId] = t
+		}
+		t.distance += distance
+		t.liters += logs[i].Liters
+		t.samples++
+	}
+
+	for i := range results {
+		if results[i].KmPerLiter == nil {
+			continue
+		}
+
+		t := totals[results[i].Log.VehicleId]
+		if t.samples < FUEL_MIN_SAMPLES {
+			continue
+		}
+
+		average := float64(t.distance) / t.liters
+		results[i].IsFlagged = *results[i].KmPerLiter < average*FUEL_THEFT_THRESHOLD
+	}
+
+	return results
+}
+
+func toFuelLogResponse(consumption *fuelConsumption) model.FuelLogResponse {
+	response := *converter.ToFuelLogResponse(consumption.Log)
+	response.Distance = consumption.Distance
+	response.IsFlagged = consumption.IsFlagged
+
+	if consumption.KmPerLiter != nil {
+		kmPerLiter := math.Round(*consumption.KmPerLiter*100) / 100
+		response.KmPerLiter = &kmPerLiter
+	}
+
+	return response
+}
+
+func (u *FuelLogUseCaseImpl) FindAll(ctx context.Context, request *model.FindAllFuelLogRequest) ([]model.FuelLogResponse, error) {
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	var startDate time.Time
+	if request.StartDate != "" {
+		startDate, _ = time.Parse("2006-01-02", request.StartDate)
+	}
+
+	endDate := time.Now()
+	if request.EndDate != "" {
+		endDate, _ = time.Parse("2006-01-02", request.EndDate)
+	}
+
+	// the whole history is needed for the vehicle average
+	logs, err := u.FuelLogRepository.FindUntil(u.Tx.DB(ctx), request.VehicleId, endDate)
+	if err != nil {
+		u.Log.Warnf("Failed find fuel logs to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	consumptions := analyzeFuelLogs(logs)
+
+	responses := []model.FuelLogResponse{}
+	for i := len(consumptions) - 1; i >= 0; i-- {
+		consumption := &consumptions[i]
+		if consumption.Log.Date.Before(startDate) {
+			continue
+		}
+		if request.OnlyFlagged && !consumption.IsFlagged {
+			continue
+		}
+		responses = append(responses, toFuelLogResponse(consumption))
+	}
+
+	return responses, nil
+}
+
+func (u *FuelLogUseCaseImpl) Create(ctx context.Context, request *model.CreateFuelLogRequest) (*model.FuelLogResponse, error) {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	date, err := time.Parse("2006-01-02", request.Date)
+	if err != nil {
+		u.Log.Warnf("Failed to parse date: %+v", err)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Format tanggal tidak valid")
+	}
+
+	vehicle, err := u.VehicleRepository.FindById(tx, request.VehicleId)
+	if err != nil {
+		u.Log.Warnf("Failed find vehicle to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if vehicle == nil {
+		u.Log.Warnf("Vehicle not found : %d", request.VehicleId)
+		return nil, fiber.NewError(fiber.StatusNotFound, "Kendaraan tidak ditemukan")
+	}
+
+	if request.DriverId != nil {
+		driver, err := u.EmployeeRepository.FindById(tx, *request.DriverId)
+		if err != nil {
+			u.Log.Warnf("Failed find driver to database : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+
+		if driver == nil {
+			u.Log.Warnf("Driver not found : %d", *request.DriverId)
+			return nil, fiber.NewError(fiber.StatusNotFound, "Supir tidak ditemukan")
+		}
+
+		if driver.Role != enum.DRIVER {
+			u.Log.Warnf("Employee is not a driver : %d", *request.DriverId)
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Karyawan bukan supir")
+		}
+	}
+
+	// the odometer must fit between the surrounding fill-ups
+	before, after, err := u.FuelLogRepository.FindOdometerBounds(tx, vehicle.ID, date)
+	if err != nil {
+		u.Log.Warnf("Failed find fuel log odometer to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if request.Odometer <= before || (after > 0 && request.Odometer >= after) {
+		u.Log.Warnf("Odometer out of order : %d", request.Odometer)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Odometer tidak sesuai dengan pengisian sebelum atau sesudahnya")
+	}
+
+	totalCost := request.PricePerLiter.MulFloat(request.Liters).RoundTo(money.New(1))
+
+	// post expense to vehicle history
+	history := &entity.VehicleHistory{
+		Date:        date,
+		Description: fmt.Sprintf("BBM %.2f liter", request.Liters),
+		Type:        enum.EXPENSE,
+		Amount:      totalCost,
+		VehicleID:   vehicle.ID,
+	}
+
+	if err := u.VehicleHistoryRepository.Create(tx, history); err != nil {
+		u.Log.Warnf("Failed create vehicle history to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	fuelLog := &entity.FuelLog{
+		VehicleId:        vehicle.ID,
+		DriverId:         request.DriverId,
+		VehicleHistoryId: &history.ID,
+		Date:             date,
+		Liters:           request.Liters,
+		PricePerLiter:    request.PricePerLiter,
+		TotalCost:        totalCost,
+		Odometer:         request.Odometer,
+		Notes:            request.Notes,
+	}
+
+	if err := u.FuelLogRepository.Create(tx, fuelLog); err != nil {
+		u.Log.Warnf("Failed create fuel log to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"vehicle_id": request.VehicleId,
+			"date":       request.Date,
+		}).Warnf("Failed commit to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ToFuelLogResponse(fuelLog), nil
+}
+
+func (u *FuelLogUseCaseImpl) Delete(ctx context.Context, request *model.DeleteFuelLogRequest) error {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	fuelLog, err := u.FuelLogRepository.FindById(tx, request.VehicleId, request.ID)
+	if err != nil {
+		u.Log.Warnf("Failed find fuel log to database : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if fuelLog == nil {
+		u.Log.Warnf("Fuel log not found : %d", request.ID)
+		return fiber.NewError(fiber.StatusNotFound, "Data pengisian BBM tidak ditemukan")
+	}
+
+	// reverse the posted expense
+	if fuelLog.VehicleHistoryId != nil {
+		if err := u.VehicleHistoryRepository.Delete(tx, *fuelLog.VehicleHistoryId); err != nil {
+			u.Log.Warnf("Failed delete vehicle history to database : %+v", err)
+			return fiber.ErrInternalServerError
+		}
+	}
+
+	if err := u.FuelLogRepository.Delete(tx, fuelLog.ID); err != nil {
+		u.Log.Warnf("Failed delete fuel log to database : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"id": request.ID,
+		}).Warnf("Failed commit to database : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (u *FuelLogUseCaseImpl) Consumption(ctx context.Context, request *model.FuelConsumptionRequest) ([]model.FuelConsumptionResponse, error) {
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	startDate, _ := time.Parse("2006-01-02", request.StartDate)
+	endDate, _ := time.Parse("2006-01-02", request.EndDate)
+	if endDate.Before(startDate) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Tanggal akhir harus setelah tanggal awal")
+	}
+
+	logs, err := u.FuelLogRepository.FindUntil(u.Tx.DB(ctx), 0, endDate)
+	if err != nil {
+		u.Log.Warnf("Failed find fuel logs to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	groups := make(map[int64]*model.FuelConsumptionResponse)
+	for _, consumption := range analyzeFuelLogs(logs) {
+		fuelLog := consumption.Log
+		if fuelLog.Date.Before(startDate) {
+			continue
+		}
+
+		var id int64
+		var name string
+		switch request.GroupBy {
+		case FUEL_GROUP_DRIVER:
+			if fuelLog.DriverId == nil {
+				continue
+			}
+			id = int64(*fuelLog.DriverId)
+			if fuelLog.Driver != nil {
+				name = fuelLog.Driver.Name
+			}
+		default:
+			id = fuelLog.VehicleId
+			if fuelLog.Vehicle != nil {
+				name = fuelLog.Vehicle.Plate
+			}
+		}
+
+		group, ok := groups[id]
+		if !ok {
+			group = &model.FuelConsumptionResponse{ID: id, Name: name}
+			groups[id] = group
+		}
+
+		group.FillCount++
+		group.TotalCost += fuelLog.TotalCost
+		if consumption.IsFlagged {
+			group.FlaggedCount++
+		}
+
+		// only measured fill-ups count towards km/l
+		if consumption.Distance != nil {
+			group.Distance += *consumption.Distance
+			group.Liters += fuelLog.Liters
+		}
+	}
+
+	responses := make([]model.FuelConsumptionResponse, 0, len(groups))
+	for _, group := range groups {
+		if group.Liters > 0 {
+			group.KmPerLiter = math.Round(float64(group.Distance)/group.Liters*100) / 100
+		}
+		responses = append(responses, *group)
+	}
+
+	sort.Slice(responses, func(i, j int) bool {
+		return responses[i].Name < responses[j].Name
+	})
+
+	return responses, nil
+}
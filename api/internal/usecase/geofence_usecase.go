@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"api/internal/entity"
+	"api/internal/model"
+	"api/internal/model/converter"
+	"api/internal/repository"
+	"api/internal/utils"
+	"context"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type GeofenceUseCase interface {
+	Create(ctx context.Context, request *model.CreateGeofenceRequest) (*model.GeofenceResponse, error)
+	FindAll(ctx context.Context, request *model.FindAllGeofenceRequest) ([]model.GeofenceResponse, int64, error)
+	Update(ctx context.Context, request *model.UpdateGeofenceRequest) (*model.GeofenceResponse, error)
+	Delete(ctx context.Context, request *model.DeleteGeofenceRequest) error
+}
+
+type GeofenceUseCaseImpl struct {
+	Tx                 repository.Transactor
+	Log                *logrus.Logger
+	Validate           *validator.Validate
+	GeofenceRepository repository.GeofenceRepository
+	FactoryRepository  repository.FactoryRepository
+}
+
+func NewGeofenceUseCase(
+	tx repository.Transactor,
+	logger *logrus.Logger,
+	validate *validator.Validate,
+	geofenceRepository repository.GeofenceRepository,
+	factoryRepository repository.FactoryRepository,
+) GeofenceUseCase {
+	return &GeofenceUseCaseImpl{
+		Tx:                 tx,
+		Log:                logger,
+		Validate:           validate,
+		GeofenceRepository: geofenceRepository,
+		FactoryRepository:  factoryRepository,
+	}
+}
+
+// Helper fuction
+func (u *GeofenceUseCaseImpl) validateGeofenceExists(tx *gorm.DB, id int) (*entity.Geofence, error) {
+	geofence, err := u.GeofenceRepository.FindById(tx, id)
+	if err != nil {
+		u.Log.Warnf("Failed find geofence to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if geofence == nil {
+		u.Log.Warnf("Geofence not found : %d", id)
+		return nil, fiber.NewError(fiber.StatusNotFound, "Area absensi tidak ditemukan")
+	}
+
+	return geofence, nil
+}
+
+func (u *GeofenceUseCaseImpl) validateFactoryExists(tx *gorm.DB, id *int64) error {
+	if id == nil {
+		return nil
+	}
+
+	factory, err := u.FactoryRepository.FindById(tx, *id)
+	if err != nil {
+		u.Log.Warnf("Failed find factory to database : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if factory == nil {
+		u.Log.Warnf("Factory not found : %d", *id)
+		return fiber.NewError(fiber.StatusNotFound, "Kilang tidak ditemukan")
+	}
+
+	return nil
+}
+
+// Usecase
+func (u *GeofenceUseCaseImpl) Create(ctx context.Context, request *model.CreateGeofenceRequest) (*model.GeofenceResponse, error) {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	if err := u.validateFactoryExists(tx, request.FactoryId); err != nil {
+		return nil, err
+	}
+
+	geofence := &entity.Geofence{
+		Name:      request.Name,
+		Latitude:  request.Latitude,
+		Longitude: request.Longitude,
+		Radius:    request.Radius,
+		IsActive:  request.IsActive == nil || *request.IsActive,
+		FactoryId: request.FactoryId,
+	}
+
+	if err := u.GeofenceRepository.Create(tx, geofence); err != nil {
+		u.Log.Warnf("Failed create geofence to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"name": request.Name,
+		}).Warnf("Failed commit to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ToGeofenceResponse(geofence), nil
+}
+
+func (u *GeofenceUseCaseImpl) FindAll(ctx context.Context, request *model.FindAllGeofenceRequest) ([]model.GeofenceResponse, int64, error) {
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, 0, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	geofences, total, err := u.GeofenceRepository.FindAll(u.Tx.DB(ctx), request)
+	if err != nil {
+		u.Log.WithError(err).Error("error getting geofences")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	// convert to arry response
+	responses := make([]model.GeofenceResponse, len(geofences))
+	for i, geofence := range geofences {
+		responses[i] = *converter.ToGeofenceResponse(&geofence)
+	}
+
+	return responses, total, nil
+}
+
+func (u *GeofenceUseCaseImpl) Update(ctx context.Context, request *model.UpdateGeofenceRequest) (*model.GeofenceResponse, error) {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	geofence, err := u.validateGeofenceExists(tx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.validateFactoryExists(tx, request.FactoryId); err != nil {
+		return nil, err
+	}
+
+	geofence.Name = request.Name
+	geofence.Latitude = request.Latitude
+	geofence.Longitude = request.Longitude
+	geofence.Radius = request.Radius
+	geofence.FactoryId = request.FactoryId
+	if request.IsActive != nil {
+		geofence.IsActive = *request.IsActive
+	}
+
+	updates := map[string]any{
+		"name":       geofence.Name,
+		"latitude":   geofence.Latitude,
+		"longitude":  geofence.Longitude,
+		"radius":     geofence.Radius,
+		"is_active":  geofence.IsActive,
+		"factory_id": geofence.FactoryId,
+	}
+
+	if err := u.GeofenceRepository.Update(tx, geofence.ID, updates); err != nil {
+		u.Log.Warnf("Failed update geofence to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"id": request.ID,
+		}).Warnf("Failed commit to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ToGeofenceResponse(geofence), nil
+}
+
+func (u *GeofenceUseCaseImpl) Delete(ctx context.Context, request *model.DeleteGeofenceRequest) error {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	if _, err := u.validateGeofenceExists(tx, request.ID); err != nil {
+		return err
+	}
+
+	if err := u.GeofenceRepository.Delete(tx, request.ID); err != nil {
+		u.Log.WithError(err).Error("error deleting geofence")
+		return fiber.ErrInternalServerError
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"id": request.ID,
+		}).Warnf("Failed commit to database : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
@@ -0,0 +1,534 @@
+package usecase
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/enum"
+	"api/internal/model"
+	"api/internal/model/converter"
+	"api/internal/repository"
+	"api/internal/utils"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	MAINTENANCE_DUE_SOON_KM   = 500
+	MAINTENANCE_DUE_SOON_DAYS = 7
+)
+
+type MaintenanceUseCase interface {
+	FindPlans(ctx context.Context, request *model.FindAllMaintenancePlanRequest) ([]model.MaintenancePlanResponse, error)
+	CreatePlan(ctx context.Context, request *model.CreateMaintenancePlanRequest) (*model.MaintenancePlanResponse, error)
+	UpdatePlan(ctx context.Context, request *model.UpdateMaintenancePlanRequest) (*model.MaintenancePlanResponse, error)
+	DeletePlan(ctx context.Context, request *model.DeleteMaintenancePlanRequest) error
+	FindServices(ctx context.Context, request *model.FindAllServiceRecordRequest) ([]model.ServiceRecordResponse, int64, error)
+	CreateService(ctx context.Context, request *model.CreateServiceRecordRequest) (*model.ServiceRecordResponse, error)
+	DeleteService(ctx context.Context, request *model.DeleteServiceRecordRequest) error
+	FindDue(ctx context.Context, request *model.FindMaintenanceDueRequest) ([]model.MaintenanceDueResponse, error)
+}
+
+type MaintenanceUseCaseImpl struct {
+	Tx                        repository.Transactor
+	Log                       *logrus.Logger
+	Validate                  *validator.Validate
+	VehicleRepository         repository.VehicleRepository
+	VehicleHistoryRepository  repository.VehicleHistoryRepository
+	MaintenancePlanRepository repository.MaintenancePlanRepository
+	ServiceRecordRepository   repository.ServiceRecordRepository
+	FuelLogRepository         repository.FuelLogRepository
+}
+
+func NewMaintenanceUseCase(
+	tx repository.Transactor,
+	logger *logrus.Logger,
+	validate *validator.Validate,
+	vehicleRepository repository.VehicleRepository,
+	vehicleHistoryRepository repository.VehicleHistoryRepository,
+	maintenancePlanRepository repository.MaintenancePlanRepository,
+	serviceRecordRepository repository.ServiceRecordRepository,
+	fuelLogRepository repository.FuelLogRepository,
+) MaintenanceUseCase {
+	return &MaintenanceUseCaseImpl{
+		Tx:                        tx,
+		Log:                       logger,
+		Validate:                  validate,
+		VehicleRepository:         vehicleRepository,
+		VehicleHistoryRepository:  vehicleHistoryRepository,
+		MaintenancePlanRepository: maintenancePlanRepository,
+		ServiceRecordRepository:   serviceRecordRepository,
+		FuelLogRepository:         fuelLogRepository,
+	}
+}
+
+// Helper fuction
+func (u *MaintenanceUseCaseImpl) findVehicle(tx *gorm.DB, id int64) (*entity.Vehicle, error) {
+	vehicle, err := u.VehicleRepository.FindById(tx, id)
+	if err != nil {
+		u.Log.Warnf("Failed find vehicle to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if vehicle == nil {
+		u.Log.Warnf("Vehicle not found : %d", id)
+		return nil, fiber.NewError(fiber.StatusNotFound, "Kendaraan tidak ditemukan")
+	}
+
+	return vehicle, nil
+}
+
+// maintenanceDue computes the next due point of a plan from its last service,
+// or from the plan start when it was never serviced
+func maintenanceDue(plan *entity.MaintenancePlan, last *entity.ServiceRecord, odometer int, today time.Time) model.MaintenanceDueResponse {
+	response := model.MaintenanceDueResponse{
+		PlanId:          plan.ID,
+		VehicleId:       plan.VehicleId,
+		ServiceType:     plan.ServiceType,
+		CurrentOdometer: odometer,
+	}
+
+	if plan.Vehicle != nil {
+		response.Plate = plan.Vehicle.Plate
+	}
+
+	baseDate, baseOdometer := plan.StartDate, plan.StartOdometer
+	if last != nil {
+		baseDate, baseOdometer = last.Date, last.Odometer
+		response.LastServiceDate = &last.Date
+		response.LastServiceOdometer = &last.Odometer
+	}
+
+	overdue, dueSoon := false, false
+
+	if plan.IntervalKm != nil {
+		dueOdometer := baseOdometer + *plan.IntervalKm
+		response.NextDueOdometer = &dueOdometer
+
+		overdue = overdue || odometer >= dueOdometer
+		dueSoon = dueSoon || dueOdometer-odometer <= MAINTENANCE_DUE_SOON_KM
+	}
+
+	if plan.IntervalDays != nil {
+		dueDate := baseDate.AddDate(0, 0, *plan.IntervalDays)
+		response.NextDueDate = &dueDate
+
+		overdue = overdue || !today.Before(dueDate)
+		dueSoon = dueSoon || !today.AddDate(0, 0, MAINTENANCE_DUE_SOON_DAYS).Before(dueDate)
+	}
+
+	status := enum.MAINTENANCE_OK
+	if overdue {
+		status = enum.MAINTENANCE_OVERDUE
+	} else if dueSoon {
+		status = enum.MAINTENANCE_DUE_SOON
+	}
+
+	response.Status = string(status)
+	return response
+}
+
+func (u *MaintenanceUseCaseImpl) FindPlans(ctx context.Context, request *model.FindAllMaintenancePlanRequest) ([]model.MaintenancePlanResponse, error) {
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	plans, err := u.MaintenancePlanRepository.FindByVehicleId(u.Tx.DB(ctx), request.VehicleId)
+	if err != nil {
+		u.Log.Warnf("Failed find maintenance plans to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.MaintenancePlanResponse, len(plans))
+	for i, plan := range plans {
+		responses[i] = *converter.ToMaintenancePlanResponse(&plan)
+	}
+
+	return responses, nil
+}
+
+func (u *MaintenanceUseCaseImpl) CreatePlan(ctx context.Context, request *model.CreateMaintenancePlanRequest) (*model.MaintenancePlanResponse, error) {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	startDate, err := time.Parse("2006-01-02", request.StartDate)
+	if err != nil {
+		u.Log.Warnf("Failed to parse date: %+v", err)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Format tanggal tidak valid")
+	}
+
+	vehicle, err := u.findVehicle(tx, request.VehicleId)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &entity.MaintenancePlan{
+		VehicleId:     vehicle.ID,
+		ServiceType:   request.ServiceType,
+		IntervalKm:    request.IntervalKm,
+		IntervalDays:  request.IntervalDays,
+		StartDate:     startDate,
+		StartOdometer: request.StartOdometer,
+		IsActive:      true,
+	}
+
+	if err := u.MaintenancePlanRepository.Create(tx, plan); err != nil {
+		u.Log.Warnf("Failed create maintenance plan to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"vehicle_id":   request.VehicleId,
+			"service_type": request.ServiceType,
+		}).Warnf("Failed commit to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ToMaintenancePlanResponse(plan), nil
+}
+
+func (u *MaintenanceUseCaseImpl) UpdatePlan(ctx context.Context, request *model.UpdateMaintenancePlanRequest) (*model.MaintenancePlanResponse, error) {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	startDate, err := time.Parse("2006-01-02", request.StartDate)
+	if err != nil {
+		u.Log.Warnf("Failed to parse date: %+v", err)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Format tanggal tidak valid")
+	}
+
+	plan, err := u.MaintenancePlanRepository.FindById(tx, request.VehicleId, request.ID)
+	if err != nil {
+		u.Log.Warnf("Failed find maintenance plan to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if plan == nil {
+		u.Log.Warnf("Maintenance plan not found : %d", request.ID)
+		return nil, fiber.NewError(fiber.StatusNotFound, "Jadwal perawatan tidak ditemukan")
+	}
+
+	// map keeps nil intervals and false is_active
+	updates := map[string]any{
+		"service_type":   request.ServiceType,
+		"interval_km":    request.IntervalKm,
+		"interval_days":  request.IntervalDays,
+		"start_date":     startDate,
+		"start_odometer": request.StartOdometer,
+		"is_active":      request.IsActive,
+	}
+
+	if err := u.MaintenancePlanRepository.Update(tx, plan.ID, updates); err != nil {
+		u.Log.Warnf("Failed update maintenance plan to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"id": request.ID,
+		}).Warnf("Failed commit to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	plan.ServiceType = request.ServiceType
+	plan.IntervalKm = request.IntervalKm
+	plan.IntervalDays = request.IntervalDays
+	plan.StartDate = startDate
+	plan.StartOdometer = request.StartOdometer
+	plan.IsActive = request.IsActive
+
+	return converter.ToMaintenancePlanResponse(plan), nil
+}
+
+func (u *MaintenanceUseCaseImpl) DeletePlan(ctx context.Context, request *model.DeleteMaintenancePlanRequest) error {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	plan, err := u.MaintenancePlanRepository.FindById(tx, request.VehicleId, request.ID)
+	if err != nil {
+		u.Log.Warnf("Failed find maintenance plan to database : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if plan == nil {
+		u.Log.Warnf("Maintenance plan not found : %d", request.ID)
+		return fiber.NewError(fiber.StatusNotFound, "Jadwal perawatan tidak ditemukan")
+	}
+
+	if err := u.MaintenancePlanRepository.Delete(tx, plan.ID); err != nil {
+		u.Log.Warnf("Failed delete maintenance plan to database : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"id": request.ID,
+		}).Warnf("Failed commit to database : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (u *MaintenanceUseCaseImpl) FindServices(ctx context.Context, request *model.FindAllServiceRecordRequest) ([]model.ServiceRecordResponse, int64, error) {
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, 0, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	records, total, err := u.ServiceRecordRepository.FindAll(u.Tx.DB(ctx), request)
+	if err != nil {
+		u.Log.Warnf("Failed find service records to database : %+v", err)
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.ServiceRecordResponse, len(records))
+	for i, record := range records {
+		responses[i] = *converter.ToServiceRecordResponse(&record)
+	}
+
+	return responses, total, nil
+}
+
+func (u *MaintenanceUseCaseImpl) CreateService(ctx context.Context, request *model.CreateServiceRecordRequest) (*model.ServiceRecordResponse, error) {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	date, err := time.Parse("2006-01-02", request.Date)
+	if err != nil {
+		u.Log.Warnf("Failed to parse date: %+v", err)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Format tanggal tidak valid")
+	}
+
+	vehicle, err := u.findVehicle(tx, request.VehicleId)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceType := request.ServiceType
+	if request.MaintenancePlanId != nil {
+		plan, err := u.MaintenancePlanRepository.FindById(tx, vehicle.ID, *request.MaintenancePlanId)
+		if err != nil {
+			u.Log.Warnf("Failed find maintenance plan to database : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+
+		if plan == nil {
+			u.Log.Warnf("Maintenance plan not found : %d", *request.MaintenancePlanId)
+			return nil, fiber.NewError(fiber.StatusNotFound, "Jadwal perawatan tidak ditemukan")
+		}
+
+		if serviceType == "" {
+			serviceType = plan.ServiceType
+		}
+	}
+
+	// post expense to vehicle history
+	description := fmt.Sprintf("Servis %s", serviceType)
+	if request.Workshop != nil && *request.Workshop != "" {
+		description = fmt.Sprintf("%s - %s", description, *request.Workshop)
+	}
+
+	history := &entity.VehicleHistory{
+		Date:        date,
+		Description: description,
+		Type:        enum.EXPENSE,
+		Amount:      request.Cost,
+		VehicleID:   vehicle.ID,
+	}
+
+	if err := u.VehicleHistoryRepository.Create(tx, history); err != nil {
+		u.Log.Warnf("Failed create vehicle history to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	record := &entity.ServiceRecord{
+		VehicleId:         vehicle.ID,
+		MaintenancePlanId: request.MaintenancePlanId,
+		VehicleHistoryId:  &history.ID,
+		Date:              date,
+		Odometer:          request.Odometer,
+		ServiceType:       serviceType,
+		Parts:             request.Parts,
+		Workshop:          request.Workshop,
+		Cost:              request.Cost,
+		Notes:             request.Notes,
+	}
+
+	if err := u.ServiceRecordRepository.Create(tx, record); err != nil {
+		u.Log.Warnf("Failed create service record to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"vehicle_id":   request.VehicleId,
+			"service_type": serviceType,
+		}).Warnf("Failed commit to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ToServiceRecordResponse(record), nil
+}
+
+func (u *MaintenanceUseCaseImpl) DeleteService(ctx context.Context, request *model.DeleteServiceRecordRequest) error {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	record, err := u.ServiceRecordRepository.FindById(tx, request.VehicleId, request.ID)
+	if err != nil {
+		u.Log.Warnf("Failed find service record to database : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if record == nil {
+		u.Log.Warnf("Service record not found : %d", request.ID)
+		return fiber.NewError(fiber.StatusNotFound, "Data servis tidak ditemukan")
+	}
+
+	// reverse the posted expense
+	if record.VehicleHistoryId != nil {
+		if err := u.VehicleHistoryRepository.Delete(tx, *record.VehicleHistoryId); err != nil {
+			u.Log.Warnf("Failed delete vehicle history to database : %+v", err)
+			return fiber.ErrInternalServerError
+		}
+	}
+
+	if err := u.ServiceRecordRepository.Delete(tx, record.ID); err != nil {
+		u.Log.Warnf("Failed delete service record to database : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"id": request.ID,
+		}).Warnf("Failed commit to database : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (u *MaintenanceUseCaseImpl) FindDue(ctx context.Context, request *model.FindMaintenanceDueRequest) ([]model.MaintenanceDueResponse, error) {
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	db := u.Tx.DB(ctx)
+
+	plans, err := u.MaintenancePlanRepository.FindAllActive(db, request.VehicleId)
+	if err != nil {
+		u.Log.Warnf("Failed find maintenance plans to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	planIds := make([]int, len(plans))
+	vehicleIds := make([]int64, 0, len(plans))
+	seen := make(map[int64]bool)
+	for i, plan := range plans {
+		planIds[i] = plan.ID
+		if !seen[plan.VehicleId] {
+			seen[plan.VehicleId] = true
+			vehicleIds = append(vehicleIds, plan.VehicleId)
+		}
+	}
+
+	lastServices, err := u.ServiceRecordRepository.FindLatestByPlanIds(db, planIds)
+	if err != nil {
+		u.Log.Warnf("Failed find service records to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	lastByPlan := make(map[int]*entity.ServiceRecord)
+	for i := range lastServices {
+		lastByPlan[*lastServices[i].MaintenancePlanId] = &lastServices[i]
+	}
+
+	odometers, err := u.ServiceRecordRepository.FindOdometers(db, vehicleIds)
+	if err != nil {
+		u.Log.Warnf("Failed find vehicle odometers to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// fuel fill-ups are recorded far more often than services
+	fuelOdometers, err := u.FuelLogRepository.FindOdometers(db, vehicleIds)
+	if err != nil {
+		u.Log.Warnf("Failed find fuel log odometers to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	odometerByVehicle := make(map[int64]int)
+	for _, odometer := range append(odometers, fuelOdometers...) {
+		odometerByVehicle[odometer.VehicleId] = max(odometerByVehicle[odometer.VehicleId], odometer.Odometer)
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	responses := []model.MaintenanceDueResponse{}
+	for i := range plans {
+		plan := &plans[i]
+
+		odometer := max(odometerByVehicle[plan.VehicleId], plan.StartOdometer)
+		due := maintenanceDue(plan, lastByPlan[plan.ID], odometer, today)
+		if request.Status != "" && due.Status != string(request.Status) {
+			continue
+		}
+		responses = append(responses, due)
+	}
+
+	return responses, nil
+}
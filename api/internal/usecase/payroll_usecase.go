@@ -0,0 +1,628 @@
+package usecase
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/enum"
+	"api/internal/entity/money"
+	"api/internal/model"
+	"api/internal/model/converter"
+	"api/internal/repository"
+	"api/internal/utils"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type PayrollUseCase interface {
+	FindAllPayRules(ctx context.Context) ([]model.PayRuleResponse, error)
+	UpsertPayRule(ctx context.Context, request *model.UpsertPayRuleRequest) (*model.PayRuleResponse, error)
+	FindAll(ctx context.Context, request *model.FindAllPayrollRequest) ([]model.PayrollResponse, error)
+	FindByEmployee(ctx context.Context, request *model.FindPayrollByEmployeeRequest) ([]model.PayrollResponse, error)
+	Generate(ctx context.Context, request *model.GeneratePayrollRequest) ([]model.PayrollResponse, error)
+	Pay(ctx context.Context, request *model.PayPayrollRequest) (*model.PayrollResponse, error)
+	PayByPeriod(ctx context.Context, request *model.PayPayrollsByPeriodRequest) (int, error)
+	Payslip(ctx context.Context, request *model.PayslipRequest) ([]byte, error)
+}
+
+type PayrollUseCaseImpl struct {
+	Tx                             repository.Transactor
+	Log                            *logrus.Logger
+	Validate                       *validator.Validate
+	PayrollRepository              repository.PayrollRepository
+	PayRuleRepository              repository.PayRuleRepository
+	PeriodRepository               repository.PeriodRepository
+	EmployeeRepository             repository.EmployeeRepository
+	EmployeeAttendanceRepository   repository.EmployeeAttendanceRepository
+	TripRepository                 repository.TripRepository
+	CashAdvanceRepository          repository.CashAdvanceRepository
+	CashAdvanceRepaymentRepository repository.CashAdvanceRepaymentRepository
+	PeriodClosureRepository        repository.PeriodClosureRepository
+}
+
+func NewPayrollUseCase(
+	tx repository.Transactor,
+	logger *logrus.Logger,
+	validate *validator.Validate,
+	payrollRepository repository.PayrollRepository,
+	payRuleRepository repository.PayRuleRepository,
+	periodRepository repository.PeriodRepository,
+	employeeRepository repository.EmployeeRepository,
+	employeeAttendanceRepository repository.EmployeeAttendanceRepository,
+	tripRepository repository.TripRepository,
+	cashAdvanceRepository repository.CashAdvanceRepository,
+	cashAdvanceRepaymentRepository repository.CashAdvanceRepaymentRepository,
+	periodClosureRepository repository.PeriodClosureRepository,
+) PayrollUseCase {
+	return &PayrollUseCaseImpl{
+		Tx:                             tx,
+		Log:                            logger,
+		Validate:                       validate,
+		PayrollRepository:              payrollRepository,
+		PayRuleRepository:              payRuleRepository,
+		PeriodRepository:               periodRepository,
+		EmployeeRepository:             employeeRepository,
+		EmployeeAttendanceRepository:   employeeAttendanceRepository,
+		TripRepository:                 tripRepository,
+		CashAdvanceRepository:          cashAdvanceRepository,
+		CashAdvanceRepaymentRepository: cashAdvanceRepaymentRepository,
+		PeriodClosureRepository:        periodClosureRepository,
+	}
+}
+
+// Helper fuction
+type operationalWork struct {
+	Days  int64
+	Trips int64
+	Sacks int64
+}
+
+func (u *PayrollUseCaseImpl) collectWork(tx *gorm.DB, period *entity.Period) (map[int]*operationalWork, error) {
+	works := make(map[int]*operationalWork)
+	workOf := func(employeeId int) *operationalWork {
+		if _, ok := works[employeeId]; !ok {
+			works[employeeId] = &operationalWork{}
+		}
+		return works[employeeId]
+	}
+
+	counts, err := u.EmployeeAttendanceRepository.CountByPeriod(tx, period.ID, enum.PRESENT)
+	if err != nil {
+		u.Log.Warnf("Failed count attendance to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	for _, count := range counts {
+		workOf(count.EmployeeId).Days = count.Total
+	}
+
+	trips, err := u.TripRepository.FindByDateRange(tx, period.StartDate, period.EndDate)
+	if err != nil {
+		u.Log.Warnf("Failed find trips to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	for _, trip := range trips {
+		sacks := int64(trip.SacksDelivered())
+
+		driver := workOf(trip.DriverId)
+		driver.Trips++
+		driver.Sacks += sacks
+
+		for _, helper := range trip.Helpers {
+			crew := workOf(helper.ID)
+			crew.Trips++
+			crew.Sacks += sacks
+		}
+	}
+
+	return works, nil
+}
+
+func calculateOperationalItems(employee *entity.Employee, rule *entity.PayRule, work *operationalWork) []entity.PayrollItem {
+	dailyRate := employee.Salary
+	if rule != nil && rule.DailyRate != nil {
+		dailyRate = *rule.DailyRate
+	}
+
+	items := []entity.PayrollItem{
+		{
+			Type:        enum.ITEM_DAILY,
+			Description: fmt.Sprintf("Gaji harian %d hari", work.Days),
+			Quantity:    float64(work.Days),
+			Rate:        dailyRate,
+			Amount:      dailyRate.Mul(work.Days),
+		},
+	}
+
+	if rule == nil {
+		return items
+	}
+
+	if rule.TripRate > 0 && work.Trips > 0 {
+		items = append(items, entity.PayrollItem{
+			Type:        enum.ITEM_TRIP,
+			Description: fmt.Sprintf("Upah %d perjalanan", work.Trips),
+			Quantity:    float64(work.Trips),
+			Rate:        rule.TripRate,
+			Amount:      rule.TripRate.Mul(work.Trips),
+		})
+	}
+
+	if rule.SackRate > 0 && work.Sacks > 0 {
+		items = append(items, entity.PayrollItem{
+			Type:        enum.ITEM_SACK,
+			Description: fmt.Sprintf("Upah %d karung", work.Sacks),
+			Quantity:    float64(work.Sacks),
+			Rate:        rule.SackRate,
+			Amount:      rule.SackRate.Mul(work.Sacks),
+		})
+	}
+
+	return items
+}
+
+// scheduleInstallments deducts each advance installment, oldest first, without exceeding the gross pay
+func scheduleInstallments(advances []entity.CashAdvance, gross money.Money, date time.Time) ([]entity.PayrollItem, []entity.CashAdvanceRepayment) {
+	var items []entity.PayrollItem
+	var repayments []entity.CashAdvanceRepayment
+
+	remaining := gross
+	for _, advance := range advances {
+		amount := money.Min(advance.Installment, advance.Outstanding())
+		amount = money.Min(amount, remaining)
+		if amount <= 0 {
+			continue
+		}
+		remaining -= amount
+
+		items = append(items, entity.PayrollItem{
+			Type:        enum.ITEM_DEDUCTION,
+			Description: fmt.Sprintf("Cicilan kasbon %s", advance.Date.Format("2006-01-02")),
+			Quantity:    1,
+			Rate:        amount,
+			Amount:      amount,
+		})
+
+		notes := "Potongan gaji"
+		repayments = append(repayments, entity.CashAdvanceRepayment{
+			Date:          date,
+			Amount:        amount,
+			Notes:         &notes,
+			CashAdvanceId: advance.ID,
+		})
+	}
+
+	return items, repayments
+}
+
+// Usecase
+func (u *PayrollUseCaseImpl) FindAllPayRules(ctx context.Context) ([]model.PayRuleResponse, error) {
+	rules, err := u.PayRuleRepository.FindAll(u.Tx.DB(ctx))
+	if err != nil {
+		u.Log.WithError(err).Error("error getting pay rules")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.PayRuleResponse, len(rules))
+	for i, rule := range rules {
+		responses[i] = *converter.ToPayRuleResponse(&rule)
+	}
+
+	return responses, nil
+}
+
+func (u *PayrollUseCaseImpl) UpsertPayRule(ctx context.Context, request *model.UpsertPayRuleRequest) (*model.PayRuleResponse, error) {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	rule := &entity.PayRule{
+		Role:      request.Role,
+		DailyRate: request.DailyRate,
+		TripRate:  request.TripRate,
+		SackRate:  request.SackRate,
+	}
+
+	if err := u.PayRuleRepository.Upsert(tx, rule); err != nil {
+		u.Log.Warnf("Failed upsert pay rule to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"role": request.Role,
+		}).Warnf("Failed commit to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return converter.ToPayRuleResponse(rule), nil
+}
+
+func (u *PayrollUseCaseImpl) FindAll(ctx context.Context, request *model.FindAllPayrollRequest) ([]model.PayrollResponse, error) {
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	payrolls, err := u.PayrollRepository.FindAll(u.Tx.DB(ctx), request)
+	if err != nil {
+		u.Log.WithError(err).Error("error getting payrolls")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.PayrollResponse, len(payrolls))
+	for i, payroll := range payrolls {
+		responses[i] = *converter.ToPayrollResponse(&payroll)
+	}
+
+	return responses, nil
+}
+
+func (u *PayrollUseCaseImpl) FindByEmployee(ctx context.Context, request *model.FindPayrollByEmployeeRequest) ([]model.PayrollResponse, error) {
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	payrolls, err := u.PayrollRepository.FindByEmployeeId(u.Tx.DB(ctx), request)
+	if err != nil {
+		u.Log.WithError(err).Error("error getting payrolls")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.PayrollResponse, len(payrolls))
+	for i, payroll := range payrolls {
+		responses[i] = *converter.ToPayrollResponse(&payroll)
+	}
+
+	return responses, nil
+}
+
+func (u *PayrollUseCaseImpl) Generate(ctx context.Context, request *model.GeneratePayrollRequest) ([]model.PayrollResponse, error) {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	period, err := u.PeriodRepository.FindById(tx, request.PeriodId)
+	if err != nil {
+		u.Log.Warnf("Failed find period to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if period == nil {
+		u.Log.Warnf("Period not found : %d", request.PeriodId)
+		return nil, fiber.NewError(fiber.StatusNotFound, "Periode tidak ditemukan")
+	}
+
+	if period.IsClosed {
+		u.Log.Warnf("Period already closed : %d", request.PeriodId)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Periode sudah ditutup")
+	}
+
+	rules, err := u.PayRuleRepository.FindAll(tx)
+	if err != nil {
+		u.Log.Warnf("Failed find pay rules to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	rulesByRole := make(map[enum.EmployeeRole]*entity.PayRule)
+	for i := range rules {
+		rulesByRole[rules[i].Role] = &rules[i]
+	}
+
+	// paid payrolls are left untouched
+	existing, err := u.PayrollRepository.FindByPeriodId(tx, period.ID)
+	if err != nil {
+		u.Log.Warnf("Failed find payrolls to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	paidEmployees := make(map[int]bool)
+	stale := make(map[int]int)
+	for _, payroll := range existing {
+		if payroll.IsPaid {
+			paidEmployees[payroll.EmployeeId] = true
+			continue
+		}
+
+		if payroll.ModuleType != enum.OPERATIONAL {
+			continue
+		}
+
+		// installments are scheduled again below
+		if err := u.CashAdvanceRepaymentRepository.DeleteByPayrollId(tx, payroll.ID); err != nil {
+			u.Log.Warnf("Failed delete cash advance repayments to database : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+		stale[payroll.EmployeeId] = payroll.ID
+	}
+
+	outstanding, err := u.CashAdvanceRepository.FindOutstanding(tx, period.EndDate)
+	if err != nil {
+		u.Log.Warnf("Failed find cash advances to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	advancesByEmployee := make(map[int][]entity.CashAdvance)
+	for _, advance := range outstanding {
+		advancesByEmployee[advance.EmployeeId] = append(advancesByEmployee[advance.EmployeeId], advance)
+	}
+
+	employees, err := u.EmployeeRepository.FindAllEmployedBetween(tx, period.StartDate, period.EndDate)
+	if err != nil {
+		u.Log.Warnf("Failed find employees to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	works, err := u.collectWork(tx, period)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []model.PayrollResponse{}
+	for i := range employees {
+		employee := &employees[i]
+
+		// sales are paid through the sales incentive module
+		if employee.Role == enum.SALES || paidEmployees[employee.ID] {
+			continue
+		}
+
+		work, ok := works[employee.ID]
+		if !ok {
+			work = &operationalWork{}
+		}
+
+		items := calculateOperationalItems(employee, rulesByRole[employee.Role], work)
+
+		payroll := &entity.Payroll{
+			AttendanceDays: int(work.Days),
+			ModuleType:     enum.OPERATIONAL,
+			EmployeeId:     employee.ID,
+			PeriodID:       period.ID,
+		}
+		for _, item := range items {
+			if item.Type == enum.ITEM_DAILY {
+				payroll.BaseSalary += item.Amount
+			} else {
+				payroll.Bonuses += item.Amount
+			}
+		}
+
+		deductions, repayments := scheduleInstallments(advancesByEmployee[employee.ID], payroll.BaseSalary+payroll.Bonuses, period.EndDate)
+		for _, item := range deductions {
+			payroll.Deductions += item.Amount
+		}
+		items = append(items, deductions...)
+
+		if err := u.PayrollRepository.Upsert(tx, payroll); err != nil {
+			u.Log.Warnf("Failed upsert payroll to database : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+
+		if err := u.PayrollRepository.ReplaceItems(tx, payroll.ID, items); err != nil {
+			u.Log.Warnf("Failed create payroll items to database : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+
+		for i := range repayments {
+			repayments[i].PayrollId = &payroll.ID
+			if err := u.CashAdvanceRepaymentRepository.Create(tx, &repayments[i]); err != nil {
+				u.Log.Warnf("Failed create cash advance repayment to database : %+v", err)
+				return nil, fiber.ErrInternalServerError
+			}
+		}
+
+		payroll.Items = items
+		payroll.Employee = employee
+		responses = append(responses, *converter.ToPayrollResponse(payroll))
+		delete(stale, employee.ID)
+	}
+
+	// employees who are no longer paid here lose their unpaid payroll
+	for _, id := range stale {
+		if err := u.PayrollRepository.ReplaceItems(tx, id, nil); err != nil {
+			u.Log.Warnf("Failed delete payroll items to database : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+
+		if err := u.PayrollRepository.Delete(tx, id); err != nil {
+			u.Log.Warnf("Failed delete payroll to database : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"period_id": request.PeriodId,
+		}).Warnf("Failed commit to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return responses, nil
+}
+
+func (u *PayrollUseCaseImpl) Pay(ctx context.Context, request *model.PayPayrollRequest) (*model.PayrollResponse, error) {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	payroll, err := u.PayrollRepository.FindById(tx, request.ID)
+	if err != nil {
+		u.Log.Warnf("Failed find payroll to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if payroll == nil {
+		u.Log.Warnf("Payroll not found : %d", request.ID)
+		return nil, fiber.NewError(fiber.StatusNotFound, "Gaji tidak ditemukan")
+	}
+
+	if payroll.IsPaid {
+		u.Log.Warnf("Payroll already paid : %d", request.ID)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Gaji sudah dibayar")
+	}
+
+	paidAt := time.Now()
+	updates := map[string]any{
+		"is_paid":        true,
+		"paid_at":        paidAt,
+		"payment_method": request.PaymentMethod,
+		"paid_by":        request.PaidBy,
+	}
+
+	if err := u.PayrollRepository.UpdatePayment(tx, []int{payroll.ID}, updates); err != nil {
+		u.Log.Warnf("Failed update payroll payment to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"id": request.ID,
+		}).Warnf("Failed commit to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	payroll.IsPaid = true
+	payroll.PaidAt = &paidAt
+	payroll.PaymentMethod = &request.PaymentMethod
+	payroll.PaidBy = &request.PaidBy
+
+	return converter.ToPayrollResponse(payroll), nil
+}
+
+func (u *PayrollUseCaseImpl) PayByPeriod(ctx context.Context, request *model.PayPayrollsByPeriodRequest) (int, error) {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return 0, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	payrolls, err := u.PayrollRepository.FindByPeriodId(tx, request.PeriodId)
+	if err != nil {
+		u.Log.Warnf("Failed find payrolls to database : %+v", err)
+		return 0, fiber.ErrInternalServerError
+	}
+
+	var ids []int
+	for _, payroll := range payrolls {
+		if !payroll.IsPaid {
+			ids = append(ids, payroll.ID)
+		}
+	}
+
+	if len(ids) == 0 {
+		u.Log.Warnf("No unpaid payroll in period : %d", request.PeriodId)
+		return 0, fiber.NewError(fiber.StatusBadRequest, "Tidak ada gaji yang belum dibayar")
+	}
+
+	updates := map[string]any{
+		"is_paid":        true,
+		"paid_at":        time.Now(),
+		"payment_method": request.PaymentMethod,
+		"paid_by":        request.PaidBy,
+	}
+
+	if err := u.PayrollRepository.UpdatePayment(tx, ids, updates); err != nil {
+		u.Log.Warnf("Failed update payroll payment to database : %+v", err)
+		return 0, fiber.ErrInternalServerError
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"period_id": request.PeriodId,
+		}).Warnf("Failed commit to database : %+v", err)
+		return 0, fiber.ErrInternalServerError
+	}
+
+	return len(ids), nil
+}
+
+func (u *PayrollUseCaseImpl) Payslip(ctx context.Context, request *model.PayslipRequest) ([]byte, error) {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	payroll, err := u.PayrollRepository.FindByEmployeeAndPeriod(tx, request.EmployeeId, request.PeriodId)
+	if err != nil {
+		u.Log.Warnf("Failed find payroll to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if payroll == nil {
+		u.Log.Warnf("Payroll not found : employee %d period %d", request.EmployeeId, request.PeriodId)
+		return nil, fiber.NewError(fiber.StatusNotFound, "Gaji tidak ditemukan")
+	}
+
+	document, err := utils.GeneratePayslipPDF(payroll)
+	if err != nil {
+		u.Log.Warnf("Failed generate payslip : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// count prints of a closed payroll
+	closure, err := u.PeriodClosureRepository.FindByPeriodAndModule(tx, request.PeriodId, string(enum.PAYROLL))
+	if err != nil {
+		u.Log.Warnf("Failed find period closure to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if closure != nil && closure.IsClosed {
+		if err := u.PeriodClosureRepository.IncrementPrintCount(tx, closure.ID); err != nil {
+			u.Log.Warnf("Failed update print count to database : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"employee_id": request.EmployeeId,
+			"period_id":   request.PeriodId,
+		}).Warnf("Failed commit to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return document, nil
+}
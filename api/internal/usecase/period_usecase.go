@@ -4,7 +4,9 @@ import (
 	"api/internal/entity"
 	"api/internal/entity/enum"
 	"api/internal/model"
+	"api/internal/model/converter"
 	"api/internal/repository"
+	"api/internal/utils"
 	"context"
 	"time"
 
@@ -15,33 +17,229 @@ import (
 )
 
 type PeriodUseCase interface {
-	GetOrCreatePeriodIdByDate(ctx context.Context, date string) (int, error)
+	GetOrCreatePeriodIdByDate(tx *gorm.DB, date string) (int, error)
+	FindAll(ctx context.Context, request *model.FindAllPeriodRequest) ([]model.PeriodResponse, int64, error)
+	FindById(ctx context.Context, request *model.FindByIdPeriodRequest) (*model.PeriodDetailResponse, error)
+	Generate(ctx context.Context, request *model.GeneratePeriodRequest) ([]model.PeriodResponse, error)
+	Deactivate(ctx context.Context, request *model.DeactivatePeriodRequest) (*model.PeriodResponse, error)
 }
 
 type PeriodUseCaseImpl struct {
-	DB               *gorm.DB
-	Log              *logrus.Logger
-	Validate         *validator.Validate
-	PeriodRepository repository.PeriodRepository
+	Tx                      repository.Transactor
+	Log                     *logrus.Logger
+	Validate                *validator.Validate
+	PeriodRepository        repository.PeriodRepository
+	PeriodClosureRepository repository.PeriodClosureRepository
+	Calendar                *utils.WeekCalendar
 }
 
 func NewPeriodUseCase(
-	db *gorm.DB,
+	tx repository.Transactor,
 	logger *logrus.Logger,
 	validate *validator.Validate,
 	employeeRepository repository.PeriodRepository,
+	periodClosureRepository repository.PeriodClosureRepository,
+	calendar *utils.WeekCalendar,
 ) PeriodUseCase {
 	return &PeriodUseCaseImpl{
-		DB:               db,
-		Log:              logger,
-		Validate:         validate,
-		PeriodRepository: employeeRepository,
+		Tx:                      tx,
+		Log:                     logger,
+		Validate:                validate,
+		PeriodRepository:        employeeRepository,
+		PeriodClosureRepository: periodClosureRepository,
+		Calendar:                calendar,
 	}
 }
 
-func (u *PeriodUseCaseImpl) GetOrCreatePeriodIdByDate(ctx context.Context, date string) (int, error) {
+func (u *PeriodUseCaseImpl) FindAll(ctx context.Context, request *model.FindAllPeriodRequest) ([]model.PeriodResponse, int64, error) {
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, 0, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	periods, total, err := u.PeriodRepository.FindAll(u.Tx.DB(ctx), request)
+	if err != nil {
+		u.Log.WithError(err).Error("error getting periods")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	responses := make([]model.PeriodResponse, len(periods))
+	for i, period := range periods {
+		responses[i] = *converter.ToPeriodResponse(&period)
+	}
+
+	return responses, total, nil
+}
+
+func (u *PeriodUseCaseImpl) FindById(ctx context.Context, request *model.FindByIdPeriodRequest) (*model.PeriodDetailResponse, error) {
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	db := u.Tx.DB(ctx)
+
+	period, err := u.PeriodRepository.FindById(db, request.ID)
+	if err != nil {
+		u.Log.WithError(err).Error("error getting period")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if period == nil {
+		u.Log.Warnf("Period not found : %d", request.ID)
+		return nil, fiber.NewError(fiber.StatusNotFound, "Periode tidak ditemukan")
+	}
+
+	attendance, err := u.PeriodRepository.SummarizeAttendance(db, period.ID)
+	if err != nil {
+		return nil, fiber.ErrInternalServerError
+	}
+
+	payroll, err := u.PeriodRepository.SummarizePayroll(db, period.ID)
+	if err != nil {
+		return nil, fiber.ErrInternalServerError
+	}
+
+	collections, err := u.PeriodRepository.CountCollections(db, period.ID)
+	if err != nil {
+		return nil, fiber.ErrInternalServerError
+	}
+
+	closures, err := u.PeriodClosureRepository.FindByPeriod(db, period.ID)
+	if err != nil {
+		return nil, fiber.ErrInternalServerError
+	}
+
+	response := &model.PeriodDetailResponse{
+		Period:      *converter.ToPeriodResponse(period),
+		Attendance:  *attendance,
+		Payroll:     *payroll,
+		Collections: collections,
+	}
+
+	// every module is listed, closed or not
+	for _, module := range []enum.ModuleType{enum.EMPLOYEE, enum.PAYROLL} {
+		var found *entity.PeriodClosure
+		for i := range closures {
+			if closures[i].ModuleName == string(module) {
+				found = &closures[i]
+			}
+		}
+		response.Modules = append(response.Modules, *converter.ToPeriodModuleResponse(module, found))
+	}
+
+	return response, nil
+}
+
+// Generate creates the periods of the coming weeks in order, each one anchored
+// on the one before so the numbering matches what attendance would have created
+func (u *PeriodUseCaseImpl) Generate(ctx context.Context, request *model.GeneratePeriodRequest) ([]model.PeriodResponse, error) {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	startDate := time.Now()
+	if request.StartDate != "" {
+		startDate, err = time.Parse("2006-01-02", request.StartDate)
+		if err != nil {
+			u.Log.Warnf("Failed to parse date: %+v", err)
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Format tanggal tidak valid")
+		}
+	}
+
+	responses := make([]model.PeriodResponse, 0, request.Count)
+	date := u.Calendar.StartOfWeek(startDate)
+	for i := 0; i < request.Count; i++ {
+		id, err := u.GetOrCreatePeriodIdByDate(tx, date.Format("2006-01-02"))
+		if err != nil {
+			return nil, err
+		}
+
+		period, err := u.PeriodRepository.FindById(tx, id)
+		if err != nil || period == nil {
+			u.Log.Warnf("Failed find generated period : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+
+		responses = append(responses, *converter.ToPeriodResponse(period))
+		date = period.EndDate.AddDate(0, 0, 1)
+	}
+
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	return responses, nil
+}
+
+// Deactivate hides a period created by mistake, it must not hold any data
+func (u *PeriodUseCaseImpl) Deactivate(ctx context.Context, request *model.DeactivatePeriodRequest) (*model.PeriodResponse, error) {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	period, err := u.PeriodRepository.FindById(tx, request.ID)
+	if err != nil {
+		u.Log.WithError(err).Error("error getting period")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if period == nil {
+		u.Log.Warnf("Period not found : %d", request.ID)
+		return nil, fiber.NewError(fiber.StatusNotFound, "Periode tidak ditemukan")
+	}
+
+	if period.IsClosed {
+		u.Log.Warnf("Period already closed : %d", request.ID)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Periode sudah ditutup")
+	}
+
+	hasData, err := u.PeriodRepository.HasData(tx, period.ID)
+	if err != nil {
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if hasData {
+		u.Log.Warnf("Period has data : %d", request.ID)
+		return nil, fiber.NewError(fiber.StatusConflict, "Periode sudah memiliki data dan tidak dapat dinonaktifkan")
+	}
+
+	if err := u.PeriodRepository.Deactivate(tx, period.ID); err != nil {
+		u.Log.Warnf("Failed deactivate period : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.Warnf("Failed commit transaction : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	period.IsActive = false
+	return converter.ToPeriodResponse(period), nil
+}
+
+// GetOrCreatePeriodIdByDate runs inside the caller's transaction, concurrent
+// callers for the same week end up with the same period
+func (u *PeriodUseCaseImpl) GetOrCreatePeriodIdByDate(tx *gorm.DB, date string) (int, error) {
 	// find period by date
-	period, err := u.FindWeekByDate(ctx, date)
+	period, err := u.FindWeekByDate(tx, date)
 	if err != nil {
 		u.Log.Warnf("Failed to find period by date: %+v", err)
 		return 0, fiber.ErrInternalServerError
@@ -49,7 +247,7 @@ func (u *PeriodUseCaseImpl) GetOrCreatePeriodIdByDate(ctx context.Context, date
 
 	// create period if not found
 	if period == nil {
-		period, err := u.CreateByDate(ctx, date)
+		period, err := u.CreateByDate(tx, date)
 		if err != nil {
 			u.Log.Warnf("Failed create period to database : %+v", err)
 			return 0, err
@@ -61,7 +259,7 @@ func (u *PeriodUseCaseImpl) GetOrCreatePeriodIdByDate(ctx context.Context, date
 	return period.ID, nil
 }
 
-func (u *PeriodUseCaseImpl) FindWeekByDate(ctx context.Context, date string) (*entity.Period, error) {
+func (u *PeriodUseCaseImpl) FindWeekByDate(tx *gorm.DB, date string) (*entity.Period, error) {
 	// parse date
 	parseDate, err := time.Parse("2006-01-02", date)
 	if err != nil {
@@ -70,7 +268,7 @@ func (u *PeriodUseCaseImpl) FindWeekByDate(ctx context.Context, date string) (*e
 	}
 
 	// find period
-	period, err := u.PeriodRepository.FindByDate(u.DB.WithContext(ctx), enum.WEEKLY, parseDate)
+	period, err := u.PeriodRepository.FindByDate(tx, u.periodTypes(), parseDate)
 	if err != nil {
 		u.Log.Warnf("Failed find period to database : %+v", err)
 		return nil, err
@@ -79,7 +277,7 @@ func (u *PeriodUseCaseImpl) FindWeekByDate(ctx context.Context, date string) (*e
 	return period, nil
 }
 
-func (u *PeriodUseCaseImpl) CreateByDate(ctx context.Context, date string) (*entity.Period, error) {
+func (u *PeriodUseCaseImpl) CreateByDate(tx *gorm.DB, date string) (*entity.Period, error) {
 	// parse date
 	parseDate, err := time.Parse("2006-01-02", date)
 	if err != nil {
@@ -87,11 +285,14 @@ func (u *PeriodUseCaseImpl) CreateByDate(ctx context.Context, date string) (*ent
 		return nil, err
 	}
 
-	weekInfo := u.CalculateWeekInfo(ctx, parseDate)
+	weekInfo, err := u.CalculateWeekInfo(tx, parseDate)
+	if err != nil {
+		return nil, err
+	}
 
 	// parse to entity
 	period := &entity.Period{
-		Type:       enum.WEEKLY,
+		Type:       u.Calendar.Type,
 		StartDate:  weekInfo.StartDate,
 		EndDate:    weekInfo.EndDate,
 		WeekNumber: weekInfo.WeekNumber,
@@ -99,137 +300,67 @@ func (u *PeriodUseCaseImpl) CreateByDate(ctx context.Context, date string) (*ent
 		Year:       weekInfo.Year,
 		IsActive:   true,
 		IsClosed:   false,
+		WeekStart:  u.Calendar.WeekStart,
+		MonthRule:  u.Calendar.MonthRule,
 	}
 
-	// create period
-	newPeriod, err := u.PeriodRepository.Create(u.DB.WithContext(ctx), period)
+	// create period, or take the one a concurrent transaction created first
+	newPeriod, err := u.PeriodRepository.FirstOrCreate(tx, period)
 	if err != nil {
-		u.Log.Warnf("Failed find period to database : %+v", err)
+		u.Log.Warnf("Failed create period to database : %+v", err)
 		return nil, err
 	}
 
-	return newPeriod, nil
-}
-
-func (u *PeriodUseCaseImpl) CalculateWeekInfo(ctx context.Context, date time.Time) *model.WeekInfo {
-	year, _ := date.ISOWeek()
-
-	// find closest sunday
-	weekday := int(date.Weekday())
-	daysFromSunday := weekday
-	if weekday == 0 {
-		daysFromSunday = 0
+	// a deactivated week stays hidden, nothing may be recorded on it
+	if !newPeriod.IsActive || newPeriod.DeletedAt.Valid {
+		u.Log.Warnf("Period not active : %d", newPeriod.ID)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Periode tidak aktif")
 	}
 
-	startDate := date.AddDate(0, 0, -daysFromSunday)
-	endDate := startDate.AddDate(0, 0, 6)
+	return newPeriod, nil
+}
 
-	// Cari Minggu pertama di bulan dimana startDate berada
-	firstDayOfMonth := time.Date(startDate.Year(), startDate.Month(), 1, 0, 0, 0, 0, startDate.Location())
-	firstSunday := firstDayOfMonth
-	firstWeekday := int(firstDayOfMonth.Weekday())
+// CalculateWeekInfo loads the inputs of the week calendar, the numbering itself
+// is done by utils.WeekCalendar
+func (u *PeriodUseCaseImpl) CalculateWeekInfo(db *gorm.DB, date time.Time) (*model.WeekInfo, error) {
+	startDate := u.Calendar.StartOfWeek(date)
 
-	if firstWeekday != 0 {
-		daysToSunday := 7 - firstWeekday
-		firstSunday = firstDayOfMonth.AddDate(0, 0, daysToSunday)
+	last, err := u.PeriodRepository.FindLastBefore(db, u.periodTypes(), startDate)
+	if err != nil {
+		u.Log.Warnf("Failed find last period: %+v", err)
+		return nil, err
 	}
 
-	// Hitung nomor minggu dan tentukan bulan yang tepat
-	var week int
-	var monthValue int
-
-	if startDate.Before(firstSunday) {
-		// Minggu ini dimulai di bulan sebelumnya
-		lastDayPrevMonth := firstDayOfMonth.AddDate(0, 0, -1)
-		prevMonthWeekInfo := u.CalculateWeekInfo(ctx, lastDayPrevMonth)
-		week = prevMonthWeekInfo.WeekNumber + 1
-		monthValue = prevMonthWeekInfo.Month
-	} else {
-		// Minggu ini di bulan yang sama dengan startDate
-
-		// CEK: Apakah firstSunday sudah dipakai bulan sebelumnya?
-		firstSundayWeekInfo, _ := u.getWeeklyPeriodByStartDate(ctx, firstSunday)
-
-		if firstSundayWeekInfo != nil && firstSundayWeekInfo.Month != int(startDate.Month()) {
-			// FirstSunday sudah dipakai bulan lain
-			// Ini adalah minggu pertama atau seterusnya di bulan baru
-
-			// Cari minggu kedua setelah firstSunday (yang merupakan minggu pertama bulan baru)
-			secondSunday := firstSunday.AddDate(0, 0, 7)
-
-			if startDate.Equal(secondSunday) || startDate.After(secondSunday) {
-				// Hitung dari secondSunday sebagai week 1
-				daysDiff := startDate.Sub(secondSunday).Hours() / 24
-				week = int(daysDiff/7) + 1
-			} else {
-				// Ini tidak mungkin terjadi karena startDate >= firstSunday
-				week = 1
-			}
-
-			monthValue = int(startDate.Month())
-		} else {
-			// FirstSunday belum dipakai atau dipakai bulan yang sama
-			// Cek apakah bulan sebelumnya ada yang closed
-			prevMonth := firstDayOfMonth.AddDate(0, -1, 0)
-			lastClosedPeriod, _ := u.getLastClosedMonthlyPeriod(ctx, prevMonth.Year(), int(prevMonth.Month()))
-
-			if lastClosedPeriod != nil {
-				// Ada monthly period yang closed, reset ke bulan baru
-				daysDiff := startDate.Sub(firstSunday).Hours() / 24
-				week = int(daysDiff/7) + 1
-				monthValue = int(startDate.Month())
-			} else {
-				// Tidak ada yang closed, cek apakah ada period weekly di bulan sebelumnya
-				lastWeeklyPeriod, _ := u.getLastWeeklyPeriodInMonth(ctx, prevMonth.Year(), int(prevMonth.Month()))
-
-				if lastWeeklyPeriod != nil {
-					// Ada period weekly sebelumnya, lanjutkan dari bulan tersebut
-					daysDiff := startDate.Sub(firstSunday).Hours() / 24
-					week = lastWeeklyPeriod.WeekNumber + int(daysDiff/7) + 1
-					monthValue = lastWeeklyPeriod.Month
-				} else {
-					// Tidak ada period weekly sebelumnya (bulan pertama sistem)
-					daysDiff := startDate.Sub(firstSunday).Hours() / 24
-					week = int(daysDiff/7) + 1
-					monthValue = int(startDate.Month())
-				}
-			}
-		}
+	if last == nil {
+		return u.Calendar.Week(date, nil), nil
 	}
 
-	return &model.WeekInfo{
-		Year:       year,
-		WeekNumber: week,
-		Month:      monthValue,
-		StartDate:  startDate,
-		EndDate:    endDate,
+	anchor := &utils.WeekAnchor{
+		StartDate:  last.StartDate,
+		EndDate:    last.EndDate,
+		WeekNumber: last.WeekNumber,
+		Month:      last.Month,
+		Year:       last.Year,
 	}
-}
 
-func (u *PeriodUseCaseImpl) getLastClosedMonthlyPeriod(ctx context.Context, year, month int) (*entity.Period, error) {
-	// find last period month
-	period, err := u.PeriodRepository.FindLastClosedMothly(u.DB.WithContext(ctx), month, year)
+	var closed []utils.MonthKey
+	closedPeriod, err := u.PeriodRepository.FindLastClosedMonthly(db, last.Month, last.Year)
 	if err != nil {
 		u.Log.Warnf("Failed find period to database : %+v", err)
 		return nil, err
 	}
-
-	return period, nil
-}
-
-func (u *PeriodUseCaseImpl) getLastWeeklyPeriodInMonth(ctx context.Context, year, month int) (*entity.Period, error) {
-	period, err := u.PeriodRepository.FindLastWeeklyInMonth(u.DB.WithContext(ctx), month, year)
-	if err != nil {
-		u.Log.Warnf("Failed find weekly period: %+v", err)
-		return nil, err
+	if closedPeriod != nil {
+		closed = append(closed, utils.MonthKey{Year: last.Year, Month: last.Month})
 	}
-	return period, nil
+
+	return u.Calendar.Week(date, anchor, closed...), nil
 }
 
-func (u *PeriodUseCaseImpl) getWeeklyPeriodByStartDate(ctx context.Context, startDate time.Time) (*entity.Period, error) {
-	period, err := u.PeriodRepository.FindByStartDate(u.DB.WithContext(ctx), startDate)
-	if err != nil {
-		return nil, err
+// periodTypes are the stored types that can hold the date, weekly and
+// bi-weekly periods never overlap so switching between them continues numbering
+func (u *PeriodUseCaseImpl) periodTypes() []enum.PeriodType {
+	if u.Calendar.Type == enum.MONTHLY {
+		return []enum.PeriodType{enum.MONTHLY}
 	}
-	return period, nil
+	return []enum.PeriodType{enum.WEEKLY, enum.BIWEEKLY}
 }
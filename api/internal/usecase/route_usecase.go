@@ -12,7 +12,6 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
-	"gorm.io/gorm"
 )
 
 type RouteUseCase interface {
@@ -20,24 +19,25 @@ type RouteUseCase interface {
 	Create(ctx context.Context, request *model.CreateRouteRequest) (*model.RouteResponse, error)
 	Update(ctx context.Context, request *model.UpdateRouteRequest) (*model.RouteResponse, error)
 	Delete(ctx context.Context, request *model.DeleteRouteRequest) error
+	FindById(ctx context.Context, request *model.FindByIdRouteRequest) (*model.RouteResponse, error)
 }
 
 type RouteUseCaseImpl struct {
-	DB              *gorm.DB
+	Tx              repository.Transactor
 	Log             *logrus.Logger
 	Validate        *validator.Validate
 	RouteRepository repository.RouteRepository
 }
 
 func NewRouteUseCase(
-	db *gorm.DB,
+	tx repository.Transactor,
 	logger *logrus.Logger,
 	validate *validator.Validate,
 	routesRepository repository.RouteRepository,
 	routeRepository repository.RouteRepository,
 ) RouteUseCase {
 	return &RouteUseCaseImpl{
-		DB:              db,
+		Tx:              tx,
 		Log:             logger,
 		Validate:        validate,
 		RouteRepository: routeRepository,
@@ -53,7 +53,7 @@ func (u *RouteUseCaseImpl) FindAll(ctx context.Context, request *model.FindAllRo
 	}
 
 	//get routes
-	routes, total, err := u.RouteRepository.FindAll(u.DB.WithContext(ctx), request)
+	routes, total, err := u.RouteRepository.FindAll(u.Tx.DB(ctx), request)
 	if err != nil {
 		u.Log.WithError(err).Error("error getting routes")
 		return nil, 0, fiber.ErrInternalServerError
@@ -69,8 +69,8 @@ func (u *RouteUseCaseImpl) FindAll(ctx context.Context, request *model.FindAllRo
 }
 
 func (u *RouteUseCaseImpl) Create(ctx context.Context, request *model.CreateRouteRequest) (*model.RouteResponse, error) {
-	tx := u.DB.WithContext(ctx).Begin()
-	defer tx.Rollback()
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
 
 	//check request validation
 	details, errorMessage, err := utils.ValidateStruct(request)
@@ -96,6 +96,11 @@ func (u *RouteUseCaseImpl) Create(ctx context.Context, request *model.CreateRout
 	route := &entity.Route{
 		Name:        request.Name,
 		Description: request.Description,
+		VisitDays:   []int{},
+	}
+
+	if request.VisitDays != nil {
+		route.VisitDays = request.VisitDays
 	}
 
 	//create route
@@ -104,8 +109,19 @@ func (u *RouteUseCaseImpl) Create(ctx context.Context, request *model.CreateRout
 		return nil, fiber.ErrInternalServerError
 	}
 
+	if err := u.RouteRepository.ReplaceStops(tx, route.ID, toRouteStops(request.Stops)); err != nil {
+		u.Log.Warnf("Failed create route stops to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	route, err = u.RouteRepository.FindById(tx, route.ID)
+	if err != nil {
+		u.Log.Warnf("Failed find route to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
 	//commit
-	if err := tx.Commit().Error; err != nil {
+	if err := u.Tx.Commit(tx); err != nil {
 		u.Log.WithFields(logrus.Fields{
 			"name": request.Name,
 		}).Warnf("Failed commit to database : %+v", err)
@@ -116,8 +132,8 @@ func (u *RouteUseCaseImpl) Create(ctx context.Context, request *model.CreateRout
 }
 
 func (u *RouteUseCaseImpl) Update(ctx context.Context, request *model.UpdateRouteRequest) (*model.RouteResponse, error) {
-	tx := u.DB.WithContext(ctx).Begin()
-	defer tx.Rollback()
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
 
 	//check request validation
 	details, errorMessage, err := utils.ValidateStruct(request)
@@ -158,14 +174,31 @@ func (u *RouteUseCaseImpl) Update(ctx context.Context, request *model.UpdateRout
 		Description: request.Description,
 	}
 
+	if request.VisitDays != nil {
+		route.VisitDays = append([]int{}, *request.VisitDays...)
+	}
+
 	//update route
 	if err := u.RouteRepository.Update(tx, route); err != nil {
 		u.Log.Warnf("Failed update route to database : %+v", err)
 		return nil, fiber.ErrInternalServerError
 	}
 
+	if request.Stops != nil {
+		if err := u.RouteRepository.ReplaceStops(tx, route.ID, toRouteStops(*request.Stops)); err != nil {
+			u.Log.Warnf("Failed replace route stops to database : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	route, err = u.RouteRepository.FindById(tx, route.ID)
+	if err != nil {
+		u.Log.Warnf("Failed find route to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
 	//commit
-	if err := tx.Commit().Error; err != nil {
+	if err := u.Tx.Commit(tx); err != nil {
 		u.Log.WithFields(logrus.Fields{
 			"name": request.Name,
 		}).Warnf("Failed commit to database : %+v", err)
@@ -176,8 +209,8 @@ func (u *RouteUseCaseImpl) Update(ctx context.Context, request *model.UpdateRout
 }
 
 func (u *RouteUseCaseImpl) Delete(ctx context.Context, request *model.DeleteRouteRequest) error {
-	tx := u.DB.WithContext(ctx).Begin()
-	defer tx.Rollback()
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
 
 	//check request validation
 	details, errorMessage, err := utils.ValidateStruct(request)
@@ -210,7 +243,7 @@ func (u *RouteUseCaseImpl) Delete(ctx context.Context, request *model.DeleteRout
 	}
 
 	//commit
-	if err := tx.Commit().Error; err != nil {
+	if err := u.Tx.Commit(tx); err != nil {
 		u.Log.WithFields(logrus.Fields{
 			"id": request.ID,
 		}).Warnf("Failed commit to database : %+v", err)
@@ -219,3 +252,40 @@ func (u *RouteUseCaseImpl) Delete(ctx context.Context, request *model.DeleteRout
 
 	return nil
 }
+
+func (u *RouteUseCaseImpl) FindById(ctx context.Context, request *model.FindByIdRouteRequest) (*model.RouteResponse, error) {
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	route, err := u.RouteRepository.FindById(u.Tx.DB(ctx), request.ID)
+	if err != nil {
+		u.Log.Warnf("Failed find route to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if route == nil {
+		u.Log.Warnf("Route not found : %d", request.ID)
+		return nil, fiber.NewError(fiber.StatusNotFound, "Rute tidak ditemukan")
+	}
+
+	return converter.ToRouteResponse(route), nil
+}
+
+// Helper fuction
+func toRouteStops(requests []model.RouteStopRequest) []entity.RouteStop {
+	stops := make([]entity.RouteStop, len(requests))
+	for i, request := range requests {
+		stops[i] = entity.RouteStop{
+			Name:      request.Name,
+			Address:   request.Address,
+			Latitude:  request.Latitude,
+			Longitude: request.Longitude,
+		}
+	}
+
+	return stops
+}
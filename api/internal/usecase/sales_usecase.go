@@ -12,7 +12,6 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
-	"gorm.io/gorm"
 )
 
 type SalesUseCase interface {
@@ -22,7 +21,7 @@ type SalesUseCase interface {
 }
 
 type SalesUseCaseImpl struct {
-	DB                 *gorm.DB
+	Tx                 repository.Transactor
 	Log                *logrus.Logger
 	Validate           *validator.Validate
 	SalesRepository    repository.SalesRepository
@@ -31,7 +30,7 @@ type SalesUseCaseImpl struct {
 }
 
 func NewSalesUseCase(
-	db *gorm.DB,
+	tx repository.Transactor,
 	logger *logrus.Logger,
 	validate *validator.Validate,
 	salesRepository repository.SalesRepository,
@@ -39,7 +38,7 @@ func NewSalesUseCase(
 	employeeRepository repository.EmployeeRepository,
 ) SalesUseCase {
 	return &SalesUseCaseImpl{
-		DB:                 db,
+		Tx:                 tx,
 		Log:                logger,
 		Validate:           validate,
 		SalesRepository:    salesRepository,
@@ -57,7 +56,7 @@ func (s *SalesUseCaseImpl) FindAll(ctx context.Context, request *model.FindAllSa
 	}
 
 	//get sales
-	sales, total, err := s.SalesRepository.FindAll(s.DB.WithContext(ctx), request)
+	sales, total, err := s.SalesRepository.FindAll(s.Tx.DB(ctx), request)
 	if err != nil {
 		s.Log.WithError(err).Error("error getting sales")
 		return nil, 0, fiber.ErrInternalServerError
@@ -82,8 +81,8 @@ func (s *SalesUseCaseImpl) FindAll(ctx context.Context, request *model.FindAllSa
 }
 
 func (s *SalesUseCaseImpl) Update(ctx context.Context, request *model.UpdateSalesRequest) (*model.SalesResponse, error) {
-	tx := s.DB.WithContext(ctx).Begin()
-	defer tx.Rollback()
+	tx := s.Tx.Begin(ctx)
+	defer s.Tx.Rollback(tx)
 
 	//check request validation
 	details, errorMessage, err := utils.ValidateStruct(request)
@@ -172,7 +171,7 @@ func (s *SalesUseCaseImpl) Update(ctx context.Context, request *model.UpdateSale
 	}
 
 	//commit
-	if err := tx.Commit().Error; err != nil {
+	if err := s.Tx.Commit(tx); err != nil {
 		s.Log.WithFields(logrus.Fields{
 			"sales_id": request.ID,
 			"name":     request.Name,
@@ -184,8 +183,8 @@ func (s *SalesUseCaseImpl) Update(ctx context.Context, request *model.UpdateSale
 }
 
 func (s *SalesUseCaseImpl) Delete(ctx context.Context, request *model.DeleteSalesRequest) error {
-	tx := s.DB.WithContext(ctx).Begin()
-	defer tx.Rollback()
+	tx := s.Tx.Begin(ctx)
+	defer s.Tx.Rollback(tx)
 
 	//check request validation
 	details, errorMessage, err := utils.ValidateStruct(request)
@@ -217,7 +216,7 @@ func (s *SalesUseCaseImpl) Delete(ctx context.Context, request *model.DeleteSale
 	}
 
 	//commit
-	if err := tx.Commit().Error; err != nil {
+	if err := s.Tx.Commit(tx); err != nil {
 		s.Log.WithFields(logrus.Fields{
 			"id": request.ID,
 		}).Warnf("Failed commit to database : %+v", err)
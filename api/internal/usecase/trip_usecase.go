@@ -0,0 +1,509 @@
+package usecase
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/enum"
+	"api/internal/model"
+	"api/internal/model/converter"
+	"api/internal/repository"
+	"api/internal/utils"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type TripUseCase interface {
+	FindAll(ctx context.Context, request *model.FindAllTripRequest) ([]model.TripResponse, int64, error)
+	FindById(ctx context.Context, request *model.FindByIdTripRequest) (*model.TripResponse, error)
+	Create(ctx context.Context, request *model.CreateTripRequest) (*model.TripResponse, error)
+	Delete(ctx context.Context, request *model.DeleteTripRequest) error
+}
+
+type TripUseCaseImpl struct {
+	Tx                           repository.Transactor
+	Log                          *logrus.Logger
+	Validate                     *validator.Validate
+	TripRepository               repository.TripRepository
+	VehicleRepository            repository.VehicleRepository
+	VehicleHistoryRepository     repository.VehicleHistoryRepository
+	EmployeeRepository           repository.EmployeeRepository
+	RouteRepository              repository.RouteRepository
+	EmployeeAttendanceRepository repository.EmployeeAttendanceRepository
+	DriverLicenseRepository      repository.DriverLicenseRepository
+	VehicleDocumentRepository    repository.VehicleDocumentRepository
+	CrewRepository               repository.CrewRepository
+	PeriodUseCase                PeriodUseCase
+}
+
+func NewTripUseCase(
+	tx repository.Transactor,
+	logger *logrus.Logger,
+	validate *validator.Validate,
+	tripRepository repository.TripRepository,
+	vehicleRepository repository.VehicleRepository,
+	vehicleHistoryRepository repository.VehicleHistoryRepository,
+	employeeRepository repository.EmployeeRepository,
+	routeRepository repository.RouteRepository,
+	employeeAttendanceRepository repository.EmployeeAttendanceRepository,
+	driverLicenseRepository repository.DriverLicenseRepository,
+	vehicleDocumentRepository repository.VehicleDocumentRepository,
+	crewRepository repository.CrewRepository,
+	periodUseCase PeriodUseCase,
+) TripUseCase {
+	return &TripUseCaseImpl{
+		Tx:                           tx,
+		Log:                          logger,
+		Validate:                     validate,
+		TripRepository:               tripRepository,
+		VehicleRepository:            vehicleRepository,
+		VehicleHistoryRepository:     vehicleHistoryRepository,
+		EmployeeRepository:           employeeRepository,
+		RouteRepository:              routeRepository,
+		EmployeeAttendanceRepository: employeeAttendanceRepository,
+		DriverLicenseRepository:      driverLicenseRepository,
+		VehicleDocumentRepository:    vehicleDocumentRepository,
+		CrewRepository:               crewRepository,
+		PeriodUseCase:                periodUseCase,
+	}
+}
+
+// Helper fuction
+// applyCrew fills the vehicle and crew the request leaves out from the crew's
+// default vehicle and its members on the date
+func (u *TripUseCaseImpl) applyCrew(tx *gorm.DB, request *model.CreateTripRequest, date time.Time) (*entity.Crew, error) {
+	crew, err := u.CrewRepository.FindById(tx, request.CrewId, date)
+	if err != nil {
+		u.Log.Warnf("Failed find crew to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if crew == nil {
+		u.Log.Warnf("Crew not found : %d", request.CrewId)
+		return nil, fiber.NewError(fiber.StatusNotFound, "Kru tidak ditemukan")
+	}
+
+	if !crew.IsActive {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Kru tidak aktif")
+	}
+
+	if request.VehicleId == 0 {
+		if crew.VehicleId == nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Kru belum memiliki kendaraan")
+		}
+		request.VehicleId = *crew.VehicleId
+	}
+
+	if request.DriverId == 0 {
+		var helperIds []int
+		for _, member := range crew.Members {
+			if member.Employee == nil {
+				continue
+			}
+
+			switch member.Employee.Role {
+			case enum.DRIVER:
+				request.DriverId = member.EmployeeId
+			case enum.HELPER:
+				helperIds = append(helperIds, member.EmployeeId)
+			}
+		}
+
+		if request.DriverId == 0 {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Kru belum memiliki supir pada tanggal tersebut")
+		}
+
+		if len(request.HelperIds) == 0 {
+			request.HelperIds = helperIds
+		}
+	}
+
+	return crew, nil
+}
+
+// Helper fuction
+func (u *TripUseCaseImpl) validateDriver(tx *gorm.DB, id int) (*entity.Employee, error) {
+	driver, err := u.EmployeeRepository.FindById(tx, id)
+	if err != nil {
+		u.Log.Warnf("Failed find driver to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if driver == nil {
+		u.Log.Warnf("Driver not found : %d", id)
+		return nil, fiber.NewError(fiber.StatusNotFound, "Supir tidak ditemukan")
+	}
+
+	if driver.Role != enum.DRIVER {
+		u.Log.Warnf("Employee is not a driver : %d", id)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Karyawan bukan supir")
+	}
+
+	if driver.Status.IsEnded() {
+		u.Log.Warnf("Driver no longer employed : %d", id)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Supir sudah tidak aktif")
+	}
+
+	return driver, nil
+}
+
+// validateDocuments blocks a dispatch when the driver has no SIM, or when the SIM
+// or a vehicle document is expired on the trip date. Vehicle documents that were
+// never recorded are not checked.
+func (u *TripUseCaseImpl) validateDocuments(tx *gorm.DB, driver *entity.Employee, vehicle *entity.Vehicle, date time.Time) error {
+	licenses, err := u.DriverLicenseRepository.FindByEmployeeId(tx, driver.ID)
+	if err != nil {
+		u.Log.Warnf("Failed find driver licenses to database : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if len(licenses) == 0 {
+		u.Log.Warnf("Driver has no license : %d", driver.ID)
+		return fiber.NewError(fiber.StatusBadRequest, "Supir belum memiliki SIM")
+	}
+
+	required := vehicle.Type.RequiredLicense()
+	valid, covered := false, false
+	for _, license := range licenses {
+		if license.ExpiryDate.Before(date) {
+			continue
+		}
+		valid = true
+		if license.Class.Covers(required) {
+			covered = true
+			break
+		}
+	}
+
+	if !valid {
+		u.Log.Warnf("Driver license expired : %d", driver.ID)
+		return fiber.NewError(fiber.StatusBadRequest, "SIM supir sudah kedaluwarsa")
+	}
+
+	if !covered {
+		u.Log.Warnf("Driver license class does not match vehicle : %d", driver.ID)
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Kendaraan membutuhkan SIM %s", required))
+	}
+
+	documents, err := u.VehicleDocumentRepository.FindLatestByVehicleId(tx, vehicle.ID)
+	if err != nil {
+		u.Log.Warnf("Failed find vehicle documents to database : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	for _, document := range documents {
+		if document.ExpiryDate.Before(date) {
+			u.Log.Warnf("Vehicle document expired : %d %s", vehicle.ID, document.Type)
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("%s kendaraan sudah kedaluwarsa", document.Type))
+		}
+	}
+
+	return nil
+}
+
+func (u *TripUseCaseImpl) validateHelpers(tx *gorm.DB, driverId int, ids []int) ([]entity.Employee, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	helpers, err := u.EmployeeRepository.FindByArryId(tx, ids)
+	if err != nil {
+		u.Log.Warnf("Failed find helpers to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	foundHelpers := make(map[int]entity.Employee)
+	for _, helper := range helpers {
+		foundHelpers[helper.ID] = helper
+	}
+
+	var missingHelpers []int
+	for _, id := range ids {
+		helper, ok := foundHelpers[id]
+		if !ok {
+			missingHelpers = append(missingHelpers, id)
+			continue
+		}
+
+		if id == driverId || helper.Role != enum.HELPER {
+			u.Log.Warnf("Employee is not a helper : %d", id)
+			errorMessage := fmt.Sprintf("Karyawan %s bukan kernet", helper.Name)
+			return nil, fiber.NewError(fiber.StatusBadRequest, errorMessage)
+		}
+
+		if helper.Status.IsEnded() {
+			u.Log.Warnf("Helper no longer employed : %d", id)
+			errorMessage := fmt.Sprintf("Kernet %s sudah tidak aktif", helper.Name)
+			return nil, fiber.NewError(fiber.StatusBadRequest, errorMessage)
+		}
+	}
+
+	if len(missingHelpers) > 0 {
+		u.Log.Warnf("Helpers not found : %v", missingHelpers)
+		errorMessage := fmt.Sprintf("Kernet dengan ID %v tidak ditemukan", missingHelpers)
+		return nil, fiber.NewError(fiber.StatusNotFound, errorMessage)
+	}
+
+	return helpers, nil
+}
+
+func (u *TripUseCaseImpl) validateRoutes(tx *gorm.DB, ids []int) ([]entity.Route, error) {
+	routes, err := u.RouteRepository.FindByArryId(tx, ids)
+	if err != nil {
+		u.Log.Warnf("Failed find route to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	foundRoutes := make(map[int]bool)
+	for _, route := range routes {
+		foundRoutes[route.ID] = true
+	}
+
+	var missingRoutes []int
+	for _, id := range ids {
+		if !foundRoutes[id] {
+			missingRoutes = append(missingRoutes, id)
+		}
+	}
+
+	if len(missingRoutes) > 0 {
+		u.Log.Warnf("Routes not found : %v", missingRoutes)
+		errorMessage := fmt.Sprintf("Route dengan ID %v tidak ditemukan", missingRoutes)
+		return nil, fiber.NewError(fiber.StatusNotFound, errorMessage)
+	}
+
+	return routes, nil
+}
+
+// Usecase
+func (u *TripUseCaseImpl) FindAll(ctx context.Context, request *model.FindAllTripRequest) ([]model.TripResponse, int64, error) {
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, 0, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	trips, total, err := u.TripRepository.FindAll(u.Tx.DB(ctx), request)
+	if err != nil {
+		u.Log.WithError(err).Error("error getting trips")
+		return nil, 0, fiber.ErrInternalServerError
+	}
+
+	// convert to arry response
+	responses := make([]model.TripResponse, len(trips))
+	for i, trip := range trips {
+		responses[i] = *converter.ToTripResponse(&trip)
+	}
+
+	return responses, total, nil
+}
+
+func (u *TripUseCaseImpl) FindById(ctx context.Context, request *model.FindByIdTripRequest) (*model.TripResponse, error) {
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	trip, err := u.TripRepository.FindById(u.Tx.DB(ctx), request.ID)
+	if err != nil {
+		u.Log.WithError(err).Error("error getting trip")
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if trip == nil {
+		u.Log.Warnf("Trip not found : %d", request.ID)
+		return nil, fiber.NewError(fiber.StatusNotFound, "Perjalanan tidak ditemukan")
+	}
+
+	return converter.ToTripResponse(trip), nil
+}
+
+func (u *TripUseCaseImpl) Create(ctx context.Context, request *model.CreateTripRequest) (*model.TripResponse, error) {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return nil, model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	date, err := time.Parse("2006-01-02", request.Date)
+	if err != nil {
+		u.Log.Warnf("Failed to parse date: %+v", err)
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Format tanggal tidak valid")
+	}
+
+	// dispatch a crew
+	var crewId *int
+	if request.CrewId > 0 {
+		crew, err := u.applyCrew(tx, request, date)
+		if err != nil {
+			return nil, err
+		}
+		crewId = &crew.ID
+	}
+
+	// check vehicle
+	vehicle, err := u.VehicleRepository.FindById(tx, request.VehicleId)
+	if err != nil {
+		u.Log.Warnf("Failed find vehicle to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	if vehicle == nil {
+		u.Log.Warnf("Vehicle not found : %d", request.VehicleId)
+		return nil, fiber.NewError(fiber.StatusNotFound, "Kendaraan tidak ditemukan")
+	}
+
+	// check crew
+	driver, err := u.validateDriver(tx, request.DriverId)
+	if err != nil {
+		return nil, err
+	}
+
+	helpers, err := u.validateHelpers(tx, driver.ID, request.HelperIds)
+	if err != nil {
+		return nil, err
+	}
+
+	// check documents
+	if err := u.validateDocuments(tx, driver, vehicle, date); err != nil {
+		return nil, err
+	}
+
+	// check routes
+	routes, err := u.validateRoutes(tx, request.RouteIds)
+	if err != nil {
+		return nil, err
+	}
+
+	// post income to vehicle history
+	sacks := request.SacksLoaded - request.SacksReturned
+	history := &entity.VehicleHistory{
+		Date:        date,
+		Description: fmt.Sprintf("Perjalanan %s - %s", request.Date, driver.Name),
+		Type:        enum.INCOME,
+		Amount:      request.Income,
+		Sack:        &sacks,
+		VehicleID:   vehicle.ID,
+	}
+
+	if err := u.VehicleHistoryRepository.Create(tx, history); err != nil {
+		u.Log.Warnf("Failed create vehicle history to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	trip := &entity.Trip{
+		Date:             date,
+		SacksLoaded:      request.SacksLoaded,
+		SacksReturned:    request.SacksReturned,
+		Income:           request.Income,
+		Notes:            request.Notes,
+		VehicleId:        vehicle.ID,
+		DriverId:         driver.ID,
+		VehicleHistoryId: &history.ID,
+		CrewId:           crewId,
+		Helpers:          helpers,
+		Routes:           routes,
+	}
+
+	if err := u.TripRepository.Create(tx, trip); err != nil {
+		u.Log.Warnf("Failed create trip to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	// mark crew present
+	if request.MarkAttendance {
+		periodId, err := u.PeriodUseCase.GetOrCreatePeriodIdByDate(tx, request.Date)
+		if err != nil {
+			u.Log.Warnf("Failed to generate period id: %+v", err)
+			return nil, err
+		}
+
+		attendances := []*entity.EmployeeAttendance{
+			{Date: date, Status: enum.PRESENT, EmployeeId: driver.ID, PeriodId: periodId},
+		}
+		for _, helper := range helpers {
+			attendances = append(attendances, &entity.EmployeeAttendance{
+				Date:       date,
+				Status:     enum.PRESENT,
+				EmployeeId: helper.ID,
+				PeriodId:   periodId,
+			})
+		}
+
+		if err := u.EmployeeAttendanceRepository.BatchUpsert(tx, attendances); err != nil {
+			u.Log.Warnf("Failed create attendance to database : %+v", err)
+			return nil, fiber.ErrInternalServerError
+		}
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"vehicle_id": request.VehicleId,
+			"driver_id":  request.DriverId,
+		}).Warnf("Failed commit to database : %+v", err)
+		return nil, fiber.ErrInternalServerError
+	}
+
+	trip.Vehicle = vehicle
+	trip.Driver = driver
+
+	return converter.ToTripResponse(trip), nil
+}
+
+func (u *TripUseCaseImpl) Delete(ctx context.Context, request *model.DeleteTripRequest) error {
+	tx := u.Tx.Begin(ctx)
+	defer u.Tx.Rollback(tx)
+
+	//check request validation
+	details, errorMessage, err := utils.ValidateStruct(request)
+	if err != nil {
+		u.Log.Warnf("Failed to validate request: %+v", details)
+		return model.NewErrorResponse(fiber.StatusBadRequest, errorMessage, details)
+	}
+
+	trip, err := u.TripRepository.FindById(tx, request.ID)
+	if err != nil {
+		u.Log.WithError(err).Error("error getting trip")
+		return fiber.ErrInternalServerError
+	}
+
+	if trip == nil {
+		u.Log.Warnf("Trip not found : %d", request.ID)
+		return fiber.NewError(fiber.StatusNotFound, "Perjalanan tidak ditemukan")
+	}
+
+	// remove the posted income
+	if trip.VehicleHistoryId != nil {
+		if err := u.VehicleHistoryRepository.Delete(tx, *trip.VehicleHistoryId); err != nil {
+			u.Log.WithError(err).Error("error deleting vehicle history")
+			return fiber.ErrInternalServerError
+		}
+	}
+
+	if err := u.TripRepository.Delete(tx, request.ID); err != nil {
+		u.Log.WithError(err).Error("error deleting trip")
+		return fiber.ErrInternalServerError
+	}
+
+	//commit
+	if err := u.Tx.Commit(tx); err != nil {
+		u.Log.WithFields(logrus.Fields{
+			"id": request.ID,
+		}).Warnf("Failed commit to database : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	return nil
+}
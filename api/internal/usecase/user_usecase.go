@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"api/internal/entity"
+	"api/internal/entity/enum"
 	"api/internal/model"
 	"api/internal/model/converter"
 	"api/internal/repository"
@@ -25,35 +26,75 @@ type UserUseCase interface {
 	Delete(ctx context.Context, request *model.DeleteUserRequest) error
 	Verify(ctx context.Context, request *model.Auth) (*model.Auth, error)
 	Current(ctx context.Context, id uuid.UUID) (*model.UserResponse, error)
+	FindEmployeeId(ctx context.Context, id uuid.UUID) (int, error)
 }
 
 type UserUseCaseImpl struct {
-	DB             *gorm.DB
-	Log            *logrus.Logger
-	Validate       *validator.Validate
-	UserRepository repository.UserRepository
-	TokenUtil      *utils.TokenUtil
+	Tx                 repository.Transactor
+	Log                *logrus.Logger
+	Validate           *validator.Validate
+	UserRepository     repository.UserRepository
+	EmployeeRepository repository.EmployeeRepository
+	TokenUtil          *utils.TokenUtil
 }
 
 func NewUserUseCase(
-	db *gorm.DB,
+	tx repository.Transactor,
 	logger *logrus.Logger,
 	validate *validator.Validate,
 	userRepository repository.UserRepository,
+	employeeRepository repository.EmployeeRepository,
 	tokenUtil *utils.TokenUtil,
 ) UserUseCase {
 	return &UserUseCaseImpl{
-		DB:             db,
-		Log:            logger,
-		Validate:       validate,
-		UserRepository: userRepository,
-		TokenUtil:      tokenUtil,
+		Tx:                 tx,
+		Log:                logger,
+		Validate:           validate,
+		UserRepository:     userRepository,
+		EmployeeRepository: employeeRepository,
+		TokenUtil:          tokenUtil,
 	}
 }
 
+// Helper fuction
+func (s *UserUseCaseImpl) validateEmployeeLink(tx *gorm.DB, role enum.UserRole, employeeId *int, userId uuid.UUID) error {
+	if employeeId == nil {
+		if role == enum.USER_EMPLOYEE {
+			s.Log.Warnf("Employee account without employee link")
+			return fiber.NewError(fiber.StatusBadRequest, "Akun karyawan harus terhubung dengan data karyawan")
+		}
+		return nil
+	}
+
+	employee, err := s.EmployeeRepository.FindById(tx, *employeeId)
+	if err != nil {
+		s.Log.Warnf("Failed find employee to database : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if employee == nil {
+		s.Log.Warnf("Employee not found : %d", *employeeId)
+		return fiber.NewError(fiber.StatusNotFound, "Karyawan tidak ditemukan")
+	}
+
+	linked, err := s.UserRepository.FindByEmployeeId(tx, *employeeId)
+	if err != nil {
+		s.Log.Warnf("Failed find user by employee to database : %+v", err)
+		return fiber.ErrInternalServerError
+	}
+
+	if linked != nil && linked.ID != userId {
+		s.Log.Warnf("Employee already linked : %d", *employeeId)
+		errorMessage := fmt.Sprintf("Karyawan %s sudah memiliki akun", employee.Name)
+		return fiber.NewError(fiber.StatusBadRequest, errorMessage)
+	}
+
+	return nil
+}
+
 func (s *UserUseCaseImpl) Create(ctx context.Context, request *model.RegisterUserRequest) (*model.UserResponse, error) {
-	tx := s.DB.WithContext(ctx).Begin()
-	defer tx.Rollback()
+	tx := s.Tx.Begin(ctx)
+	defer s.Tx.Rollback(tx)
 
 	//check request validation
 	details, errorMessage, err := utils.ValidateStruct(request)
@@ -88,6 +129,11 @@ func (s *UserUseCaseImpl) Create(ctx context.Context, request *model.RegisterUse
 		return nil, fiber.NewError(fiber.StatusBadRequest, errorMessage)
 	}
 
+	//check employee link
+	if err := s.validateEmployeeLink(tx, request.Role, request.EmployeeId, uuid.Nil); err != nil {
+		return nil, err
+	}
+
 	//encript password
 	password, err := bcrypt.GenerateFromPassword([]byte(request.Password), bcrypt.DefaultCost)
 	if err != nil {
@@ -97,12 +143,13 @@ func (s *UserUseCaseImpl) Create(ctx context.Context, request *model.RegisterUse
 
 	//set user
 	user := &entity.User{
-		ID:       uuid.New(),
-		Username: request.Username,
-		Password: string(password),
-		Name:     request.Name,
-		Role:     request.Role,
-		Phone:    request.Phone,
+		ID:         uuid.New(),
+		Username:   request.Username,
+		Password:   string(password),
+		Name:       request.Name,
+		Role:       request.Role,
+		Phone:      request.Phone,
+		EmployeeId: request.EmployeeId,
 	}
 
 	if err := s.UserRepository.Create(tx, user); err != nil {
@@ -111,7 +158,7 @@ func (s *UserUseCaseImpl) Create(ctx context.Context, request *model.RegisterUse
 	}
 
 	//commit
-	if err := tx.Commit().Error; err != nil {
+	if err := s.Tx.Commit(tx); err != nil {
 		s.Log.WithFields(logrus.Fields{
 			"username": request.Username,
 			"name":     request.Name,
@@ -132,7 +179,7 @@ func (s *UserUseCaseImpl) FindAll(ctx context.Context, request *model.FindAllUse
 	}
 
 	//get users
-	users, total, err := s.UserRepository.FindAll(s.DB.WithContext(ctx), request)
+	users, total, err := s.UserRepository.FindAll(s.Tx.DB(ctx), request)
 	if err != nil {
 		s.Log.WithError(err).Error("error getting users")
 		return nil, 0, fiber.ErrInternalServerError
@@ -148,8 +195,8 @@ func (s *UserUseCaseImpl) FindAll(ctx context.Context, request *model.FindAllUse
 }
 
 func (s *UserUseCaseImpl) Update(ctx context.Context, request *model.UpdateUserRequest) (*model.UserResponse, error) {
-	tx := s.DB.WithContext(ctx).Begin()
-	defer tx.Rollback()
+	tx := s.Tx.Begin(ctx)
+	defer s.Tx.Rollback(tx)
 
 	//check request validation
 	details, errorMessage, err := utils.ValidateStruct(request)
@@ -196,22 +243,37 @@ func (s *UserUseCaseImpl) Update(ctx context.Context, request *model.UpdateUserR
 		return nil, fiber.NewError(fiber.StatusBadRequest, errorMessage)
 	}
 
+	//check employee link
+	if err := s.validateEmployeeLink(tx, request.Role, request.EmployeeId, user.ID); err != nil {
+		return nil, err
+	}
+
 	//set user
 	updateUser := &entity.User{
-		ID:       request.ID,
-		Name:     request.Name,
-		Username: request.Username,
-		Phone:    request.Phone,
-		Role:     request.Role,
+		ID:         request.ID,
+		Name:       request.Name,
+		Username:   request.Username,
+		Phone:      request.Phone,
+		Role:       request.Role,
+		EmployeeId: request.EmployeeId,
 	}
 
-	if err := s.UserRepository.Update(tx, user.ID, updateUser); err != nil {
+	// map updates so the employee link can be removed
+	updates := map[string]any{
+		"name":        updateUser.Name,
+		"username":    updateUser.Username,
+		"phone":       updateUser.Phone,
+		"role":        updateUser.Role,
+		"employee_id": updateUser.EmployeeId,
+	}
+
+	if err := s.UserRepository.Update(tx, user.ID, updates); err != nil {
 		s.Log.Warnf("Failed update user to database : %+v", err)
 		return nil, fiber.ErrInternalServerError
 	}
 
 	//commit
-	if err := tx.Commit().Error; err != nil {
+	if err := s.Tx.Commit(tx); err != nil {
 		s.Log.WithFields(logrus.Fields{
 			"username": request.Username,
 			"name":     request.Name,
@@ -225,8 +287,8 @@ func (s *UserUseCaseImpl) Update(ctx context.Context, request *model.UpdateUserR
 }
 
 func (s *UserUseCaseImpl) Delete(ctx context.Context, request *model.DeleteUserRequest) error {
-	tx := s.DB.WithContext(ctx).Begin()
-	defer tx.Rollback()
+	tx := s.Tx.Begin(ctx)
+	defer s.Tx.Rollback(tx)
 
 	//check request validation
 	details, errorMessage, err := utils.ValidateStruct(request)
@@ -253,7 +315,7 @@ func (s *UserUseCaseImpl) Delete(ctx context.Context, request *model.DeleteUserR
 	}
 
 	//commit
-	if err := tx.Commit().Error; err != nil {
+	if err := s.Tx.Commit(tx); err != nil {
 		s.Log.WithFields(logrus.Fields{
 			"id": request.ID,
 		}).Warnf("Failed commit to database : %+v", err)
@@ -264,8 +326,8 @@ func (s *UserUseCaseImpl) Delete(ctx context.Context, request *model.DeleteUserR
 }
 
 func (s *UserUseCaseImpl) Login(ctx context.Context, request *model.LoginUserRequest) (*model.UserResponse, string, error) {
-	tx := s.DB.WithContext(ctx).Begin()
-	defer tx.Rollback()
+	tx := s.Tx.Begin(ctx)
+	defer s.Tx.Rollback(tx)
 
 	//check request validation
 	details, errorMessage, err := utils.ValidateStruct(request)
@@ -285,7 +347,7 @@ func (s *UserUseCaseImpl) Login(ctx context.Context, request *model.LoginUserReq
 		return nil, "", fiber.NewError(fiber.StatusUnauthorized, "Username atau kata sandi tidak valid")
 	}
 
-	token, err := s.TokenUtil.CreateToken(ctx, &model.Auth{ID: user.ID})
+	token, err := s.TokenUtil.CreateToken(ctx, &model.Auth{ID: user.ID, Role: user.Role})
 	if err != nil {
 		s.Log.Warnf("Failed to create token : %+v", err)
 		return nil, "", fiber.ErrInternalServerError
@@ -302,7 +364,7 @@ func (s *UserUseCaseImpl) Verify(ctx context.Context, request *model.Auth) (*mod
 	}
 
 	// Chek user
-	user, err := s.UserRepository.FindById(s.DB.WithContext(ctx), request.ID)
+	user, err := s.UserRepository.FindById(s.Tx.DB(ctx), request.ID)
 	if err != nil {
 		s.Log.Warnf("Failed find user by username : %+v", err)
 		return nil, fiber.ErrUnauthorized
@@ -313,12 +375,12 @@ func (s *UserUseCaseImpl) Verify(ctx context.Context, request *model.Auth) (*mod
 		return nil, fiber.NewError(fiber.StatusNotFound, "pengguna tidak ditemukan")
 	}
 
-	return &model.Auth{ID: user.ID}, nil
+	return &model.Auth{ID: user.ID, Role: user.Role}, nil
 }
 
 func (s *UserUseCaseImpl) Current(ctx context.Context, id uuid.UUID) (*model.UserResponse, error) {
 
-	user, err := s.UserRepository.FindById(s.DB.WithContext(ctx), id)
+	user, err := s.UserRepository.FindById(s.Tx.DB(ctx), id)
 	if err != nil {
 		s.Log.Warnf("Failed find user by username : %+v", err)
 		return nil, fiber.ErrUnauthorized
@@ -331,3 +393,23 @@ func (s *UserUseCaseImpl) Current(ctx context.Context, id uuid.UUID) (*model.Use
 
 	return converter.ToUserResponse(user), nil
 }
+
+func (s *UserUseCaseImpl) FindEmployeeId(ctx context.Context, id uuid.UUID) (int, error) {
+	user, err := s.UserRepository.FindById(s.Tx.DB(ctx), id)
+	if err != nil {
+		s.Log.Warnf("Failed find user to database : %+v", err)
+		return 0, fiber.ErrInternalServerError
+	}
+
+	if user == nil {
+		s.Log.Warnf("User not found : %s", id)
+		return 0, fiber.NewError(fiber.StatusNotFound, "pengguna tidak ditemukan")
+	}
+
+	if user.EmployeeId == nil {
+		s.Log.Warnf("User not linked to employee : %s", id)
+		return 0, fiber.NewError(fiber.StatusForbidden, "Akun tidak terhubung dengan data karyawan")
+	}
+
+	return *user.EmployeeId, nil
+}
@@ -24,20 +24,20 @@ type VehicleUseCase interface {
 }
 
 type VehicleUseCaseImpl struct {
-	DB                *gorm.DB
+	Tx                repository.Transactor
 	Log               *logrus.Logger
 	Validate          *validator.Validate
 	VehicleRepository repository.VehicleRepository
 }
 
 func NewVehicleUseCase(
-	db *gorm.DB,
+	tx repository.Transactor,
 	logger *logrus.Logger,
 	validate *validator.Validate,
 	vehicleRepository repository.VehicleRepository,
 ) VehicleUseCase {
 	return &VehicleUseCaseImpl{
-		DB:                db,
+		Tx:                tx,
 		Log:               logger,
 		Validate:          validate,
 		VehicleRepository: vehicleRepository,
@@ -82,8 +82,8 @@ func (s *VehicleUseCaseImpl) validatePlateUniqueness(tx *gorm.DB, plate string,
 
 // Usecase
 func (s *VehicleUseCaseImpl) Create(ctx context.Context, request *model.CreateVehicleRequest) (*model.VehicleResponse, error) {
-	tx := s.DB.WithContext(ctx).Begin()
-	defer tx.Rollback()
+	tx := s.Tx.Begin(ctx)
+	defer s.Tx.Rollback(tx)
 
 	//check request validation
 	details, errorMessage, err := utils.ValidateStruct(request)
@@ -109,7 +109,7 @@ func (s *VehicleUseCaseImpl) Create(ctx context.Context, request *model.CreateVe
 	}
 
 	//commit
-	if err := tx.Commit().Error; err != nil {
+	if err := s.Tx.Commit(tx); err != nil {
 		s.Log.WithFields(logrus.Fields{
 			"name": request.Plate,
 		}).Warnf("Failed commit to database : %+v", err)
@@ -129,7 +129,7 @@ func (s *VehicleUseCaseImpl) FindAll(ctx context.Context, request *model.FindAll
 	}
 
 	//get vehicles
-	vehicles, total, err := s.VehicleRepository.FindAll(s.DB.WithContext(ctx), request)
+	vehicles, total, err := s.VehicleRepository.FindAll(s.Tx.DB(ctx), request)
 	if err != nil {
 		s.Log.WithError(err).Error("error getting vehicles")
 		return nil, 0, fiber.ErrInternalServerError
@@ -145,8 +145,8 @@ func (s *VehicleUseCaseImpl) FindAll(ctx context.Context, request *model.FindAll
 }
 
 func (s *VehicleUseCaseImpl) Update(ctx context.Context, request *model.UpdateVehicleRequest) (*model.VehicleResponse, error) {
-	tx := s.DB.WithContext(ctx).Begin()
-	defer tx.Rollback()
+	tx := s.Tx.Begin(ctx)
+	defer s.Tx.Rollback(tx)
 
 	// Check if vehicle exists
 	vehicle, err := s.validateVehicleExists(tx, request.ID)
@@ -172,7 +172,7 @@ func (s *VehicleUseCaseImpl) Update(ctx context.Context, request *model.UpdateVe
 	}
 
 	//commit
-	if err := tx.Commit().Error; err != nil {
+	if err := s.Tx.Commit(tx); err != nil {
 		s.Log.WithFields(logrus.Fields{
 			"name": request.Plate,
 		}).Warnf("Failed commit to database : %+v", err)
@@ -183,8 +183,8 @@ func (s *VehicleUseCaseImpl) Update(ctx context.Context, request *model.UpdateVe
 }
 
 func (s *VehicleUseCaseImpl) Delete(ctx context.Context, request *model.DeleteVehicleRequest) error {
-	tx := s.DB.WithContext(ctx).Begin()
-	defer tx.Rollback()
+	tx := s.Tx.Begin(ctx)
+	defer s.Tx.Rollback(tx)
 
 	//check request validation
 	details, errorMessage, err := utils.ValidateStruct(request)
@@ -205,7 +205,7 @@ func (s *VehicleUseCaseImpl) Delete(ctx context.Context, request *model.DeleteVe
 	}
 
 	//commit
-	if err := tx.Commit().Error; err != nil {
+	if err := s.Tx.Commit(tx); err != nil {
 		s.Log.WithFields(logrus.Fields{
 			"id": request.ID,
 		}).Warnf("Failed commit to database : %+v", err)
@@ -0,0 +1,20 @@
+package utils
+
+import "math"
+
+const earthRadiusMeters = 6371000
+
+// HaversineDistance returns the great-circle distance in meters between two coordinates
+func HaversineDistance(lat1, lng1, lat2, lng2 float64) float64 {
+	toRadian := func(degree float64) float64 {
+		return degree * math.Pi / 180
+	}
+
+	dLat := toRadian(lat2 - lat1)
+	dLng := toRadian(lng2 - lng1)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadian(lat1))*math.Cos(toRadian(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	return earthRadiusMeters * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
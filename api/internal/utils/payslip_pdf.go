@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/money"
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// FormatRupiah formats an amount as Rp 1.234.567
+func FormatRupiah(amount money.Money) string {
+	sign := ""
+	if amount.IsNegative() {
+		sign = "-"
+		amount = amount.Abs()
+	}
+
+	digits := fmt.Sprintf("%d", amount.Rupiah())
+	var grouped strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			grouped.WriteByte('.')
+		}
+		grouped.WriteRune(digit)
+	}
+
+	return sign + "Rp " + grouped.String()
+}
+
+// GeneratePayslipPDF renders a payslip, payroll must have Employee, Period and Items loaded
+func GeneratePayslipPDF(payroll *entity.Payroll) ([]byte, error) {
+	if payroll.Employee == nil || payroll.Period == nil {
+		return nil, fmt.Errorf("payroll %d has no employee or period loaded", payroll.ID)
+	}
+
+	pdf := fpdf.New("P", "mm", "A5", "")
+	pdf.SetMargins(12, 12, 12)
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.CellFormat(0, 8, "SLIP GAJI", "", 1, "C", false, 0, "")
+	pdf.Ln(2)
+
+	period := payroll.Period
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.CellFormat(30, 5, "Nama", "", 0, "L", false, 0, "")
+	pdf.CellFormat(0, 5, ": "+payroll.Employee.Name, "", 1, "L", false, 0, "")
+	pdf.CellFormat(30, 5, "Jabatan", "", 0, "L", false, 0, "")
+	pdf.CellFormat(0, 5, ": "+string(payroll.Employee.Role), "", 1, "L", false, 0, "")
+	pdf.CellFormat(30, 5, "Periode", "", 0, "L", false, 0, "")
+	pdf.CellFormat(0, 5, fmt.Sprintf(": %s s/d %s", period.StartDate.Format("02-01-2006"), period.EndDate.Format("02-01-2006")), "", 1, "L", false, 0, "")
+	pdf.CellFormat(30, 5, "Hari Kerja", "", 0, "L", false, 0, "")
+	pdf.CellFormat(0, 5, fmt.Sprintf(": %d hari", payroll.AttendanceDays), "", 1, "L", false, 0, "")
+	pdf.Ln(3)
+
+	// itemized breakdown
+	if len(payroll.Items) > 0 {
+		pdf.SetFont("Helvetica", "B", 9)
+		pdf.CellFormat(70, 6, "Keterangan", "B", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 6, "Jumlah", "B", 1, "R", false, 0, "")
+		pdf.SetFont("Helvetica", "", 9)
+		for _, item := range payroll.Items {
+			pdf.CellFormat(70, 5, item.Description, "", 0, "L", false, 0, "")
+			pdf.CellFormat(0, 5, FormatRupiah(item.Amount), "", 1, "R", false, 0, "")
+		}
+		pdf.Ln(3)
+	}
+
+	net := payroll.BaseSalary + payroll.Bonuses - payroll.Deductions
+	rows := []struct {
+		label  string
+		amount money.Money
+	}{
+		{"Gaji Pokok", payroll.BaseSalary},
+		{"Bonus", payroll.Bonuses},
+		{"Potongan", -payroll.Deductions},
+	}
+
+	for _, row := range rows {
+		pdf.CellFormat(70, 5, row.label, "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 5, FormatRupiah(row.amount), "", 1, "R", false, 0, "")
+	}
+
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(70, 7, "Gaji Bersih", "T", 0, "L", false, 0, "")
+	pdf.CellFormat(0, 7, FormatRupiah(net), "T", 1, "R", false, 0, "")
+
+	if payroll.IsPaid && payroll.PaidAt != nil {
+		pdf.Ln(3)
+		pdf.SetFont("Helvetica", "I", 8)
+		method := ""
+		if payroll.PaymentMethod != nil {
+			method = " (" + string(*payroll.PaymentMethod) + ")"
+		}
+		pdf.CellFormat(0, 5, "Dibayar "+payroll.PaidAt.Format("02-01-2006")+method, "", 1, "L", false, 0, "")
+	}
+
+	var buffer bytes.Buffer
+	if err := pdf.Output(&buffer); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
@@ -1,43 +1,225 @@
 package utils
 
 import (
+	"api/internal/entity/enum"
+	"api/internal/model"
 	"time"
 )
 
-type PeriodUtils struct {
+// DefaultEpoch aligns bi-weekly periods when no epoch is configured
+var DefaultEpoch = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// WeekAnchor is the last stored period before the one being calculated
+type WeekAnchor struct {
+	StartDate  time.Time
+	EndDate    time.Time
+	WeekNumber int
+	Month      int
+	Year       int
+}
+
+// MonthKey identifies a payroll month
+type MonthKey struct {
+	Year  int
+	Month int
+}
+
+// WeekCalendar numbers payroll periods inside their month. It never reads the
+// database, everything the numbering depends on is passed in, so the same
+// inputs always give the same period.
+//
+// MonthRule picks the month of a period that spans two months and week 1 is
+// the first period assigned to a month. While the previous month is still
+// open the periods keep counting in it, once it is closed numbering restarts
+// after the last period it took. Bi-weekly periods are aligned on Epoch and
+// monthly periods simply follow the calendar month.
+type WeekCalendar struct {
+	Type      enum.PeriodType
+	WeekStart time.Weekday
+	MonthRule enum.PeriodMonthRule
+	Epoch     time.Time
+}
+
+func NewWeekCalendar(weekStart time.Weekday) *WeekCalendar {
+	return &WeekCalendar{
+		Type:      enum.WEEKLY,
+		WeekStart: weekStart,
+		MonthRule: enum.MONTH_RULE_START,
+		Epoch:     DefaultEpoch,
+	}
+}
+
+// Length is the number of days in a weekly or bi-weekly period
+func (c *WeekCalendar) Length() int {
+	if c.Type == enum.BIWEEKLY {
+		return 14
+	}
+	return 7
+}
+
+// StartOfWeek returns the first day of the period containing date, at midnight UTC
+func (c *WeekCalendar) StartOfWeek(date time.Time) time.Time {
+	day := truncateDay(date)
+	if c.Type == enum.MONTHLY {
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	epoch := truncateDay(c.Epoch)
+	if c.Epoch.IsZero() {
+		epoch = DefaultEpoch
+	}
+	epoch = epoch.AddDate(0, 0, -((int(epoch.Weekday()) - int(c.WeekStart) + 7) % 7))
+
+	length := c.Length()
+	return epoch.AddDate(0, 0, floorDiv(daysBetween(epoch, day), length)*length)
+}
+
+// FirstWeekStart returns the start of the first full period assigned to the month
+func (c *WeekCalendar) FirstWeekStart(year, month int) time.Time {
+	first := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	if c.Type == enum.MONTHLY {
+		return first
+	}
+
+	length := c.Length()
+	target := first.AddDate(0, 0, -c.monthOffset(length))
+
+	start := c.StartOfWeek(target)
+	if start.Before(target) {
+		start = start.AddDate(0, 0, length)
+	}
+	return start
+}
+
+// Week calculates the period containing date. anchor is the latest stored
+// period starting before it, nil when there is none, and closed lists the
+// months that have been closed.
+func (c *WeekCalendar) Week(date time.Time, anchor *WeekAnchor, closed ...MonthKey) *model.WeekInfo {
+	day := truncateDay(date)
+	startDate := c.StartOfWeek(day)
+
+	if c.Type == enum.MONTHLY {
+		return &model.WeekInfo{
+			StartDate:  startDate,
+			EndDate:    startDate.AddDate(0, 1, -1),
+			WeekNumber: 1,
+			Month:      int(startDate.Month()),
+			Year:       startDate.Year(),
+		}
+	}
+
+	length := c.Length()
+	endDate := startDate.AddDate(0, 0, length-1)
+
+	var anchorStart, anchorEnd time.Time
+	if anchor != nil {
+		anchorStart = truncateDay(anchor.StartDate)
+		anchorEnd = truncateDay(anchor.EndDate)
+		if anchor.EndDate.IsZero() {
+			anchorEnd = anchorStart.AddDate(0, 0, length-1)
+		}
+
+		// an anchor still covering the date is not a previous period
+		if !anchorEnd.Before(day) {
+			anchor = nil
+		} else if !anchorEnd.Before(startDate) {
+			// the policy changed, the first period only starts after the old one
+			startDate = anchorEnd.AddDate(0, 0, 1)
+		}
+	}
+
+	month := c.monthOf(startDate, endDate)
+	week := &model.WeekInfo{
+		StartDate: startDate,
+		EndDate:   endDate,
+		Month:     month.Month,
+		Year:      month.Year,
+	}
+
+	base := c.FirstWeekStart(month.Year, month.Month)
+
+	if anchor != nil {
+		anchorMonth := MonthKey{Year: anchor.Year, Month: anchor.Month}
+
+		if !anchorMonth.Before(month) || (anchorMonth == month.Prev() && !containsMonth(closed, anchorMonth)) {
+			// the anchor's month is still running, keep counting from it
+			week.Month = anchor.Month
+			week.Year = anchor.Year
+			week.WeekNumber = anchor.WeekNumber + ceilDiv(daysBetween(anchorStart, startDate), length)
+			return week
+		}
+
+		// periods of this month already taken by the previous one are skipped
+		if next := anchorEnd.AddDate(0, 0, 1); next.After(base) {
+			base = next
+		}
+	}
+
+	week.WeekNumber = floorDiv(daysBetween(base, startDate), length) + 1
+	if week.WeekNumber < 1 {
+		week.WeekNumber = 1
+	}
+
+	return week
+}
+
+// Helper fuction
+func (c *WeekCalendar) monthOffset(length int) int {
+	switch c.MonthRule {
+	case enum.MONTH_RULE_END:
+		return length - 1
+	case enum.MONTH_RULE_MAJORITY:
+		return (length - 1) / 2
+	default:
+		return 0
+	}
 }
 
-func NewPeriodUtils() *PeriodUtils {
-	return &PeriodUtils{}
+// Helper fuction
+func (c *WeekCalendar) monthOf(startDate, endDate time.Time) MonthKey {
+	day := startDate.AddDate(0, 0, c.monthOffset(daysBetween(startDate, endDate)+1))
+	return MonthKey{Year: day.Year(), Month: int(day.Month())}
 }
 
-func (p *PeriodUtils) findFirstSunday(year, month int, loc *time.Location) time.Time {
-	firstDay := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, loc)
+func (m MonthKey) Before(other MonthKey) bool {
+	if m.Year != other.Year {
+		return m.Year < other.Year
+	}
+	return m.Month < other.Month
+}
 
-	for firstDay.Weekday() != time.Sunday {
-		firstDay = firstDay.AddDate(0, 0, 1)
-		// Safety: if went to next month, return first day
-		if firstDay.Month() != time.Month(month) {
-			return time.Date(year, time.Month(month), 1, 0, 0, 0, 0, loc)
+func (m MonthKey) Prev() MonthKey {
+	if m.Month == 1 {
+		return MonthKey{Year: m.Year - 1, Month: 12}
+	}
+	return MonthKey{Year: m.Year, Month: m.Month - 1}
+}
+
+func containsMonth(months []MonthKey, month MonthKey) bool {
+	for _, m := range months {
+		if m == month {
+			return true
 		}
 	}
-	return firstDay
+	return false
 }
 
-func (p *PeriodUtils) calculateWeekNumberFromSunday(date, firstSunday time.Time) int {
-	days := int(date.Sub(firstSunday).Hours() / 24)
-	return (days / 7) + 1
+func truncateDay(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
 }
 
-func (p *PeriodUtils) getWeekBoundaries(firstSunday time.Time, weekNumber int) (time.Time, time.Time) {
-	startDate := firstSunday.AddDate(0, 0, (weekNumber-1)*7)
-	endDate := startDate.AddDate(0, 0, 6)
-	return startDate, endDate
+// daysBetween counts calendar days, both dates must be at midnight UTC
+func daysBetween(from, to time.Time) int {
+	return int((to.Unix() - from.Unix()) / (24 * 60 * 60))
 }
 
-func (p *PeriodUtils) getPreviousMonth(year, month int) (int, int) {
-	if month == 1 {
-		return year - 1, 12
+func floorDiv(a, b int) int {
+	if a < 0 && a%b != 0 {
+		return a/b - 1
 	}
-	return year, month - 1
+	return a / b
+}
+
+func ceilDiv(a, b int) int {
+	return -floorDiv(-a, b)
 }
@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// SessionStore keeps the issued tokens, a token missing from the store is
+// rejected even when its signature is valid
+type SessionStore interface {
+	Save(ctx context.Context, token string, userId uuid.UUID, ttl time.Duration) error
+	Exists(ctx context.Context, token string) (bool, error)
+}
+
+type RedisSessionStore struct {
+	Client *redis.Client
+}
+
+func NewRedisSessionStore(client *redis.Client) *RedisSessionStore {
+	return &RedisSessionStore{
+		Client: client,
+	}
+}
+
+func (s *RedisSessionStore) Save(ctx context.Context, token string, userId uuid.UUID, ttl time.Duration) error {
+	return s.Client.SetEx(ctx, token, userId, ttl).Err()
+}
+
+func (s *RedisSessionStore) Exists(ctx context.Context, token string) (bool, error) {
+	result, err := s.Client.Exists(ctx, token).Result()
+	if err != nil {
+		return false, err
+	}
+	return result > 0, nil
+}
+
+// memorySessionPruneEvery is how many saves pass between two sweeps of the
+// expired tokens
+const memorySessionPruneEvery = 256
+
+// MemorySessionStore keeps the tokens in the process, they are lost on
+// restart and not shared between instances
+type MemorySessionStore struct {
+	mu      sync.Mutex
+	expires map[string]time.Time
+	saves   int
+}
+
+func NewMemorySessionStore() *MemorySessionStore {
+	return &MemorySessionStore{
+		expires: make(map[string]time.Time),
+	}
+}
+
+func (s *MemorySessionStore) Save(ctx context.Context, token string, userId uuid.UUID, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.saves++
+	if s.saves%memorySessionPruneEvery == 0 {
+		s.prune(now)
+	}
+
+	s.expires[token] = now.Add(ttl)
+	return nil
+}
+
+func (s *MemorySessionStore) Exists(ctx context.Context, token string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expire, ok := s.expires[token]
+	return ok && expire.After(time.Now()), nil
+}
+
+// Len returns how many tokens are kept, expired ones included until the next sweep
+func (s *MemorySessionStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.expires)
+}
+
+// Helper fuction
+// prune drops the expired tokens so the map does not grow forever, the caller
+// holds the lock
+func (s *MemorySessionStore) prune(now time.Time) {
+	for key, expire := range s.expires {
+		if !expire.After(now) {
+			delete(s.expires, key)
+		}
+	}
+}
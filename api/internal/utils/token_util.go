@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"api/internal/entity/enum"
 	"api/internal/model"
 	"context"
 	"time"
@@ -8,24 +9,24 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
-	"github.com/redis/go-redis/v9"
 )
 
 type TokenUtil struct {
 	SecretKey string
-	Redis     *redis.Client
+	Sessions  SessionStore
 }
 
-func NewTokenUtil(secretKey string, redisClient *redis.Client) *TokenUtil {
+func NewTokenUtil(secretKey string, sessions SessionStore) *TokenUtil {
 	return &TokenUtil{
 		SecretKey: secretKey,
-		Redis:     redisClient,
+		Sessions:  sessions,
 	}
 }
 
 func (t TokenUtil) CreateToken(ctx context.Context, auth *model.Auth) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"id":     auth.ID,
+		"role":   auth.Role,
 		"expire": time.Now().Add(time.Hour * 24 * 30).UnixMilli(),
 	})
 
@@ -34,7 +35,7 @@ func (t TokenUtil) CreateToken(ctx context.Context, auth *model.Auth) (string, e
 		return "", err
 	}
 
-	_, err = t.Redis.SetEx(ctx, jwtToken, auth.ID, time.Hour*25*30).Result()
+	err = t.Sessions.Save(ctx, jwtToken, auth.ID, time.Hour*25*30)
 	if err != nil {
 		return "", err
 	}
@@ -57,18 +58,23 @@ func (t TokenUtil) ParseToken(ctx context.Context, jwtToken string) (*model.Auth
 		return nil, fiber.ErrUnauthorized
 	}
 
-	result, err := t.Redis.Exists(ctx, jwtToken).Result()
+	exists, err := t.Sessions.Exists(ctx, jwtToken)
 	if err != nil {
 		return nil, err
 	}
 
-	if result == 0 {
+	if !exists {
 		return nil, fiber.ErrUnauthorized
 	}
 
+	// tokens issued before roles were embedded have none, the auth middleware
+	// takes the role from the user row anyway
+	role, _ := claims["role"].(string)
+
 	id := claims["id"].(string)
 	auth := &model.Auth{
-		ID: uuid.MustParse(id),
+		ID:   uuid.MustParse(id),
+		Role: enum.UserRole(role),
 	}
 	return auth, nil
 }
@@ -40,7 +40,7 @@ func RegisterAbsenStatusValidation(validate *validator.Validate) {
 // Helper function to check valid status
 func isValidStatus(status enum.AttendanceStatus) bool {
 	switch status {
-	case enum.PERMIT, enum.PRESENT:
+	case enum.PRESENT, enum.LEAVE, enum.SICK, enum.ABSENT:
 		return true
 	default:
 		return false
@@ -40,7 +40,7 @@ func RegisterUserRoleValidation(validate *validator.Validate) {
 // Helper function to check valid role
 func isValidRole(role enum.UserRole) bool {
 	switch role {
-	case enum.OWNER, enum.SUPER_ADMIN, enum.TREASURER, enum.WAREHOUSE_HEAD:
+	case enum.OWNER, enum.SUPER_ADMIN, enum.TREASURER, enum.WAREHOUSE_HEAD, enum.USER_EMPLOYEE:
 		return true
 	default:
 		return false
@@ -54,6 +54,12 @@ func ConvertTagToMessage(tag string, param string) string {
 		return fmt.Sprintf("This field must not exceed %s characters.", param)
 	case "len":
 		return fmt.Sprintf("This field must have exactly %s characters.", param)
+	case "numeric":
+		return "This field must contain only digits."
+	case "gte":
+		return fmt.Sprintf("This field must be at least %s.", param)
+	case "ltefield":
+		return fmt.Sprintf("This field must not exceed %s.", param)
 	case "userrole":
 		return "Invalid role Type."
 	default:
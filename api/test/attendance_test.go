@@ -0,0 +1,155 @@
+package test
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/enum"
+	"api/internal/model"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createGeofenceRequest(t *testing.T, token string, requestBody model.CreateGeofenceRequest) model.GeofenceResponse {
+	bodyJson, err := json.Marshal(requestBody)
+	assert.Nil(t, err)
+
+	request := httptest.NewRequest(http.MethodPost, "/api/geofences", strings.NewReader(string(bodyJson)))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+	bytes, err := io.ReadAll(response.Body)
+	assert.Nil(t, err)
+
+	responseBody := new(model.WebResponse[model.GeofenceResponse])
+	err = json.Unmarshal(bytes, responseBody)
+	assert.Nil(t, err)
+
+	return responseBody.Data
+}
+
+func checkInRequest(t *testing.T, token string, requestBody model.CheckInRequest) (*http.Response, *model.WebResponse[model.CheckInResponse]) {
+	bodyJson, err := json.Marshal(requestBody)
+	assert.Nil(t, err)
+
+	request := httptest.NewRequest(http.MethodPost, "/api/attendance/check-in", strings.NewReader(string(bodyJson)))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+
+	bytes, err := io.ReadAll(response.Body)
+	assert.Nil(t, err)
+
+	responseBody := new(model.WebResponse[model.CheckInResponse])
+	err = json.Unmarshal(bytes, responseBody)
+	assert.Nil(t, err)
+
+	return response, responseBody
+}
+
+func TestCheckInInsideGeofence(t *testing.T) {
+	defer ClearAll()
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	// the warehouse and a factory 5 km away
+	warehouse := createGeofenceRequest(t, token, model.CreateGeofenceRequest{Name: "Gudang", Latitude: -7.2575, Longitude: 112.7521, Radius: 200})
+	createGeofenceRequest(t, token, model.CreateGeofenceRequest{Name: "Pabrik", Latitude: -7.3025, Longitude: 112.7521, Radius: 200})
+
+	employee := CreateEmployees(1, enum.EMPLOYEE_WAREHOUSE_HEAD)[0]
+	employeeToken, err := GenerateLinkedTokenHelper(employee.ID, enum.WAREHOUSE_HEAD)
+	assert.Nil(t, err)
+	accuracy := 12.5
+
+	// about 55 m north of the warehouse, the employee comes from the account
+	response, responseBody := checkInRequest(t, employeeToken, model.CheckInRequest{
+		Latitude:  -7.2570,
+		Longitude: 112.7521,
+		Accuracy:  &accuracy,
+	})
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, warehouse.ID, responseBody.Data.GeofenceId)
+	assert.Equal(t, "Gudang", responseBody.Data.GeofenceName)
+	assert.InDelta(t, 55, responseBody.Data.Distance, 2)
+
+	// the raw location is kept for audit
+	attendance := new(entity.EmployeeAttendance)
+	assert.Nil(t, db.Where("employee_id = ?", employee.ID).First(attendance).Error)
+	assert.Equal(t, enum.PRESENT, attendance.Status)
+	assert.NotNil(t, attendance.CheckInAt)
+	assert.InDelta(t, -7.2570, *attendance.Latitude, 0.0000001)
+	assert.InDelta(t, 112.7521, *attendance.Longitude, 0.0000001)
+	assert.Equal(t, accuracy, *attendance.LocationAccuracy)
+	assert.Equal(t, warehouse.ID, *attendance.GeofenceId)
+}
+
+func TestCheckInOutsideGeofence(t *testing.T) {
+	defer ClearAll()
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	createGeofenceRequest(t, token, model.CreateGeofenceRequest{Name: "Gudang", Latitude: -7.2575, Longitude: 112.7521, Radius: 200})
+	retired := createGeofenceRequest(t, token, model.CreateGeofenceRequest{Name: "Pos Lama", Latitude: -7.2800, Longitude: 112.7521, Radius: 200})
+
+	inactive := false
+	bodyJson, err := json.Marshal(model.UpdateGeofenceRequest{Name: retired.Name, Latitude: retired.Latitude, Longitude: retired.Longitude, Radius: retired.Radius, IsActive: &inactive})
+	assert.Nil(t, err)
+
+	request := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/geofences/%d", retired.ID), strings.NewReader(string(bodyJson)))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	employee := CreateEmployees(1, enum.EMPLOYEE_WAREHOUSE_HEAD)[0]
+	employeeToken, err := GenerateLinkedTokenHelper(employee.ID, enum.WAREHOUSE_HEAD)
+	assert.Nil(t, err)
+
+	// about 1 km from the warehouse
+	response, _ = checkInRequest(t, employeeToken, model.CheckInRequest{Latitude: -7.2665, Longitude: 112.7521})
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	// an inactive geofence does not count
+	response, _ = checkInRequest(t, employeeToken, model.CheckInRequest{Latitude: -7.2800, Longitude: 112.7521})
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	var count int64
+	assert.Nil(t, db.Model(&entity.EmployeeAttendance{}).Where("employee_id = ?", employee.ID).Count(&count).Error)
+	assert.Zero(t, count)
+}
+
+func TestCheckInWithoutLinkedEmployee(t *testing.T) {
+	defer ClearAll()
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	createGeofenceRequest(t, token, model.CreateGeofenceRequest{Name: "Gudang", Latitude: -7.2575, Longitude: 112.7521, Radius: 200})
+
+	// the superadmin account is not an employee, an employeeId in the body is ignored
+	employee := CreateEmployees(1, enum.DRIVER)[0]
+	response, _ := checkInRequest(t, token, model.CheckInRequest{EmployeeId: employee.ID, Latitude: -7.2575, Longitude: 112.7521})
+	assert.Equal(t, http.StatusForbidden, response.StatusCode)
+
+	var count int64
+	assert.Nil(t, db.Model(&entity.EmployeeAttendance{}).Where("employee_id = ?", employee.ID).Count(&count).Error)
+	assert.Zero(t, count)
+}
@@ -0,0 +1,100 @@
+package test
+
+import (
+	"api/internal/entity/enum"
+	"api/internal/entity/money"
+	"api/internal/model"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateCashAdvance(t *testing.T) {
+	defer ClearAll()
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	employees := CreateEmployees(1, enum.DRIVER)
+
+	requestBody := model.CreateCashAdvanceRequest{
+		EmployeeId:  employees[0].ID,
+		Date:        "2026-01-05",
+		Amount:      money.New(300000),
+		Installment: money.New(100000),
+	}
+
+	bodyJson, err := json.Marshal(requestBody)
+	assert.Nil(t, err)
+
+	request := httptest.NewRequest(http.MethodPost, "/api/cash-advances", strings.NewReader(string(bodyJson)))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+
+	bytes, err := io.ReadAll(response.Body)
+	assert.Nil(t, err)
+
+	responseBody := new(model.WebResponse[model.CashAdvanceResponse])
+	err = json.Unmarshal(bytes, responseBody)
+	assert.Nil(t, err)
+
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+	assert.NotZero(t, responseBody.Data.ID)
+	assert.Equal(t, requestBody.Amount, responseBody.Data.Outstanding)
+}
+
+func TestCashAdvanceStatement(t *testing.T) {
+	defer ClearAll()
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	employees := CreateEmployees(1, enum.HELPER)
+	advance := CreateCashAdvance(employees[0].ID, money.New(300000), money.New(100000))
+
+	requestBody := model.CreateCashAdvanceRepaymentRequest{
+		Date:   "2026-01-10",
+		Amount: money.New(50000),
+	}
+
+	bodyJson, err := json.Marshal(requestBody)
+	assert.Nil(t, err)
+
+	request := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/cash-advances/%d/repayments", advance.ID), strings.NewReader(string(bodyJson)))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+	request = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/cash-advances/statement/%d", employees[0].ID), nil)
+	request.Header.Set("Authorization", token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err = app.Test(request)
+	assert.Nil(t, err)
+
+	bytes, err := io.ReadAll(response.Body)
+	assert.Nil(t, err)
+
+	responseBody := new(model.WebResponse[model.CashAdvanceStatementResponse])
+	err = json.Unmarshal(bytes, responseBody)
+	assert.Nil(t, err)
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, 2, len(responseBody.Data.Entries))
+	assert.Equal(t, money.New(250000), responseBody.Data.Outstanding)
+	assert.Equal(t, money.New(250000), responseBody.Data.Entries[1].Balance)
+}
@@ -3,8 +3,10 @@ package test
 import (
 	"api/internal/entity"
 	"api/internal/entity/enum"
+	"api/internal/entity/money"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
@@ -46,7 +48,7 @@ func CreateSales(total int) []entity.Sales {
 		// Create Employee
 		employee := entity.Employee{
 			Name:   "Sales " + strconv.Itoa(i+1),
-			Salary: 400000,
+			Salary: money.New(400000),
 			Role:   "SALES",
 		}
 
@@ -94,7 +96,7 @@ func CreateSalesWithRoutes(count int, routeIDs []int) []entity.Sales {
 		// Create Employee
 		employee := entity.Employee{
 			Name:   fmt.Sprintf("Sales %d", i+1),
-			Salary: 400000,
+			Salary: money.New(400000),
 			Role:   "SALES",
 		}
 
@@ -134,3 +136,112 @@ func CreateSalesWithRoutes(count int, routeIDs []int) []entity.Sales {
 
 	return salesList
 }
+
+func CreateCustomers(total int, routeID int) []entity.Customer {
+	customers := make([]entity.Customer, total)
+
+	for i := 0; i < total; i++ {
+		customers[i] = entity.Customer{
+			Name:    "Customer " + strconv.Itoa(i),
+			RouteId: routeID,
+		}
+
+		dbErr := db.Create(&customers[i]).Error
+		if dbErr != nil {
+			log.Fatalf("Failed create customer data : %+v", dbErr)
+		}
+	}
+	return customers
+}
+
+func CreateReceivable(customerID int, amount money.Money) entity.Receivable {
+	receivable := entity.Receivable{
+		CustomerId: customerID,
+		Date:       time.Now(),
+		Amount:     amount,
+	}
+
+	dbErr := db.Create(&receivable).Error
+	if dbErr != nil {
+		log.Fatalf("Failed create receivable data : %+v", dbErr)
+	}
+	return receivable
+}
+
+func CreateEmployees(total int, role enum.EmployeeRole) []entity.Employee {
+	employees := make([]entity.Employee, total)
+
+	for i := 0; i < total; i++ {
+		employees[i] = entity.Employee{
+			Name:   string(role) + " " + strconv.Itoa(i+1),
+			Salary: money.New(100000),
+			Role:   role,
+		}
+
+		dbErr := db.Create(&employees[i]).Error
+		if dbErr != nil {
+			log.Fatalf("Failed create employee data : %+v", dbErr)
+		}
+	}
+	return employees
+}
+
+func CreateDriverLicense(employeeID int, class enum.LicenseClass) entity.DriverLicense {
+	license := entity.DriverLicense{
+		EmployeeId: employeeID,
+		Number:     "SIM " + strconv.Itoa(employeeID),
+		Class:      class,
+		ExpiryDate: time.Date(2040, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	dbErr := db.Create(&license).Error
+	if dbErr != nil {
+		log.Fatalf("Failed create driver license data : %+v", dbErr)
+	}
+	return license
+}
+
+func CreateCashAdvance(employeeID int, amount, installment money.Money) entity.CashAdvance {
+	advance := entity.CashAdvance{
+		EmployeeId:  employeeID,
+		Date:        time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		Amount:      amount,
+		Installment: installment,
+	}
+
+	dbErr := db.Create(&advance).Error
+	if dbErr != nil {
+		log.Fatalf("Failed create cash advance data : %+v", dbErr)
+	}
+	return advance
+}
+
+func CreateVehicle(vehicleType enum.VehicleType) entity.Vehicle {
+	vehicle := entity.Vehicle{
+		Plate: "B " + strconv.Itoa(int(time.Now().UnixNano()%10000)) + " TST",
+		Type:  vehicleType,
+	}
+
+	dbErr := db.Create(&vehicle).Error
+	if dbErr != nil {
+		log.Fatalf("Failed create vehicle data : %+v", dbErr)
+	}
+	return vehicle
+}
+
+func CreatePeriod(startDate time.Time) entity.Period {
+	period := entity.Period{
+		Type:       enum.WEEKLY,
+		StartDate:  startDate,
+		EndDate:    startDate.AddDate(0, 0, 6),
+		WeekNumber: 1,
+		Month:      int(startDate.Month()),
+		Year:       startDate.Year(),
+	}
+
+	dbErr := db.Create(&period).Error
+	if dbErr != nil {
+		log.Fatalf("Failed create period data : %+v", dbErr)
+	}
+	return period
+}
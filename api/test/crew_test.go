@@ -0,0 +1,107 @@
+package test
+
+import (
+	"api/internal/entity/enum"
+	"api/internal/model"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createCrew(t *testing.T, token string, requestBody model.CreateCrewRequest) (int, *model.CrewResponse) {
+	bodyJson, err := json.Marshal(requestBody)
+	assert.Nil(t, err)
+
+	request := httptest.NewRequest(http.MethodPost, "/api/crews", strings.NewReader(string(bodyJson)))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+
+	bytes, err := io.ReadAll(response.Body)
+	assert.Nil(t, err)
+
+	responseBody := new(model.WebResponse[*model.CrewResponse])
+	err = json.Unmarshal(bytes, responseBody)
+	assert.Nil(t, err)
+
+	return response.StatusCode, responseBody.Data
+}
+
+func TestCreateCrewAndMarkAttendance(t *testing.T) {
+	defer ClearAll()
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	vehicle := CreateVehicle(enum.TRUCK)
+	driver := CreateEmployees(1, enum.DRIVER)[0]
+	helpers := CreateEmployees(2, enum.HELPER)
+
+	status, crew := createCrew(t, token, model.CreateCrewRequest{
+		Name:      "Kru A",
+		VehicleId: &vehicle.ID,
+		DriverId:  driver.ID,
+		HelperIds: []int{helpers[0].ID, helpers[1].ID},
+		StartDate: "2026-01-01",
+	})
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Len(t, crew.Members, 3)
+
+	bodyJson, err := json.Marshal(model.MarkCrewAttendanceRequest{
+		CrewIds: []int{crew.ID},
+		Date:    "2026-01-05",
+	})
+	assert.Nil(t, err)
+
+	request := httptest.NewRequest(http.MethodPost, "/api/crews/attendance", strings.NewReader(string(bodyJson)))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+
+	bytes, err := io.ReadAll(response.Body)
+	assert.Nil(t, err)
+
+	responseBody := new(model.WebResponse[[]model.EmployeeAttendanceResponse])
+	err = json.Unmarshal(bytes, responseBody)
+	assert.Nil(t, err)
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Len(t, responseBody.Data, 3)
+}
+
+func TestCreateCrewMemberInAnotherCrew(t *testing.T) {
+	defer ClearAll()
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	driver := CreateEmployees(1, enum.DRIVER)[0]
+	helpers := CreateEmployees(2, enum.HELPER)
+
+	status, _ := createCrew(t, token, model.CreateCrewRequest{
+		Name:      "Kru A",
+		DriverId:  driver.ID,
+		HelperIds: []int{helpers[0].ID},
+		StartDate: "2026-01-01",
+	})
+	assert.Equal(t, http.StatusCreated, status)
+
+	status, _ = createCrew(t, token, model.CreateCrewRequest{
+		Name:      "Kru B",
+		DriverId:  driver.ID,
+		HelperIds: []int{helpers[1].ID},
+		StartDate: "2026-02-01",
+	})
+	assert.Equal(t, http.StatusConflict, status)
+}
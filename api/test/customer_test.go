@@ -0,0 +1,174 @@
+package test
+
+import (
+	"api/internal/entity/money"
+	"api/internal/model"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateCustomer(t *testing.T) {
+	defer ClearAll()
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	routes := CreateRoutes(1)
+
+	requestBody := model.CreateCustomerRequest{
+		Name:    "Customer Test",
+		RouteId: routes[0].ID,
+	}
+
+	bodyJson, err := json.Marshal(requestBody)
+	assert.Nil(t, err)
+
+	request := httptest.NewRequest(http.MethodPost, "/api/customers", strings.NewReader(string(bodyJson)))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+
+	bytes, err := io.ReadAll(response.Body)
+	assert.Nil(t, err)
+
+	responseBody := new(model.WebResponse[model.CustomerResponse])
+	err = json.Unmarshal(bytes, responseBody)
+	assert.Nil(t, err)
+
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+	assert.NotZero(t, responseBody.Data.ID)
+	assert.Equal(t, requestBody.Name, responseBody.Data.Name)
+	assert.Equal(t, requestBody.RouteId, responseBody.Data.RouteId)
+}
+
+func TestGetAllCustomersOutstanding(t *testing.T) {
+	defer ClearAll()
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	routes := CreateRoutes(1)
+	customers := CreateCustomers(1, routes[0].ID)
+	CreateReceivable(customers[0].ID, money.New(150000))
+
+	request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/customers?routeId=%d", routes[0].ID), nil)
+	request.Header.Set("Authorization", token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+
+	bytes, err := io.ReadAll(response.Body)
+	assert.Nil(t, err)
+
+	responseBody := new(model.WebResponse[[]model.CustomerResponse])
+	err = json.Unmarshal(bytes, responseBody)
+	assert.Nil(t, err)
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, 1, len(responseBody.Data))
+	assert.Equal(t, money.New(150000), responseBody.Data[0].Outstanding)
+}
+
+func TestCreateCollectionExceedOutstanding(t *testing.T) {
+	defer ClearAll()
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	routes := CreateRoutes(1)
+	customers := CreateCustomers(1, routes[0].ID)
+	sales := CreateSales(1)
+	CreateReceivable(customers[0].ID, money.New(100000))
+
+	requestBody := model.CreateCollectionRequest{
+		CustomerId: customers[0].ID,
+		SalesId:    sales[0].ID,
+		Date:       "2026-01-05",
+		Amount:     money.New(200000),
+	}
+
+	bodyJson, err := json.Marshal(requestBody)
+	assert.Nil(t, err)
+
+	request := httptest.NewRequest(http.MethodPost, "/api/collections", strings.NewReader(string(bodyJson)))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+
+	bytes, err := io.ReadAll(response.Body)
+	assert.Nil(t, err)
+
+	responseBody := new(model.ErrorResponse)
+	err = json.Unmarshal(bytes, responseBody)
+	assert.Nil(t, err)
+
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	assert.NotEmpty(t, responseBody.Message)
+}
+
+func TestCreateCollectionConcurrent(t *testing.T) {
+	defer ClearAll()
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	routes := CreateRoutes(1)
+	customers := CreateCustomers(1, routes[0].ID)
+	sales := CreateSales(1)
+	CreateReceivable(customers[0].ID, money.New(100000))
+
+	bodyJson, err := json.Marshal(model.CreateCollectionRequest{
+		CustomerId: customers[0].ID,
+		SalesId:    sales[0].ID,
+		Date:       "2026-01-05",
+		Amount:     money.New(100000),
+	})
+	assert.Nil(t, err)
+
+	// every payment settles the whole balance, only one may go through
+	const workers = 5
+	statuses := make([]int, workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			request := httptest.NewRequest(http.MethodPost, "/api/collections", strings.NewReader(string(bodyJson)))
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Authorization", token)
+			request.Header.Set("Accept", "application/json")
+
+			response, err := app.Test(request)
+			assert.Nil(t, err)
+			statuses[i] = response.StatusCode
+		}(i)
+	}
+	wg.Wait()
+
+	created := 0
+	for _, status := range statuses {
+		if status == http.StatusCreated {
+			created++
+			continue
+		}
+		assert.Equal(t, http.StatusBadRequest, status)
+	}
+	assert.Equal(t, 1, created)
+}
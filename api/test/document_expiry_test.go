@@ -0,0 +1,141 @@
+package test
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/enum"
+	"api/internal/model"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createTrip(t *testing.T, token string, requestBody model.CreateTripRequest) (int, string) {
+	bodyJson, err := json.Marshal(requestBody)
+	assert.Nil(t, err)
+
+	request := httptest.NewRequest(http.MethodPost, "/api/trips", strings.NewReader(string(bodyJson)))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+
+	bytes, err := io.ReadAll(response.Body)
+	assert.Nil(t, err)
+
+	return response.StatusCode, string(bytes)
+}
+
+func TestDispatchBlockedByExpiredDocuments(t *testing.T) {
+	defer ClearAll()
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	drivers := CreateEmployees(1, enum.DRIVER)
+	routes := CreateRoutes(1)
+	vehicle := CreateVehicle(enum.TRUCK)
+
+	requestBody := model.CreateTripRequest{
+		Date:      "2026-03-10",
+		VehicleId: vehicle.ID,
+		DriverId:  drivers[0].ID,
+		RouteIds:  []int{routes[0].ID},
+	}
+
+	// no SIM recorded at all
+	status, body := createTrip(t, token, requestBody)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, body, "belum memiliki SIM")
+
+	// SIM A expired before the trip
+	assert.Nil(t, db.Create(&entity.DriverLicense{
+		EmployeeId: drivers[0].ID,
+		Number:     "1234567890",
+		Class:      enum.LICENSE_A,
+		ExpiryDate: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	}).Error)
+
+	status, body = createTrip(t, token, requestBody)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, body, "kedaluwarsa")
+
+	// renewed but the class is too low for a truck
+	assert.Nil(t, db.Create(&entity.DriverLicense{
+		EmployeeId: drivers[0].ID,
+		Number:     "1234567891",
+		Class:      enum.LICENSE_A,
+		ExpiryDate: time.Date(2031, 3, 1, 0, 0, 0, 0, time.UTC),
+	}).Error)
+
+	status, body = createTrip(t, token, requestBody)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, body, "SIM B1")
+
+	// valid SIM but an expired KIR
+	assert.Nil(t, db.Create(&entity.DriverLicense{
+		EmployeeId: drivers[0].ID,
+		Number:     "1234567892",
+		Class:      enum.LICENSE_B1,
+		ExpiryDate: time.Date(2031, 3, 1, 0, 0, 0, 0, time.UTC),
+	}).Error)
+	assert.Nil(t, db.Create(&entity.VehicleDocument{
+		VehicleId:  vehicle.ID,
+		Type:       enum.VEHICLE_DOCUMENT_KIR,
+		Number:     "KIR-001",
+		ExpiryDate: time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC),
+	}).Error)
+
+	status, body = createTrip(t, token, requestBody)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, body, "KIR")
+}
+
+func TestFindExpiringDocuments(t *testing.T) {
+	defer ClearAll()
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	drivers := CreateEmployees(2, enum.DRIVER)
+	soon := time.Now().AddDate(0, 0, 10)
+
+	assert.Nil(t, db.Create(&entity.DriverLicense{
+		EmployeeId: drivers[0].ID,
+		Number:     "1111",
+		Class:      enum.LICENSE_B1,
+		ExpiryDate: soon,
+	}).Error)
+	assert.Nil(t, db.Create(&entity.DriverLicense{
+		EmployeeId: drivers[1].ID,
+		Number:     "2222",
+		Class:      enum.LICENSE_B1,
+		ExpiryDate: time.Now().AddDate(1, 0, 0),
+	}).Error)
+
+	request := httptest.NewRequest(http.MethodGet, "/api/document-expiries?days=30", nil)
+	request.Header.Set("Authorization", token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+
+	bytes, err := io.ReadAll(response.Body)
+	assert.Nil(t, err)
+
+	responseBody := new(model.WebResponse[[]model.ExpiringDocumentResponse])
+	err = json.Unmarshal(bytes, responseBody)
+	assert.Nil(t, err)
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Len(t, responseBody.Data, 1)
+	assert.Equal(t, "1111", responseBody.Data[0].Number)
+	assert.Equal(t, 10, responseBody.Data[0].DaysLeft)
+}
@@ -0,0 +1,103 @@
+package test
+
+import (
+	"api/internal/entity/enum"
+	"api/internal/entity/money"
+	"api/internal/model"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateEmployeeInvalidNIK(t *testing.T) {
+	defer ClearAll()
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	nik := "12345"
+	requestBody := model.CreateEmployeeRequest{
+		Name:   "Budi",
+		Salary: money.New(100000),
+		Role:   enum.STAFF,
+		EmployeeProfileRequest: model.EmployeeProfileRequest{
+			NIK: &nik,
+		},
+	}
+
+	bodyJson, err := json.Marshal(requestBody)
+	assert.Nil(t, err)
+
+	request := httptest.NewRequest(http.MethodPost, "/api/employees", strings.NewReader(string(bodyJson)))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+}
+
+func TestUploadAndDownloadEmployeeDocument(t *testing.T) {
+	defer ClearAll()
+	defer os.RemoveAll(viperConfig.GetString("storage.path"))
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	employees := CreateEmployees(1, enum.DRIVER)
+	content := []byte("%PDF-1.4 sim")
+
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	assert.Nil(t, writer.WriteField("type", string(enum.DOCUMENT_SIM)))
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", `form-data; name="file"; filename="sim.pdf"`)
+	header.Set("Content-Type", "application/pdf")
+	part, err := writer.CreatePart(header)
+	assert.Nil(t, err)
+	_, err = part.Write(content)
+	assert.Nil(t, err)
+	assert.Nil(t, writer.Close())
+
+	request := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/employees/%d/documents", employees[0].ID), body)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	request.Header.Set("Authorization", token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+
+	responseBytes, err := io.ReadAll(response.Body)
+	assert.Nil(t, err)
+
+	responseBody := new(model.WebResponse[model.EmployeeDocumentResponse])
+	err = json.Unmarshal(responseBytes, responseBody)
+	assert.Nil(t, err)
+
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+	assert.Equal(t, "sim.pdf", responseBody.Data.FileName)
+	assert.Equal(t, int64(len(content)), responseBody.Data.Size)
+
+	request = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/employees/%d/documents/%d", employees[0].ID, responseBody.Data.ID), nil)
+	request.Header.Set("Authorization", token)
+
+	response, err = app.Test(request)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	downloaded, err := io.ReadAll(response.Body)
+	assert.Nil(t, err)
+	assert.Equal(t, content, downloaded)
+}
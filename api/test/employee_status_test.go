@@ -0,0 +1,120 @@
+package test
+
+import (
+	"api/internal/entity/enum"
+	"api/internal/model"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func changeEmployeeStatus(t *testing.T, token string, employeeID int, requestBody model.ChangeEmployeeStatusRequest) int {
+	bodyJson, err := json.Marshal(requestBody)
+	assert.Nil(t, err)
+
+	request := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/employees/%d/status", employeeID), strings.NewReader(string(bodyJson)))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+
+	return response.StatusCode
+}
+
+func findAttendanceSheet(t *testing.T, token string, startDate, endDate string) []model.EmployeeResponse {
+	request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/attendance?startDate=%s&endDate=%s", startDate, endDate), nil)
+	request.Header.Set("Authorization", token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	bytes, err := io.ReadAll(response.Body)
+	assert.Nil(t, err)
+
+	responseBody := new(model.WebResponse[[]model.EmployeeResponse])
+	err = json.Unmarshal(bytes, responseBody)
+	assert.Nil(t, err)
+
+	return responseBody.Data
+}
+
+func TestTerminateAndRehireEmployee(t *testing.T) {
+	defer ClearAll()
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	employees := CreateEmployees(1, enum.DRIVER)
+	reason := "Kontrak selesai"
+
+	status := changeEmployeeStatus(t, token, employees[0].ID, model.ChangeEmployeeStatusRequest{
+		Status:        enum.EMPLOYEE_TERMINATED,
+		EffectiveDate: "2026-01-15",
+		Reason:        &reason,
+	})
+	assert.Equal(t, http.StatusCreated, status)
+
+	// still visible in the period containing the termination date
+	assert.Len(t, findAttendanceSheet(t, token, "2026-01-01", "2026-01-31"), 1)
+	assert.Len(t, findAttendanceSheet(t, token, "2026-02-01", "2026-02-28"), 0)
+
+	// only a rehire is allowed after termination
+	status = changeEmployeeStatus(t, token, employees[0].ID, model.ChangeEmployeeStatusRequest{
+		Status:        enum.EMPLOYEE_ON_LEAVE,
+		EffectiveDate: "2026-02-01",
+	})
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status = changeEmployeeStatus(t, token, employees[0].ID, model.ChangeEmployeeStatusRequest{
+		Status:        enum.EMPLOYEE_ACTIVE,
+		EffectiveDate: "2026-03-01",
+	})
+	assert.Equal(t, http.StatusCreated, status)
+
+	assert.Len(t, findAttendanceSheet(t, token, "2026-02-01", "2026-02-28"), 0)
+	assert.Len(t, findAttendanceSheet(t, token, "2026-03-01", "2026-03-31"), 1)
+
+	request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/employees/%d/status-history", employees[0].ID), nil)
+	request.Header.Set("Authorization", token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+
+	bytes, err := io.ReadAll(response.Body)
+	assert.Nil(t, err)
+
+	responseBody := new(model.WebResponse[[]model.EmployeeStatusHistoryResponse])
+	err = json.Unmarshal(bytes, responseBody)
+	assert.Nil(t, err)
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Len(t, responseBody.Data, 2)
+	assert.Equal(t, string(enum.EMPLOYEE_TERMINATED), responseBody.Data[0].Status)
+	assert.Equal(t, string(enum.EMPLOYEE_ACTIVE), responseBody.Data[1].Status)
+}
+
+func TestTerminateEmployeeRequiresReason(t *testing.T) {
+	defer ClearAll()
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	employees := CreateEmployees(1, enum.HELPER)
+
+	status := changeEmployeeStatus(t, token, employees[0].ID, model.ChangeEmployeeStatusRequest{
+		Status:        enum.EMPLOYEE_RESIGNED,
+		EffectiveDate: "2026-01-15",
+	})
+	assert.Equal(t, http.StatusBadRequest, status)
+}
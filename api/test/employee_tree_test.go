@@ -0,0 +1,122 @@
+package test
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/enum"
+	"api/internal/entity/money"
+	"api/internal/model"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// createHierarchy builds warehouse head > sales > driver > helper
+func createHierarchy(t *testing.T) []entity.Employee {
+	employees := []entity.Employee{
+		CreateEmployees(1, enum.EMPLOYEE_WAREHOUSE_HEAD)[0],
+		CreateEmployees(1, enum.SALES)[0],
+		CreateEmployees(1, enum.DRIVER)[0],
+		CreateEmployees(1, enum.HELPER)[0],
+	}
+
+	for i := 1; i < len(employees); i++ {
+		err := db.Model(&employees[i]).Update("supervisor_id", employees[i-1].ID).Error
+		assert.Nil(t, err)
+	}
+
+	return employees
+}
+
+func TestFindEmployeeTree(t *testing.T) {
+	defer ClearAll()
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	employees := createHierarchy(t)
+
+	request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/employees/org-tree?rootId=%d", employees[1].ID), nil)
+	request.Header.Set("Authorization", token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+
+	bytes, err := io.ReadAll(response.Body)
+	assert.Nil(t, err)
+
+	responseBody := new(model.WebResponse[[]model.EmployeeTreeResponse])
+	err = json.Unmarshal(bytes, responseBody)
+	assert.Nil(t, err)
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Len(t, responseBody.Data, 1)
+
+	sales := responseBody.Data[0]
+	assert.Equal(t, employees[1].ID, sales.ID)
+	assert.Len(t, sales.Subordinates, 1)
+	assert.Equal(t, employees[2].ID, sales.Subordinates[0].ID)
+	assert.Len(t, sales.Subordinates[0].Subordinates, 1)
+	assert.Equal(t, employees[3].ID, sales.Subordinates[0].Subordinates[0].ID)
+}
+
+func TestFindAllEmployeeSubordinates(t *testing.T) {
+	defer ClearAll()
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	employees := createHierarchy(t)
+
+	request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/employees?subordinateOf=%d", employees[1].ID), nil)
+	request.Header.Set("Authorization", token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+
+	bytes, err := io.ReadAll(response.Body)
+	assert.Nil(t, err)
+
+	responseBody := new(model.WebResponse[[]model.EmployeeResponse])
+	err = json.Unmarshal(bytes, responseBody)
+	assert.Nil(t, err)
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Len(t, responseBody.Data, 2)
+}
+
+func TestCreateEmployeeInvalidSupervisorRole(t *testing.T) {
+	defer ClearAll()
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	head := CreateEmployees(1, enum.EMPLOYEE_WAREHOUSE_HEAD)[0]
+
+	requestBody := model.CreateEmployeeRequest{
+		Name:         "Helper",
+		Salary:       money.New(100000),
+		Role:         enum.HELPER,
+		SupervisorId: head.ID,
+	}
+
+	bodyJson, err := json.Marshal(requestBody)
+	assert.Nil(t, err)
+
+	request := httptest.NewRequest(http.MethodPost, "/api/employees", strings.NewReader(string(bodyJson)))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+}
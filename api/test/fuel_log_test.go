@@ -0,0 +1,60 @@
+package test
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/enum"
+	"api/internal/entity/money"
+	"api/internal/model"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindFlaggedFuelLogs(t *testing.T) {
+	defer ClearAll()
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	vehicle := CreateVehicle(enum.TRUCK)
+
+	// 10 km/l on every fill-up except the last one
+	odometers := []int{1000, 1400, 1800, 2200, 2400}
+	start := time.Now().AddDate(0, 0, -len(odometers))
+	for i, odometer := range odometers {
+		assert.Nil(t, db.Create(&entity.FuelLog{
+			VehicleId:     vehicle.ID,
+			Date:          start.AddDate(0, 0, i),
+			Liters:        40,
+			PricePerLiter: money.New(6800),
+			TotalCost:     money.New(272000),
+			Odometer:      odometer,
+		}).Error)
+	}
+
+	request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/vehicles/%d/fuel-logs?onlyFlagged=true", vehicle.ID), nil)
+	request.Header.Set("Authorization", token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+
+	bytes, err := io.ReadAll(response.Body)
+	assert.Nil(t, err)
+
+	responseBody := new(model.WebResponse[[]model.FuelLogResponse])
+	err = json.Unmarshal(bytes, responseBody)
+	assert.Nil(t, err)
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Len(t, responseBody.Data, 1)
+	assert.Equal(t, 2400, responseBody.Data[0].Odometer)
+	assert.Equal(t, 5.0, *responseBody.Data[0].KmPerLiter)
+	assert.True(t, responseBody.Data[0].IsFlagged)
+}
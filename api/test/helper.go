@@ -2,18 +2,32 @@ package test
 
 import (
 	"api/internal/entity"
+	"api/internal/entity/enum"
 	"context"
 	"errors"
+	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 func ClearAll() {
+	ClearCashAdvances()
+	ClearTrips()
+	ClearPayrolls()
+	ClearCollections()
+	ClearCustomers()
 	ClearSalesRoutes()
 	ClearSales()
+	ClearCrews()
+	ClearAttendances()
+	ClearGeofences()
 	ClearEmployees()
+	ClearServiceRecords()
+	ClearFuelLogs()
+	ClearVehicles()
 	ClearRoutes()
 	ClearUsers()
 }
@@ -32,6 +46,58 @@ func ClearEmployees() {
 	}
 }
 
+func ClearAttendances() {
+	err := db.Exec("DELETE FROM employee_attendances").Error
+	if err != nil {
+		log.Fatalf("Failed clear employee_attendances data : %+v", err)
+	}
+}
+
+func ClearGeofences() {
+	err := db.Exec("DELETE FROM geofences").Error
+	if err != nil {
+		log.Fatalf("Failed clear geofences data : %+v", err)
+	}
+}
+
+func ClearTrips() {
+	// trip_helpers and trip_routes follow by cascade
+	err := db.Exec("DELETE FROM trips").Error
+	if err != nil {
+		log.Fatalf("Failed clear trips data : %+v", err)
+	}
+}
+
+func ClearPayrolls() {
+	// payroll_items follow by cascade
+	for _, table := range []string{"payrolls", "pay_rules"} {
+		err := db.Exec("DELETE FROM " + table).Error
+		if err != nil {
+			log.Fatalf("Failed clear %s data : %+v", table, err)
+		}
+	}
+}
+
+// ClearPeriod removes a period a test created with its closures, other tests share the table
+func ClearPeriod(id int) {
+	err := db.Exec("DELETE FROM period_closures WHERE period_id = ?", id).Error
+	if err != nil {
+		log.Fatalf("Failed clear period_closures data : %+v", err)
+	}
+
+	err = db.Unscoped().Delete(&entity.Period{}, id).Error
+	if err != nil {
+		log.Fatalf("Failed clear periods data : %+v", err)
+	}
+}
+
+func ClearCrews() {
+	err := db.Exec("DELETE FROM crews").Error
+	if err != nil {
+		log.Fatalf("Failed clear crews data : %+v", err)
+	}
+}
+
 func ClearSales() {
 	err := db.Unscoped().Where("id IS NOT NULL").Delete(&entity.Sales{}).Error
 	if err != nil {
@@ -47,6 +113,57 @@ func ClearSalesRoutes() {
 	}
 }
 
+func ClearCollections() {
+	for _, table := range []string{"cash_deposits", "collections", "receivables"} {
+		err := db.Exec("DELETE FROM " + table).Error
+		if err != nil {
+			log.Fatalf("Failed clear %s data : %+v", table, err)
+		}
+	}
+}
+
+func ClearCashAdvances() {
+	for _, table := range []string{"cash_advance_repayments", "cash_advances"} {
+		err := db.Exec("DELETE FROM " + table).Error
+		if err != nil {
+			log.Fatalf("Failed clear %s data : %+v", table, err)
+		}
+	}
+}
+
+func ClearCustomers() {
+	err := db.Unscoped().Where("id IS NOT NULL").Delete(&entity.Customer{}).Error
+	if err != nil {
+		log.Fatalf("Failed clear customers data : %+v", err)
+	}
+}
+
+func ClearServiceRecords() {
+	err := db.Exec("DELETE FROM service_records").Error
+	if err != nil {
+		log.Fatalf("Failed clear service_records data : %+v", err)
+	}
+}
+
+func ClearFuelLogs() {
+	err := db.Exec("DELETE FROM fuel_logs").Error
+	if err != nil {
+		log.Fatalf("Failed clear fuel_logs data : %+v", err)
+	}
+}
+
+func ClearVehicles() {
+	err := db.Exec("DELETE FROM vehicle_history").Error
+	if err != nil {
+		log.Fatalf("Failed clear vehicle_history data : %+v", err)
+	}
+
+	err = db.Unscoped().Where("id IS NOT NULL").Delete(&entity.Vehicle{}).Error
+	if err != nil {
+		log.Fatalf("Failed clear vehicles data : %+v", err)
+	}
+}
+
 func ClearRoutes() {
 	err := db.Unscoped().Where("id IS NOT NULL").Delete(&entity.Route{}).Error
 	if err != nil {
@@ -78,7 +195,49 @@ func GenerateTokenHelper() (string, error) {
 		return "", err
 	}
 
-	_, err = redisClient.SetEx(context.Background(), jwtToken, user.ID, time.Hour*25*30).Result()
+	err = sessions.Save(context.Background(), jwtToken, user.ID, time.Hour*25*30)
+	if err != nil {
+		return "", err
+	}
+
+	return jwtToken, nil
+}
+
+func GenerateEmployeeTokenHelper(employeeID int) (string, error) {
+	return GenerateLinkedTokenHelper(employeeID, enum.USER_EMPLOYEE)
+}
+
+// GenerateLinkedTokenHelper logs in a new account with the role linked to the employee
+func GenerateLinkedTokenHelper(employeeID int, role enum.UserRole) (string, error) {
+	jwtSecret := viperConfig.GetString("secret_key")
+
+	user := &entity.User{
+		ID:         uuid.New(),
+		Name:       "Employee Account",
+		Username:   "employee" + strconv.Itoa(employeeID),
+		Password:   "password",
+		Phone:      "0812" + strconv.Itoa(employeeID),
+		Role:       role,
+		EmployeeId: &employeeID,
+	}
+
+	if err := db.Create(user).Error; err != nil {
+		return "", err
+	}
+
+	// create token
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"id":     user.ID,
+		"role":   user.Role,
+		"expire": time.Now().Add(time.Hour * 24 * 30).UnixMilli(),
+	})
+
+	jwtToken, err := token.SignedString([]byte(jwtSecret))
+	if err != nil {
+		return "", err
+	}
+
+	err = sessions.Save(context.Background(), jwtToken, user.ID, time.Hour*25*30)
 	if err != nil {
 		return "", err
 	}
@@ -1,11 +1,13 @@
 package test
 
 import (
+	"api/db/migrations"
 	"api/internal/config"
+	"api/internal/migration"
+	"api/internal/utils"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
-	"github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 	"gorm.io/gorm"
@@ -16,15 +18,25 @@ var db *gorm.DB
 var viperConfig *viper.Viper
 var log *logrus.Logger
 var validate *validator.Validate
-var redisClient *redis.Client
+var sessions utils.SessionStore
 
 func init() {
 	viperConfig = config.NewViper()
 	log = config.NewLogger(viperConfig)
+	if err := config.ValidateSecrets(viperConfig, true); err != nil {
+		log.Fatalf("Invalid test configuration : %+v", err)
+	}
 	validate = config.NewValidator(viperConfig)
 	app = config.NewFiber(viperConfig)
 	db = config.NewDatabase(viperConfig, log, true)
-	redisClient = config.NewRedis(viperConfig)
+
+	// keep the test schema current with the embedded migrations
+	if _, err := migration.NewMigrator(db, log, migrations.FS).Up(); err != nil {
+		log.Fatalf("Failed to migrate test database : %+v", err)
+	}
+
+	// tokens only live as long as the test process
+	sessions = utils.NewMemorySessionStore()
 
 	config.Bootstrap(&config.BootstrapConfig{
 		DB:       db,
@@ -32,6 +44,6 @@ func init() {
 		Log:      log,
 		Config:   viperConfig,
 		Validate: validate,
-		Redis:    redisClient,
+		Sessions: sessions,
 	})
 }
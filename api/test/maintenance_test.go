@@ -0,0 +1,78 @@
+package test
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/enum"
+	"api/internal/entity/money"
+	"api/internal/model"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindMaintenanceDue(t *testing.T) {
+	defer ClearAll()
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	vehicle := CreateVehicle(enum.PICKUP)
+	intervalKm, intervalDays := 5000, 90
+
+	oilPlan := entity.MaintenancePlan{
+		VehicleId:   vehicle.ID,
+		ServiceType: "Ganti oli",
+		IntervalKm:  &intervalKm,
+		StartDate:   time.Now(),
+		IsActive:    true,
+	}
+	assert.Nil(t, db.Create(&oilPlan).Error)
+
+	tirePlan := entity.MaintenancePlan{
+		VehicleId:    vehicle.ID,
+		ServiceType:  "Rotasi ban",
+		IntervalDays: &intervalDays,
+		StartDate:    time.Now().AddDate(0, 0, -100),
+		IsActive:     true,
+	}
+	assert.Nil(t, db.Create(&tirePlan).Error)
+
+	// an unrelated service moves the odometer close to the oil change
+	assert.Nil(t, db.Create(&entity.ServiceRecord{
+		VehicleId:   vehicle.ID,
+		Date:        time.Now(),
+		Odometer:    4700,
+		ServiceType: "Ganti aki",
+		Cost:        money.New(750000),
+	}).Error)
+
+	request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/maintenance/due?vehicleId=%d", vehicle.ID), nil)
+	request.Header.Set("Authorization", token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+
+	bytes, err := io.ReadAll(response.Body)
+	assert.Nil(t, err)
+
+	responseBody := new(model.WebResponse[[]model.MaintenanceDueResponse])
+	err = json.Unmarshal(bytes, responseBody)
+	assert.Nil(t, err)
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Len(t, responseBody.Data, 2)
+
+	statuses := make(map[int]string)
+	for _, due := range responseBody.Data {
+		statuses[due.PlanId] = due.Status
+	}
+	assert.Equal(t, string(enum.MAINTENANCE_DUE_SOON), statuses[oilPlan.ID])
+	assert.Equal(t, string(enum.MAINTENANCE_OVERDUE), statuses[tirePlan.ID])
+}
@@ -0,0 +1,57 @@
+package test
+
+import (
+	"api/internal/entity/enum"
+	"api/internal/model"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMePayrolls(t *testing.T) {
+	defer ClearAll()
+
+	employees := CreateEmployees(1, enum.DRIVER)
+
+	token, err := GenerateEmployeeTokenHelper(employees[0].ID)
+	assert.Nil(t, err)
+
+	request := httptest.NewRequest(http.MethodGet, "/api/me/payrolls", nil)
+	request.Header.Set("Authorization", token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+
+	bytes, err := io.ReadAll(response.Body)
+	assert.Nil(t, err)
+
+	responseBody := new(model.WebResponse[[]model.PayrollResponse])
+	err = json.Unmarshal(bytes, responseBody)
+	assert.Nil(t, err)
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, 0, len(responseBody.Data))
+}
+
+func TestEmployeeAccountForbiddenOnStaffRoutes(t *testing.T) {
+	defer ClearAll()
+
+	employees := CreateEmployees(1, enum.HELPER)
+
+	token, err := GenerateEmployeeTokenHelper(employees[0].ID)
+	assert.Nil(t, err)
+
+	request := httptest.NewRequest(http.MethodGet, "/api/users", nil)
+	request.Header.Set("Authorization", token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+
+	assert.Equal(t, http.StatusForbidden, response.StatusCode)
+}
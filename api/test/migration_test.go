@@ -0,0 +1,110 @@
+package test
+
+import (
+	"api/db/migrations"
+	"api/internal/migration"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// scratchMigrator runs a migrator in a schema of its own inside a transaction
+// that is rolled back, so the shared test schema is left alone
+func scratchMigrator(t *testing.T, files fstest.MapFS) (*migration.Migrator, *gorm.DB) {
+	tx := db.Begin()
+	t.Cleanup(func() { tx.Rollback() })
+
+	assert.Nil(t, tx.Exec(`CREATE SCHEMA migration_scratch`).Error)
+	assert.Nil(t, tx.Exec(`SET LOCAL search_path TO migration_scratch`).Error)
+
+	return migration.NewMigrator(tx, log, files), tx
+}
+
+var scratchFiles = fstest.MapFS{
+	"1_create_alpha.up.sql":   {Data: []byte("CREATE TABLE alpha (id int)")},
+	"1_create_alpha.down.sql": {Data: []byte("DROP TABLE alpha")},
+	"2_create_beta.up.sql":    {Data: []byte("CREATE TABLE beta (id int)")},
+	"2_create_beta.down.sql":  {Data: []byte("DROP TABLE beta")},
+	"3_create_gamma.up.sql":   {Data: []byte("CREATE TABLE gamma (id int)")},
+}
+
+func TestMigrationStatusUpToDate(t *testing.T) {
+	migrator := migration.NewMigrator(db, log, migrations.FS)
+
+	statuses, err := migrator.Status()
+	assert.Nil(t, err)
+	assert.NotEmpty(t, statuses)
+
+	for _, status := range statuses {
+		assert.NotNil(t, status.AppliedAt, "migration %d_%s is pending", status.Version, status.Name)
+	}
+
+	assert.Nil(t, migrator.EnsureUpToDate())
+}
+
+func TestMigrationUpAndDown(t *testing.T) {
+	migrator, tx := scratchMigrator(t, scratchFiles)
+
+	total, err := migrator.Up()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, total)
+	assert.Nil(t, migrator.EnsureUpToDate())
+
+	// gamma has no down script, nothing is rolled back
+	total, err = migrator.Down(1)
+	assert.EqualError(t, err, "migration 3_create_gamma has no down script")
+	assert.Equal(t, 0, total)
+	assert.True(t, tx.Migrator().HasTable("gamma"))
+
+	assert.Nil(t, tx.Exec(`DROP TABLE gamma`).Error)
+	assert.Nil(t, tx.Exec(`DELETE FROM schema_migrations WHERE version = 3`).Error)
+
+	// newest first
+	total, err = migrator.Down(1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, total)
+	assert.False(t, tx.Migrator().HasTable("beta"))
+	assert.True(t, tx.Migrator().HasTable("alpha"))
+
+	pending, err := migrator.Pending()
+	assert.Nil(t, err)
+	assert.Len(t, pending, 2)
+	assert.Equal(t, int64(2), pending[0].Version)
+
+	_, err = migrator.Down(0)
+	assert.NotNil(t, err)
+}
+
+func TestMigrationAdoptsLegacyTable(t *testing.T) {
+	migrator, tx := scratchMigrator(t, scratchFiles)
+
+	// golang-migrate keeps one row with the last version applied
+	assert.Nil(t, tx.Exec(`CREATE TABLE schema_migrations (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)`).Error)
+	assert.Nil(t, tx.Exec(`INSERT INTO schema_migrations (version, dirty) VALUES (2, false)`).Error)
+
+	statuses, err := migrator.Status()
+	assert.Nil(t, err)
+	assert.Len(t, statuses, 3)
+	assert.NotNil(t, statuses[0].AppliedAt)
+	assert.NotNil(t, statuses[1].AppliedAt)
+	assert.Nil(t, statuses[2].AppliedAt)
+
+	assert.False(t, tx.Migrator().HasColumn(&migration.SchemaMigration{}, "dirty"))
+
+	var names []string
+	assert.Nil(t, tx.Model(&migration.SchemaMigration{}).Order("version").Pluck("name", &names).Error)
+	assert.Equal(t, []string{"create_alpha", "create_beta"}, names)
+}
+
+func TestMigrationRejectsDirtyLegacyTable(t *testing.T) {
+	migrator, tx := scratchMigrator(t, scratchFiles)
+
+	assert.Nil(t, tx.Exec(`CREATE TABLE schema_migrations (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)`).Error)
+	assert.Nil(t, tx.Exec(`INSERT INTO schema_migrations (version, dirty) VALUES (2, true)`).Error)
+
+	_, err := migrator.Status()
+	assert.EqualError(t, err, "legacy schema_migrations is dirty at version 2, fix it by hand first")
+	assert.True(t, tx.Migrator().HasColumn(&migration.SchemaMigration{}, "dirty"))
+}
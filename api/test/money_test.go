@@ -0,0 +1,28 @@
+package test
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/enum"
+	"api/internal/entity/money"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoneyDatabaseRoundTrip(t *testing.T) {
+	defer ClearAll()
+
+	employees := CreateEmployees(1, enum.DRIVER)
+	advance := CreateCashAdvance(employees[0].ID, money.MustParse("300000.10"), money.MustParse("100000.20"))
+
+	var found entity.CashAdvance
+	err := db.First(&found, advance.ID).Error
+	assert.Nil(t, err)
+	assert.Equal(t, money.MustParse("300000.10"), found.Amount)
+	assert.Equal(t, money.MustParse("100000.20"), found.Installment)
+
+	var total money.Money
+	err = db.Model(&entity.CashAdvance{}).Select("SUM(amount + installment)").Scan(&total).Error
+	assert.Nil(t, err)
+	assert.Equal(t, money.MustParse("400000.30"), total)
+}
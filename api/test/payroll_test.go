@@ -0,0 +1,302 @@
+package test
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/enum"
+	"api/internal/entity/money"
+	"api/internal/model"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func upsertPayRuleRequest(t *testing.T, token string, requestBody model.UpsertPayRuleRequest) *http.Response {
+	bodyJson, err := json.Marshal(requestBody)
+	assert.Nil(t, err)
+
+	request := httptest.NewRequest(http.MethodPut, "/api/pay-rules", strings.NewReader(string(bodyJson)))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+
+	return response
+}
+
+func generatePayrollRequest(t *testing.T, token string, periodId int) (*http.Response, map[int]model.PayrollResponse) {
+	bodyJson, err := json.Marshal(model.GeneratePayrollRequest{PeriodId: periodId})
+	assert.Nil(t, err)
+
+	request := httptest.NewRequest(http.MethodPost, "/api/payrolls/generate", strings.NewReader(string(bodyJson)))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+
+	bytes, err := io.ReadAll(response.Body)
+	assert.Nil(t, err)
+
+	responseBody := new(model.WebResponse[[]model.PayrollResponse])
+	err = json.Unmarshal(bytes, responseBody)
+	assert.Nil(t, err)
+
+	byEmployee := make(map[int]model.PayrollResponse)
+	for _, payroll := range responseBody.Data {
+		byEmployee[payroll.EmployeeId] = payroll
+	}
+
+	return response, byEmployee
+}
+
+func itemOf(payroll model.PayrollResponse, itemType enum.PayrollItemType) *model.PayrollItemResponse {
+	for i := range payroll.Items {
+		if payroll.Items[i].Type == itemType {
+			return &payroll.Items[i]
+		}
+	}
+	return nil
+}
+
+func TestGenerateOperationalPayroll(t *testing.T) {
+	period := CreatePeriod(time.Date(2031, time.June, 1, 0, 0, 0, 0, time.UTC))
+	defer ClearPeriod(period.ID)
+	defer ClearAll()
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	driverDailyRate := money.New(50000)
+	assert.Equal(t, http.StatusOK, upsertPayRuleRequest(t, token, model.UpsertPayRuleRequest{
+		Role:      enum.DRIVER,
+		DailyRate: &driverDailyRate,
+		TripRate:  money.New(20000),
+		SackRate:  money.New(500),
+	}).StatusCode)
+
+	// without a daily rate the helper is paid the salary per day
+	assert.Equal(t, http.StatusOK, upsertPayRuleRequest(t, token, model.UpsertPayRuleRequest{
+		Role:     enum.HELPER,
+		TripRate: money.New(10000),
+	}).StatusCode)
+
+	driver := CreateEmployees(1, enum.DRIVER)[0]
+	helper := CreateEmployees(1, enum.HELPER)[0]
+	vehicle := CreateVehicle(enum.TRUCK)
+
+	attendances := []entity.EmployeeAttendance{
+		{Date: period.StartDate.AddDate(0, 0, 1), Status: enum.PRESENT, EmployeeId: driver.ID, PeriodId: period.ID},
+		{Date: period.StartDate.AddDate(0, 0, 2), Status: enum.PRESENT, EmployeeId: driver.ID, PeriodId: period.ID},
+		{Date: period.StartDate.AddDate(0, 0, 1), Status: enum.PRESENT, EmployeeId: helper.ID, PeriodId: period.ID},
+	}
+	assert.Nil(t, db.Create(&attendances).Error)
+
+	trip := entity.Trip{
+		Date:          period.StartDate.AddDate(0, 0, 1),
+		SacksLoaded:   100,
+		SacksReturned: 10,
+		VehicleId:     vehicle.ID,
+		DriverId:      driver.ID,
+		Helpers:       []entity.Employee{helper},
+	}
+	assert.Nil(t, db.Create(&trip).Error)
+
+	response, payrolls := generatePayrollRequest(t, token, period.ID)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	// 2 days x 50.000, 1 trip x 20.000 and 90 sacks x 500
+	driverPayroll := payrolls[driver.ID]
+	assert.Equal(t, enum.OPERATIONAL, driverPayroll.ModuleType)
+	assert.Equal(t, 2, driverPayroll.AttendanceDays)
+	assert.Equal(t, money.New(100000), driverPayroll.BaseSalary)
+	assert.Equal(t, money.New(65000), driverPayroll.Bonuses)
+	assert.Len(t, driverPayroll.Items, 3)
+	assert.Equal(t, float64(90), itemOf(driverPayroll, enum.ITEM_SACK).Quantity)
+
+	// 1 day x 100.000 salary and 1 trip x 10.000, no sack rate
+	helperPayroll := payrolls[helper.ID]
+	assert.Equal(t, money.New(100000), helperPayroll.BaseSalary)
+	assert.Equal(t, money.New(10000), helperPayroll.Bonuses)
+	assert.Len(t, helperPayroll.Items, 2)
+	assert.Nil(t, itemOf(helperPayroll, enum.ITEM_SACK))
+
+	// generating again replaces the lines instead of adding to them
+	response, payrolls = generatePayrollRequest(t, token, period.ID)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, driverPayroll.ID, payrolls[driver.ID].ID)
+
+	var items int64
+	assert.Nil(t, db.Model(&entity.PayrollItem{}).Where("payroll_id = ?", driverPayroll.ID).Count(&items).Error)
+	assert.Equal(t, int64(3), items)
+}
+
+func TestGeneratePayrollUnknownPeriod(t *testing.T) {
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	response, _ := generatePayrollRequest(t, token, 999999)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+}
+
+func createPayroll(employeeId, periodId int, baseSalary money.Money) entity.Payroll {
+	payroll := entity.Payroll{
+		BaseSalary:     baseSalary,
+		AttendanceDays: 5,
+		ModuleType:     enum.OPERATIONAL,
+		EmployeeId:     employeeId,
+		PeriodID:       periodId,
+	}
+
+	dbErr := db.Create(&payroll).Error
+	if dbErr != nil {
+		log.Fatalf("Failed create payroll data : %+v", dbErr)
+	}
+	return payroll
+}
+
+func payRequest(t *testing.T, token string, url string, requestBody any) (*http.Response, []byte) {
+	bodyJson, err := json.Marshal(requestBody)
+	assert.Nil(t, err)
+
+	request := httptest.NewRequest(http.MethodPost, url, strings.NewReader(string(bodyJson)))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+
+	bytes, err := io.ReadAll(response.Body)
+	assert.Nil(t, err)
+
+	return response, bytes
+}
+
+func TestPayPayroll(t *testing.T) {
+	period := CreatePeriod(time.Date(2031, time.June, 8, 0, 0, 0, 0, time.UTC))
+	defer ClearPeriod(period.ID)
+	defer ClearAll()
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	superadmin := new(entity.User)
+	assert.Nil(t, db.First(superadmin, "username = ?", "superadmin").Error)
+
+	employee := CreateEmployees(1, enum.DRIVER)[0]
+	payroll := createPayroll(employee.ID, period.ID, money.New(500000))
+
+	response, bytes := payRequest(t, token, fmt.Sprintf("/api/payrolls/%d/pay", payroll.ID), model.PayPayrollRequest{PaymentMethod: enum.TRANSFER})
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	responseBody := new(model.WebResponse[model.PayrollResponse])
+	assert.Nil(t, json.Unmarshal(bytes, responseBody))
+	assert.True(t, responseBody.Data.IsPaid)
+	assert.NotNil(t, responseBody.Data.PaidAt)
+	assert.Equal(t, enum.TRANSFER, *responseBody.Data.PaymentMethod)
+	assert.Equal(t, superadmin.ID, *responseBody.Data.PaidBy)
+
+	// a line is paid once
+	response, _ = payRequest(t, token, fmt.Sprintf("/api/payrolls/%d/pay", payroll.ID), model.PayPayrollRequest{PaymentMethod: enum.CASH})
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	stored := new(entity.Payroll)
+	assert.Nil(t, db.First(stored, payroll.ID).Error)
+	assert.Equal(t, enum.TRANSFER, *stored.PaymentMethod)
+}
+
+func TestPayPayrollsByPeriod(t *testing.T) {
+	period := CreatePeriod(time.Date(2031, time.June, 8, 0, 0, 0, 0, time.UTC))
+	defer ClearPeriod(period.ID)
+	defer ClearAll()
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	employees := CreateEmployees(3, enum.HELPER)
+	paid := createPayroll(employees[0].ID, period.ID, money.New(300000))
+	createPayroll(employees[1].ID, period.ID, money.New(300000))
+	createPayroll(employees[2].ID, period.ID, money.New(300000))
+
+	paidAt := time.Date(2031, time.June, 14, 0, 0, 0, 0, time.UTC)
+	assert.Nil(t, db.Model(&paid).Updates(map[string]any{"is_paid": true, "paid_at": paidAt}).Error)
+
+	// only the unpaid lines are paid
+	response, bytes := payRequest(t, token, "/api/payrolls/pay", model.PayPayrollsByPeriodRequest{PeriodId: period.ID, PaymentMethod: enum.CASH})
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	responseBody := new(model.WebResponse[int])
+	assert.Nil(t, json.Unmarshal(bytes, responseBody))
+	assert.Equal(t, 2, responseBody.Data)
+
+	stored := new(entity.Payroll)
+	assert.Nil(t, db.First(stored, paid.ID).Error)
+	assert.Equal(t, paidAt, stored.PaidAt.UTC())
+	assert.Nil(t, stored.PaymentMethod)
+
+	// nothing is left to pay
+	response, _ = payRequest(t, token, "/api/payrolls/pay", model.PayPayrollsByPeriodRequest{PeriodId: period.ID, PaymentMethod: enum.CASH})
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+}
+
+func payslipRequest(t *testing.T, token string, employeeId, periodId int) (*http.Response, []byte) {
+	request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/payrolls/payslip?periodId=%d&employeeId=%d", periodId, employeeId), nil)
+	request.Header.Set("Authorization", token)
+
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+
+	bytes, err := io.ReadAll(response.Body)
+	assert.Nil(t, err)
+
+	return response, bytes
+}
+
+func TestPayslip(t *testing.T) {
+	period := CreatePeriod(time.Date(2031, time.June, 8, 0, 0, 0, 0, time.UTC))
+	defer ClearPeriod(period.ID)
+	defer ClearAll()
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	employee := CreateEmployees(1, enum.DRIVER)[0]
+	createPayroll(employee.ID, period.ID, money.New(500000))
+
+	response, bytes := payslipRequest(t, token, employee.ID, period.ID)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "application/pdf", response.Header.Get("Content-Type"))
+	assert.True(t, strings.HasPrefix(string(bytes), "%PDF"))
+
+	// an open payroll is not counted
+	var closures int64
+	assert.Nil(t, db.Model(&entity.PeriodClosure{}).Where("period_id = ?", period.ID).Count(&closures).Error)
+	assert.Zero(t, closures)
+
+	// every print of a closed payroll is
+	closure := entity.PeriodClosure{ModuleName: string(enum.PAYROLL), Notes: "Ditutup", PeriodID: period.ID, IsClosed: true}
+	assert.Nil(t, db.Create(&closure).Error)
+
+	for i := 0; i < 2; i++ {
+		response, _ = payslipRequest(t, token, employee.ID, period.ID)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	}
+
+	assert.Nil(t, db.First(&closure, closure.ID).Error)
+	assert.Equal(t, 2, closure.PrintCount)
+
+	// an employee without a payroll line has no payslip
+	other := CreateEmployees(1, enum.HELPER)[0]
+	response, _ = payslipRequest(t, token, other.ID, period.ID)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+}
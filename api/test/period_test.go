@@ -0,0 +1,319 @@
+package test
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/enum"
+	"api/internal/model"
+	"api/internal/repository"
+	"api/internal/usecase"
+	"api/internal/utils"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetOrCreatePeriodIdByDateConcurrent(t *testing.T) {
+	// periods are shared by every test, use a week nothing else touches
+	startDate := time.Date(2031, time.March, 2, 0, 0, 0, 0, time.UTC)
+	db.Unscoped().Where("type = ? AND start_date = ?", enum.WEEKLY, startDate).Delete(&entity.Period{})
+	defer db.Unscoped().Where("type = ? AND start_date = ?", enum.WEEKLY, startDate).Delete(&entity.Period{})
+
+	periodUseCase := usecase.NewPeriodUseCase(repository.NewGormTransactor(db), log, validate, repository.NewPeriodRepository(log), repository.NewPeriodClosureRepository(log), utils.NewWeekCalendar(time.Sunday))
+
+	const workers = 20
+	ids := make([]int, workers)
+	errs := make([]error, workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			tx := db.Begin()
+			defer tx.Rollback()
+
+			ids[i], errs[i] = periodUseCase.GetOrCreatePeriodIdByDate(tx, "2031-03-05")
+			if errs[i] == nil {
+				errs[i] = tx.Commit().Error
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < workers; i++ {
+		assert.Nil(t, errs[i])
+		assert.NotZero(t, ids[i])
+		assert.Equal(t, ids[0], ids[i])
+	}
+
+	var count int64
+	err := db.Model(&entity.Period{}).Where("type = ? AND start_date = ?", enum.WEEKLY, startDate).Count(&count).Error
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestGetOrCreatePeriodIdByDateJoinsTransaction(t *testing.T) {
+	startDate := time.Date(2031, time.March, 9, 0, 0, 0, 0, time.UTC)
+	db.Unscoped().Where("type = ? AND start_date = ?", enum.WEEKLY, startDate).Delete(&entity.Period{})
+
+	periodUseCase := usecase.NewPeriodUseCase(repository.NewGormTransactor(db), log, validate, repository.NewPeriodRepository(log), repository.NewPeriodClosureRepository(log), utils.NewWeekCalendar(time.Sunday))
+
+	tx := db.Begin()
+	id, err := periodUseCase.GetOrCreatePeriodIdByDate(tx, "2031-03-12")
+	assert.Nil(t, err)
+	assert.NotZero(t, id)
+	tx.Rollback()
+
+	// the period goes away with the caller's rollback
+	var count int64
+	err = db.Model(&entity.Period{}).Where("id = ?", id).Count(&count).Error
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestGetOrCreatePeriodIdByDateStoresPolicy(t *testing.T) {
+	calendar := &utils.WeekCalendar{Type: enum.WEEKLY, WeekStart: time.Saturday, MonthRule: enum.MONTH_RULE_MAJORITY}
+	startDate := time.Date(2031, time.April, 26, 0, 0, 0, 0, time.UTC)
+	db.Unscoped().Where("type = ? AND start_date = ?", enum.WEEKLY, startDate).Delete(&entity.Period{})
+	defer db.Unscoped().Where("type = ? AND start_date = ?", enum.WEEKLY, startDate).Delete(&entity.Period{})
+
+	periodUseCase := usecase.NewPeriodUseCase(repository.NewGormTransactor(db), log, validate, repository.NewPeriodRepository(log), repository.NewPeriodClosureRepository(log), calendar)
+
+	tx := db.Begin()
+	id, err := periodUseCase.GetOrCreatePeriodIdByDate(tx, "2031-04-30")
+	assert.Nil(t, err)
+	assert.Nil(t, tx.Commit().Error)
+
+	var period entity.Period
+	err = db.First(&period, id).Error
+	assert.Nil(t, err)
+	assert.Equal(t, startDate, period.StartDate.UTC())
+	assert.Equal(t, time.Saturday, period.WeekStart)
+	assert.Equal(t, enum.MONTH_RULE_MAJORITY, period.MonthRule)
+	// saturday 26 april to friday 2 may has five days in april
+	assert.Equal(t, 4, period.Month)
+}
+
+// day parses a yyyy-mm-dd date
+func day(value string) time.Time {
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		panic(err)
+	}
+	return date
+}
+
+// clearPeriods removes the periods a test created, other tests share the table
+func clearPeriods(from, to time.Time) {
+	db.Unscoped().Where("start_date BETWEEN ? AND ?", from, to).Delete(&entity.Period{})
+}
+
+func generatePeriods(t *testing.T, token string, requestBody model.GeneratePeriodRequest) (int, []model.PeriodResponse) {
+	bodyJson, err := json.Marshal(requestBody)
+	assert.Nil(t, err)
+
+	request := httptest.NewRequest(http.MethodPost, "/api/periods/generate", strings.NewReader(string(bodyJson)))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+
+	bytes, err := io.ReadAll(response.Body)
+	assert.Nil(t, err)
+
+	responseBody := new(model.WebResponse[[]model.PeriodResponse])
+	err = json.Unmarshal(bytes, responseBody)
+	assert.Nil(t, err)
+
+	return response.StatusCode, responseBody.Data
+}
+
+func TestGeneratePeriods(t *testing.T) {
+	from, to := day("2032-02-01"), day("2032-05-01")
+	clearPeriods(from, to)
+	defer clearPeriods(from, to)
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	status, periods := generatePeriods(t, token, model.GeneratePeriodRequest{StartDate: "2032-03-03", Count: 4})
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Len(t, periods, 4)
+
+	assert.False(t, periods[0].StartDate.After(day("2032-03-03")))
+	assert.False(t, periods[0].EndDate.Before(day("2032-03-03")))
+	for i := 1; i < len(periods); i++ {
+		assert.Equal(t, periods[i-1].EndDate.AddDate(0, 0, 1), periods[i].StartDate)
+		assert.True(t, periods[i].IsActive)
+	}
+
+	// generating again returns the same periods
+	status, again := generatePeriods(t, token, model.GeneratePeriodRequest{StartDate: "2032-03-03", Count: 4})
+	assert.Equal(t, http.StatusCreated, status)
+	for i := range periods {
+		assert.Equal(t, periods[i].ID, again[i].ID)
+	}
+
+	status, _ = generatePeriods(t, token, model.GeneratePeriodRequest{StartDate: "2032-03-03", Count: 0})
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestFindAllPeriods(t *testing.T) {
+	from, to := day("2032-06-01"), day("2032-09-01")
+	clearPeriods(from, to)
+	defer clearPeriods(from, to)
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	_, periods := generatePeriods(t, token, model.GeneratePeriodRequest{StartDate: "2032-07-07", Count: 3})
+	assert.Len(t, periods, 3)
+
+	err = db.Model(&entity.Period{}).Where("id = ?", periods[2].ID).Update("is_active", false).Error
+	assert.Nil(t, err)
+
+	findAll := func(query string) []model.PeriodResponse {
+		request := httptest.NewRequest(http.MethodGet, "/api/periods?"+query, nil)
+		request.Header.Set("Authorization", token)
+		request.Header.Set("Accept", "application/json")
+
+		response, err := app.Test(request)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		bytes, err := io.ReadAll(response.Body)
+		assert.Nil(t, err)
+
+		responseBody := new(model.WebResponse[[]model.PeriodResponse])
+		err = json.Unmarshal(bytes, responseBody)
+		assert.Nil(t, err)
+
+		var found []model.PeriodResponse
+		for _, period := range responseBody.Data {
+			if !period.StartDate.Before(from) && period.StartDate.Before(to) {
+				found = append(found, period)
+			}
+		}
+		return found
+	}
+
+	year := strconv.Itoa(periods[0].Year)
+	assert.Len(t, findAll("year="+year+"&status=open"), 2)
+	assert.Len(t, findAll("year="+year+"&includeInactive=true"), 3)
+	assert.Len(t, findAll("year="+year+"&status=closed"), 0)
+	assert.Len(t, findAll("year="+year+"&type=MONTHLY"), 0)
+
+	month := strconv.Itoa(periods[0].Month)
+	for _, period := range findAll("year=" + year + "&month=" + month) {
+		assert.Equal(t, periods[0].Month, period.Month)
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "/api/periods?status=unknown", nil)
+	request.Header.Set("Authorization", token)
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+}
+
+func TestFindPeriodDetailAndDeactivate(t *testing.T) {
+	from, to := day("2032-10-01"), day("2032-12-01")
+	clearPeriods(from, to)
+	defer clearPeriods(from, to)
+	// attendances go first, they hold the periods
+	defer ClearAll()
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	_, periods := generatePeriods(t, token, model.GeneratePeriodRequest{StartDate: "2032-10-13", Count: 2})
+	assert.Len(t, periods, 2)
+
+	employees := CreateEmployees(2, enum.DRIVER)
+	for i, status := range []enum.AttendanceStatus{enum.PRESENT, enum.SICK} {
+		err := db.Create(&entity.EmployeeAttendance{
+			Date:       periods[0].StartDate,
+			Status:     status,
+			EmployeeId: employees[i].ID,
+			PeriodId:   periods[0].ID,
+		}).Error
+		assert.Nil(t, err)
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "/api/periods/"+strconv.Itoa(periods[0].ID), nil)
+	request.Header.Set("Authorization", token)
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+
+	bytes, err := io.ReadAll(response.Body)
+	assert.Nil(t, err)
+
+	detail := new(model.WebResponse[*model.PeriodDetailResponse])
+	err = json.Unmarshal(bytes, detail)
+	assert.Nil(t, err)
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, periods[0].ID, detail.Data.Period.ID)
+	assert.Equal(t, int64(2), detail.Data.Attendance.Employees)
+	assert.Equal(t, int64(1), detail.Data.Attendance.Present)
+	assert.Equal(t, int64(1), detail.Data.Attendance.Sick)
+	assert.Equal(t, int64(0), detail.Data.Payroll.Total)
+	assert.Len(t, detail.Data.Modules, 2)
+	for _, module := range detail.Data.Modules {
+		assert.False(t, module.IsClosed)
+	}
+
+	deactivate := func(id int) *http.Response {
+		request := httptest.NewRequest(http.MethodPost, "/api/periods/"+strconv.Itoa(id)+"/deactivate", nil)
+		request.Header.Set("Authorization", token)
+		response, err := app.Test(request)
+		assert.Nil(t, err)
+		return response
+	}
+
+	// a period holding attendance stays
+	assert.Equal(t, http.StatusConflict, deactivate(periods[0].ID).StatusCode)
+	assert.Equal(t, http.StatusOK, deactivate(periods[1].ID).StatusCode)
+	assert.Equal(t, http.StatusNotFound, deactivate(999999999).StatusCode)
+
+	var period entity.Period
+	err = db.First(&period, periods[1].ID).Error
+	assert.Nil(t, err)
+	assert.False(t, period.IsActive)
+
+	// attendance on a deactivated week is refused, the period stays hidden
+	bodyJson, err := json.Marshal(model.UpsertEmployeeAttendanceRequest{
+		Attendances: []model.AttendanceAction{{
+			Action:    "update",
+			Date:      periods[1].StartDate.Format("2006-01-02"),
+			Employees: []model.EmployeeAttendance{{ID: employees[0].ID, Status: string(enum.PRESENT)}},
+		}},
+	})
+	assert.Nil(t, err)
+
+	request = httptest.NewRequest(http.MethodPost, "/api/attendance/batch", strings.NewReader(string(bodyJson)))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", token)
+	response, err = app.Test(request)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	err = db.First(&period, periods[1].ID).Error
+	assert.Nil(t, err)
+	assert.False(t, period.IsActive)
+
+	var count int64
+	err = db.Model(&entity.EmployeeAttendance{}).Where("period_id = ?", periods[1].ID).Count(&count).Error
+	assert.Nil(t, err)
+	assert.Zero(t, count)
+}
@@ -48,6 +48,50 @@ func TestCreateRoute(t *testing.T) {
 	assert.Equal(t, requestBody.Description, responseBody.Data.Description)
 }
 
+func TestCreateRouteWithStops(t *testing.T) {
+	defer ClearAll()
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	requestBody := model.CreateRouteRequest{
+		Name:        "Route Test 1",
+		Description: "Description for route test 1",
+		VisitDays:   []int{1, 4},
+		Stops: []model.RouteStopRequest{
+			{Name: "Gudang", Latitude: -6.2, Longitude: 106.8},
+			{Name: "Pasar", Latitude: -6.2, Longitude: 106.81},
+			{Name: "Toko", Latitude: -6.21, Longitude: 106.81},
+		},
+	}
+
+	bodyJson, err := json.Marshal(requestBody)
+	assert.Nil(t, err)
+
+	request := httptest.NewRequest(http.MethodPost, "/api/routes", strings.NewReader(string(bodyJson)))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+
+	bytes, err := io.ReadAll(response.Body)
+	assert.Nil(t, err)
+
+	responseBody := new(model.WebResponse[model.RouteResponse])
+	err = json.Unmarshal(bytes, responseBody)
+	assert.Nil(t, err)
+
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+	assert.Equal(t, []int{1, 4}, responseBody.Data.VisitDays)
+	assert.Len(t, responseBody.Data.Stops, 3)
+	assert.Equal(t, "Pasar", responseBody.Data.Stops[1].Name)
+	assert.Equal(t, 2, responseBody.Data.Stops[1].Sequence)
+	assert.InDelta(t, 1.11, responseBody.Data.Stops[1].DistanceFromPrevious, 0.01)
+	assert.InDelta(t, 2.22, *responseBody.Data.Distance, 0.02)
+}
+
 func TestCreateRouteDuplicateName(t *testing.T) {
 	defer ClearAll()
 
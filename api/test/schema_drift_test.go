@@ -0,0 +1,17 @@
+package test
+
+import (
+	"api/internal/migration"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaDrift(t *testing.T) {
+	drifts, err := migration.NewDriftChecker(db, log).Check()
+	assert.Nil(t, err)
+
+	for _, drift := range drifts {
+		t.Errorf("schema drift %s", drift)
+	}
+}
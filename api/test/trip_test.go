@@ -0,0 +1,217 @@
+package test
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/enum"
+	"api/internal/entity/money"
+	"api/internal/model"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createTripRequest(t *testing.T, token string, requestBody model.CreateTripRequest) (*http.Response, *model.WebResponse[model.TripResponse]) {
+	bodyJson, err := json.Marshal(requestBody)
+	assert.Nil(t, err)
+
+	request := httptest.NewRequest(http.MethodPost, "/api/trips", strings.NewReader(string(bodyJson)))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := app.Test(request)
+	assert.Nil(t, err)
+
+	bytes, err := io.ReadAll(response.Body)
+	assert.Nil(t, err)
+
+	responseBody := new(model.WebResponse[model.TripResponse])
+	err = json.Unmarshal(bytes, responseBody)
+	assert.Nil(t, err)
+
+	return response, responseBody
+}
+
+func TestCreateTrip(t *testing.T) {
+	defer ClearAll()
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	driver := CreateEmployees(1, enum.DRIVER)[0]
+	CreateDriverLicense(driver.ID, enum.LICENSE_B1)
+	helpers := CreateEmployees(2, enum.HELPER)
+	vehicle := CreateVehicle(enum.TRUCK)
+	routes := CreateRoutes(2)
+
+	response, responseBody := createTripRequest(t, token, model.CreateTripRequest{
+		Date:           "2031-05-06",
+		VehicleId:      vehicle.ID,
+		DriverId:       driver.ID,
+		HelperIds:      []int{helpers[0].ID, helpers[1].ID},
+		RouteIds:       []int{routes[0].ID, routes[1].ID},
+		SacksLoaded:    100,
+		SacksReturned:  10,
+		Income:         money.New(450000),
+		MarkAttendance: true,
+	})
+
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+	assert.NotZero(t, responseBody.Data.ID)
+	assert.NotNil(t, responseBody.Data.VehicleHistoryId)
+
+	// the income is posted to the vehicle with the delivered sacks
+	history := new(entity.VehicleHistory)
+	assert.Nil(t, db.First(history, *responseBody.Data.VehicleHistoryId).Error)
+	assert.Equal(t, enum.INCOME, history.Type)
+	assert.Equal(t, money.New(450000), history.Amount)
+	assert.Equal(t, 90, *history.Sack)
+
+	// the whole crew is marked present on the trip date
+	var attendances []entity.EmployeeAttendance
+	assert.Nil(t, db.Where("date = ?", "2031-05-06").Find(&attendances).Error)
+	assert.Len(t, attendances, 3)
+	for _, attendance := range attendances {
+		assert.Equal(t, enum.PRESENT, attendance.Status)
+	}
+}
+
+func TestCreateTripWrongCrewRole(t *testing.T) {
+	defer ClearAll()
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	driver := CreateEmployees(1, enum.DRIVER)[0]
+	CreateDriverLicense(driver.ID, enum.LICENSE_B1)
+	helper := CreateEmployees(1, enum.HELPER)[0]
+	vehicle := CreateVehicle(enum.TRUCK)
+	routes := CreateRoutes(1)
+
+	// a helper cannot drive
+	response, _ := createTripRequest(t, token, model.CreateTripRequest{
+		Date:      "2031-05-06",
+		VehicleId: vehicle.ID,
+		DriverId:  helper.ID,
+		RouteIds:  []int{routes[0].ID},
+	})
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	// nor can the driver be their own helper
+	response, _ = createTripRequest(t, token, model.CreateTripRequest{
+		Date:      "2031-05-06",
+		VehicleId: vehicle.ID,
+		DriverId:  driver.ID,
+		HelperIds: []int{driver.ID},
+		RouteIds:  []int{routes[0].ID},
+	})
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	var count int64
+	assert.Nil(t, db.Model(&entity.Trip{}).Count(&count).Error)
+	assert.Zero(t, count)
+}
+
+func TestCreateTripWithEndedCrew(t *testing.T) {
+	defer ClearAll()
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	drivers := CreateEmployees(2, enum.DRIVER)
+	helper := CreateEmployees(1, enum.HELPER)[0]
+	vehicle := CreateVehicle(enum.TRUCK)
+	routes := CreateRoutes(1)
+	for _, driver := range drivers {
+		CreateDriverLicense(driver.ID, enum.LICENSE_B1)
+	}
+
+	assert.Nil(t, db.Model(&entity.Employee{}).Where("id = ?", drivers[1].ID).Update("status", enum.EMPLOYEE_RESIGNED).Error)
+	assert.Nil(t, db.Model(&entity.Employee{}).Where("id = ?", helper.ID).Update("status", enum.EMPLOYEE_TERMINATED).Error)
+
+	// a driver who resigned cannot be dispatched
+	response, _ := createTripRequest(t, token, model.CreateTripRequest{
+		Date:      "2031-05-06",
+		VehicleId: vehicle.ID,
+		DriverId:  drivers[1].ID,
+		RouteIds:  []int{routes[0].ID},
+	})
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	// nor can a terminated helper ride along
+	response, _ = createTripRequest(t, token, model.CreateTripRequest{
+		Date:      "2031-05-06",
+		VehicleId: vehicle.ID,
+		DriverId:  drivers[0].ID,
+		HelperIds: []int{helper.ID},
+		RouteIds:  []int{routes[0].ID},
+	})
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	var count int64
+	assert.Nil(t, db.Model(&entity.Trip{}).Count(&count).Error)
+	assert.Zero(t, count)
+}
+
+func TestCreateTripSacksReturnedAboveLoaded(t *testing.T) {
+	defer ClearAll()
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	driver := CreateEmployees(1, enum.DRIVER)[0]
+	CreateDriverLicense(driver.ID, enum.LICENSE_B1)
+	vehicle := CreateVehicle(enum.TRUCK)
+	routes := CreateRoutes(1)
+
+	response, _ := createTripRequest(t, token, model.CreateTripRequest{
+		Date:          "2031-05-06",
+		VehicleId:     vehicle.ID,
+		DriverId:      driver.ID,
+		RouteIds:      []int{routes[0].ID},
+		SacksLoaded:   10,
+		SacksReturned: 11,
+	})
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+}
+
+func TestDeleteTrip(t *testing.T) {
+	defer ClearAll()
+
+	token, err := GenerateTokenHelper()
+	assert.Nil(t, err)
+
+	driver := CreateEmployees(1, enum.DRIVER)[0]
+	CreateDriverLicense(driver.ID, enum.LICENSE_B1)
+	vehicle := CreateVehicle(enum.TRUCK)
+	routes := CreateRoutes(1)
+
+	response, responseBody := createTripRequest(t, token, model.CreateTripRequest{
+		Date:        "2031-05-06",
+		VehicleId:   vehicle.ID,
+		DriverId:    driver.ID,
+		RouteIds:    []int{routes[0].ID},
+		SacksLoaded: 50,
+		Income:      money.New(200000),
+	})
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+	request := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/trips/%d", responseBody.Data.ID), nil)
+	request.Header.Set("Authorization", token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err = app.Test(request)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	// the posted income goes with the trip
+	var count int64
+	assert.Nil(t, db.Model(&entity.VehicleHistory{}).Where("vehicle_id = ?", vehicle.ID).Count(&count).Error)
+	assert.Zero(t, count)
+}
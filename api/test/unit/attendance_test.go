@@ -0,0 +1,89 @@
+package unit
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/enum"
+	"api/internal/model"
+	"api/internal/repository/memory"
+	"api/internal/usecase"
+	"api/internal/utils"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newEmployeeAttendanceUseCase(store *memory.Store) usecase.EmployeeAttendanceUseCase {
+	return usecase.NewEmployeeAttendanceUseCase(memory.NewTransactor(store), log, validate, memory.NewEmployeeAttendanceRepository(store), memory.NewEmployeeRepository(store), memory.NewGeofenceRepository(store), newPeriodUseCase(store))
+}
+
+func TestHaversineDistance(t *testing.T) {
+	assert.Equal(t, 0.0, utils.HaversineDistance(-7.25, 112.75, -7.25, 112.75))
+
+	// one degree of latitude is about 111.2 km anywhere on earth
+	assert.InDelta(t, 111195, utils.HaversineDistance(0, 110, 1, 110), 1)
+	assert.InDelta(t, 111195, utils.HaversineDistance(-8, 112, -7, 112), 1)
+
+	// one degree of longitude shrinks with the cosine of the latitude
+	assert.InDelta(t, 111195, utils.HaversineDistance(0, 110, 0, 111), 1)
+	assert.InDelta(t, 55597, utils.HaversineDistance(60, 10, 60, 11), 50)
+
+	// the distance is the same both ways
+	assert.Equal(t, utils.HaversineDistance(-7.2575, 112.7521, -6.2088, 106.8456), utils.HaversineDistance(-6.2088, 106.8456, -7.2575, 112.7521))
+}
+
+func TestCheckInGeofence(t *testing.T) {
+	store := memory.NewStore()
+	attendanceUseCase := newEmployeeAttendanceUseCase(store)
+
+	employee := &entity.Employee{Name: "Andi", Role: enum.STAFF, JoinDate: time.Now(), Status: enum.EMPLOYEE_ACTIVE}
+	closed := &entity.Geofence{Name: "Kantor lama", Latitude: -7.3000000, Longitude: 112.7500000, Radius: 500}
+	store.Insert(
+		employee,
+		&entity.Geofence{Name: "Gudang", Latitude: -7.2500000, Longitude: 112.7500000, Radius: 100, IsActive: true},
+		&entity.Geofence{Name: "Pabrik", Latitude: -7.2504000, Longitude: 112.7500000, Radius: 100, IsActive: true},
+		closed,
+	)
+	assert.Nil(t, memory.NewGeofenceRepository(store).Update(nil, closed.ID, map[string]any{"is_active": false}))
+
+	// about 220 m from the warehouse, outside every radius
+	_, err := attendanceUseCase.CheckIn(context.Background(), &model.CheckInRequest{
+		EmployeeId: employee.ID,
+		Latitude:   -7.2480000,
+		Longitude:  112.7500000,
+	})
+	assert.Equal(t, http.StatusBadRequest, StatusOf(err))
+
+	// inactive areas are ignored
+	_, err = attendanceUseCase.CheckIn(context.Background(), &model.CheckInRequest{
+		EmployeeId: employee.ID,
+		Latitude:   -7.3000000,
+		Longitude:  112.7500000,
+	})
+	assert.Equal(t, http.StatusBadRequest, StatusOf(err))
+
+	// inside both areas, the nearest one is recorded
+	response, err := attendanceUseCase.CheckIn(context.Background(), &model.CheckInRequest{
+		EmployeeId: employee.ID,
+		Latitude:   -7.2503000,
+		Longitude:  112.7500000,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "Pabrik", response.GeofenceName)
+	assert.InDelta(t, 11, response.Distance, 1)
+	assert.Equal(t, string(enum.PRESENT), response.Status)
+}
+
+func TestCheckInUnknownEmployee(t *testing.T) {
+	store := memory.NewStore()
+	attendanceUseCase := newEmployeeAttendanceUseCase(store)
+
+	_, err := attendanceUseCase.CheckIn(context.Background(), &model.CheckInRequest{
+		EmployeeId: 99,
+		Latitude:   -7.25,
+		Longitude:  112.75,
+	})
+	assert.Equal(t, http.StatusNotFound, StatusOf(err))
+}
@@ -0,0 +1,106 @@
+package unit
+
+import (
+	"api/internal/delivery/http/middleware"
+	"api/internal/entity"
+	"api/internal/entity/enum"
+	"api/internal/model"
+	"api/internal/repository/memory"
+	"api/internal/usecase"
+	"api/internal/utils"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenWithoutRoleGetsCurrentRole(t *testing.T) {
+	store := memory.NewStore()
+	sessions := utils.NewMemorySessionStore()
+	tokenUtil := utils.NewTokenUtil("secret", sessions)
+	userUseCase := usecase.NewUserUseCase(memory.NewTransactor(store), log, validate, memory.NewUserRepository(store), memory.NewEmployeeRepository(store), tokenUtil)
+
+	user := &entity.User{ID: uuid.New(), Name: "Andi", Username: "andi", Phone: "08123456789", Role: enum.OWNER}
+	store.Insert(user)
+
+	// issued before roles were embedded in the token
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"id":     user.ID.String(),
+		"expire": time.Now().Add(time.Hour).UnixMilli(),
+	}).SignedString([]byte("secret"))
+	assert.Nil(t, err)
+	assert.Nil(t, sessions.Save(context.Background(), token, user.ID, time.Hour))
+
+	parsed, err := tokenUtil.ParseToken(context.Background(), token)
+	assert.Nil(t, err)
+	assert.Equal(t, user.ID, parsed.ID)
+	assert.Empty(t, parsed.Role)
+
+	app := fiber.New()
+	app.Use(middleware.NewAuth(userUseCase, tokenUtil, log))
+	app.Get("/role", func(ctx *fiber.Ctx) error {
+		return ctx.SendString(string(middleware.GetUser(ctx).Role))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/role", nil)
+	req.Header.Set("Authorization", token)
+	res, err := app.Test(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	body, err := io.ReadAll(res.Body)
+	assert.Nil(t, err)
+	assert.Equal(t, string(enum.OWNER), string(body))
+}
+
+func TestAuthUsesCurrentRole(t *testing.T) {
+	store := memory.NewStore()
+	tokenUtil := utils.NewTokenUtil("secret", utils.NewMemorySessionStore())
+	userUseCase := usecase.NewUserUseCase(memory.NewTransactor(store), log, validate, memory.NewUserRepository(store), memory.NewEmployeeRepository(store), tokenUtil)
+
+	employee := &entity.Employee{Name: "Andi", Role: enum.STAFF, JoinDate: time.Now(), Status: enum.EMPLOYEE_ACTIVE}
+	user := &entity.User{ID: uuid.New(), Name: "Andi", Username: "andi", Phone: "08123456789", Role: enum.OWNER}
+	store.Insert(employee, user)
+
+	app := fiber.New()
+	app.Use(middleware.NewAuth(userUseCase, tokenUtil, log))
+	app.Use(middleware.NewDenyRoles(log, enum.USER_EMPLOYEE))
+	app.Get("/staff", func(ctx *fiber.Ctx) error {
+		return ctx.SendStatus(http.StatusOK)
+	})
+
+	token, err := tokenUtil.CreateToken(context.Background(), &model.Auth{ID: user.ID, Role: user.Role})
+	assert.Nil(t, err)
+
+	request := func() int {
+		req := httptest.NewRequest(http.MethodGet, "/staff", nil)
+		req.Header.Set("Authorization", token)
+		res, err := app.Test(req)
+		assert.Nil(t, err)
+		return res.StatusCode
+	}
+	assert.Equal(t, http.StatusOK, request())
+
+	// the token still says OWNER, the demotion applies right away
+	_, err = userUseCase.Update(context.Background(), &model.UpdateUserRequest{
+		ID:         user.ID,
+		Name:       user.Name,
+		Username:   user.Username,
+		Phone:      user.Phone,
+		Role:       enum.USER_EMPLOYEE,
+		EmployeeId: &employee.ID,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusForbidden, request())
+
+	// a deleted user is logged out
+	assert.Nil(t, userUseCase.Delete(context.Background(), &model.DeleteUserRequest{ID: user.ID}))
+	assert.Equal(t, http.StatusUnauthorized, request())
+}
@@ -0,0 +1,68 @@
+package unit
+
+import (
+	"api/internal/entity/enum"
+	"api/internal/entity/money"
+	"api/internal/model"
+	"api/internal/repository/memory"
+	"api/internal/usecase"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCashAdvanceRepayments(t *testing.T) {
+	store := memory.NewStore()
+	employeeUseCase := newEmployeeUseCase(store)
+	cashAdvanceUseCase := usecase.NewCashAdvanceUseCase(memory.NewTransactor(store), log, validate, memory.NewCashAdvanceRepository(store), memory.NewCashAdvanceRepaymentRepository(store), memory.NewEmployeeRepository(store))
+
+	employee, err := employeeUseCase.Create(context.Background(), &model.CreateEmployeeRequest{
+		Name:   "Andi",
+		Salary: money.New(100000),
+		Role:   enum.STAFF,
+	})
+	assert.Nil(t, err)
+
+	advance, err := cashAdvanceUseCase.Create(context.Background(), &model.CreateCashAdvanceRequest{
+		EmployeeId:  employee.ID,
+		Date:        "2031-03-02",
+		Amount:      money.New(300000),
+		Installment: money.New(100000),
+	})
+	assert.Nil(t, err)
+
+	// a repayment can not exceed what is still owed
+	_, err = cashAdvanceUseCase.CreateRepayment(context.Background(), &model.CreateCashAdvanceRepaymentRequest{
+		CashAdvanceId: advance.ID,
+		Date:          "2031-03-09",
+		Amount:        money.New(400000),
+	})
+	assert.Equal(t, http.StatusBadRequest, StatusOf(err))
+
+	_, err = cashAdvanceUseCase.CreateRepayment(context.Background(), &model.CreateCashAdvanceRepaymentRequest{
+		CashAdvanceId: advance.ID,
+		Date:          "2031-03-09",
+		Amount:        money.New(100000),
+	})
+	assert.Nil(t, err)
+
+	advances, total, err := cashAdvanceUseCase.FindAll(context.Background(), &model.FindAllCashAdvanceRequest{OnlyOutstanding: true})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, money.New(200000), advances[0].Outstanding)
+
+	_, err = cashAdvanceUseCase.CreateRepayment(context.Background(), &model.CreateCashAdvanceRepaymentRequest{
+		CashAdvanceId: advance.ID,
+		Date:          "2031-03-16",
+		Amount:        money.New(200000),
+	})
+	assert.Nil(t, err)
+
+	// a settled advance is no longer outstanding
+	advances, total, err = cashAdvanceUseCase.FindAll(context.Background(), &model.FindAllCashAdvanceRequest{OnlyOutstanding: true})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), total)
+	assert.Empty(t, advances)
+}
@@ -0,0 +1,114 @@
+package unit
+
+import (
+	"api/internal/config"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func newConfig(t *testing.T, content string) *viper.Viper {
+	v := viper.New()
+	v.SetConfigType("json")
+	assert.Nil(t, v.ReadConfig(strings.NewReader(content)))
+	assert.Nil(t, config.ApplyEnv(v))
+	return v
+}
+
+func TestConfigEnvOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secret")
+	assert.Nil(t, os.WriteFile(path, []byte("a-secret-key-read-from-a-file-0123456789\n"), 0o600))
+
+	t.Setenv("API_DATABASE_HOST", "db.internal")
+	t.Setenv("API_DATABASE_POOL_MAX", "5")
+	t.Setenv("API_SECRET_KEY_FILE", path)
+	t.Setenv("API_DATABASE_PASSWORD", "a-generated-database-password")
+
+	v := newConfig(t, `{"database": {"host": "localhost", "password": "P@ssw0rd", "pool": {"max": 100}}}`)
+
+	assert.Equal(t, "db.internal", v.GetString("database.host"))
+	assert.Equal(t, 5, v.GetInt("database.pool.max"))
+	assert.Equal(t, "a-secret-key-read-from-a-file-0123456789", v.GetString("secret_key"))
+	assert.Nil(t, config.ValidateSecrets(v, false))
+}
+
+func TestConfigMissingSecretFile(t *testing.T) {
+	t.Setenv("API_SECRET_KEY_FILE", filepath.Join(t.TempDir(), "missing"))
+
+	v := viper.New()
+	v.SetConfigType("json")
+	assert.Nil(t, v.ReadConfig(strings.NewReader(`{}`)))
+	assert.NotNil(t, config.ApplyEnv(v))
+}
+
+func TestValidateSecrets(t *testing.T) {
+	// the key nested under redis is not the signing key
+	v := newConfig(t, `{"redis": {"secret_key": "sumber_rezeki"}, "database": {"password": "P@ssw0rd"}}`)
+	assert.ErrorContains(t, config.ValidateSecrets(v, false), "secret_key is missing")
+
+	t.Setenv("API_SECRET_KEY", "short")
+	v = newConfig(t, `{}`)
+	assert.ErrorContains(t, config.ValidateSecrets(v, false), "at least 32 characters")
+	assert.ErrorContains(t, config.ValidateSecrets(v, false), "database.password is missing")
+	assert.ErrorContains(t, config.ValidateSecrets(v, true), "testing.database.password is missing")
+}
+
+func TestValidateSecretsFromEnvOrFile(t *testing.T) {
+	// secrets kept in the config file are accepted
+	v := newConfig(t, `{"secret_key": "a-long-secret-key-kept-in-the-config-file", "database": {"password": "a-generated-database-password"}}`)
+	assert.Nil(t, config.ValidateSecrets(v, false))
+
+	// the environment wins over the config file and the _FILE variable
+	path := filepath.Join(t.TempDir(), "secret")
+	assert.Nil(t, os.WriteFile(path, []byte("a-secret-key-read-from-a-file-0123456789\n"), 0o600))
+	t.Setenv("API_SECRET_KEY_FILE", path)
+	t.Setenv("API_SECRET_KEY", "a-generated-secret-key-0123456789abcdef")
+	v = newConfig(t, `{"secret_key": "a-long-secret-key-kept-in-the-config-file"}`)
+	assert.Equal(t, "a-generated-secret-key-0123456789abcdef", v.GetString("secret_key"))
+
+	// a known sample value is refused wherever it comes from
+	t.Setenv("API_SECRET_KEY", "sumber-rezeki-development-secret-key")
+	v = newConfig(t, `{"database": {"password": "P@ssw0rd"}}`)
+	assert.ErrorContains(t, config.ValidateSecrets(v, false), "secret_key is a known default value")
+	assert.ErrorContains(t, config.ValidateSecrets(v, false), "database.password is a known default value")
+}
+
+func TestNewViperWithoutConfigFile(t *testing.T) {
+	wd, err := os.Getwd()
+	assert.Nil(t, err)
+	dir := filepath.Join(t.TempDir(), "app", "cmd")
+	assert.Nil(t, os.MkdirAll(dir, 0o700))
+	assert.Nil(t, os.Chdir(dir))
+	defer os.Chdir(wd)
+
+	t.Setenv("API_SECRET_KEY", "a-generated-secret-key-0123456789abcdef")
+
+	// every key comes from the environment and the defaults
+	v := config.NewViper()
+	assert.Equal(t, "a-generated-secret-key-0123456789abcdef", v.GetString("secret_key"))
+	assert.Equal(t, "WEEKLY", v.GetString("period.type"))
+}
+
+func TestRedactedConfig(t *testing.T) {
+	t.Setenv("API_SECRET_KEY", "a-generated-secret-key-0123456789abcdef")
+	t.Setenv("API_REDIS_HOST", "redis:6379")
+
+	v := newConfig(t, `{"database": {"host": "localhost", "password": "P@ssw0rd"}, "testing": {"database": {"password": ""}}}`)
+	settings := config.Redacted(v)
+
+	database := settings["database"].(map[string]any)
+	assert.Equal(t, "localhost", database["host"])
+	assert.Equal(t, "******", database["password"])
+	assert.Equal(t, "******", settings["secret_key"])
+
+	// an override of a key missing from the config file is shown
+	assert.Equal(t, "redis:6379", settings["redis"].(map[string]any)["host"])
+
+	// a missing secret stays visible
+	testDatabase := settings["testing"].(map[string]any)["database"].(map[string]any)
+	assert.Equal(t, "", testDatabase["password"])
+}
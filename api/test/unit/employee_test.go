@@ -0,0 +1,183 @@
+package unit
+
+import (
+	"api/internal/entity/enum"
+	"api/internal/entity/money"
+	"api/internal/model"
+	"api/internal/repository/memory"
+	"api/internal/usecase"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newEmployeeUseCase(store *memory.Store) usecase.EmployeeUseCase {
+	return usecase.NewEmployeeUseCase(memory.NewTransactor(store), log, validate, memory.NewEmployeeRepository(store), memory.NewRouteRepository(store), memory.NewSalesRepository(store), memory.NewEmployeeStatusHistoryRepository(store))
+}
+
+func TestCreateSalesEmployeeWithUnknownRoute(t *testing.T) {
+	store := memory.NewStore()
+	employeeUseCase := newEmployeeUseCase(store)
+
+	_, err := employeeUseCase.Create(context.Background(), &model.CreateEmployeeRequest{
+		Name:     "Budi",
+		Salary:   money.New(100000),
+		Role:     enum.SALES,
+		Phone:    "08123456789",
+		RouteIDs: []int{99},
+	})
+	assert.Equal(t, http.StatusNotFound, StatusOf(err))
+
+	// the employee saved before the route check is rolled back
+	employees, total, err := employeeUseCase.FindAll(context.Background(), &model.FindAllEmployeeRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), total)
+	assert.Empty(t, employees)
+}
+
+func TestTerminateAndRehireEmployee(t *testing.T) {
+	store := memory.NewStore()
+	employeeUseCase := newEmployeeUseCase(store)
+
+	employee, err := employeeUseCase.Create(context.Background(), &model.CreateEmployeeRequest{
+		Name:   "Andi",
+		Salary: money.New(100000),
+		Role:   enum.STAFF,
+	})
+	assert.Nil(t, err)
+
+	today := time.Now().Format("2006-01-02")
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+	reason := "Kontrak selesai"
+
+	_, err = employeeUseCase.ChangeStatus(context.Background(), &model.ChangeEmployeeStatusRequest{
+		ID:            employee.ID,
+		Status:        enum.EMPLOYEE_TERMINATED,
+		EffectiveDate: today,
+		Reason:        &reason,
+	})
+	assert.Nil(t, err)
+
+	// an ended employee can only be rehired, and not on the last working day
+	_, err = employeeUseCase.ChangeStatus(context.Background(), &model.ChangeEmployeeStatusRequest{
+		ID:            employee.ID,
+		Status:        enum.EMPLOYEE_ON_LEAVE,
+		EffectiveDate: tomorrow,
+	})
+	assert.Equal(t, http.StatusBadRequest, StatusOf(err))
+
+	_, err = employeeUseCase.ChangeStatus(context.Background(), &model.ChangeEmployeeStatusRequest{
+		ID:            employee.ID,
+		Status:        enum.EMPLOYEE_ACTIVE,
+		EffectiveDate: today,
+	})
+	assert.Equal(t, http.StatusBadRequest, StatusOf(err))
+
+	_, err = employeeUseCase.ChangeStatus(context.Background(), &model.ChangeEmployeeStatusRequest{
+		ID:            employee.ID,
+		Status:        enum.EMPLOYEE_ACTIVE,
+		EffectiveDate: tomorrow,
+	})
+	assert.Nil(t, err)
+
+	histories, err := employeeUseCase.FindStatusHistory(context.Background(), &model.FindEmployeeStatusHistoryRequest{ID: employee.ID})
+	assert.Nil(t, err)
+	assert.Len(t, histories, 3)
+	assert.Equal(t, string(enum.EMPLOYEE_ACTIVE), histories[0].Status)
+	assert.Equal(t, string(enum.EMPLOYEE_TERMINATED), histories[1].Status)
+	assert.Equal(t, string(enum.EMPLOYEE_ACTIVE), histories[2].Status)
+
+	// the rehire starts tomorrow, today the employee is still terminated
+	found, err := employeeUseCase.FindById(context.Background(), &model.FindByIdEmployeeRequest{ID: employee.ID})
+	assert.Nil(t, err)
+	assert.Equal(t, string(enum.EMPLOYEE_TERMINATED), found.Status)
+}
+
+func TestFutureResignation(t *testing.T) {
+	store := memory.NewStore()
+	employeeUseCase := newEmployeeUseCase(store)
+
+	employee, err := employeeUseCase.Create(context.Background(), &model.CreateEmployeeRequest{
+		Name:   "Andi",
+		Salary: money.New(100000),
+		Role:   enum.STAFF,
+	})
+	assert.Nil(t, err)
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	resignDate := today.AddDate(0, 0, 30)
+	reason := "Pindah kota"
+
+	_, err = employeeUseCase.ChangeStatus(context.Background(), &model.ChangeEmployeeStatusRequest{
+		ID:            employee.ID,
+		Status:        enum.EMPLOYEE_RESIGNED,
+		EffectiveDate: resignDate.Format("2006-01-02"),
+		Reason:        &reason,
+	})
+	assert.Nil(t, err)
+
+	// the employee works until the resignation date
+	found, err := employeeUseCase.FindById(context.Background(), &model.FindByIdEmployeeRequest{ID: employee.ID})
+	assert.Nil(t, err)
+	assert.Equal(t, string(enum.EMPLOYEE_ACTIVE), found.Status)
+
+	histories, err := employeeUseCase.FindStatusHistory(context.Background(), &model.FindEmployeeStatusHistoryRequest{ID: employee.ID})
+	assert.Nil(t, err)
+	assert.Len(t, histories, 2)
+	assert.Equal(t, string(enum.EMPLOYEE_RESIGNED), histories[1].Status)
+
+	// the scheduled resignation is the status the next change follows
+	_, err = employeeUseCase.ChangeStatus(context.Background(), &model.ChangeEmployeeStatusRequest{
+		ID:            employee.ID,
+		Status:        enum.EMPLOYEE_RESIGNED,
+		EffectiveDate: resignDate.AddDate(0, 0, 1).Format("2006-01-02"),
+		Reason:        &reason,
+	})
+	assert.Equal(t, http.StatusBadRequest, StatusOf(err))
+
+	// periods read the resignation from the history by date
+	employeeRepository := memory.NewEmployeeRepository(store)
+	employed, err := employeeRepository.FindAllEmployedBetween(nil, today, today.AddDate(0, 0, 6))
+	assert.Nil(t, err)
+	assert.Len(t, employed, 1)
+
+	employed, err = employeeRepository.FindAllEmployedBetween(nil, resignDate.AddDate(0, 0, 1), resignDate.AddDate(0, 0, 7))
+	assert.Nil(t, err)
+	assert.Empty(t, employed)
+}
+
+func TestSupervisorIgnoredForRoleWithoutSupervisors(t *testing.T) {
+	store := memory.NewStore()
+	employeeUseCase := newEmployeeUseCase(store)
+
+	staff, err := employeeUseCase.Create(context.Background(), &model.CreateEmployeeRequest{
+		Name:   "Andi",
+		Salary: money.New(100000),
+		Role:   enum.STAFF,
+	})
+	assert.Nil(t, err)
+
+	// a warehouse head reports to nobody, the supervisor sent is left out
+	head, err := employeeUseCase.Create(context.Background(), &model.CreateEmployeeRequest{
+		Name:         "Budi",
+		Salary:       money.New(150000),
+		Role:         enum.EMPLOYEE_WAREHOUSE_HEAD,
+		SupervisorId: staff.ID,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, head.SupervisorId)
+
+	updated, err := employeeUseCase.Update(context.Background(), &model.UpdateEmployeeRequest{
+		ID:           head.ID,
+		Name:         head.Name,
+		Salary:       money.New(150000),
+		Role:         enum.EMPLOYEE_WAREHOUSE_HEAD,
+		SupervisorId: staff.ID,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, updated.SupervisorId)
+}
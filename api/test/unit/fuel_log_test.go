@@ -0,0 +1,59 @@
+package unit
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/enum"
+	"api/internal/entity/money"
+	"api/internal/model"
+	"api/internal/repository/memory"
+	"api/internal/usecase"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newFuelLogUseCase(store *memory.Store) usecase.FuelLogUseCase {
+	return usecase.NewFuelLogUseCase(memory.NewTransactor(store), log, validate, memory.NewFuelLogRepository(store), memory.NewVehicleRepository(store), memory.NewVehicleHistoryRepository(store), memory.NewEmployeeRepository(store))
+}
+
+func TestCreateFuelLogPostsExpense(t *testing.T) {
+	store := memory.NewStore()
+	fuelLogUseCase := newFuelLogUseCase(store)
+
+	vehicle := &entity.Vehicle{Plate: "L 1234 AB", Type: enum.TRUCK}
+	store.Insert(vehicle)
+
+	fuelLog, err := fuelLogUseCase.Create(context.Background(), &model.CreateFuelLogRequest{
+		VehicleId:     vehicle.ID,
+		Date:          "2031-03-04",
+		Liters:        40.5,
+		PricePerLiter: money.New(10000),
+		Odometer:      120000,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, money.New(405000), fuelLog.TotalCost)
+
+	// the cost is posted with the value of the VehicleHistoryType database enum
+	histories := store.VehicleHistories(vehicle.ID)
+	assert.Len(t, histories, 1)
+	assert.Equal(t, enum.VehicleHistoryType("EXPENSE"), histories[0].Type)
+	assert.Equal(t, money.New(405000), histories[0].Amount)
+	assert.Equal(t, "BBM 40.50 liter", histories[0].Description)
+
+	// a fill-up out of odometer order posts nothing
+	_, err = fuelLogUseCase.Create(context.Background(), &model.CreateFuelLogRequest{
+		VehicleId:     vehicle.ID,
+		Date:          "2031-03-05",
+		Liters:        30,
+		PricePerLiter: money.New(10000),
+		Odometer:      119000,
+	})
+	assert.Equal(t, http.StatusBadRequest, StatusOf(err))
+	assert.Len(t, store.VehicleHistories(vehicle.ID), 1)
+
+	// deleting the fill-up reverses the posting
+	assert.Nil(t, fuelLogUseCase.Delete(context.Background(), &model.DeleteFuelLogRequest{VehicleId: vehicle.ID, ID: fuelLog.ID}))
+	assert.Empty(t, store.VehicleHistories(vehicle.ID))
+}
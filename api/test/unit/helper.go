@@ -0,0 +1,44 @@
+package unit
+
+import (
+	"api/internal/model"
+	"api/internal/utils"
+	"errors"
+	"io"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// The unit tests run the use cases on the memory repositories, they need no
+// database or redis and every test starts from its own empty store.
+
+var log = newLogger()
+var validate = validator.New()
+
+func init() {
+	// register the custom tags used by utils.ValidateStruct
+	utils.InitValidator()
+}
+
+func newLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return logger
+}
+
+// StatusOf returns the http status a use-case error is answered with
+func StatusOf(err error) int {
+	var fiberError *fiber.Error
+	if errors.As(err, &fiberError) {
+		return fiberError.Code
+	}
+
+	var errorResponse *model.ErrorResponse
+	if errors.As(err, &errorResponse) {
+		return errorResponse.Code
+	}
+
+	return 0
+}
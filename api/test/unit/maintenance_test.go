@@ -0,0 +1,67 @@
+package unit
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/enum"
+	"api/internal/entity/money"
+	"api/internal/model"
+	"api/internal/repository/memory"
+	"api/internal/usecase"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newMaintenanceUseCase(store *memory.Store) usecase.MaintenanceUseCase {
+	return usecase.NewMaintenanceUseCase(memory.NewTransactor(store), log, validate, memory.NewVehicleRepository(store), memory.NewVehicleHistoryRepository(store), memory.NewMaintenancePlanRepository(store), memory.NewServiceRecordRepository(store), memory.NewFuelLogRepository(store))
+}
+
+func TestCreateServicePostsExpense(t *testing.T) {
+	store := memory.NewStore()
+	maintenanceUseCase := newMaintenanceUseCase(store)
+
+	vehicle := &entity.Vehicle{Plate: "L 1234 AB", Type: enum.TRUCK}
+	store.Insert(vehicle)
+
+	workshop := "Bengkel Jaya"
+	record, err := maintenanceUseCase.CreateService(context.Background(), &model.CreateServiceRecordRequest{
+		VehicleId:   vehicle.ID,
+		Date:        "2031-03-04",
+		Odometer:    120000,
+		ServiceType: "Ganti oli",
+		Workshop:    &workshop,
+		Cost:        money.New(350000),
+	})
+	assert.Nil(t, err)
+	assert.NotNil(t, record.VehicleHistoryId)
+
+	// the cost is posted with the value of the VehicleHistoryType database enum
+	histories := store.VehicleHistories(vehicle.ID)
+	assert.Len(t, histories, 1)
+	assert.Equal(t, *record.VehicleHistoryId, histories[0].ID)
+	assert.Equal(t, enum.VehicleHistoryType("EXPENSE"), histories[0].Type)
+	assert.Equal(t, money.New(350000), histories[0].Amount)
+	assert.Equal(t, "Servis Ganti oli - Bengkel Jaya", histories[0].Description)
+	assert.Equal(t, time.Date(2031, time.March, 4, 0, 0, 0, 0, time.UTC), histories[0].Date)
+
+	// deleting the service reverses the posting
+	assert.Nil(t, maintenanceUseCase.DeleteService(context.Background(), &model.DeleteServiceRecordRequest{VehicleId: vehicle.ID, ID: record.ID}))
+	assert.Empty(t, store.VehicleHistories(vehicle.ID))
+}
+
+func TestCreateServiceUnknownVehicle(t *testing.T) {
+	store := memory.NewStore()
+	maintenanceUseCase := newMaintenanceUseCase(store)
+
+	_, err := maintenanceUseCase.CreateService(context.Background(), &model.CreateServiceRecordRequest{
+		VehicleId:   99,
+		Date:        "2031-03-04",
+		ServiceType: "Ganti oli",
+		Cost:        money.New(350000),
+	})
+	assert.Equal(t, http.StatusNotFound, StatusOf(err))
+	assert.Empty(t, store.VehicleHistories(99))
+}
@@ -0,0 +1,349 @@
+package unit
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/enum"
+	"api/internal/entity/money"
+	"api/internal/model"
+	"api/internal/repository/memory"
+	"api/internal/usecase"
+	"api/internal/utils"
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPayrollUseCase(store *memory.Store) usecase.PayrollUseCase {
+	return usecase.NewPayrollUseCase(memory.NewTransactor(store), log, validate, memory.NewPayrollRepository(store), memory.NewPayRuleRepository(store), memory.NewPeriodRepository(store), memory.NewEmployeeRepository(store), memory.NewEmployeeAttendanceRepository(store), memory.NewTripRepository(store), memory.NewCashAdvanceRepository(store), memory.NewCashAdvanceRepaymentRepository(store), memory.NewPeriodClosureRepository(store))
+}
+
+// newPayslipFixture stores an employee with a payroll in a March 2031 week
+func newPayslipFixture(store *memory.Store) (*entity.Employee, *entity.Period, *entity.Payroll) {
+	employee := &entity.Employee{Name: "Andi", Role: enum.DRIVER, JoinDate: time.Now(), Status: enum.EMPLOYEE_ACTIVE}
+	period := &entity.Period{
+		Type:       enum.WEEKLY,
+		StartDate:  time.Date(2031, time.March, 2, 0, 0, 0, 0, time.UTC),
+		EndDate:    time.Date(2031, time.March, 8, 0, 0, 0, 0, time.UTC),
+		WeekNumber: 1,
+		Month:      3,
+		Year:       2031,
+		IsActive:   true,
+	}
+	store.Insert(employee, period)
+
+	payroll := &entity.Payroll{
+		EmployeeId:     employee.ID,
+		PeriodID:       period.ID,
+		ModuleType:     enum.OPERATIONAL,
+		BaseSalary:     money.New(600000),
+		AttendanceDays: 6,
+		Bonuses:        money.New(50000),
+		Deductions:     money.New(25000),
+	}
+	store.Insert(payroll)
+	store.Insert(&entity.PayrollItem{
+		PayrollId:   payroll.ID,
+		Type:        enum.ITEM_DAILY,
+		Description: "Upah harian",
+		Quantity:    6,
+		Rate:        money.New(100000),
+		Amount:      money.New(600000),
+	})
+
+	return employee, period, payroll
+}
+
+func TestFormatRupiah(t *testing.T) {
+	assert.Equal(t, "Rp 0", utils.FormatRupiah(0))
+	assert.Equal(t, "Rp 999", utils.FormatRupiah(money.New(999)))
+	assert.Equal(t, "Rp 1.000", utils.FormatRupiah(money.New(1000)))
+	assert.Equal(t, "Rp 1.234.567", utils.FormatRupiah(money.New(1234567)))
+	assert.Equal(t, "-Rp 25.000", utils.FormatRupiah(money.New(-25000)))
+
+	// sen are rounded to the whole rupiah
+	assert.Equal(t, "Rp 1.001", utils.FormatRupiah(money.MustParse("1000.50")))
+	assert.Equal(t, "Rp 1.000", utils.FormatRupiah(money.MustParse("1000.49")))
+}
+
+func TestGeneratePayslipPDF(t *testing.T) {
+	store := memory.NewStore()
+	employee, period, _ := newPayslipFixture(store)
+
+	payroll, err := memory.NewPayrollRepository(store).FindByEmployeeAndPeriod(nil, employee.ID, period.ID)
+	assert.Nil(t, err)
+
+	document, err := utils.GeneratePayslipPDF(payroll)
+	assert.Nil(t, err)
+	assert.True(t, bytes.HasPrefix(document, []byte("%PDF-")))
+
+	// a payroll without its relations is refused instead of panicking
+	_, err = utils.GeneratePayslipPDF(&entity.Payroll{ID: payroll.ID, Period: payroll.Period})
+	assert.NotNil(t, err)
+}
+
+func TestPayslipCountsPrintsOfClosedPayroll(t *testing.T) {
+	store := memory.NewStore()
+	payrollUseCase := newPayrollUseCase(store)
+	closures := memory.NewPeriodClosureRepository(store)
+	employee, period, _ := newPayslipFixture(store)
+
+	// printing an open payroll leaves no closure behind
+	document, err := payrollUseCase.Payslip(context.Background(), &model.PayslipRequest{EmployeeId: employee.ID, PeriodId: period.ID})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, document)
+
+	closure, err := closures.FindByPeriodAndModule(nil, period.ID, string(enum.PAYROLL))
+	assert.Nil(t, err)
+	assert.Nil(t, closure)
+
+	// every print of a closed payroll is counted
+	store.Insert(&entity.PeriodClosure{PeriodID: period.ID, ModuleName: string(enum.PAYROLL), IsClosed: true})
+	for i := 0; i < 2; i++ {
+		_, err := payrollUseCase.Payslip(context.Background(), &model.PayslipRequest{EmployeeId: employee.ID, PeriodId: period.ID})
+		assert.Nil(t, err)
+	}
+
+	closure, err = closures.FindByPeriodAndModule(nil, period.ID, string(enum.PAYROLL))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, closure.PrintCount)
+
+	// the payslip of a deleted employee still renders
+	assert.Nil(t, memory.NewEmployeeRepository(store).Delete(nil, employee.ID))
+	_, err = payrollUseCase.Payslip(context.Background(), &model.PayslipRequest{EmployeeId: employee.ID, PeriodId: period.ID})
+	assert.Nil(t, err)
+
+	_, err = payrollUseCase.Payslip(context.Background(), &model.PayslipRequest{EmployeeId: employee.ID, PeriodId: period.ID + 1})
+	assert.Equal(t, http.StatusNotFound, StatusOf(err))
+}
+
+func TestPayPayroll(t *testing.T) {
+	store := memory.NewStore()
+	payrollUseCase := newPayrollUseCase(store)
+	_, period, payroll := newPayslipFixture(store)
+	paidBy := uuid.New()
+
+	response, err := payrollUseCase.Pay(context.Background(), &model.PayPayrollRequest{ID: payroll.ID, PaymentMethod: enum.TRANSFER, PaidBy: paidBy})
+	assert.Nil(t, err)
+	assert.True(t, response.IsPaid)
+	assert.NotNil(t, response.PaidAt)
+	assert.Equal(t, enum.TRANSFER, *response.PaymentMethod)
+	assert.Equal(t, paidBy, *response.PaidBy)
+	assert.Equal(t, money.New(625000), response.NetSalary)
+
+	// the payment is stored and cannot be made twice
+	payrolls, err := payrollUseCase.FindAll(context.Background(), &model.FindAllPayrollRequest{PeriodId: period.ID})
+	assert.Nil(t, err)
+	assert.Len(t, payrolls, 1)
+	assert.True(t, payrolls[0].IsPaid)
+
+	_, err = payrollUseCase.Pay(context.Background(), &model.PayPayrollRequest{ID: payroll.ID, PaymentMethod: enum.CASH, PaidBy: paidBy})
+	assert.Equal(t, http.StatusBadRequest, StatusOf(err))
+
+	_, err = payrollUseCase.Pay(context.Background(), &model.PayPayrollRequest{ID: payroll.ID + 1, PaymentMethod: enum.CASH, PaidBy: paidBy})
+	assert.Equal(t, http.StatusNotFound, StatusOf(err))
+
+	_, err = payrollUseCase.Pay(context.Background(), &model.PayPayrollRequest{ID: payroll.ID, PaymentMethod: "CHEQUE", PaidBy: paidBy})
+	assert.Equal(t, http.StatusBadRequest, StatusOf(err))
+}
+
+// newOperationalFixture stores a March 2031 week with staff, a driver and a
+// helper paid by the pay rules, and a sales employee left to another module
+func newOperationalFixture(t *testing.T, store *memory.Store, payrollUseCase usecase.PayrollUseCase) (*entity.Period, map[enum.EmployeeRole]*entity.Employee) {
+	period := &entity.Period{
+		Type:       enum.WEEKLY,
+		StartDate:  time.Date(2031, time.March, 2, 0, 0, 0, 0, time.UTC),
+		EndDate:    time.Date(2031, time.March, 8, 0, 0, 0, 0, time.UTC),
+		WeekNumber: 1,
+		Month:      3,
+		Year:       2031,
+		IsActive:   true,
+	}
+	store.Insert(period)
+
+	joinDate := time.Date(2031, time.January, 1, 0, 0, 0, 0, time.UTC)
+	employees := map[enum.EmployeeRole]*entity.Employee{}
+	for role, salary := range map[enum.EmployeeRole]int64{enum.STAFF: 100000, enum.DRIVER: 150000, enum.HELPER: 80000, enum.SALES: 90000} {
+		employees[role] = &entity.Employee{Name: string(role), Role: role, Salary: money.New(salary), JoinDate: joinDate, Status: enum.EMPLOYEE_ACTIVE}
+		store.Insert(employees[role])
+	}
+
+	// the driver has a daily rate of its own, the helper keeps its salary
+	dailyRate := money.New(120000)
+	_, err := payrollUseCase.UpsertPayRule(context.Background(), &model.UpsertPayRuleRequest{Role: enum.DRIVER, DailyRate: &dailyRate, TripRate: money.New(50000), SackRate: money.New(1000)})
+	assert.Nil(t, err)
+	_, err = payrollUseCase.UpsertPayRule(context.Background(), &model.UpsertPayRuleRequest{Role: enum.HELPER, TripRate: money.New(25000), SackRate: money.New(500)})
+	assert.Nil(t, err)
+
+	present := map[enum.EmployeeRole]int{enum.STAFF: 5, enum.DRIVER: 3, enum.HELPER: 2, enum.SALES: 4}
+	for role, days := range present {
+		for day := 0; day < days; day++ {
+			store.Insert(&entity.EmployeeAttendance{EmployeeId: employees[role].ID, PeriodId: period.ID, Date: period.StartDate.AddDate(0, 0, day), Status: enum.PRESENT})
+		}
+	}
+	// only the days present are paid
+	store.Insert(&entity.EmployeeAttendance{EmployeeId: employees[enum.STAFF].ID, PeriodId: period.ID, Date: period.StartDate.AddDate(0, 0, 6), Status: enum.ABSENT})
+
+	vehicle := &entity.Vehicle{Plate: "L 1234 AB", Type: enum.TRUCK}
+	store.Insert(vehicle)
+	store.Insert(
+		// 90 sacks delivered by the driver and the helper
+		&entity.Trip{Date: time.Date(2031, time.March, 3, 0, 0, 0, 0, time.UTC), VehicleId: vehicle.ID, DriverId: employees[enum.DRIVER].ID, SacksLoaded: 100, SacksReturned: 10, Helpers: []entity.Employee{{ID: employees[enum.HELPER].ID}}},
+		// 50 sacks delivered by the driver alone
+		&entity.Trip{Date: time.Date(2031, time.March, 5, 0, 0, 0, 0, time.UTC), VehicleId: vehicle.ID, DriverId: employees[enum.DRIVER].ID, SacksLoaded: 50},
+		// the next week is not paid here
+		&entity.Trip{Date: time.Date(2031, time.March, 9, 0, 0, 0, 0, time.UTC), VehicleId: vehicle.ID, DriverId: employees[enum.DRIVER].ID, SacksLoaded: 70, Helpers: []entity.Employee{{ID: employees[enum.HELPER].ID}}},
+	)
+
+	return period, employees
+}
+
+func payrollsByEmployee(payrolls []model.PayrollResponse) map[int]model.PayrollResponse {
+	byEmployee := make(map[int]model.PayrollResponse)
+	for _, payroll := range payrolls {
+		byEmployee[payroll.EmployeeId] = payroll
+	}
+	return byEmployee
+}
+
+func itemAmounts(items []model.PayrollItemResponse) map[enum.PayrollItemType]money.Money {
+	amounts := make(map[enum.PayrollItemType]money.Money)
+	for _, item := range items {
+		amounts[item.Type] += item.Amount
+	}
+	return amounts
+}
+
+func TestGenerateOperationalPayroll(t *testing.T) {
+	store := memory.NewStore()
+	payrollUseCase := newPayrollUseCase(store)
+	period, employees := newOperationalFixture(t, store, payrollUseCase)
+
+	payrolls, err := payrollUseCase.Generate(context.Background(), &model.GeneratePayrollRequest{PeriodId: period.ID})
+	assert.Nil(t, err)
+	assert.Len(t, payrolls, 3)
+
+	byEmployee := payrollsByEmployee(payrolls)
+	_, ok := byEmployee[employees[enum.SALES].ID]
+	assert.False(t, ok, "sales are paid by the sales incentive module")
+
+	// no rule, the salary is the daily rate
+	staff := byEmployee[employees[enum.STAFF].ID]
+	assert.Equal(t, 5, staff.AttendanceDays)
+	assert.Equal(t, money.New(500000), staff.BaseSalary)
+	assert.Equal(t, money.Money(0), staff.Bonuses)
+	assert.Equal(t, map[enum.PayrollItemType]money.Money{enum.ITEM_DAILY: money.New(500000)}, itemAmounts(staff.Items))
+
+	// the rule daily rate wins over the salary, trips and sacks are bonuses
+	driver := byEmployee[employees[enum.DRIVER].ID]
+	assert.Equal(t, 3, driver.AttendanceDays)
+	assert.Equal(t, money.New(360000), driver.BaseSalary)
+	assert.Equal(t, money.New(240000), driver.Bonuses)
+	assert.Equal(t, map[enum.PayrollItemType]money.Money{
+		enum.ITEM_DAILY: money.New(360000),
+		enum.ITEM_TRIP:  money.New(100000),
+		enum.ITEM_SACK:  money.New(140000),
+	}, itemAmounts(driver.Items))
+
+	// a helper is paid for the trips it rode along
+	helper := byEmployee[employees[enum.HELPER].ID]
+	assert.Equal(t, money.New(160000), helper.BaseSalary)
+	assert.Equal(t, money.New(70000), helper.Bonuses)
+	assert.Equal(t, map[enum.PayrollItemType]money.Money{
+		enum.ITEM_DAILY: money.New(160000),
+		enum.ITEM_TRIP:  money.New(25000),
+		enum.ITEM_SACK:  money.New(45000),
+	}, itemAmounts(helper.Items))
+	assert.Equal(t, money.New(230000), helper.NetSalary)
+}
+
+func TestRegenerateOperationalPayroll(t *testing.T) {
+	store := memory.NewStore()
+	payrollUseCase := newPayrollUseCase(store)
+	payrollRepository := memory.NewPayrollRepository(store)
+	period, employees := newOperationalFixture(t, store, payrollUseCase)
+
+	first, err := payrollUseCase.Generate(context.Background(), &model.GeneratePayrollRequest{PeriodId: period.ID})
+	assert.Nil(t, err)
+	firstByEmployee := payrollsByEmployee(first)
+
+	// the driver is paid before the week gets more work
+	driver := firstByEmployee[employees[enum.DRIVER].ID]
+	_, err = payrollUseCase.Pay(context.Background(), &model.PayPayrollRequest{ID: driver.ID, PaymentMethod: enum.CASH, PaidBy: uuid.New()})
+	assert.Nil(t, err)
+
+	for _, role := range []enum.EmployeeRole{enum.STAFF, enum.DRIVER} {
+		store.Insert(&entity.EmployeeAttendance{EmployeeId: employees[role].ID, PeriodId: period.ID, Date: period.EndDate, Status: enum.PRESENT})
+	}
+
+	second, err := payrollUseCase.Generate(context.Background(), &model.GeneratePayrollRequest{PeriodId: period.ID})
+	assert.Nil(t, err)
+	assert.Len(t, second, 2)
+	secondByEmployee := payrollsByEmployee(second)
+
+	// an unpaid payroll keeps its row, its amounts and items are replaced
+	staff := secondByEmployee[employees[enum.STAFF].ID]
+	assert.Equal(t, firstByEmployee[employees[enum.STAFF].ID].ID, staff.ID)
+	assert.Equal(t, 6, staff.AttendanceDays)
+	assert.Equal(t, money.New(600000), staff.BaseSalary)
+
+	stored, err := payrollRepository.FindById(nil, staff.ID)
+	assert.Nil(t, err)
+	assert.Len(t, stored.Items, 1)
+	assert.Equal(t, money.New(600000), stored.Items[0].Amount)
+
+	// a paid payroll is left as it was paid
+	_, ok := secondByEmployee[employees[enum.DRIVER].ID]
+	assert.False(t, ok)
+
+	stored, err = payrollRepository.FindById(nil, driver.ID)
+	assert.Nil(t, err)
+	assert.True(t, stored.IsPaid)
+	assert.Equal(t, 3, stored.AttendanceDays)
+	assert.Equal(t, money.New(360000), stored.BaseSalary)
+	assert.Len(t, stored.Items, 3)
+
+	payrolls, err := payrollRepository.FindByPeriodId(nil, period.ID)
+	assert.Nil(t, err)
+	assert.Len(t, payrolls, 3)
+}
+
+func TestRegenerateDropsStalePayroll(t *testing.T) {
+	store := memory.NewStore()
+	payrollUseCase := newPayrollUseCase(store)
+	payrollRepository := memory.NewPayrollRepository(store)
+	cashAdvanceRepository := memory.NewCashAdvanceRepository(store)
+	period, employees := newOperationalFixture(t, store, payrollUseCase)
+
+	helper := employees[enum.HELPER]
+	advance := &entity.CashAdvance{Date: period.StartDate, Amount: money.New(100000), Installment: money.New(50000), EmployeeId: helper.ID}
+	store.Insert(advance)
+
+	first, err := payrollUseCase.Generate(context.Background(), &model.GeneratePayrollRequest{PeriodId: period.ID})
+	assert.Nil(t, err)
+	assert.Len(t, first, 3)
+	assert.Equal(t, money.New(50000), payrollsByEmployee(first)[helper.ID].Deductions)
+
+	// the helper leaves before the payroll is generated again
+	assert.Nil(t, memory.NewEmployeeRepository(store).Delete(nil, helper.ID))
+
+	second, err := payrollUseCase.Generate(context.Background(), &model.GeneratePayrollRequest{PeriodId: period.ID})
+	assert.Nil(t, err)
+	assert.Len(t, second, 2)
+
+	payrolls, err := payrollRepository.FindByPeriodId(nil, period.ID)
+	assert.Nil(t, err)
+	assert.Len(t, payrolls, 2)
+	for _, payroll := range payrolls {
+		assert.NotEqual(t, helper.ID, payroll.EmployeeId)
+	}
+
+	// the installment goes with the stale payroll, the advance is outstanding again
+	stored, err := cashAdvanceRepository.FindById(nil, advance.ID)
+	assert.Nil(t, err)
+	assert.Empty(t, stored.Repayments)
+}
@@ -0,0 +1,158 @@
+package unit
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/enum"
+	"api/internal/model"
+	"api/internal/repository/memory"
+	"api/internal/usecase"
+	"api/internal/utils"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newPeriodUseCase(store *memory.Store) usecase.PeriodUseCase {
+	return usecase.NewPeriodUseCase(memory.NewTransactor(store), log, validate, memory.NewPeriodRepository(store), memory.NewPeriodClosureRepository(store), utils.NewWeekCalendar(time.Sunday))
+}
+
+func TestGeneratePeriodsFollowTheCalendar(t *testing.T) {
+	periodUseCase := newPeriodUseCase(memory.NewStore())
+
+	periods, err := periodUseCase.Generate(context.Background(), &model.GeneratePeriodRequest{StartDate: "2031-03-04", Count: 3})
+	assert.Nil(t, err)
+	assert.Len(t, periods, 3)
+
+	// weeks start on sunday and follow each other
+	for i, period := range periods {
+		startDate := time.Date(2031, time.March, 2+7*i, 0, 0, 0, 0, time.UTC)
+		assert.Equal(t, enum.WEEKLY, period.Type)
+		assert.True(t, startDate.Equal(period.StartDate))
+		assert.True(t, startDate.AddDate(0, 0, 6).Equal(period.EndDate))
+		assert.True(t, period.IsActive)
+	}
+
+	// generating again returns the same periods
+	again, err := periodUseCase.Generate(context.Background(), &model.GeneratePeriodRequest{StartDate: "2031-03-02", Count: 3})
+	assert.Nil(t, err)
+	for i := range periods {
+		assert.Equal(t, periods[i].ID, again[i].ID)
+	}
+}
+
+func TestGeneratePeriodValidation(t *testing.T) {
+	periodUseCase := newPeriodUseCase(memory.NewStore())
+
+	_, err := periodUseCase.Generate(context.Background(), &model.GeneratePeriodRequest{StartDate: "2031-03-04", Count: 0})
+	assert.Equal(t, http.StatusBadRequest, StatusOf(err))
+}
+
+func TestDeactivatePeriod(t *testing.T) {
+	store := memory.NewStore()
+	periodUseCase := newPeriodUseCase(store)
+
+	periods, err := periodUseCase.Generate(context.Background(), &model.GeneratePeriodRequest{StartDate: "2031-03-04", Count: 1})
+	assert.Nil(t, err)
+
+	period, err := periodUseCase.Deactivate(context.Background(), &model.DeactivatePeriodRequest{ID: periods[0].ID})
+	assert.Nil(t, err)
+	assert.False(t, period.IsActive)
+
+	// hidden from the list unless asked for
+	active, total, err := periodUseCase.FindAll(context.Background(), &model.FindAllPeriodRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), total)
+	assert.Empty(t, active)
+
+	all, total, err := periodUseCase.FindAll(context.Background(), &model.FindAllPeriodRequest{IncludeInactive: true})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.False(t, all[0].IsActive)
+
+	// the week stays hidden when a date in it comes up again
+	transactor := memory.NewTransactor(store)
+	tx := transactor.Begin(context.Background())
+	_, err = periodUseCase.GetOrCreatePeriodIdByDate(tx, "2031-03-05")
+	assert.Equal(t, http.StatusBadRequest, StatusOf(err))
+	transactor.Rollback(tx)
+
+	detail, err := periodUseCase.FindById(context.Background(), &model.FindByIdPeriodRequest{ID: periods[0].ID})
+	assert.Nil(t, err)
+	assert.False(t, detail.Period.IsActive)
+}
+
+func TestAttendanceOnDeactivatedPeriod(t *testing.T) {
+	store := memory.NewStore()
+	periodUseCase := newPeriodUseCase(store)
+	attendanceUseCase := newEmployeeAttendanceUseCase(store)
+
+	periods, err := periodUseCase.Generate(context.Background(), &model.GeneratePeriodRequest{StartDate: "2031-03-04", Count: 1})
+	assert.Nil(t, err)
+
+	_, err = periodUseCase.Deactivate(context.Background(), &model.DeactivatePeriodRequest{ID: periods[0].ID})
+	assert.Nil(t, err)
+
+	employee := &entity.Employee{Name: "Andi", Role: enum.STAFF, JoinDate: time.Date(2031, time.January, 1, 0, 0, 0, 0, time.UTC), Status: enum.EMPLOYEE_ACTIVE}
+	store.Insert(employee)
+
+	err = attendanceUseCase.Upsert(context.Background(), &model.UpsertEmployeeAttendanceRequest{
+		Attendances: []model.AttendanceAction{{
+			Action:    "update",
+			Date:      "2031-03-05",
+			Employees: []model.EmployeeAttendance{{ID: employee.ID, Status: string(enum.PRESENT)}},
+		}},
+	})
+	assert.Equal(t, http.StatusBadRequest, StatusOf(err))
+
+	// the period is not brought back and holds nothing
+	detail, err := periodUseCase.FindById(context.Background(), &model.FindByIdPeriodRequest{ID: periods[0].ID})
+	assert.Nil(t, err)
+	assert.False(t, detail.Period.IsActive)
+	assert.Equal(t, int64(0), detail.Attendance.Employees)
+}
+
+func TestDeactivatePeriodWithData(t *testing.T) {
+	store := memory.NewStore()
+	periodUseCase := newPeriodUseCase(store)
+
+	periods, err := periodUseCase.Generate(context.Background(), &model.GeneratePeriodRequest{StartDate: "2031-03-04", Count: 1})
+	assert.Nil(t, err)
+
+	store.Insert(&entity.EmployeeAttendance{
+		EmployeeId: 1,
+		PeriodId:   periods[0].ID,
+		Date:       time.Date(2031, time.March, 4, 0, 0, 0, 0, time.UTC),
+		Status:     enum.PRESENT,
+	})
+
+	_, err = periodUseCase.Deactivate(context.Background(), &model.DeactivatePeriodRequest{ID: periods[0].ID})
+	assert.Equal(t, http.StatusConflict, StatusOf(err))
+
+	detail, err := periodUseCase.FindById(context.Background(), &model.FindByIdPeriodRequest{ID: periods[0].ID})
+	assert.Nil(t, err)
+	assert.True(t, detail.Period.IsActive)
+	assert.Equal(t, int64(1), detail.Attendance.Present)
+}
+
+func TestDeactivateClosedPeriod(t *testing.T) {
+	store := memory.NewStore()
+	periodUseCase := newPeriodUseCase(store)
+
+	period := &entity.Period{
+		Type:      enum.WEEKLY,
+		StartDate: time.Date(2031, time.March, 2, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2031, time.March, 8, 0, 0, 0, 0, time.UTC),
+		IsActive:  true,
+		IsClosed:  true,
+	}
+	store.Insert(period)
+
+	_, err := periodUseCase.Deactivate(context.Background(), &model.DeactivatePeriodRequest{ID: period.ID})
+	assert.Equal(t, http.StatusBadRequest, StatusOf(err))
+
+	_, err = periodUseCase.Deactivate(context.Background(), &model.DeactivatePeriodRequest{ID: period.ID + 1})
+	assert.Equal(t, http.StatusNotFound, StatusOf(err))
+}
@@ -0,0 +1,59 @@
+package unit
+
+import (
+	"api/internal/entity/enum"
+	"api/internal/model"
+	"api/internal/utils"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenWithMemorySessions(t *testing.T) {
+	tokenUtil := utils.NewTokenUtil("secret", utils.NewMemorySessionStore())
+	auth := &model.Auth{ID: uuid.New(), Role: enum.SUPER_ADMIN}
+
+	token, err := tokenUtil.CreateToken(context.Background(), auth)
+	assert.Nil(t, err)
+
+	parsed, err := tokenUtil.ParseToken(context.Background(), token)
+	assert.Nil(t, err)
+	assert.Equal(t, auth.ID, parsed.ID)
+	assert.Equal(t, auth.Role, parsed.Role)
+
+	// a valid signature is not enough, the token must have been issued here
+	other := utils.NewTokenUtil("secret", utils.NewMemorySessionStore())
+	_, err = other.ParseToken(context.Background(), token)
+	assert.NotNil(t, err)
+}
+
+func TestMemorySessionsExpire(t *testing.T) {
+	sessions := utils.NewMemorySessionStore()
+
+	assert.Nil(t, sessions.Save(context.Background(), "expired", uuid.New(), -time.Second))
+	assert.Nil(t, sessions.Save(context.Background(), "active", uuid.New(), time.Hour))
+
+	exists, err := sessions.Exists(context.Background(), "expired")
+	assert.Nil(t, err)
+	assert.False(t, exists)
+
+	exists, err = sessions.Exists(context.Background(), "active")
+	assert.Nil(t, err)
+	assert.True(t, exists)
+}
+
+func TestMemorySessionsPrune(t *testing.T) {
+	sessions := utils.NewMemorySessionStore()
+
+	assert.Nil(t, sessions.Save(context.Background(), "expired", uuid.New(), -time.Second))
+	assert.Equal(t, 1, sessions.Len())
+
+	// the expired token is swept by a later save, not on every save
+	for i := 0; i < 1000; i++ {
+		assert.Nil(t, sessions.Save(context.Background(), uuid.NewString(), uuid.New(), time.Hour))
+	}
+	assert.Equal(t, 1000, sessions.Len())
+}
@@ -0,0 +1,34 @@
+package unit
+
+import (
+	"api/internal/entity"
+	"api/internal/repository/memory"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactorRollback(t *testing.T) {
+	store := memory.NewStore()
+	transactor := memory.NewTransactor(store)
+	vehicleRepository := memory.NewVehicleRepository(store)
+
+	tx := transactor.Begin(context.Background())
+	assert.Nil(t, vehicleRepository.Create(tx, &entity.Vehicle{Plate: "B 1234 CD"}))
+	transactor.Rollback(tx)
+
+	count, err := vehicleRepository.CountByPlate(transactor.DB(context.Background()), "B 1234 CD")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), count)
+
+	// a rollback after the commit, like the deferred one, keeps the rows
+	tx = transactor.Begin(context.Background())
+	assert.Nil(t, vehicleRepository.Create(tx, &entity.Vehicle{Plate: "B 1234 CD"}))
+	assert.Nil(t, transactor.Commit(tx))
+	transactor.Rollback(tx)
+
+	count, err = vehicleRepository.CountByPlate(transactor.DB(context.Background()), "B 1234 CD")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), count)
+}
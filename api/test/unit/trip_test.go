@@ -0,0 +1,147 @@
+package unit
+
+import (
+	"api/internal/entity"
+	"api/internal/entity/enum"
+	"api/internal/entity/money"
+	"api/internal/model"
+	"api/internal/repository/memory"
+	"api/internal/usecase"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTripUseCase(store *memory.Store) usecase.TripUseCase {
+	return usecase.NewTripUseCase(memory.NewTransactor(store), log, validate, memory.NewTripRepository(store), memory.NewVehicleRepository(store), memory.NewVehicleHistoryRepository(store), memory.NewEmployeeRepository(store), memory.NewRouteRepository(store), memory.NewEmployeeAttendanceRepository(store), memory.NewDriverLicenseRepository(store), memory.NewVehicleDocumentRepository(store), memory.NewCrewRepository(store), newPeriodUseCase(store))
+}
+
+func TestCreateTripSacks(t *testing.T) {
+	store := memory.NewStore()
+	tripUseCase := newTripUseCase(store)
+
+	vehicle := &entity.Vehicle{Plate: "L 1234 AB", Type: enum.TRUCK}
+	driver := &entity.Employee{Name: "Budi", Role: enum.DRIVER, Salary: money.New(100000), JoinDate: time.Now(), Status: enum.EMPLOYEE_ACTIVE}
+	route := &entity.Route{Name: "Surabaya Barat"}
+	store.Insert(vehicle, driver, route)
+	store.Insert(&entity.DriverLicense{EmployeeId: driver.ID, Number: "1234567890", Class: enum.LICENSE_B1, ExpiryDate: time.Date(2035, time.January, 1, 0, 0, 0, 0, time.UTC)})
+
+	request := func(loaded, returned int) *model.CreateTripRequest {
+		return &model.CreateTripRequest{
+			Date:          "2031-03-04",
+			VehicleId:     vehicle.ID,
+			DriverId:      driver.ID,
+			RouteIds:      []int{route.ID},
+			SacksLoaded:   loaded,
+			SacksReturned: returned,
+			Income:        money.New(500000),
+		}
+	}
+
+	fieldOf := func(err error) string {
+		var errorResponse *model.ErrorResponse
+		if !errors.As(err, &errorResponse) || len(errorResponse.Details) == 0 {
+			return ""
+		}
+		return errorResponse.Details[0].Field
+	}
+
+	// more sacks back than loaded
+	_, err := tripUseCase.Create(context.Background(), request(100, 101))
+	assert.Equal(t, http.StatusBadRequest, StatusOf(err))
+	assert.Equal(t, "SacksReturned", fieldOf(err))
+
+	// negative counts
+	_, err = tripUseCase.Create(context.Background(), request(-1, 0))
+	assert.Equal(t, http.StatusBadRequest, StatusOf(err))
+	assert.Equal(t, "SacksLoaded", fieldOf(err))
+
+	_, err = tripUseCase.Create(context.Background(), request(10, -1))
+	assert.Equal(t, http.StatusBadRequest, StatusOf(err))
+	assert.Equal(t, "SacksReturned", fieldOf(err))
+
+	assert.Empty(t, store.VehicleHistories(vehicle.ID))
+
+	// every sack coming back is allowed, nothing was delivered
+	trip, err := tripUseCase.Create(context.Background(), request(100, 100))
+	assert.Nil(t, err)
+	assert.Equal(t, 100, trip.SacksReturned)
+
+	histories := store.VehicleHistories(vehicle.ID)
+	assert.Len(t, histories, 1)
+	assert.Equal(t, enum.INCOME, histories[0].Type)
+	assert.Equal(t, 0, *histories[0].Sack)
+
+	_, err = tripUseCase.Create(context.Background(), request(100, 40))
+	assert.Nil(t, err)
+	histories = store.VehicleHistories(vehicle.ID)
+	assert.Len(t, histories, 2)
+	assert.Equal(t, 60, *histories[1].Sack)
+}
+
+func TestCreateTripDriverWithoutLicense(t *testing.T) {
+	store := memory.NewStore()
+	tripUseCase := newTripUseCase(store)
+
+	vehicle := &entity.Vehicle{Plate: "L 1234 AB", Type: enum.TRUCK}
+	driver := &entity.Employee{Name: "Budi", Role: enum.DRIVER, Salary: money.New(100000), JoinDate: time.Now(), Status: enum.EMPLOYEE_ACTIVE}
+	route := &entity.Route{Name: "Surabaya Barat"}
+	store.Insert(vehicle, driver, route)
+
+	request := &model.CreateTripRequest{
+		Date:      "2031-03-04",
+		VehicleId: vehicle.ID,
+		DriverId:  driver.ID,
+		RouteIds:  []int{route.ID},
+	}
+
+	// a driver without any SIM recorded is not dispatched
+	_, err := tripUseCase.Create(context.Background(), request)
+	assert.Equal(t, http.StatusBadRequest, StatusOf(err))
+	assert.Equal(t, "Supir belum memiliki SIM", err.Error())
+
+	store.Insert(&entity.DriverLicense{EmployeeId: driver.ID, Number: "1234567890", Class: enum.LICENSE_B1, ExpiryDate: time.Date(2035, time.January, 1, 0, 0, 0, 0, time.UTC)})
+
+	_, err = tripUseCase.Create(context.Background(), request)
+	assert.Nil(t, err)
+}
+
+func TestCreateTripWithEndedCrew(t *testing.T) {
+	store := memory.NewStore()
+	tripUseCase := newTripUseCase(store)
+
+	vehicle := &entity.Vehicle{Plate: "L 1234 AB", Type: enum.TRUCK}
+	driver := &entity.Employee{Name: "Budi", Role: enum.DRIVER, Salary: money.New(100000), JoinDate: time.Now(), Status: enum.EMPLOYEE_ACTIVE}
+	resigned := &entity.Employee{Name: "Joko", Role: enum.DRIVER, Salary: money.New(100000), JoinDate: time.Now(), Status: enum.EMPLOYEE_RESIGNED}
+	terminated := &entity.Employee{Name: "Agus", Role: enum.HELPER, Salary: money.New(80000), JoinDate: time.Now(), Status: enum.EMPLOYEE_TERMINATED}
+	route := &entity.Route{Name: "Surabaya Barat"}
+	store.Insert(vehicle, driver, resigned, terminated, route)
+	for _, employee := range []*entity.Employee{driver, resigned} {
+		store.Insert(&entity.DriverLicense{EmployeeId: employee.ID, Number: "SIM " + employee.Name, Class: enum.LICENSE_B1, ExpiryDate: time.Date(2035, time.January, 1, 0, 0, 0, 0, time.UTC)})
+	}
+
+	// a driver who resigned cannot be dispatched
+	_, err := tripUseCase.Create(context.Background(), &model.CreateTripRequest{
+		Date:      "2031-03-04",
+		VehicleId: vehicle.ID,
+		DriverId:  resigned.ID,
+		RouteIds:  []int{route.ID},
+	})
+	assert.Equal(t, http.StatusBadRequest, StatusOf(err))
+
+	// nor can a terminated helper ride along
+	_, err = tripUseCase.Create(context.Background(), &model.CreateTripRequest{
+		Date:      "2031-03-04",
+		VehicleId: vehicle.ID,
+		DriverId:  driver.ID,
+		HelperIds: []int{terminated.ID},
+		RouteIds:  []int{route.ID},
+	})
+	assert.Equal(t, http.StatusBadRequest, StatusOf(err))
+
+	assert.Empty(t, store.VehicleHistories(vehicle.ID))
+}
@@ -0,0 +1,296 @@
+package unit
+
+import (
+	"api/internal/entity/enum"
+	"api/internal/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// day parses a yyyy-mm-dd date
+func day(value string) time.Time {
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		panic(err)
+	}
+	return date
+}
+
+func TestWeekCalendarWithoutAnchor(t *testing.T) {
+	calendar := utils.NewWeekCalendar(time.Sunday)
+
+	cases := []struct {
+		name  string
+		date  string
+		start string
+		end   string
+		week  int
+		month int
+		year  int
+	}{
+		{"month starts on sunday", "2025-06-01", "2025-06-01", "2025-06-07", 1, 6, 2025},
+		{"month starts on monday", "2025-09-01", "2025-08-31", "2025-09-06", 5, 8, 2025},
+		{"month starts on tuesday", "2025-04-01", "2025-03-30", "2025-04-05", 5, 3, 2025},
+		{"month starts on wednesday", "2025-01-01", "2024-12-29", "2025-01-04", 5, 12, 2024},
+		{"month starts on thursday", "2025-05-01", "2025-04-27", "2025-05-03", 4, 4, 2025},
+		{"month starts on friday", "2025-08-01", "2025-07-27", "2025-08-02", 4, 7, 2025},
+		{"month starts on saturday", "2025-02-01", "2025-01-26", "2025-02-01", 4, 1, 2025},
+		{"last day of month", "2025-06-30", "2025-06-29", "2025-07-05", 5, 6, 2025},
+		{"leap day", "2024-02-29", "2024-02-25", "2024-03-02", 4, 2, 2024},
+		{"week ending on new year", "2022-12-31", "2022-12-25", "2022-12-31", 4, 12, 2022},
+		{"week starting on new year", "2023-01-01", "2023-01-01", "2023-01-07", 1, 1, 2023},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			week := calendar.Week(day(c.date), nil)
+			assert.Equal(t, day(c.start), week.StartDate)
+			assert.Equal(t, day(c.end), week.EndDate)
+			assert.Equal(t, c.week, week.WeekNumber)
+			assert.Equal(t, c.month, week.Month)
+			assert.Equal(t, c.year, week.Year)
+		})
+	}
+}
+
+func TestWeekCalendarWithAnchor(t *testing.T) {
+	calendar := utils.NewWeekCalendar(time.Sunday)
+
+	cases := []struct {
+		name   string
+		date   string
+		anchor utils.WeekAnchor
+		closed []utils.MonthKey
+		week   int
+		month  int
+		year   int
+	}{
+		{
+			name:   "open month keeps counting",
+			date:   "2025-02-05",
+			anchor: utils.WeekAnchor{StartDate: day("2025-01-26"), WeekNumber: 4, Month: 1, Year: 2025},
+			week:   5, month: 1, year: 2025,
+		},
+		{
+			name:   "closed month restarts numbering",
+			date:   "2025-02-05",
+			anchor: utils.WeekAnchor{StartDate: day("2025-01-26"), WeekNumber: 4, Month: 1, Year: 2025},
+			closed: []utils.MonthKey{{Year: 2025, Month: 1}},
+			week:   1, month: 2, year: 2025,
+		},
+		{
+			name:   "open december carries into january",
+			date:   "2025-01-08",
+			anchor: utils.WeekAnchor{StartDate: day("2024-12-29"), WeekNumber: 5, Month: 12, Year: 2024},
+			week:   6, month: 12, year: 2024,
+		},
+		{
+			name:   "closed december starts the new year",
+			date:   "2025-01-08",
+			anchor: utils.WeekAnchor{StartDate: day("2024-12-29"), WeekNumber: 5, Month: 12, Year: 2024},
+			closed: []utils.MonthKey{{Year: 2024, Month: 12}},
+			week:   1, month: 1, year: 2025,
+		},
+		{
+			name:   "weeks taken by the closed month are skipped",
+			date:   "2025-02-12",
+			anchor: utils.WeekAnchor{StartDate: day("2025-02-02"), WeekNumber: 5, Month: 1, Year: 2025},
+			closed: []utils.MonthKey{{Year: 2025, Month: 1}},
+			week:   1, month: 2, year: 2025,
+		},
+		{
+			name:   "same month continues from the anchor",
+			date:   "2025-06-18",
+			anchor: utils.WeekAnchor{StartDate: day("2025-06-01"), WeekNumber: 1, Month: 6, Year: 2025},
+			week:   3, month: 6, year: 2025,
+		},
+		{
+			name:   "carried month continues past its own weeks",
+			date:   "2025-03-20",
+			anchor: utils.WeekAnchor{StartDate: day("2025-03-02"), WeekNumber: 6, Month: 2, Year: 2025},
+			week:   8, month: 2, year: 2025,
+		},
+		{
+			name:   "anchor older than the previous month is not carried",
+			date:   "2025-03-12",
+			anchor: utils.WeekAnchor{StartDate: day("2025-01-26"), WeekNumber: 4, Month: 1, Year: 2025},
+			week:   2, month: 3, year: 2025,
+		},
+		{
+			name:   "anchor on the same week is ignored",
+			date:   "2025-06-18",
+			anchor: utils.WeekAnchor{StartDate: day("2025-06-15"), WeekNumber: 9, Month: 5, Year: 2025},
+			week:   3, month: 6, year: 2025,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			week := calendar.Week(day(c.date), &c.anchor, c.closed...)
+			assert.Equal(t, c.week, week.WeekNumber)
+			assert.Equal(t, c.month, week.Month)
+			assert.Equal(t, c.year, week.Year)
+		})
+	}
+}
+
+func TestWeekCalendarWeekStart(t *testing.T) {
+	cases := []struct {
+		weekStart time.Weekday
+		date      string
+		start     string
+		week      int
+		month     int
+		year      int
+	}{
+		{time.Saturday, "2025-06-04", "2025-05-31", 5, 5, 2025},
+		{time.Saturday, "2025-06-07", "2025-06-07", 1, 6, 2025},
+		{time.Monday, "2025-01-01", "2024-12-30", 5, 12, 2024},
+		{time.Monday, "2025-01-06", "2025-01-06", 1, 1, 2025},
+	}
+
+	for _, c := range cases {
+		week := utils.NewWeekCalendar(c.weekStart).Week(day(c.date), nil)
+		assert.Equal(t, day(c.start), week.StartDate, c.date)
+		assert.Equal(t, day(c.start).AddDate(0, 0, 6), week.EndDate, c.date)
+		assert.Equal(t, c.week, week.WeekNumber, c.date)
+		assert.Equal(t, c.month, week.Month, c.date)
+		assert.Equal(t, c.year, week.Year, c.date)
+	}
+}
+
+func TestWeekCalendarIgnoresTimeOfDay(t *testing.T) {
+	calendar := utils.NewWeekCalendar(time.Sunday)
+	jakarta := time.FixedZone("WIB", 7*60*60)
+
+	week := calendar.Week(time.Date(2025, time.June, 4, 23, 30, 0, 0, jakarta), nil)
+	assert.Equal(t, day("2025-06-01"), week.StartDate)
+	assert.Equal(t, 1, week.WeekNumber)
+}
+
+func TestWeekCalendarClosedMonthsChainWithoutGaps(t *testing.T) {
+	calendar := utils.NewWeekCalendar(time.Sunday)
+
+	var anchor *utils.WeekAnchor
+	var closed []utils.MonthKey
+	for date := day("2024-11-03"); date.Before(day("2026-03-01")); date = date.AddDate(0, 0, 7) {
+		week := calendar.Week(date, anchor, closed...)
+		assert.Equal(t, date, week.StartDate)
+
+		if anchor != nil {
+			if week.Month == anchor.Month {
+				assert.Equal(t, anchor.WeekNumber+1, week.WeekNumber, date)
+			} else {
+				assert.Equal(t, 1, week.WeekNumber, date)
+				assert.LessOrEqual(t, week.StartDate.Day(), 7, date)
+			}
+		}
+
+		assert.Equal(t, int(date.Month()), week.Month, date)
+		assert.Equal(t, date.Year(), week.Year, date)
+
+		closed = append(closed, utils.MonthKey{Year: week.Year, Month: week.Month})
+		anchor = &utils.WeekAnchor{StartDate: week.StartDate, WeekNumber: week.WeekNumber, Month: week.Month, Year: week.Year}
+	}
+}
+
+func TestWeekCalendarPolicies(t *testing.T) {
+	cases := []struct {
+		name     string
+		calendar utils.WeekCalendar
+		date     string
+		start    string
+		end      string
+		week     int
+		month    int
+		year     int
+	}{
+		{
+			name:     "end rule moves a straddling week forward",
+			calendar: utils.WeekCalendar{Type: enum.WEEKLY, WeekStart: time.Sunday, MonthRule: enum.MONTH_RULE_END},
+			date:     "2025-04-30", start: "2025-04-27", end: "2025-05-03", week: 1, month: 5, year: 2025,
+		},
+		{
+			name:     "end rule counts from the first week ending in the month",
+			calendar: utils.WeekCalendar{Type: enum.WEEKLY, WeekStart: time.Sunday, MonthRule: enum.MONTH_RULE_END},
+			date:     "2025-05-28", start: "2025-05-25", end: "2025-05-31", week: 5, month: 5, year: 2025,
+		},
+		{
+			name:     "end rule crosses the year",
+			calendar: utils.WeekCalendar{Type: enum.WEEKLY, WeekStart: time.Sunday, MonthRule: enum.MONTH_RULE_END},
+			date:     "2024-12-31", start: "2024-12-29", end: "2025-01-04", week: 1, month: 1, year: 2025,
+		},
+		{
+			name:     "majority rule keeps a week with four days in the month",
+			calendar: utils.WeekCalendar{Type: enum.WEEKLY, WeekStart: time.Sunday, MonthRule: enum.MONTH_RULE_MAJORITY},
+			date:     "2025-04-30", start: "2025-04-27", end: "2025-05-03", week: 5, month: 4, year: 2025,
+		},
+		{
+			name:     "majority rule moves a week with four days in the next month",
+			calendar: utils.WeekCalendar{Type: enum.WEEKLY, WeekStart: time.Sunday, MonthRule: enum.MONTH_RULE_MAJORITY},
+			date:     "2025-07-01", start: "2025-06-29", end: "2025-07-05", week: 1, month: 7, year: 2025,
+		},
+		{
+			name:     "saturday to friday weeks",
+			calendar: utils.WeekCalendar{Type: enum.WEEKLY, WeekStart: time.Saturday, MonthRule: enum.MONTH_RULE_START},
+			date:     "2025-06-13", start: "2025-06-07", end: "2025-06-13", week: 1, month: 6, year: 2025,
+		},
+		{
+			name:     "bi-weekly periods follow the epoch",
+			calendar: utils.WeekCalendar{Type: enum.BIWEEKLY, WeekStart: time.Sunday, MonthRule: enum.MONTH_RULE_START, Epoch: day("2024-01-01")},
+			date:     "2025-01-15", start: "2025-01-12", end: "2025-01-25", week: 1, month: 1, year: 2025,
+		},
+		{
+			name:     "bi-weekly period straddling the year",
+			calendar: utils.WeekCalendar{Type: enum.BIWEEKLY, WeekStart: time.Sunday, MonthRule: enum.MONTH_RULE_START, Epoch: day("2024-01-01")},
+			date:     "2025-01-05", start: "2024-12-29", end: "2025-01-11", week: 3, month: 12, year: 2024,
+		},
+		{
+			name:     "bi-weekly majority across the year",
+			calendar: utils.WeekCalendar{Type: enum.BIWEEKLY, WeekStart: time.Sunday, MonthRule: enum.MONTH_RULE_MAJORITY, Epoch: day("2024-01-01")},
+			date:     "2025-01-05", start: "2024-12-29", end: "2025-01-11", week: 1, month: 1, year: 2025,
+		},
+		{
+			name:     "monthly period",
+			calendar: utils.WeekCalendar{Type: enum.MONTHLY},
+			date:     "2025-02-14", start: "2025-02-01", end: "2025-02-28", week: 1, month: 2, year: 2025,
+		},
+		{
+			name:     "monthly period in a leap year",
+			calendar: utils.WeekCalendar{Type: enum.MONTHLY},
+			date:     "2024-02-10", start: "2024-02-01", end: "2024-02-29", week: 1, month: 2, year: 2024,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			week := c.calendar.Week(day(c.date), nil)
+			assert.Equal(t, day(c.start), week.StartDate)
+			assert.Equal(t, day(c.end), week.EndDate)
+			assert.Equal(t, c.week, week.WeekNumber)
+			assert.Equal(t, c.month, week.Month)
+			assert.Equal(t, c.year, week.Year)
+		})
+	}
+}
+
+func TestWeekCalendarPolicyChange(t *testing.T) {
+	// sunday weeks were stored, the branch now pays friday to thursday
+	calendar := utils.WeekCalendar{Type: enum.WEEKLY, WeekStart: time.Friday, MonthRule: enum.MONTH_RULE_START}
+	anchor := &utils.WeekAnchor{StartDate: day("2025-06-01"), EndDate: day("2025-06-07"), WeekNumber: 1, Month: 6, Year: 2025}
+
+	// the first period is shortened so it does not overlap the stored one
+	week := calendar.Week(day("2025-06-08"), anchor)
+	assert.Equal(t, day("2025-06-08"), week.StartDate)
+	assert.Equal(t, day("2025-06-12"), week.EndDate)
+	assert.Equal(t, 2, week.WeekNumber)
+	assert.Equal(t, 6, week.Month)
+
+	anchor = &utils.WeekAnchor{StartDate: week.StartDate, EndDate: week.EndDate, WeekNumber: week.WeekNumber, Month: week.Month, Year: week.Year}
+	week = calendar.Week(day("2025-06-13"), anchor)
+	assert.Equal(t, day("2025-06-13"), week.StartDate)
+	assert.Equal(t, day("2025-06-19"), week.EndDate)
+	assert.Equal(t, 3, week.WeekNumber)
+}
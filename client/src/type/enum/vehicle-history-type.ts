@@ -1,4 +1,4 @@
 export enum VehicleHistoryType {
   INCOME = "INCOME",
-  EXPANSE = "EXPANSE",
+  EXPENSE = "EXPENSE",
 }